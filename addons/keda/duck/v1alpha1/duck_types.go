@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains duck-types for accessing KEDA resources
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KEDAGroup                     = "keda.sh"
+	KEDAVersion                   = "v1alpha1"
+	KEDAKindScaledObject          = "ScaledObject"
+	KEDAKindTriggerAuthentication = "TriggerAuthentication"
+)
+
+// +kubebuilder:object:root=true
+
+// ScaledObject is a specification for a ScaledObject resource
+type ScaledObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaledObjectSpec `json:"spec"`
+}
+
+// ScaledObjectSpec is the spec for a ScaledObject resource
+type ScaledObjectSpec struct {
+	ScaleTargetRef  *ScaleTarget    `json:"scaleTargetRef"`
+	PollingInterval *int32          `json:"pollingInterval,omitempty"`
+	CooldownPeriod  *int32          `json:"cooldownPeriod,omitempty"`
+	MinReplicaCount *int32          `json:"minReplicaCount,omitempty"`
+	MaxReplicaCount *int32          `json:"maxReplicaCount,omitempty"`
+	Triggers        []ScaleTriggers `json:"triggers"`
+}
+
+// ScaleTarget holds the a reference to the scale target Object
+type ScaleTarget struct {
+	Name       string `json:"name"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+}
+
+// ScaleTriggers reference the scaler that will be used
+type ScaleTriggers struct {
+	Type              string               `json:"type"`
+	Metadata          map[string]string    `json:"metadata"`
+	AuthenticationRef *ScaledObjectAuthRef `json:"authenticationRef,omitempty"`
+}
+
+// ScaledObjectAuthRef points to the TriggerAuthentication object that
+// is used to authenticate the scaler with the environment
+type ScaledObjectAuthRef struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScaledObjectList is a list of ScaledObject resources
+type ScaledObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScaledObject `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+
+// TriggerAuthentication defines how a trigger can authenticate
+type TriggerAuthentication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TriggerAuthenticationSpec `json:"spec"`
+}
+
+// TriggerAuthenticationSpec defines the various ways to authenticate
+type TriggerAuthenticationSpec struct {
+	SecretTargetRef []AuthSecretTargetRef `json:"secretTargetRef,omitempty"`
+}
+
+// AuthSecretTargetRef is used to authenticate using a reference to a secret
+type AuthSecretTargetRef struct {
+	Parameter string `json:"parameter"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+// +kubebuilder:object:root=true
+
+// TriggerAuthenticationList contains a list of TriggerAuthentication
+type TriggerAuthenticationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []TriggerAuthentication `json:"items"`
+}
@@ -0,0 +1,310 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/apache/camel-k/addons/keda/duck/v1alpha1"
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/uri"
+)
+
+// The KEDA trait can be used for automatic integration with KEDA autoscalers, so that integrations
+// can scale down to zero when there's nothing to consume, without requiring Knative.
+//
+// The trait creates a KEDA `ScaledObject` that targets the integration, with one trigger for each
+// supported consumer found in the sources (Kafka, RabbitMQ and AWS SQS), and the triggers declared explicitly
+// in the trait configuration. Trigger metadata declared in the configuration complement, or override,
+// the metadata inferred from the endpoints of the same type.
+//
+// When a trigger references an authentication secret, a KEDA `TriggerAuthentication` is created, that maps
+// each key of the secret to the trigger parameter of the same name.
+//
+// NOTE: this trait requires the https://keda.sh[KEDA] custom resource definitions to be installed,
+// and is only applicable to integrations deployed as a `Deployment`. KEDA scales the integration
+// through its `scale` sub-resource: the `camel-k-keda` role, installed with the operator, grants access to
+// `integrations/scale` to the `keda-operator` service account of the `keda` namespace.
+//
+// The KEDA trait is disabled by default.
+//
+// +camel-k:trait=keda
+type kedaTrait struct {
+	trait.BaseTrait `property:",squash"`
+	// Enables automatic configuration of the trait, including the inference of the triggers from the consumers.
+	Auto *bool `property:"auto" json:"auto,omitempty"`
+	// Interval (seconds) to check each trigger on.
+	PollingInterval *int32 `property:"polling-interval" json:"pollingInterval,omitempty"`
+	// The wait period between the last active trigger reported and scaling the resource back to 0.
+	CooldownPeriod *int32 `property:"cooldown-period" json:"cooldownPeriod,omitempty"`
+	// Minimum number of replicas (default 0, i.e. the integration scales down to zero).
+	MinReplicaCount *int32 `property:"min-replica-count" json:"minReplicaCount,omitempty"`
+	// Maximum number of replicas.
+	MaxReplicaCount *int32 `property:"max-replica-count" json:"maxReplicaCount,omitempty"`
+	// Definition of triggers according to the KEDA format. Each trigger must contain a `type` field corresponding
+	// to the name of a KEDA autoscaler and a key/value map named `metadata` containing specific trigger options.
+	// An optional `authentication-secret` can be declared per trigger.
+	Triggers []kedaTrigger `property:"triggers" json:"triggers,omitempty"`
+}
+
+type kedaTrigger struct {
+	Type                 string            `property:"type" json:"type,omitempty"`
+	Metadata             map[string]string `property:"metadata" json:"metadata,omitempty"`
+	AuthenticationSecret string            `property:"authentication-secret" json:"authenticationSecret,omitempty"`
+}
+
+const (
+	kedaTriggerKafka    = "kafka"
+	kedaTriggerRabbitMQ = "rabbitmq"
+	kedaTriggerSQS      = "aws-sqs-queue"
+)
+
+// NewKedaTrait --
+func NewKedaTrait() trait.Trait {
+	return &kedaTrait{
+		BaseTrait: trait.NewBaseTrait("keda", trait.TraitOrderPostProcessResources),
+	}
+}
+
+func (t *kedaTrait) Configure(e *trait.Environment) (bool, error) {
+	if trait.IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	for i, trigger := range t.Triggers {
+		if trigger.Type == "" {
+			return false, fmt.Errorf("the type of KEDA trigger %d is missing", i)
+		}
+	}
+
+	if !e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		return false, nil
+	}
+
+	strategy, err := e.DetermineControllerStrategy()
+	if err != nil {
+		return false, err
+	}
+	if strategy != trait.ControllerStrategyDeployment {
+		t.L.Infof("KEDA trait is not applicable to integrations deployed with the %s controller strategy", strategy)
+		return false, nil
+	}
+
+	if trait.IsNilOrTrue(t.Auto) {
+		sources, err := kubernetes.ResolveIntegrationSources(e.C, t.Client, e.Integration, e.Resources)
+		if err != nil {
+			return false, err
+		}
+
+		meta := metadata.ExtractAll(e.CamelCatalog, sources)
+		t.Triggers = mergeTriggers(inferTriggers(meta.FromURIs), t.Triggers)
+	}
+
+	return len(t.Triggers) > 0, nil
+}
+
+func (t *kedaTrait) Apply(e *trait.Environment) error {
+	scaledObject := kedav1alpha1.ScaledObject{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kedav1alpha1.SchemeGroupVersion.String(),
+			Kind:       kedav1alpha1.KEDAKindScaledObject,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			// Target the integration scale sub-resource, so that the operator reconciles the replicas
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKind,
+				Name:       e.Integration.Name,
+			},
+			PollingInterval: t.PollingInterval,
+			CooldownPeriod:  t.CooldownPeriod,
+			MinReplicaCount: t.MinReplicaCount,
+			MaxReplicaCount: t.MaxReplicaCount,
+		},
+	}
+
+	for i, trigger := range t.Triggers {
+		scaleTrigger := kedav1alpha1.ScaleTriggers{
+			Type:     trigger.Type,
+			Metadata: trigger.Metadata,
+		}
+
+		if trigger.AuthenticationSecret != "" {
+			auth, err := t.triggerAuthentication(e, fmt.Sprintf("%s-%d", e.Integration.Name, i), trigger.AuthenticationSecret)
+			if err != nil {
+				return err
+			}
+			e.Resources.Add(auth)
+
+			scaleTrigger.AuthenticationRef = &kedav1alpha1.ScaledObjectAuthRef{
+				Name: auth.Name,
+				Kind: kedav1alpha1.KEDAKindTriggerAuthentication,
+			}
+		}
+
+		scaledObject.Spec.Triggers = append(scaledObject.Spec.Triggers, scaleTrigger)
+	}
+
+	e.Resources.Add(&scaledObject)
+
+	return nil
+}
+
+// triggerAuthentication maps each key of the given secret to the trigger parameter of the same name
+func (t *kedaTrait) triggerAuthentication(e *trait.Environment, name string, secretName string) (*kedav1alpha1.TriggerAuthentication, error) {
+	secret, err := t.Client.CoreV1().Secrets(e.Integration.Namespace).Get(e.C, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the KEDA authentication secret %q: %w", secretName, err)
+	}
+
+	keys := make([]string, 0, len(secret.Data)+len(secret.StringData))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	for key := range secret.StringData {
+		if _, ok := secret.Data[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	auth := kedav1alpha1.TriggerAuthentication{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kedav1alpha1.SchemeGroupVersion.String(),
+			Kind:       kedav1alpha1.KEDAKindTriggerAuthentication,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+	}
+	for _, key := range keys {
+		auth.Spec.SecretTargetRef = append(auth.Spec.SecretTargetRef, kedav1alpha1.AuthSecretTargetRef{
+			Parameter: key,
+			Name:      secretName,
+			Key:       key,
+		})
+	}
+
+	return &auth, nil
+}
+
+// inferTriggers returns the KEDA triggers matching the supported consumers
+func inferTriggers(fromURIs []string) []kedaTrigger {
+	triggers := make([]kedaTrigger, 0)
+
+	// Sort the endpoints so that the generated triggers are stable across reconciliations
+	endpoints := make([]string, len(fromURIs))
+	copy(endpoints, fromURIs)
+	sort.Strings(endpoints)
+
+	for _, endpoint := range endpoints {
+		switch uri.GetComponent(endpoint) {
+		case "kafka":
+			triggers = append(triggers, kedaTrigger{
+				Type: kedaTriggerKafka,
+				Metadata: withoutEmptyValues(map[string]string{
+					"topic":            uri.GetPath(endpoint),
+					"bootstrapServers": uri.GetQueryParameter(endpoint, "brokers"),
+					"consumerGroup":    uri.GetQueryParameter(endpoint, "groupId"),
+				}),
+			})
+		case "rabbitmq", "spring-rabbitmq":
+			// The AMQP 1.0 consumers of the amqp component are not inferred, as the
+			// rabbitmq scaler only supports the AMQP 0-9-1 protocol of RabbitMQ
+			queue := uri.GetQueryParameter(endpoint, "queue")
+			if queue == "" {
+				queue = uri.GetQueryParameter(endpoint, "queues")
+			}
+			triggers = append(triggers, kedaTrigger{
+				Type: kedaTriggerRabbitMQ,
+				Metadata: withoutEmptyValues(map[string]string{
+					"protocol":  "amqp",
+					"queueName": queue,
+				}),
+			})
+		case "aws2-sqs", "aws-sqs":
+			triggers = append(triggers, kedaTrigger{
+				Type: kedaTriggerSQS,
+				Metadata: withoutEmptyValues(map[string]string{
+					"queueURL":  uri.GetPath(endpoint),
+					"awsRegion": uri.GetQueryParameter(endpoint, "region"),
+				}),
+			})
+		}
+	}
+
+	return triggers
+}
+
+// mergeTriggers complements the inferred triggers with the metadata and authentication of the configured
+// triggers of the same type, configured values taking precedence. Configured triggers that do not match any
+// inferred trigger are added as is.
+func mergeTriggers(inferred []kedaTrigger, configured []kedaTrigger) []kedaTrigger {
+	merged := make([]kedaTrigger, 0, len(inferred)+len(configured))
+
+	used := make(map[int]bool)
+	for _, trigger := range inferred {
+		for i, conf := range configured {
+			if conf.Type != trigger.Type {
+				continue
+			}
+			used[i] = true
+			for k, v := range conf.Metadata {
+				trigger.Metadata[k] = v
+			}
+			if conf.AuthenticationSecret != "" {
+				trigger.AuthenticationSecret = conf.AuthenticationSecret
+			}
+		}
+		merged = append(merged, trigger)
+	}
+
+	for i, conf := range configured {
+		if !used[i] {
+			merged = append(merged, conf)
+		}
+	}
+
+	return merged
+}
+
+func withoutEmptyValues(m map[string]string) map[string]string {
+	for k, v := range m {
+		if v == "" {
+			delete(m, k)
+		}
+	}
+	return m
+}
@@ -0,0 +1,252 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kedav1alpha1 "github.com/apache/camel-k/addons/keda/duck/v1alpha1"
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestKedaDisabledByDefault(t *testing.T) {
+	keda, e := createKedaTest(t, `from("kafka:orders?brokers=my-cluster:9092").to("log:info")`)
+	keda.Enabled = nil
+
+	ok, err := keda.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestKedaInferredKafkaTrigger(t *testing.T) {
+	keda, e := createKedaTest(t, `from("kafka:orders?brokers=my-cluster:9092&groupId=camel").to("log:info")`)
+	keda.MaxReplicaCount = &[]int32{5}[0]
+
+	ok, err := keda.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, keda.Apply(e))
+
+	so := getScaledObject(e)
+	assert.NotNil(t, so)
+	assert.Equal(t, "test", so.Name)
+	assert.Equal(t, &kedav1alpha1.ScaleTarget{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationKind,
+		Name:       "test",
+	}, so.Spec.ScaleTargetRef)
+	assert.Equal(t, int32(5), *so.Spec.MaxReplicaCount)
+	assert.Len(t, so.Spec.Triggers, 1)
+	assert.Equal(t, "kafka", so.Spec.Triggers[0].Type)
+	assert.Equal(t, map[string]string{
+		"topic":            "orders",
+		"bootstrapServers": "my-cluster:9092",
+		"consumerGroup":    "camel",
+	}, so.Spec.Triggers[0].Metadata)
+	assert.Nil(t, so.Spec.Triggers[0].AuthenticationRef)
+}
+
+func TestKedaInferredRabbitMQAndSQSTriggers(t *testing.T) {
+	keda, e := createKedaTest(t, `
+from("amqp:queue:invoices").to("log:info")
+from("rabbitmq:shop?queue=orders").to("log:info")
+from("aws2-sqs:payments?region=eu-west-1").to("log:info")
+`)
+
+	ok, err := keda.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, keda.Apply(e))
+
+	so := getScaledObject(e)
+	assert.NotNil(t, so)
+	assert.Len(t, so.Spec.Triggers, 2)
+	assert.Equal(t, "aws-sqs-queue", so.Spec.Triggers[0].Type)
+	assert.Equal(t, map[string]string{"queueURL": "payments", "awsRegion": "eu-west-1"}, so.Spec.Triggers[0].Metadata)
+	assert.Equal(t, "rabbitmq", so.Spec.Triggers[1].Type)
+	assert.Equal(t, map[string]string{"protocol": "amqp", "queueName": "orders"}, so.Spec.Triggers[1].Metadata)
+}
+
+func TestKedaConfiguredTriggerMetadataAndAuthentication(t *testing.T) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-auth",
+			Namespace: "ns",
+		},
+		Data: map[string][]byte{
+			"sasl":     []byte("plaintext"),
+			"password": []byte("secret"),
+		},
+	}
+	keda, e := createKedaTest(t, `from("kafka:orders?brokers=my-cluster:9092").to("log:info")`, secret)
+	keda.Triggers = []kedaTrigger{
+		{
+			Type: "kafka",
+			Metadata: map[string]string{
+				"lagThreshold":  "10",
+				"consumerGroup": "my-group",
+			},
+			AuthenticationSecret: "kafka-auth",
+		},
+		{
+			Type: "cron",
+			Metadata: map[string]string{
+				"timezone": "Europe/Rome",
+			},
+		},
+	}
+
+	ok, err := keda.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, keda.Apply(e))
+
+	so := getScaledObject(e)
+	assert.NotNil(t, so)
+	assert.Len(t, so.Spec.Triggers, 2)
+	assert.Equal(t, map[string]string{
+		"topic":            "orders",
+		"bootstrapServers": "my-cluster:9092",
+		"consumerGroup":    "my-group",
+		"lagThreshold":     "10",
+	}, so.Spec.Triggers[0].Metadata)
+	assert.Equal(t, &kedav1alpha1.ScaledObjectAuthRef{
+		Name: "test-0",
+		Kind: "TriggerAuthentication",
+	}, so.Spec.Triggers[0].AuthenticationRef)
+	assert.Equal(t, "cron", so.Spec.Triggers[1].Type)
+
+	var auth *kedav1alpha1.TriggerAuthentication
+	e.Resources.Visit(func(o runtime.Object) {
+		if ta, ok := o.(*kedav1alpha1.TriggerAuthentication); ok {
+			auth = ta
+		}
+	})
+	assert.NotNil(t, auth)
+	assert.Equal(t, "test-0", auth.Name)
+	assert.Equal(t, []kedav1alpha1.AuthSecretTargetRef{
+		{Parameter: "password", Name: "kafka-auth", Key: "password"},
+		{Parameter: "sasl", Name: "kafka-auth", Key: "sasl"},
+	}, auth.Spec.SecretTargetRef)
+}
+
+func TestKedaMissingAuthenticationSecret(t *testing.T) {
+	keda, e := createKedaTest(t, `from("timer:tick").to("log:info")`)
+	keda.Triggers = []kedaTrigger{
+		{
+			Type:                 "kafka",
+			AuthenticationSecret: "missing",
+		},
+	}
+
+	ok, err := keda.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.NotNil(t, keda.Apply(e))
+}
+
+func TestKedaMissingTriggerType(t *testing.T) {
+	keda, e := createKedaTest(t, `from("timer:tick").to("log:info")`)
+	keda.Triggers = []kedaTrigger{
+		{
+			Metadata: map[string]string{
+				"timezone": "Europe/Rome",
+			},
+		},
+	}
+
+	_, err := keda.Configure(e)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the type of KEDA trigger 0 is missing")
+}
+
+func TestKedaNoTriggers(t *testing.T) {
+	keda, e := createKedaTest(t, `from("timer:tick").to("log:info")`)
+
+	ok, err := keda.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func getScaledObject(e *trait.Environment) *kedav1alpha1.ScaledObject {
+	var scaledObject *kedav1alpha1.ScaledObject
+	e.Resources.Visit(func(o runtime.Object) {
+		if so, ok := o.(*kedav1alpha1.ScaledObject); ok {
+			scaledObject = so
+		}
+	})
+	return scaledObject
+}
+
+func createKedaTest(t *testing.T, source string, objects ...runtime.Object) (*kedaTrait, *trait.Environment) {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	client, err := test.NewFakeClient(objects...)
+	assert.Nil(t, err)
+
+	keda := NewKedaTrait().(*kedaTrait)
+	keda.Enabled = trait.BoolP(true)
+	keda.Client = client
+
+	e := trait.Environment{
+		C:            context.TODO(),
+		Catalog:      trait.NewCatalog(context.TODO(), nil),
+		CamelCatalog: catalog,
+		Resources:    kubernetes.NewCollection(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Spec: v1.IntegrationSpec{
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.groovy",
+							Content: source,
+						},
+						Language: v1.LanguageGroovy,
+					},
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+
+	return keda, &e
+}
@@ -93,6 +93,10 @@ func (t *masterTrait) Configure(e *trait.Environment) (bool, error) {
 		return false, errors.New(`you cannot set both the "configmap" and "resource-name" properties on the "master" trait`)
 	}
 
+	if t.ResourceType != nil && *t.ResourceType != leaseResourceType && *t.ResourceType != configMapResourceType {
+		return false, fmt.Errorf(`invalid "resource-type" %q for the "master" trait, must be one of %q or %q`, *t.ResourceType, leaseResourceType, configMapResourceType)
+	}
+
 	if t.Auto == nil || *t.Auto {
 		// Check if the master component has been used
 		sources, err := kubernetes.ResolveIntegrationSources(t.Ctx, t.Client, e.Integration, e.Resources)
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package master
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestMasterOn(t *testing.T) {
+	master, e := createMasterTest(t, `from("master:lock:timer:tick").to("log:info")`, v1.IntegrationPhaseInitialization)
+
+	ok, err := master.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, master.Apply(e))
+
+	assert.Contains(t, e.Integration.Status.Capabilities, v1.CapabilityMaster)
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:timer")
+}
+
+func TestMasterOff(t *testing.T) {
+	master, e := createMasterTest(t, `from("timer:tick").to("log:info")`, v1.IntegrationPhaseInitialization)
+
+	ok, err := master.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestMasterDeploying(t *testing.T) {
+	master, e := createMasterTest(t, `from("master:lock:timer:tick").to("log:info")`, v1.IntegrationPhaseDeploying)
+	master.ResourceType = &configMapResourceType
+	master.LabelKey = &[]string{"app"}[0]
+
+	ok, err := master.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, master.Apply(e))
+
+	var role *rbacv1.Role
+	var roleBinding *rbacv1.RoleBinding
+	e.Resources.Visit(func(o runtime.Object) {
+		switch r := o.(type) {
+		case *rbacv1.Role:
+			role = r
+		case *rbacv1.RoleBinding:
+			roleBinding = r
+		}
+	})
+	assert.NotNil(t, role)
+	assert.Equal(t, "test-master", role.Name)
+	assert.Equal(t, "configmaps", role.Rules[0].Resources[0])
+	assert.NotNil(t, roleBinding)
+	assert.Equal(t, "default", roleBinding.Subjects[0].Name)
+
+	assert.Contains(t, e.Integration.Status.Configuration, v1.ConfigurationSpec{Type: "property", Value: "customizer.master.enabled=true"})
+	assert.Contains(t, e.Integration.Status.Configuration, v1.ConfigurationSpec{Type: "property", Value: "customizer.master.kubernetesResourceName=test-lock"})
+	assert.Contains(t, e.Integration.Status.Configuration, v1.ConfigurationSpec{Type: "property", Value: "customizer.master.leaseResourceType=ConfigMap"})
+	assert.Contains(t, e.Integration.Status.Configuration, v1.ConfigurationSpec{Type: "property", Value: "customizer.master.labelKey=app"})
+	assert.Contains(t, e.Integration.Status.Configuration, v1.ConfigurationSpec{Type: "property", Value: "customizer.master.labelValue=test"})
+}
+
+func TestMasterInvalidResourceType(t *testing.T) {
+	master, e := createMasterTest(t, `from("master:lock:timer:tick").to("log:info")`, v1.IntegrationPhaseDeploying)
+	master.ResourceType = &[]string{"Secret"}[0]
+
+	ok, err := master.Configure(e)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+}
+
+func TestMasterConfigMapAndResourceName(t *testing.T) {
+	master, e := createMasterTest(t, `from("master:lock:timer:tick").to("log:info")`, v1.IntegrationPhaseDeploying)
+	master.DeprecatedConfigMap = &[]string{"lock"}[0]
+	master.ResourceName = &[]string{"lock"}[0]
+
+	ok, err := master.Configure(e)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+}
+
+func createMasterTest(t *testing.T, source string, phase v1.IntegrationPhase) (*masterTrait, *trait.Environment) {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	client, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	master := NewMasterTrait().(*masterTrait)
+	master.Ctx = context.TODO()
+	master.Client = client
+
+	e := trait.Environment{
+		C:            context.TODO(),
+		Client:       client,
+		CamelCatalog: catalog,
+		Resources:    kubernetes.NewCollection(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Spec: v1.IntegrationSpec{
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.groovy",
+							Content: source,
+						},
+						Language: v1.LanguageGroovy,
+					},
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Phase: phase,
+			},
+		},
+	}
+
+	return master, &e
+}
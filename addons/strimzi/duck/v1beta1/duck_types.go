@@ -40,6 +40,14 @@ const (
 type KafkaTopic struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KafkaTopicSpec `json:"spec,omitempty"`
+}
+
+// KafkaTopicSpec contains the relevant info of the KafkaTopic spec
+type KafkaTopicSpec struct {
+	// The name of the topic, defaulting to the KafkaTopic resource name
+	TopicName string `json:"topicName,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -73,6 +81,7 @@ type KafkaStatus struct {
 type KafkaStatusListener struct {
 	BootstrapServers string `json:"bootstrapServers,omitempty"`
 	Type             string `json:"type,omitempty"`
+	Name             string `json:"name,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -106,6 +106,7 @@ func (in *KafkaTopic) DeepCopyInto(out *KafkaTopic) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopic.
@@ -157,3 +158,18 @@ func (in *KafkaTopicList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSpec.
+func (in *KafkaTopicSpec) DeepCopy() *KafkaTopicSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,267 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/addons/strimzi/duck/v1beta1"
+	"github.com/apache/camel-k/addons/strimzi/duck/v1beta1/client/internalclientset"
+	typedclient "github.com/apache/camel-k/addons/strimzi/duck/v1beta1/client/internalclientset/typed/duck/v1beta1"
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/uri"
+)
+
+// The Strimzi trait configures the Kafka component of the integrations connecting to the topics
+// of Kafka clusters managed by https://strimzi.io[Strimzi].
+//
+// The trait resolves the `kafka:` endpoints referencing `KafkaTopic` resources, and configures the bootstrap
+// servers of the Kafka cluster the topics belong to, from the status of the Strimzi `Kafka` resource.
+// When a `KafkaUser` is declared, its credentials are injected from the secret generated by Strimzi,
+// either for TLS client authentication, or for SCRAM-SHA-512 authentication.
+//
+// NOTE: this trait requires the Strimzi custom resource definitions to be installed.
+//
+// The Strimzi trait is disabled by default.
+//
+// +camel-k:trait=strimzi
+type strimziTrait struct {
+	trait.BaseTrait `property:",squash"`
+	// Automatically infer the Kafka cluster from the `KafkaTopic` resources referenced by the `kafka:` endpoints.
+	Auto *bool `property:"auto" json:"auto,omitempty"`
+	// The name of the Strimzi `Kafka` cluster, required when it cannot be inferred from the topics.
+	Cluster string `property:"cluster" json:"cluster,omitempty"`
+	// The name of the cluster listener to connect to (default `plain`, or `tls` when TLS is enabled).
+	Listener string `property:"listener" json:"listener,omitempty"`
+	// Connect to the cluster with TLS, trusting the cluster CA certificate (default `false`).
+	TLS *bool `property:"tls" json:"tls,omitempty"`
+	// The name of the Strimzi `KafkaUser` whose credentials are used to authenticate to the cluster.
+	User string `property:"user" json:"user,omitempty"`
+
+	kafkaClient typedclient.KafkaV1beta1Interface
+}
+
+const (
+	strimziListenerTypeTLS = "tls"
+
+	strimziClusterCACertEnvVar      = "STRIMZI_CLUSTER_CA_CERT"
+	strimziUserCertEnvVar           = "STRIMZI_USER_CERT"
+	strimziUserKeyEnvVar            = "STRIMZI_USER_KEY"
+	strimziUserSaslJaasConfigEnvVar = "STRIMZI_USER_SASL_JAAS_CONFIG"
+
+	kafkaComponentPrefix = "camel.component.kafka."
+)
+
+// NewStrimziTrait --
+func NewStrimziTrait() trait.Trait {
+	return &strimziTrait{
+		BaseTrait: trait.NewBaseTrait("strimzi", trait.TraitOrderBeforeControllerCreation),
+	}
+}
+
+func (t *strimziTrait) Configure(e *trait.Environment) (bool, error) {
+	if trait.IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		return false, nil
+	}
+
+	if t.Cluster == "" && trait.IsNilOrTrue(t.Auto) {
+		cluster, err := t.inferCluster(e)
+		if err != nil {
+			return false, err
+		}
+		t.Cluster = cluster
+	}
+
+	return t.Cluster != "", nil
+}
+
+func (t *strimziTrait) Apply(e *trait.Environment) error {
+	client, err := t.getKafkaClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := client.Kafkas(e.Integration.Namespace).Get(e.C, t.Cluster, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	listenerName := t.Listener
+	if listenerName == "" {
+		listenerName = v1beta1.StrimziListenerTypePlain
+		if trait.IsTrue(t.TLS) {
+			listenerName = strimziListenerTypeTLS
+		}
+	}
+	var bootstrapServers string
+	for _, l := range cluster.Status.Listeners {
+		if l.Name == listenerName || l.Type == listenerName {
+			bootstrapServers = l.BootstrapServers
+			break
+		}
+	}
+	if bootstrapServers == "" {
+		return fmt.Errorf("cluster %q has no bootstrap servers in %q listener", t.Cluster, listenerName)
+	}
+
+	if e.ApplicationProperties == nil {
+		e.ApplicationProperties = make(map[string]string)
+	}
+	e.ApplicationProperties[kafkaComponentPrefix+"brokers"] = bootstrapServers
+
+	securityProtocol := "PLAINTEXT"
+	if trait.IsTrue(t.TLS) {
+		securityProtocol = "SSL"
+		// Trust the cluster CA certificate
+		setSecretEnvVar(e, strimziClusterCACertEnvVar, t.Cluster+"-cluster-ca-cert", "ca.crt")
+		setAdditionalProperty(e, "ssl.truststore.type", "PEM")
+		setAdditionalProperty(e, "ssl.truststore.certificates", "${"+strimziClusterCACertEnvVar+"}")
+	}
+
+	if t.User != "" {
+		secret, err := t.Client.CoreV1().Secrets(e.Integration.Namespace).Get(e.C, t.User, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		switch {
+		case secret.Data["sasl.jaas.config"] != nil:
+			// SCRAM-SHA-512 authentication, i.e. SASL_PLAINTEXT or SASL_SSL
+			securityProtocol = "SASL_" + securityProtocol
+			setSecretEnvVar(e, strimziUserSaslJaasConfigEnvVar, t.User, "sasl.jaas.config")
+			e.ApplicationProperties[kafkaComponentPrefix+"sasl-mechanism"] = "SCRAM-SHA-512"
+			e.ApplicationProperties[kafkaComponentPrefix+"sasl-jaas-config"] = "${" + strimziUserSaslJaasConfigEnvVar + "}"
+		case secret.Data["user.key"] != nil:
+			// TLS client authentication
+			if !trait.IsTrue(t.TLS) {
+				return fmt.Errorf("user %q uses TLS client authentication, that requires TLS to be enabled", t.User)
+			}
+			setSecretEnvVar(e, strimziUserCertEnvVar, t.User, "user.crt")
+			setSecretEnvVar(e, strimziUserKeyEnvVar, t.User, "user.key")
+			setAdditionalProperty(e, "ssl.keystore.type", "PEM")
+			setAdditionalProperty(e, "ssl.keystore.certificate.chain", "${"+strimziUserCertEnvVar+"}")
+			setAdditionalProperty(e, "ssl.keystore.key", "${"+strimziUserKeyEnvVar+"}")
+		default:
+			return fmt.Errorf("secret %q does not contain the credentials of a Strimzi user", t.User)
+		}
+	}
+
+	e.ApplicationProperties[kafkaComponentPrefix+"security-protocol"] = securityProtocol
+
+	return nil
+}
+
+// inferCluster returns the Kafka cluster the topics referenced by the kafka endpoints belong to
+func (t *strimziTrait) inferCluster(e *trait.Environment) (string, error) {
+	sources, err := kubernetes.ResolveIntegrationSources(e.C, t.Client, e.Integration, e.Resources)
+	if err != nil {
+		return "", err
+	}
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+
+	client, err := t.getKafkaClient()
+	if err != nil {
+		return "", err
+	}
+
+	var topics *v1beta1.KafkaTopicList
+	clusters := make([]string, 0)
+	for _, endpoint := range append(meta.FromURIs, meta.ToURIs...) {
+		// Endpoints with explicit brokers are left untouched
+		if uri.GetComponent(endpoint) != "kafka" || uri.GetQueryParameter(endpoint, "brokers") != "" {
+			continue
+		}
+		if topics == nil {
+			if topics, err = client.KafkaTopics(e.Integration.Namespace).List(e.C, metav1.ListOptions{}); err != nil {
+				return "", err
+			}
+		}
+		topic := findKafkaTopic(topics.Items, uri.GetPath(endpoint))
+		if topic == nil {
+			continue
+		}
+		if cluster := topic.Labels[v1beta1.StrimziKafkaClusterLabel]; cluster != "" {
+			util.StringSliceUniqueAdd(&clusters, cluster)
+		}
+	}
+
+	switch len(clusters) {
+	case 0:
+		return "", nil
+	case 1:
+		return clusters[0], nil
+	default:
+		sort.Strings(clusters)
+		return "", fmt.Errorf("topics belong to several Kafka clusters (%s), the cluster must be configured explicitly", strings.Join(clusters, ", "))
+	}
+}
+
+// findKafkaTopic returns the KafkaTopic resource managing the given topic, whose name is the spec.topicName
+// of the resource, or the resource name when the spec.topicName is not set
+func findKafkaTopic(topics []v1beta1.KafkaTopic, name string) *v1beta1.KafkaTopic {
+	for i := range topics {
+		topicName := topics[i].Spec.TopicName
+		if topicName == "" {
+			topicName = topics[i].Name
+		}
+		if topicName == name {
+			return &topics[i]
+		}
+	}
+	return nil
+}
+
+func (t *strimziTrait) getKafkaClient() (typedclient.KafkaV1beta1Interface, error) {
+	if t.kafkaClient == nil {
+		kafkaClient, err := internalclientset.NewForConfig(t.Client.GetConfig())
+		if err != nil {
+			return nil, err
+		}
+		t.kafkaClient = kafkaClient.KafkaV1beta1()
+	}
+	return t.kafkaClient, nil
+}
+
+func setSecretEnvVar(e *trait.Environment, name string, secret string, key string) {
+	envvar.SetVar(&e.EnvVars, corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  key,
+			},
+		},
+	})
+}
+
+func setAdditionalProperty(e *trait.Environment, name string, value string) {
+	e.ApplicationProperties[kafkaComponentPrefix+"additional-properties["+name+"]"] = value
+}
@@ -0,0 +1,277 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/apache/camel-k/addons/strimzi/duck/v1beta1"
+	"github.com/apache/camel-k/addons/strimzi/duck/v1beta1/client/internalclientset/fake"
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestStrimziTraitDisabledByDefault(t *testing.T) {
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:orders").to("log:info")`)
+	strimzi.Enabled = nil
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestStrimziTraitInferredCluster(t *testing.T) {
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:orders").to("kafka:invoices?brokers=other:9092")`)
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "my-cluster", strimzi.Cluster)
+
+	assert.Nil(t, strimzi.Apply(e))
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9092", e.ApplicationProperties["camel.component.kafka.brokers"])
+	assert.Equal(t, "PLAINTEXT", e.ApplicationProperties["camel.component.kafka.security-protocol"])
+	assert.Empty(t, e.EnvVars)
+}
+
+func TestStrimziTraitInferredClusterFromTopicName(t *testing.T) {
+	topic := v1beta1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "payments-topic",
+			Labels: map[string]string{
+				v1beta1.StrimziKafkaClusterLabel: "other-cluster",
+			},
+		},
+		Spec: v1beta1.KafkaTopicSpec{
+			TopicName: "payments",
+		},
+	}
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:payments").to("log:info")`, &topic)
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "other-cluster", strimzi.Cluster)
+}
+
+func TestStrimziTraitNoTopic(t *testing.T) {
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:unknown").to("log:info")`)
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestStrimziTraitSeveralClusters(t *testing.T) {
+	other := v1beta1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "invoices",
+			Labels: map[string]string{
+				v1beta1.StrimziKafkaClusterLabel: "other-cluster",
+			},
+		},
+	}
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:orders").to("kafka:invoices")`, &other)
+
+	_, err := strimzi.Configure(e)
+	assert.NotNil(t, err)
+}
+
+func TestStrimziTraitTLSWithTLSUser(t *testing.T) {
+	user := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-user",
+		},
+		Data: map[string][]byte{
+			"user.crt": []byte("crt"),
+			"user.key": []byte("key"),
+		},
+	}
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:orders").to("log:info")`, &user)
+	strimzi.TLS = trait.BoolP(true)
+	strimzi.User = "my-user"
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, strimzi.Apply(e))
+
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9093", e.ApplicationProperties["camel.component.kafka.brokers"])
+	assert.Equal(t, "SSL", e.ApplicationProperties["camel.component.kafka.security-protocol"])
+	assert.Equal(t, "${STRIMZI_CLUSTER_CA_CERT}", e.ApplicationProperties["camel.component.kafka.additional-properties[ssl.truststore.certificates]"])
+	assert.Equal(t, "${STRIMZI_USER_KEY}", e.ApplicationProperties["camel.component.kafka.additional-properties[ssl.keystore.key]"])
+
+	ca := envvar.Get(e.EnvVars, "STRIMZI_CLUSTER_CA_CERT")
+	assert.NotNil(t, ca)
+	assert.Equal(t, "my-cluster-cluster-ca-cert", ca.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "ca.crt", ca.ValueFrom.SecretKeyRef.Key)
+	key := envvar.Get(e.EnvVars, "STRIMZI_USER_KEY")
+	assert.NotNil(t, key)
+	assert.Equal(t, "my-user", key.ValueFrom.SecretKeyRef.Name)
+}
+
+func TestStrimziTraitScramUser(t *testing.T) {
+	user := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-user",
+		},
+		Data: map[string][]byte{
+			"password":         []byte("password"),
+			"sasl.jaas.config": []byte("config"),
+		},
+	}
+	strimzi, e := createStrimziTraitTest(t, `from("log:info").to("log:info")`, &user)
+	strimzi.Cluster = "my-cluster"
+	strimzi.User = "my-user"
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, strimzi.Apply(e))
+
+	assert.Equal(t, "SASL_PLAINTEXT", e.ApplicationProperties["camel.component.kafka.security-protocol"])
+	assert.Equal(t, "SCRAM-SHA-512", e.ApplicationProperties["camel.component.kafka.sasl-mechanism"])
+	assert.Equal(t, "${STRIMZI_USER_SASL_JAAS_CONFIG}", e.ApplicationProperties["camel.component.kafka.sasl-jaas-config"])
+	assert.NotNil(t, envvar.Get(e.EnvVars, "STRIMZI_USER_SASL_JAAS_CONFIG"))
+}
+
+func TestStrimziTraitTLSUserWithoutTLS(t *testing.T) {
+	user := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-user",
+		},
+		Data: map[string][]byte{
+			"user.key": []byte("key"),
+		},
+	}
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:orders").to("log:info")`, &user)
+	strimzi.User = "my-user"
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.NotNil(t, strimzi.Apply(e))
+}
+
+func TestStrimziTraitUnknownListener(t *testing.T) {
+	strimzi, e := createStrimziTraitTest(t, `from("kafka:orders").to("log:info")`)
+	strimzi.Listener = "external"
+
+	ok, err := strimzi.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.NotNil(t, strimzi.Apply(e))
+}
+
+func createStrimziTraitTest(t *testing.T, source string, objects ...runtime.Object) (*strimziTrait, *trait.Environment) {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	topic := v1beta1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "orders",
+			Labels: map[string]string{
+				v1beta1.StrimziKafkaClusterLabel: "my-cluster",
+			},
+		},
+	}
+	cluster := v1beta1.Kafka{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-cluster",
+		},
+		Status: v1beta1.KafkaStatus{
+			Listeners: []v1beta1.KafkaStatusListener{
+				{
+					Type:             "plain",
+					BootstrapServers: "my-cluster-kafka-bootstrap:9092",
+				},
+				{
+					Name:             "tls",
+					BootstrapServers: "my-cluster-kafka-bootstrap:9093",
+				},
+			},
+		},
+	}
+
+	kafkaObjects := []runtime.Object{&topic, &cluster}
+	coreObjects := make([]runtime.Object, 0)
+	for _, o := range objects {
+		if _, ok := o.(*v1beta1.KafkaTopic); ok {
+			kafkaObjects = append(kafkaObjects, o)
+		} else {
+			coreObjects = append(coreObjects, o)
+		}
+	}
+
+	client, err := test.NewFakeClient(coreObjects...)
+	assert.Nil(t, err)
+
+	strimzi := NewStrimziTrait().(*strimziTrait)
+	strimzi.Enabled = trait.BoolP(true)
+	strimzi.Client = client
+	strimzi.kafkaClient = fake.NewSimpleClientset(kafkaObjects...).KafkaV1beta1()
+
+	e := trait.Environment{
+		C:            context.TODO(),
+		Catalog:      trait.NewCatalog(context.TODO(), nil),
+		CamelCatalog: catalog,
+		Resources:    kubernetes.NewCollection(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Spec: v1.IntegrationSpec{
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.groovy",
+							Content: source,
+						},
+						Language: v1.LanguageGroovy,
+					},
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+
+	return strimzi, &e
+}
@@ -39,7 +39,7 @@ type threeScaleTrait struct {
 	Scheme string `property:"scheme" json:"scheme,omitempty"`
 	// The path where the API is published (default `/`)
 	Path string `property:"path" json:"path,omitempty"`
-	// The port where the service is exposed (default `80`)
+	// The port where the service is exposed (defaults to the port of the integration service, or `80`)
 	Port int `property:"port" json:"port,omitempty"`
 	// The path where the Open-API specification is published (default `/openapi.json`)
 	DescriptionPath *string `property:"description-path" json:"descriptionPath,omitempty"`
@@ -96,9 +96,6 @@ func (t *threeScaleTrait) Configure(e *trait.Environment) (bool, error) {
 		if t.Path == "" {
 			t.Path = ThreeScalePathDefaultValue
 		}
-		if t.Port == 0 {
-			t.Port = ThreeScalePortDefaultValue
-		}
 		if t.DescriptionPath == nil {
 			openAPI := ThreeScaleDescriptionPathDefaultValue
 			t.DescriptionPath = &openAPI
@@ -109,6 +106,13 @@ func (t *threeScaleTrait) Configure(e *trait.Environment) (bool, error) {
 
 func (t *threeScaleTrait) Apply(e *trait.Environment) error {
 	if svc := e.Resources.GetServiceForIntegration(e.Integration); svc != nil {
+		if t.Port == 0 && (t.Auto == nil || *t.Auto) {
+			// Advertise the port the service actually exposes, e.g. when customized with the container trait
+			t.Port = ThreeScalePortDefaultValue
+			if len(svc.Spec.Ports) > 0 && svc.Spec.Ports[0].Port > 0 {
+				t.Port = int(svc.Spec.Ports[0].Port)
+			}
+		}
 		t.addLabelsAndAnnotations(&svc.ObjectMeta)
 	}
 	return nil
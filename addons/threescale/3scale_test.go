@@ -84,6 +84,49 @@ func TestThreeScaleInjectionNoAPIPath(t *testing.T) {
 	assert.False(t, p)
 }
 
+func TestThreeScaleInjectionServicePort(t *testing.T) {
+	svc, e := createEnvironment(t)
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name: "http",
+			Port: 8080,
+		},
+	}
+	threeScale := NewThreeScaleTrait()
+	enabled := true
+	threeScale.(*threeScaleTrait).Enabled = &enabled
+	ok, err := threeScale.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = threeScale.Apply(e)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "8080", svc.Annotations["discovery.3scale.net/port"])
+}
+
+func TestThreeScaleInjectionExplicitPort(t *testing.T) {
+	svc, e := createEnvironment(t)
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name: "http",
+			Port: 8080,
+		},
+	}
+	threeScale := NewThreeScaleTrait()
+	enabled := true
+	threeScale.(*threeScaleTrait).Enabled = &enabled
+	threeScale.(*threeScaleTrait).Port = 9090
+	ok, err := threeScale.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = threeScale.Apply(e)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "9090", svc.Annotations["discovery.3scale.net/port"])
+}
+
 func createEnvironment(t *testing.T) (*corev1.Service, *trait.Environment) {
 	catalog, err := camel.DefaultCatalog()
 	assert.Nil(t, err)
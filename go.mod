@@ -15,6 +15,7 @@ require (
 	github.com/gertd/go-pluralize v0.1.1
 	github.com/go-logr/logr v0.4.0
 	github.com/golangplus/testing v1.0.0
+	github.com/google/go-containerregistry v0.5.0
 	github.com/google/go-github/v32 v32.1.0
 	github.com/google/uuid v1.2.0
 	github.com/jpillora/backoff v1.0.0
@@ -43,6 +44,7 @@ require (
 	github.com/stretchr/testify v1.6.1
 	go.uber.org/multierr v1.6.0
 	go.uber.org/zap v1.16.0
+	golang.org/x/crypto v0.0.0-20210415154028-4f45737414dc
 	golang.org/x/oauth2 v0.0.0-20210413134643-5e61552d6c78
 	gopkg.in/inf.v0 v0.9.1
 	gopkg.in/yaml.v2 v2.4.0
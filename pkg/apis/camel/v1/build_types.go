@@ -34,15 +34,44 @@ type BuildSpec struct {
 	// and its phase set to BuildPhaseFailed.
 	// +kubebuilder:validation:Format=duration
 	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// MaxRetries defines the maximum number of times the Build is retried,
+	// when it has failed with a transient error, e.g. a registry or Maven repository
+	// server error. The retries are delayed with an exponential backoff.
+	MaxRetries *int `json:"maxRetries,omitempty"`
+	// Tolerations defines the tolerations of the Build pod,
+	// applicable when the Build is executed with the pod strategy.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector defines the node selector of the Build pod,
+	// applicable when the Build is executed with the pod strategy.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Resources defines the compute resources of the Build pod builder container,
+	// applicable when the Build is executed with the pod strategy.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// PriorityClassName defines the priority class of the Build pod,
+	// applicable when the Build is executed with the pod strategy.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// HTTPProxy defines the HTTP_PROXY environment variable of the Build pod containers,
+	// applicable when the Build is executed with the pod strategy.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy defines the HTTPS_PROXY environment variable of the Build pod containers,
+	// applicable when the Build is executed with the pod strategy.
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy defines the NO_PROXY environment variable of the Build pod containers,
+	// applicable when the Build is executed with the pod strategy.
+	NoProxy string `json:"noProxy,omitempty"`
 }
 
 // Task --
 type Task struct {
 	Builder  *BuilderTask  `json:"builder,omitempty"`
+	Native   *NativeTask   `json:"native,omitempty"`
 	Buildah  *BuildahTask  `json:"buildah,omitempty"`
 	Kaniko   *KanikoTask   `json:"kaniko,omitempty"`
+	BuildKit *BuildKitTask `json:"buildKit,omitempty"`
+	Jib      *JibTask      `json:"jib,omitempty"`
 	Spectrum *SpectrumTask `json:"spectrum,omitempty"`
 	S2i      *S2iTask      `json:"s2i,omitempty"`
+	Custom   *CustomTask   `json:"custom,omitempty"`
 }
 
 // BaseTask --
@@ -53,14 +82,45 @@ type BaseTask struct {
 // BuilderTask --
 type BuilderTask struct {
 	BaseTask     `json:",inline"`
-	BaseImage    string            `json:"baseImage,omitempty"`
-	Runtime      RuntimeSpec       `json:"runtime,omitempty"`
-	Sources      []SourceSpec      `json:"sources,omitempty"`
-	Resources    []ResourceSpec    `json:"resources,omitempty"`
-	Dependencies []string          `json:"dependencies,omitempty"`
-	Steps        []string          `json:"steps,omitempty"`
-	Maven        MavenSpec         `json:"maven,omitempty"`
-	BuildDir     string            `json:"buildDir,omitempty"`
+	BaseImage    string         `json:"baseImage,omitempty"`
+	Runtime      RuntimeSpec    `json:"runtime,omitempty"`
+	Sources      []SourceSpec   `json:"sources,omitempty"`
+	Resources    []ResourceSpec `json:"resources,omitempty"`
+	Dependencies []string       `json:"dependencies,omitempty"`
+	// DependencyExclusions lists the groupId:artifactId of the transitive dependencies to exclude
+	DependencyExclusions []string `json:"dependencyExclusions,omitempty"`
+	// DependencyOverrides lists the groupId:artifactId:version of the dependencies whose version is pinned
+	DependencyOverrides []string  `json:"dependencyOverrides,omitempty"`
+	Steps               []string  `json:"steps,omitempty"`
+	Maven               MavenSpec `json:"maven,omitempty"`
+	BuildDir            string    `json:"buildDir,omitempty"`
+}
+
+// NativeTask compiles the native executable of the application, from the native sources
+// generated by the builder task, with the GraalVM / Mandrel native-image tool
+type NativeTask struct {
+	BaseTask `json:",inline"`
+	// The image providing the native-image tool, that runs the task when the Build is executed with the pod strategy
+	Image string `json:"image,omitempty"`
+	// The build directory, shared with the builder task
+	BuildDir string `json:"buildDir,omitempty"`
+	// The compute resources of the native compilation, when the Build is executed with the pod strategy
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// CustomTask runs a user provided container, after the builder task and before the image is published,
+// e.g. to sign the artifacts, or scan the image build context. As the application is already built,
+// it cannot contribute sources or dependencies to it.
+// It requires the Build to be executed with the pod strategy.
+type CustomTask struct {
+	BaseTask `json:",inline"`
+	// The container image that runs the task
+	Image string `json:"image,omitempty"`
+	// The shell command executed in the container. The working directory is the build directory,
+	// shared with the other tasks, that contains the image build context in the `context` directory.
+	Command string `json:"command,omitempty"`
+	// The compute resources of the task, defaulting to the ones of the Build
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // PublishTask --
@@ -69,6 +129,8 @@ type PublishTask struct {
 	BaseImage  string                          `json:"baseImage,omitempty"`
 	Image      string                          `json:"image,omitempty"`
 	Registry   IntegrationPlatformRegistrySpec `json:"registry,omitempty"`
+	// The platforms the image is built for, published as a manifest list, e.g. `linux/amd64` and `linux/arm64`
+	Platforms []string `json:"platforms,omitempty"`
 }
 
 // BuildahTask --
@@ -77,6 +139,8 @@ type BuildahTask struct {
 	PublishTask     `json:",inline"`
 	Verbose         *bool  `json:"verbose,omitempty"`
 	HttpProxySecret string `json:"httpProxySecret,omitempty"`
+	// Whether Buildah runs as a non-root user, with the chroot isolation
+	Rootless *bool `json:"rootless,omitempty"`
 }
 
 // KanikoTask --
@@ -94,6 +158,24 @@ type KanikoTaskCache struct {
 	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
 }
 
+// BuildKitTask builds and publishes the image with BuildKit, when the Build is executed with the pod strategy
+type BuildKitTask struct {
+	BaseTask        `json:",inline"`
+	PublishTask     `json:",inline"`
+	Verbose         *bool  `json:"verbose,omitempty"`
+	HttpProxySecret string `json:"httpProxySecret,omitempty"`
+	// The address of the BuildKit daemon, the daemon running rootless in the build pod when not set
+	Address string `json:"address,omitempty"`
+	// The image the layer cache is exported to, and imported from, the cache being disabled when not set
+	CacheImage string `json:"cacheImage,omitempty"`
+}
+
+// JibTask --
+type JibTask struct {
+	BaseTask    `json:",inline"`
+	PublishTask `json:",inline"`
+}
+
 // SpectrumTask --
 type SpectrumTask struct {
 	BaseTask    `json:",inline"`
@@ -109,21 +191,51 @@ type S2iTask struct {
 
 // BuildStatus defines the observed state of Build
 type BuildStatus struct {
-	Phase      BuildPhase       `json:"phase,omitempty"`
-	Image      string           `json:"image,omitempty"`
-	Digest     string           `json:"digest,omitempty"`
-	BaseImage  string           `json:"baseImage,omitempty"`
-	Artifacts  []Artifact       `json:"artifacts,omitempty"`
-	Error      string           `json:"error,omitempty"`
-	Failure    *Failure         `json:"failure,omitempty"`
-	StartedAt  *metav1.Time     `json:"startedAt,omitempty"`
-	Platform   string           `json:"platform,omitempty"`
-	Conditions []BuildCondition `json:"conditions,omitempty"`
+	Phase     BuildPhase `json:"phase,omitempty"`
+	Image     string     `json:"image,omitempty"`
+	Digest    string     `json:"digest,omitempty"`
+	BaseImage string     `json:"baseImage,omitempty"`
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+	// The results of the artifacts verification, when it is configured
+	Verifications []ArtifactVerification `json:"verifications,omitempty"`
+	Error         string                 `json:"error,omitempty"`
+	Failure       *Failure               `json:"failure,omitempty"`
+	StartedAt     *metav1.Time           `json:"startedAt,omitempty"`
+	Platform      string                 `json:"platform,omitempty"`
+	Conditions    []BuildCondition       `json:"conditions,omitempty"`
+	// QueuePosition is the position of the Build in the build queue, while it's waiting to be scheduled
+	QueuePosition int `json:"queuePosition,omitempty"`
 	// Change to Duration / ISO 8601 when CRD uses OpenAPI spec v3
 	// https://github.com/OAI/OpenAPI-Specification/issues/845
 	Duration string `json:"duration,omitempty"`
 }
 
+// ArtifactVerification records the verification of an artifact, for supply-chain auditing
+type ArtifactVerification struct {
+	// The artifact ID
+	ID string `json:"id"`
+	// The SHA-256 checksum of the artifact
+	SHA256 string `json:"sha256,omitempty"`
+	// The result of the signature verification, when a keyring is configured
+	Signature ArtifactSignatureStatus `json:"signature,omitempty"`
+	// The ID of the PGP key the artifact is signed with
+	SignatureKeyID string `json:"signatureKeyId,omitempty"`
+}
+
+// ArtifactSignatureStatus --
+type ArtifactSignatureStatus string
+
+const (
+	// ArtifactSignatureVerified --
+	ArtifactSignatureVerified ArtifactSignatureStatus = "Verified"
+	// ArtifactSignatureMissing --
+	ArtifactSignatureMissing ArtifactSignatureStatus = "Missing"
+	// ArtifactSignatureUntrusted is the status of the artifacts signed with a key that is not in the keyring
+	ArtifactSignatureUntrusted ArtifactSignatureStatus = "Untrusted"
+	// ArtifactSignatureNotApplicable is the status of the artifacts that are not resolved from a Maven repository
+	ArtifactSignatureNotApplicable ArtifactSignatureStatus = "NotApplicable"
+)
+
 // BuildPhase --
 type BuildPhase string
 
@@ -159,6 +271,17 @@ const (
 	BuildConditionPlatformAvailable BuildConditionType = "IntegrationPlatformAvailable"
 	// BuildConditionPlatformAvailableReason --
 	BuildConditionPlatformAvailableReason string = "IntegrationPlatformAvailable"
+	// BuildConditionTimedOut --
+	BuildConditionTimedOut BuildConditionType = "TimedOut"
+	// BuildConditionTimedOutReason --
+	BuildConditionTimedOutReason string = "DeadlineExceeded"
+	// BuildConditionArtifactMissing --
+	BuildConditionArtifactMissing BuildConditionType = "ArtifactMissing"
+	// BuildConditionArtifactMissingReason --
+	BuildConditionArtifactMissingReason string = "OfflineArtifactMissing"
+
+	// BuildDefaultMaxRetries is the maximum number of retries of a failed Build, when not configured
+	BuildDefaultMaxRetries = 5
 )
 
 // +genclient
@@ -18,6 +18,10 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -62,6 +66,28 @@ func (in *Build) SetIntegrationPlatform(platform *IntegrationPlatform) {
 	in.Status.Platform = platform.Name
 }
 
+// BuildPriorityAnnotation sets the priority of the Build in the build queue,
+// the Builds with a higher priority being scheduled first
+const BuildPriorityAnnotation = "camel.apache.org/build.priority"
+
+// GetPriority returns the priority of the Build in the build queue, that defaults to 0
+func (in *Build) GetPriority() int {
+	if value, ok := in.Annotations[BuildPriorityAnnotation]; ok {
+		if priority, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			return priority
+		}
+	}
+	return 0
+}
+
+// GetMaxRetries returns the maximum number of retries of the Build or the default one
+func (in BuildSpec) GetMaxRetries() int {
+	if in.MaxRetries == nil {
+		return BuildDefaultMaxRetries
+	}
+	return *in.MaxRetries
+}
+
 // GetCondition returns the condition with the provided type.
 func (in *BuildStatus) GetCondition(condType BuildConditionType) *BuildCondition {
 	for i := range in.Conditions {
@@ -79,6 +105,24 @@ func (in *BuildStatus) Failed(err error) BuildStatus {
 	return *in
 }
 
+// TimedOut marks the build as failed, because it has not completed before its deadline
+func (in *BuildStatus) TimedOut(timeout metav1.Duration) BuildStatus {
+	message := fmt.Sprintf("Build timeout: the build has not completed within %s", timeout.Duration)
+	in.Error = message
+	in.Phase = BuildPhaseFailed
+	in.SetCondition(BuildConditionTimedOut, corev1.ConditionTrue, BuildConditionTimedOutReason, message)
+	return *in
+}
+
+// ArtifactMissing marks the build as errored, because the artifact is missing from the local Maven repository,
+// and cannot be downloaded by the offline build
+func (in *BuildStatus) ArtifactMissing(artifact string) BuildStatus {
+	message := fmt.Sprintf("Offline build: the artifact %s is missing from the local Maven repository", artifact)
+	in.Phase = BuildPhaseError
+	in.SetCondition(BuildConditionArtifactMissing, corev1.ConditionTrue, BuildConditionArtifactMissingReason, message)
+	return *in
+}
+
 // SetCondition --
 func (in *BuildStatus) SetCondition(condType BuildConditionType, status corev1.ConditionStatus, reason string, message string) {
 	in.SetConditions(BuildCondition{
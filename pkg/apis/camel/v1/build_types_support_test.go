@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildTimedOut(t *testing.T) {
+	status := BuildStatus{
+		Phase: BuildPhaseRunning,
+	}
+
+	result := status.TimedOut(metav1.Duration{Duration: 5 * time.Minute})
+
+	assert.Equal(t, BuildPhaseFailed, status.Phase)
+	assert.Equal(t, "Build timeout: the build has not completed within 5m0s", status.Error)
+	condition := status.GetCondition(BuildConditionTimedOut)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, BuildConditionTimedOutReason, condition.Reason)
+	assert.Equal(t, status.Error, condition.Message)
+	assert.Equal(t, status.Phase, result.Phase)
+}
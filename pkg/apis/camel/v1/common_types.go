@@ -54,6 +54,16 @@ type FailureRecovery struct {
 	AttemptMax int `json:"attemptMax"`
 	// +optional
 	AttemptTime metav1.Time `json:"attemptTime"`
+	// The history of the failed attempts
+	// +optional
+	History []FailureRecoveryAttempt `json:"history,omitempty"`
+}
+
+// FailureRecoveryAttempt records the failure of an execution attempt
+type FailureRecoveryAttempt struct {
+	Attempt int         `json:"attempt"`
+	Reason  string      `json:"reason"`
+	Time    metav1.Time `json:"time"`
 }
 
 // A TraitSpec contains the configuration of a trait
@@ -93,11 +103,18 @@ type PlatformInjectable interface {
 type MavenSpec struct {
 	// The path of the local Maven repository.
 	LocalRepository string `json:"localRepository,omitempty"`
+	// The cache of the local Maven repository, shared across builds.
+	Cache *MavenCacheSpec `json:"cache,omitempty"`
 	// The Maven properties.
 	Properties map[string]string `json:"properties,omitempty"`
 	// A reference to the ConfigMap or Secret key that contains
 	// the Maven settings.
 	Settings ValueSource `json:"settings,omitempty"`
+	// A reference to the ConfigMap or Secret key that contains
+	// the security configuration of the Maven settings, i.e. the encrypted master password,
+	// used to decrypt the server passwords of custom settings.
+	// See https://maven.apache.org/guides/mini/guide-encryption.html.
+	SettingsSecurity ValueSource `json:"settingsSecurity,omitempty"`
 	// The Secret name and key, containing the CA certificate(s) used to connect
 	// to remote Maven repositories.
 	// It can contain X.509 certificates, and PKCS#7 formatted certificate chains.
@@ -108,8 +125,49 @@ type MavenSpec struct {
 	// Deprecated: use IntegrationPlatform.Spec.Build.Timeout instead
 	Timeout      *metav1.Duration `json:"timeout,omitempty"`
 	Repositories []Repository     `json:"repositories,omitempty"`
+	// The mirrors of the remote repositories, added to the Maven settings generated
+	// when no custom settings are configured.
+	Mirrors []Mirror `json:"mirrors,omitempty"`
+	// The proxies used to connect to the remote repositories, added to the Maven settings
+	// generated when no custom settings are configured.
+	Proxies []MavenProxy `json:"proxies,omitempty"`
+	// The credentials of the remote repositories and mirrors, added to the Maven settings
+	// generated when no custom settings are configured.
+	// The generated settings are then stored in a Secret, rather than in a ConfigMap.
+	Servers []MavenServer `json:"servers,omitempty"`
 	// Maven build extensions https://maven.apache.org/guides/mini/guide-using-extensions.html
 	Extension []MavenArtifact `json:"extension,omitempty"`
+	// The verification of the artifacts checksums and signatures.
+	Verification *MavenVerificationSpec `json:"verification,omitempty"`
+	// The CLI options that are appended to the list of arguments for Maven commands,
+	// e.g., `--offline` or `--debug`.
+	// See https://maven.apache.org/ref/current/maven-embedder/cli.html.
+	CLIOptions []string `json:"cliOptions,omitempty"`
+}
+
+// MavenCacheSpec configures the local Maven repository shared across builds
+type MavenCacheSpec struct {
+	// The name of the PersistentVolumeClaim mounted as the local Maven repository
+	// by the builder pods, when the builds run in pods.
+	// The volume can be pre-populated to avoid downloading the dependencies on the first builds.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// The age above which the cached artifacts are pruned, e.g. `168h`.
+	// The artifacts age is computed from the last time they were used by a build, or downloaded.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// The size above which the oldest cached artifacts are pruned, e.g. `10Gi`.
+	MaxSize string `json:"maxSize,omitempty"`
+}
+
+// MavenVerificationSpec configures the verification of the artifacts resolved by the builds.
+// When it is set, the remote repositories checksums are strictly checked, preferably with the SHA-256 algorithm,
+// while the artifacts are resolved, and the SHA-256 checksums of the artifacts are verified when they are copied into the kit image.
+type MavenVerificationSpec struct {
+	// A reference to the ConfigMap or Secret key that contains the armored PGP public keyring,
+	// used to verify the signatures of the artifacts. The signatures are not verified when no keyring is set.
+	Keyring ValueSource `json:"keyring,omitempty"`
+	// Whether the builds fail when the signature of an artifact is missing, or is issued by a key that is not in the keyring.
+	// The missing and untrusted signatures are only recorded in the Build status by default.
+	RequireSignatures bool `json:"requireSignatures,omitempty"`
 }
 
 // ValueSource --
@@ -226,6 +284,13 @@ type SourceSpec struct {
 	Type SourceType `json:"type,omitempty"`
 	// List of property names defined in the source (e.g. if type is "template")
 	PropertyNames []string `json:"property-names,omitempty"`
+	// Location is the URL of a remote source, e.g. `https://host/path/routes.groovy` or `github:user/repo/path`,
+	// whose content is fetched by the operator when the integration is initialized
+	Location string `json:"location,omitempty"`
+	// GitSecret is the name of the secret, in the integration namespace, holding the credentials used to fetch
+	// the remote source. It either holds a token in the `token` key, or is a basic-auth secret, and optionally
+	// lists the hosts the credentials are sent to in the `hosts` key. SSH keys are not supported.
+	GitSecret string `json:"gitSecret,omitempty"`
 }
 
 type SourceType string
@@ -21,8 +21,40 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// OperatorIDAnnotation assigns a resource to the operator configured with the same id,
+// so that several operators can coexist in the same cluster
+const OperatorIDAnnotation = "camel.apache.org/operator.id"
+
+// GetOperatorIDAnnotation returns the id of the operator the resource is assigned to, if any
+func GetOperatorIDAnnotation(obj metav1.Object) string {
+	if obj == nil || obj.GetAnnotations() == nil {
+		return ""
+	}
+	return obj.GetAnnotations()[OperatorIDAnnotation]
+}
+
+// SetOperatorIDAnnotation assigns the resource to the operator with the given id,
+// or removes the assignment when the id is empty
+func SetOperatorIDAnnotation(obj metav1.Object, operatorID string) {
+	annotations := obj.GetAnnotations()
+	if operatorID == "" {
+		if annotations != nil {
+			delete(annotations, OperatorIDAnnotation)
+			obj.SetAnnotations(annotations)
+		}
+		return
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[OperatorIDAnnotation] = operatorID
+	obj.SetAnnotations(annotations)
+}
+
 func (in *Artifact) String() string {
 	return in.ID
 }
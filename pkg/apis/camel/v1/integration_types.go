@@ -203,6 +203,30 @@ const (
 	IntegrationConditionKameletsAvailableReason string = "KameletsAvailable"
 	// IntegrationConditionKameletsNotAvailableReason --
 	IntegrationConditionKameletsNotAvailableReason string = "KameletsNotAvailable"
+
+	// IntegrationConditionGarbageCollectionDryRun --
+	IntegrationConditionGarbageCollectionDryRun IntegrationConditionType = "GarbageCollectionDryRun"
+	// IntegrationConditionGarbageCollectionDryRunReason --
+	IntegrationConditionGarbageCollectionDryRunReason string = "GarbageCollectionDryRun"
+
+	// IntegrationConditionJVMAvailable --
+	IntegrationConditionJVMAvailable IntegrationConditionType = "JVMAvailable"
+	// IntegrationConditionJVMAvailableReason --
+	IntegrationConditionJVMAvailableReason string = "JVMAvailable"
+
+	// IntegrationConditionVaultAvailable --
+	IntegrationConditionVaultAvailable IntegrationConditionType = "VaultAvailable"
+	// IntegrationConditionVaultAvailableReason --
+	IntegrationConditionVaultAvailableReason string = "VaultAvailable"
+	// IntegrationConditionVaultNotAvailableReason --
+	IntegrationConditionVaultNotAvailableReason string = "VaultNotAvailable"
+
+	// IntegrationConditionResumeAvailable --
+	IntegrationConditionResumeAvailable IntegrationConditionType = "ResumeAvailable"
+	// IntegrationConditionResumeAvailableReason --
+	IntegrationConditionResumeAvailableReason string = "ResumeAvailable"
+	// IntegrationConditionResumeNotAvailableReason --
+	IntegrationConditionResumeNotAvailableReason string = "ResumeNotAvailable"
 )
 
 // IntegrationCondition describes the state of a resource at a certain point.
@@ -246,4 +270,6 @@ type PodSpec struct {
 	NodeSelector map[string]string `json:"nodeSelector,omitempty" protobuf:"bytes,7,rep,name=nodeSelector"`
 
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty" patchStrategy:"merge" patchMergeKey:"topologyKey" protobuf:"bytes,33,opt,name=topologySpreadConstraints"`
+
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty" patchStrategy:"merge" patchMergeKey:"ip" protobuf:"bytes,23,rep,name=hostAliases"`
 }
@@ -63,10 +63,15 @@ func (in *Integration) Initialize() {
 	}
 }
 
-// Sources return a new slice containing all the sources associated to the integration
+// Sources return a new slice containing all the sources associated to the integration.
+// The remote sources are only returned once their content has been fetched into the generated sources.
 func (in *Integration) Sources() []SourceSpec {
 	sources := make([]SourceSpec, 0, len(in.Spec.Sources)+len(in.Status.GeneratedSources))
-	sources = append(sources, in.Spec.Sources...)
+	for _, source := range in.Spec.Sources {
+		if source.Location == "" {
+			sources = append(sources, source)
+		}
+	}
 	sources = append(sources, in.Status.GeneratedSources...)
 
 	return sources
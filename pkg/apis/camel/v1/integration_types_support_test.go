@@ -73,3 +73,15 @@ func TestAddDependency(t *testing.T) {
 	integration.AddDependency("file:dep")
 	assert.Equal(t, integration.Dependencies, []string{"file:dep"})
 }
+
+func TestOperatorIDAnnotation(t *testing.T) {
+	integration := NewIntegration("default", "test")
+	assert.Empty(t, GetOperatorIDAnnotation(&integration))
+
+	SetOperatorIDAnnotation(&integration, "my-operator")
+	assert.Equal(t, "my-operator", GetOperatorIDAnnotation(&integration))
+
+	SetOperatorIDAnnotation(&integration, "")
+	assert.Empty(t, GetOperatorIDAnnotation(&integration))
+	assert.NotContains(t, integration.Annotations, OperatorIDAnnotation)
+}
@@ -96,6 +96,15 @@ const (
 	// IntegrationKitTypeExternal --
 	IntegrationKitTypeExternal = "external"
 
+	// IntegrationKitLayoutLabel --
+	IntegrationKitLayoutLabel = "camel.apache.org/kit.layout"
+
+	// IntegrationKitLayoutFastJar --
+	IntegrationKitLayoutFastJar = "fast-jar"
+
+	// IntegrationKitLayoutNative --
+	IntegrationKitLayoutNative = "native"
+
 	// IntegrationKitPhaseNone --
 	IntegrationKitPhaseNone IntegrationKitPhase = ""
 	// IntegrationKitPhaseInitialization --
@@ -115,6 +124,8 @@ const (
 	IntegrationKitConditionPlatformAvailable IntegrationKitConditionType = "IntegrationPlatformAvailable"
 	// IntegrationKitConditionPlatformAvailableReason --
 	IntegrationKitConditionPlatformAvailableReason string = "IntegrationPlatformAvailable"
+	// IntegrationKitConditionArtifactMissing --
+	IntegrationKitConditionArtifactMissing IntegrationKitConditionType = "ArtifactMissing"
 )
 
 // IntegrationKitCondition describes the state of a resource at a certain point.
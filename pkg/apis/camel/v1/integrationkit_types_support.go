@@ -64,6 +64,23 @@ func (in *IntegrationKit) Configurations() []ConfigurationSpec {
 	return in.Spec.Configuration
 }
 
+// Layout returns the layout of the kit artifacts, defaulting to fast-jar when the kit has no layout label
+func (in *IntegrationKit) Layout() string {
+	if in == nil {
+		return IntegrationKitLayoutFastJar
+	}
+	if layout, ok := in.Labels[IntegrationKitLayoutLabel]; ok && layout != "" {
+		return layout
+	}
+
+	return IntegrationKitLayoutFastJar
+}
+
+// IsNative returns true if the kit contains a native executable instead of JVM artifacts
+func (in *IntegrationKit) IsNative() bool {
+	return in.Layout() == IntegrationKitLayoutNative
+}
+
 // SetIntegrationPlatform --
 func (in *IntegrationKit) SetIntegrationPlatform(platform *IntegrationPlatform) {
 	cs := corev1.ConditionTrue
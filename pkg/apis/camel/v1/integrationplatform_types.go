@@ -116,7 +116,46 @@ type IntegrationPlatformBuildSpec struct {
 	PersistentVolumeClaim string                                  `json:"persistentVolumeClaim,omitempty"`
 	Maven                 MavenSpec                               `json:"maven,omitempty"`
 	HTTPProxySecret       string                                  `json:"httpProxySecret,omitempty"`
-	KanikoBuildCache      *bool                                   `json:"kanikoBuildCache,omitempty"`
+	// The URL of the proxy server for HTTP requests, set as the HTTP_PROXY environment variable of the builds
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// The URL of the proxy server for HTTPS requests, set as the HTTPS_PROXY environment variable of the builds
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// The comma-separated list of hosts that are accessed without proxy, set as the NO_PROXY environment variable of the builds
+	NoProxy          string `json:"noProxy,omitempty"`
+	KanikoBuildCache *bool  `json:"kanikoBuildCache,omitempty"`
+	// The size of the persistent volume claim used by the Kaniko cache (default `1Gi`)
+	KanikoBuildCacheSize string `json:"kanikoBuildCacheSize,omitempty"`
+	// The storage class of the persistent volume claim used by the Kaniko cache,
+	// the cluster default storage class being used when not set
+	KanikoBuildCacheStorageClass string `json:"kanikoBuildCacheStorageClass,omitempty"`
+	// Whether Buildah runs as a non-root user, with the chroot isolation, when using the Buildah publish strategy
+	BuildahRootless *bool `json:"buildahRootless,omitempty"`
+	// The address of the BuildKit daemon, e.g. `tcp://buildkitd.buildkit:1234`, when using the BuildKit publish strategy.
+	// The BuildKit daemon runs rootless in the build pod when not set.
+	BuildKitAddress string `json:"buildKitAddress,omitempty"`
+	// Whether the BuildKit layer cache is exported to, and imported from, the registry (default `true`)
+	BuildKitCache *bool `json:"buildKitCache,omitempty"`
+	// The image the BuildKit layer cache is exported to (default `camel-k-buildkit-cache` in the registry organization)
+	BuildKitCacheImage string `json:"buildKitCacheImage,omitempty"`
+	// The maximum number of times a build, that has failed with a transient error, is retried (default `5`)
+	MaxRetries *int `json:"maxRetries,omitempty"`
+	// The maximum number of builds running concurrently in the namespace (default `1`, for the builds to run sequentially)
+	MaxRunningBuilds int32 `json:"maxRunningBuilds,omitempty"`
+	// Whether the builds run in offline mode, for disconnected clusters.
+	// The dependencies are only resolved from the local Maven repository, that must be pre-populated,
+	// e.g. with the Maven cache persistent volume claim, and the base image must be pulled from a mirror registry.
+	Offline *bool `json:"offline,omitempty"`
+	// The compute resources of the build pod containers, applicable when the builds are executed with the pod strategy.
+	// They can be overridden per kit with the builder trait.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// The node selector of the build pods, applicable when the builds are executed with the pod strategy
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// The tolerations of the build pods, applicable when the builds are executed with the pod strategy
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// The platforms the kit images are built for, e.g. `linux/amd64` and `linux/arm64`, so that the integrations
+	// can run on nodes of different architectures. The kit images are then published as a manifest list.
+	// Only supported by the Jib and BuildKit publish strategies, and for JVM kits.
+	Platforms []string `json:"platforms,omitempty"`
 }
 
 // IntegrationPlatformRegistrySpec --
@@ -160,6 +199,10 @@ type IntegrationPlatformBuildPublishStrategy string
 const (
 	// IntegrationPlatformBuildPublishStrategyBuildah --
 	IntegrationPlatformBuildPublishStrategyBuildah IntegrationPlatformBuildPublishStrategy = "Buildah"
+	// IntegrationPlatformBuildPublishStrategyBuildKit --
+	IntegrationPlatformBuildPublishStrategyBuildKit IntegrationPlatformBuildPublishStrategy = "BuildKit"
+	// IntegrationPlatformBuildPublishStrategyJib --
+	IntegrationPlatformBuildPublishStrategyJib IntegrationPlatformBuildPublishStrategy = "Jib"
 	// IntegrationPlatformBuildPublishStrategyKaniko --
 	IntegrationPlatformBuildPublishStrategyKaniko IntegrationPlatformBuildPublishStrategy = "Kaniko"
 	// IntegrationPlatformBuildPublishStrategyS2I --
@@ -171,6 +214,8 @@ const (
 // IntegrationPlatformBuildPublishStrategies --
 var IntegrationPlatformBuildPublishStrategies = []IntegrationPlatformBuildPublishStrategy{
 	IntegrationPlatformBuildPublishStrategyBuildah,
+	IntegrationPlatformBuildPublishStrategyBuildKit,
+	IntegrationPlatformBuildPublishStrategyJib,
 	IntegrationPlatformBuildPublishStrategyKaniko,
 	IntegrationPlatformBuildPublishStrategyS2I,
 	IntegrationPlatformBuildPublishStrategySpectrum,
@@ -198,6 +243,14 @@ const (
 	IntegrationPlatformPhaseError IntegrationPlatformPhase = "Error"
 	// IntegrationPlatformPhaseDuplicate --
 	IntegrationPlatformPhaseDuplicate IntegrationPlatformPhase = "Duplicate"
+
+	// IntegrationPlatformConditionKnativeAvailable --
+	IntegrationPlatformConditionKnativeAvailable IntegrationPlatformConditionType = "KnativeAvailable"
+
+	// IntegrationPlatformConditionKnativeAvailableReason --
+	IntegrationPlatformConditionKnativeAvailableReason string = "KnativeAvailable"
+	// IntegrationPlatformConditionKnativeNotAvailableReason --
+	IntegrationPlatformConditionKnativeNotAvailableReason string = "KnativeNotAvailable"
 )
 
 // IntegrationPlatformCondition describes the state of a resource at a certain point.
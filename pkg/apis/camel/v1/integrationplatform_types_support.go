@@ -187,6 +187,20 @@ func (b IntegrationPlatformBuildSpec) IsKanikoCacheEnabled() bool {
 	return *b.KanikoBuildCache
 }
 
+// IsBuildKitCacheEnabled tells if the BuildKit registry cache is enabled on the integration platform build spec
+func (b IntegrationPlatformBuildSpec) IsBuildKitCacheEnabled() bool {
+	if b.BuildKitCache == nil {
+		// Cache is enabled by default
+		return true
+	}
+	return *b.BuildKitCache
+}
+
+// IsOffline tells if the builds run in offline mode, only resolving the dependencies from the local Maven repository
+func (b IntegrationPlatformBuildSpec) IsOffline() bool {
+	return b.Offline != nil && *b.Offline
+}
+
 // GetTimeout returns the specified duration or a default one
 func (b IntegrationPlatformBuildSpec) GetTimeout() metav1.Duration {
 	if b.Timeout == nil {
@@ -195,6 +209,14 @@ func (b IntegrationPlatformBuildSpec) GetTimeout() metav1.Duration {
 	return *b.Timeout
 }
 
+// GetMaxRetries returns the maximum number of retries of failed builds or the default one
+func (b IntegrationPlatformBuildSpec) GetMaxRetries() int {
+	if b.MaxRetries == nil {
+		return BuildDefaultMaxRetries
+	}
+	return *b.MaxRetries
+}
+
 var _ ResourceCondition = IntegrationPlatformCondition{}
 
 // GetConditions --
@@ -79,4 +79,6 @@ const (
 	CamelMetaEndpointKind = "camel.endpoint.kind"
 
 	CamelMetaFilterPrefix = "filter."
+
+	CamelMetaCeOverridePrefix = "ce.override."
 )
@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 // Repository --
 type Repository struct {
 	ID        string           `xml:"id" json:"id"`
@@ -32,3 +36,39 @@ type RepositoryPolicy struct {
 	UpdatePolicy   string `xml:"updatePolicy,omitempty" json:"updatePolicy,omitempty"`
 	ChecksumPolicy string `xml:"checksumPolicy,omitempty" json:"checksumPolicy,omitempty"`
 }
+
+// Mirror --
+type Mirror struct {
+	ID       string `xml:"id" json:"id"`
+	Name     string `xml:"name,omitempty" json:"name,omitempty"`
+	URL      string `xml:"url" json:"url"`
+	MirrorOf string `xml:"mirrorOf" json:"mirrorOf"`
+}
+
+// MavenProxy configures a proxy used by the Maven builds to connect to the remote repositories
+type MavenProxy struct {
+	// The proxy ID, that must be unique.
+	ID string `json:"id,omitempty"`
+	// The protocol of the proxy server, e.g. `http` or `https` (default `http`).
+	Protocol string `json:"protocol,omitempty"`
+	// The proxy server host.
+	Host string `json:"host"`
+	// The proxy server port.
+	Port int `json:"port,omitempty"`
+	// The list of hosts that are not accessed through the proxy, separated by `|`, e.g. `*.example.com|localhost`.
+	NonProxyHosts string `json:"nonProxyHosts,omitempty"`
+	// The Secret key containing the username used to authenticate to the proxy server.
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+	// The Secret key containing the password used to authenticate to the proxy server.
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+}
+
+// MavenServer configures the credentials used to authenticate to the repository, or the mirror, with the same ID
+type MavenServer struct {
+	// The ID of the repository, or of the mirror.
+	ID string `json:"id"`
+	// The Secret key containing the username.
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+	// The Secret key containing the password.
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+}
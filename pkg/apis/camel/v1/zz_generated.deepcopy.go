@@ -25,6 +25,21 @@ func (in *Artifact) DeepCopy() *Artifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactVerification) DeepCopyInto(out *ArtifactVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactVerification.
+func (in *ArtifactVerification) DeepCopy() *ArtifactVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseTask) DeepCopyInto(out *BaseTask) {
 	*out = *in
@@ -84,6 +99,28 @@ func (in *BuildCondition) DeepCopy() *BuildCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildKitTask) DeepCopyInto(out *BuildKitTask) {
+	*out = *in
+	out.BaseTask = in.BaseTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
+	if in.Verbose != nil {
+		in, out := &in.Verbose, &out.Verbose
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildKitTask.
+func (in *BuildKitTask) DeepCopy() *BuildKitTask {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildKitTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildList) DeepCopyInto(out *BuildList) {
 	*out = *in
@@ -127,6 +164,30 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		}
 	}
 	out.Timeout = in.Timeout
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSpec.
@@ -147,6 +208,11 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = make([]Artifact, len(*in))
 		copy(*out, *in)
 	}
+	if in.Verifications != nil {
+		in, out := &in.Verifications, &out.Verifications
+		*out = make([]ArtifactVerification, len(*in))
+		copy(*out, *in)
+	}
 	if in.Failure != nil {
 		in, out := &in.Failure, &out.Failure
 		*out = new(Failure)
@@ -179,12 +245,17 @@ func (in *BuildStatus) DeepCopy() *BuildStatus {
 func (in *BuildahTask) DeepCopyInto(out *BuildahTask) {
 	*out = *in
 	out.BaseTask = in.BaseTask
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 	if in.Verbose != nil {
 		in, out := &in.Verbose, &out.Verbose
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Rootless != nil {
+		in, out := &in.Rootless, &out.Rootless
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildahTask.
@@ -221,6 +292,16 @@ func (in *BuilderTask) DeepCopyInto(out *BuilderTask) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependencyExclusions != nil {
+		in, out := &in.DependencyExclusions, &out.DependencyExclusions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependencyOverrides != nil {
+		in, out := &in.DependencyOverrides, &out.DependencyOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Steps != nil {
 		in, out := &in.Steps, &out.Steps
 		*out = make([]string, len(*in))
@@ -531,6 +612,27 @@ func (in *ConfigurationSpec) DeepCopy() *ConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomTask) DeepCopyInto(out *CustomTask) {
+	*out = *in
+	out.BaseTask = in.BaseTask
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTask.
+func (in *CustomTask) DeepCopy() *CustomTask {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSpec) DeepCopyInto(out *DataSpec) {
 	*out = *in
@@ -572,6 +674,13 @@ func (in *Failure) DeepCopy() *Failure {
 func (in *FailureRecovery) DeepCopyInto(out *FailureRecovery) {
 	*out = *in
 	in.AttemptTime.DeepCopyInto(&out.AttemptTime)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]FailureRecoveryAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureRecovery.
@@ -584,6 +693,22 @@ func (in *FailureRecovery) DeepCopy() *FailureRecovery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureRecoveryAttempt) DeepCopyInto(out *FailureRecoveryAttempt) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureRecoveryAttempt.
+func (in *FailureRecoveryAttempt) DeepCopy() *FailureRecoveryAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureRecoveryAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Flow) DeepCopyInto(out *Flow) {
 	*out = *in
@@ -871,6 +996,50 @@ func (in *IntegrationPlatformBuildSpec) DeepCopyInto(out *IntegrationPlatformBui
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BuildahRootless != nil {
+		in, out := &in.BuildahRootless, &out.BuildahRootless
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BuildKitCache != nil {
+		in, out := &in.BuildKitCache, &out.BuildKitCache
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.Offline != nil {
+		in, out := &in.Offline, &out.Offline
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformBuildSpec.
@@ -1188,11 +1357,28 @@ func (in *IntegrationStatus) DeepCopy() *IntegrationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JibTask) DeepCopyInto(out *JibTask) {
+	*out = *in
+	out.BaseTask = in.BaseTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JibTask.
+func (in *JibTask) DeepCopy() *JibTask {
+	if in == nil {
+		return nil
+	}
+	out := new(JibTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanikoTask) DeepCopyInto(out *KanikoTask) {
 	*out = *in
 	out.BaseTask = in.BaseTask
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 	if in.Verbose != nil {
 		in, out := &in.Verbose, &out.Verbose
 		*out = new(bool)
@@ -1246,9 +1432,84 @@ func (in *MavenArtifact) DeepCopy() *MavenArtifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenCacheSpec) DeepCopyInto(out *MavenCacheSpec) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenCacheSpec.
+func (in *MavenCacheSpec) DeepCopy() *MavenCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenProxy) DeepCopyInto(out *MavenProxy) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenProxy.
+func (in *MavenProxy) DeepCopy() *MavenProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenServer) DeepCopyInto(out *MavenServer) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenServer.
+func (in *MavenServer) DeepCopy() *MavenServer {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 	*out = *in
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(MavenCacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
 		*out = make(map[string]string, len(*in))
@@ -1257,6 +1518,7 @@ func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 		}
 	}
 	in.Settings.DeepCopyInto(&out.Settings)
+	in.SettingsSecurity.DeepCopyInto(&out.SettingsSecurity)
 	if in.CASecret != nil {
 		in, out := &in.CASecret, &out.CASecret
 		*out = new(corev1.SecretKeySelector)
@@ -1272,11 +1534,40 @@ func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 		*out = make([]Repository, len(*in))
 		copy(*out, *in)
 	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]Mirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.Proxies != nil {
+		in, out := &in.Proxies, &out.Proxies
+		*out = make([]MavenProxy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]MavenServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Extension != nil {
 		in, out := &in.Extension, &out.Extension
 		*out = make([]MavenArtifact, len(*in))
 		copy(*out, *in)
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(MavenVerificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CLIOptions != nil {
+		in, out := &in.CLIOptions, &out.CLIOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenSpec.
@@ -1289,6 +1580,58 @@ func (in *MavenSpec) DeepCopy() *MavenSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenVerificationSpec) DeepCopyInto(out *MavenVerificationSpec) {
+	*out = *in
+	in.Keyring.DeepCopyInto(&out.Keyring)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenVerificationSpec.
+func (in *MavenVerificationSpec) DeepCopy() *MavenVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mirror) DeepCopyInto(out *Mirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mirror.
+func (in *Mirror) DeepCopy() *Mirror {
+	if in == nil {
+		return nil
+	}
+	out := new(Mirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NativeTask) DeepCopyInto(out *NativeTask) {
+	*out = *in
+	out.BaseTask = in.BaseTask
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NativeTask.
+func (in *NativeTask) DeepCopy() *NativeTask {
+	if in == nil {
+		return nil
+	}
+	out := new(NativeTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 	*out = *in
@@ -1344,6 +1687,13 @@ func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSpec.
@@ -1376,6 +1726,11 @@ func (in *PodSpecTemplate) DeepCopy() *PodSpecTemplate {
 func (in *PublishTask) DeepCopyInto(out *PublishTask) {
 	*out = *in
 	out.Registry = in.Registry
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishTask.
@@ -1535,7 +1890,7 @@ func (in *SourceSpec) DeepCopy() *SourceSpec {
 func (in *SpectrumTask) DeepCopyInto(out *SpectrumTask) {
 	*out = *in
 	out.BaseTask = in.BaseTask
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpectrumTask.
@@ -1556,6 +1911,11 @@ func (in *Task) DeepCopyInto(out *Task) {
 		*out = new(BuilderTask)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Native != nil {
+		in, out := &in.Native, &out.Native
+		*out = new(NativeTask)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Buildah != nil {
 		in, out := &in.Buildah, &out.Buildah
 		*out = new(BuildahTask)
@@ -1566,16 +1926,31 @@ func (in *Task) DeepCopyInto(out *Task) {
 		*out = new(KanikoTask)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BuildKit != nil {
+		in, out := &in.BuildKit, &out.BuildKit
+		*out = new(BuildKitTask)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jib != nil {
+		in, out := &in.Jib, &out.Jib
+		*out = new(JibTask)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Spectrum != nil {
 		in, out := &in.Spectrum, &out.Spectrum
 		*out = new(SpectrumTask)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.S2i != nil {
 		in, out := &in.S2i, &out.S2i
 		*out = new(S2iTask)
 		**out = **in
 	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomTask)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Task.
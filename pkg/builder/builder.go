@@ -61,6 +61,7 @@ func (t *builderTask) Do(ctx context.Context) v1.BuildStatus {
 		Path:      buildDir,
 		Namespace: t.build.Namespace,
 		Build:     *t.task,
+		BuildName: t.build.Name,
 		BaseImage: t.task.BaseImage,
 	}
 
@@ -141,6 +142,9 @@ steps:
 	result.BaseImage = c.BaseImage
 	result.Artifacts = make([]v1.Artifact, 0, len(c.Artifacts))
 	result.Artifacts = append(result.Artifacts, c.Artifacts...)
+	if len(c.Verifications) > 0 {
+		result.Verifications = c.Verifications
+	}
 
 	t.log.Infof("dependencies: %s", t.task.Dependencies)
 	t.log.Infof("artifacts: %s", artifactIDs(c.Artifacts))
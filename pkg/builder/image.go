@@ -18,6 +18,8 @@ limitations under the License.
 package builder
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
@@ -29,6 +31,7 @@ import (
 	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/controller"
+	"github.com/apache/camel-k/pkg/util/digest"
 )
 
 const (
@@ -97,11 +100,29 @@ func imageContext(ctx *builderContext, selector artifactsSelector) error {
 		return err
 	}
 
+	checksums := make(map[string]string, len(ctx.Verifications))
+	for _, v := range ctx.Verifications {
+		checksums[v.ID] = v.SHA256
+	}
+
 	for _, entry := range ctx.SelectedArtifacts {
+		if entry.Location == "" {
+			// The artifact is produced into the context directory by a subsequent task
+			continue
+		}
 		_, err := util.CopyFile(entry.Location, path.Join(contextDir, entry.Target))
 		if err != nil {
 			return err
 		}
+		if checksum, ok := checksums[entry.ID]; ok {
+			sha256, err := digest.ComputeSHA256(contextDir, entry.Target)
+			if err != nil {
+				return err
+			}
+			if sha256 != checksum {
+				return fmt.Errorf("SHA-256 checksum mismatch for artifact %s: expected %s, got %s", entry.ID, checksum, sha256)
+			}
+		}
 	}
 
 	for _, entry := range ctx.Resources {
@@ -151,12 +172,35 @@ func listPublishedImages(context *builderContext) ([]v1.IntegrationKitStatus, er
 		if kit.Status.Phase != v1.IntegrationKitPhaseReady {
 			continue
 		}
+		// Native kit images do not contain any JVM artifacts to be reused
+		if kit.IsNative() {
+			continue
+		}
+		// Kit images built from a custom base image must not leak into other kits
+		if hasCustomBaseImage(kit) {
+			continue
+		}
 
 		images = append(images, kit.Status)
 	}
 	return images, nil
 }
 
+// hasCustomBaseImage returns whether the kit image has been built from the base image configured
+// with the builder trait, rather than the platform one
+func hasCustomBaseImage(kit v1.IntegrationKit) bool {
+	spec, ok := kit.Spec.Traits["builder"]
+	if !ok || len(spec.Configuration.RawMessage) == 0 {
+		return false
+	}
+	config := make(map[string]interface{})
+	if err := json.Unmarshal(spec.Configuration.RawMessage, &config); err != nil {
+		return false
+	}
+	baseImage, ok := config["baseImage"].(string)
+	return ok && baseImage != ""
+}
+
 func findBestImage(images []v1.IntegrationKitStatus, artifacts []v1.Artifact) (v1.IntegrationKitStatus, map[string]bool) {
 	var bestImage v1.IntegrationKitStatus
 
@@ -77,6 +77,36 @@ func TestListPublishedImages(t *testing.T) {
 				RuntimeProvider: catalog.Runtime.Provider,
 			},
 		},
+		&v1.IntegrationKit{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKitKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-kit-3",
+				Labels: map[string]string{
+					"camel.apache.org/kit.type":         v1.IntegrationKitTypePlatform,
+					"camel.apache.org/runtime.version":  catalog.Runtime.Version,
+					"camel.apache.org/runtime.provider": string(catalog.Runtime.Provider),
+				},
+			},
+			Spec: v1.IntegrationKitSpec{
+				Traits: map[string]v1.TraitSpec{
+					"builder": {
+						Configuration: v1.TraitConfiguration{
+							RawMessage: []byte(`{"baseImage":"gcr.io/distroless/java17"}`),
+						},
+					},
+				},
+			},
+			Status: v1.IntegrationKitStatus{
+				Phase:           v1.IntegrationKitPhaseReady,
+				Image:           "image-3",
+				RuntimeVersion:  catalog.Runtime.Version,
+				RuntimeProvider: catalog.Runtime.Provider,
+			},
+		},
 	)
 
 	assert.Nil(t, err)
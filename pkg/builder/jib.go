@@ -0,0 +1,220 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+const (
+	jibDir             = "jib"
+	jibLayersDir       = "layers"
+	jibDependenciesDir = "dependencies"
+	jibApplicationDir  = "application"
+	jibDigestFile      = "target/jib-image.digest"
+)
+
+type jibTask struct {
+	c     client.Client
+	build *v1.Build
+	task  *v1.JibTask
+}
+
+var _ Task = &jibTask{}
+
+func (t *jibTask) Do(ctx context.Context) v1.BuildStatus {
+	status := v1.BuildStatus{}
+
+	baseImage := t.build.Status.BaseImage
+	if baseImage == "" {
+		baseImage = t.task.BaseImage
+		status.BaseImage = baseImage
+	}
+
+	contextDir := t.task.ContextDir
+	if contextDir == "" {
+		// Use the working directory.
+		// This is useful when the task is executed in-container,
+		// so that its WorkingDir can be used to share state and
+		// coordinate with other tasks.
+		pwd, err := os.Getwd()
+		if err != nil {
+			return status.Failed(err)
+		}
+		contextDir = path.Join(pwd, ContextDir)
+	}
+	buildDir := path.Dir(contextDir)
+	projectDir := path.Join(buildDir, jibDir)
+
+	layers, err := prepareJibLayers(contextDir, path.Join(projectDir, jibLayersDir))
+	if err != nil {
+		return status.Failed(err)
+	}
+	// Jib expects the project output directory, that has nothing to contribute to the image
+	if err := os.MkdirAll(path.Join(projectDir, "target", "classes"), os.ModePerm); err != nil {
+		return status.Failed(err)
+	}
+
+	mc := maven.NewContext(projectDir)
+	// Reuse the Maven configuration of the builder task, that has resolved the integration dependencies
+	settings, err := ioutil.ReadFile(path.Join(buildDir, "maven", "settings.xml"))
+	if err == nil {
+		mc.SettingsContent = settings
+	} else if !os.IsNotExist(err) {
+		return status.Failed(err)
+	}
+	settingsSecurity, err := ioutil.ReadFile(path.Join(buildDir, "maven", "settings-security.xml"))
+	if err == nil {
+		mc.SettingsSecurityContent = settingsSecurity
+	} else if !os.IsNotExist(err) {
+		return status.Failed(err)
+	}
+	for _, task := range t.build.Spec.Tasks {
+		if task.Builder != nil {
+			mc.LocalRepository = task.Builder.Maven.LocalRepository
+			mc.AddArguments(task.Builder.Maven.CLIOptions...)
+		}
+	}
+
+	if t.task.Registry.Secret != "" {
+		// Jib looks up the registry credentials into the Docker configuration of the user home directory
+		registryConfigDir, err := mountSecret(ctx, t.c, t.build.Namespace, t.task.Registry.Secret)
+		if err != nil {
+			return status.Failed(err)
+		}
+		defer os.RemoveAll(registryConfigDir)
+
+		home, err := ioutil.TempDir("", "jib-home-")
+		if err != nil {
+			return status.Failed(err)
+		}
+		defer os.RemoveAll(home)
+		if err := os.Rename(registryConfigDir, path.Join(home, ".docker")); err != nil {
+			return status.Failed(err)
+		}
+		mc.ExtraMavenOpts = append(mc.ExtraMavenOpts, "-Duser.home="+home)
+	}
+
+	mc.AddArgument("com.google.cloud.tools:jib-maven-plugin:" + defaults.JibVersion + ":build")
+	properties := jibProperties(baseImage, t.task.Image, t.task.Registry.Insecure, layers, t.task.Platforms)
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		mc.AddSystemProperty(k, properties[k])
+	}
+
+	log.Debugf("Registry address: %s", t.task.Registry.Address)
+	log.Debugf("Base image: %s", baseImage)
+
+	project := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration-image", defaults.Version)
+	if err := project.Command(mc).Do(ctx); err != nil {
+		return status.Failed(errors.Wrap(err, "failure while building the image with Jib"))
+	}
+
+	digest, err := ioutil.ReadFile(path.Join(projectDir, jibDigestFile))
+	if err != nil {
+		return status.Failed(errors.Wrap(err, "unable to read the digest of the image built with Jib"))
+	}
+
+	status.Image = t.task.Image
+	status.Digest = strings.TrimSpace(string(digest))
+
+	return status
+}
+
+// prepareJibLayers moves the content of the image build context into the directories Jib adds as
+// separate layers, so that the dependencies, that change less often, are layered below the application.
+// It returns the layer directories, in order.
+func prepareJibLayers(contextDir string, layersDir string) ([]string, error) {
+	layers := make([]string, 0, 2)
+	deploymentDir := strings.TrimPrefix(DeploymentDir, "/")
+
+	dependencies := path.Join(contextDir, DependenciesDir, "lib")
+	if _, err := os.Stat(dependencies); err == nil {
+		layer := path.Join(layersDir, jibDependenciesDir)
+		target := path.Join(layer, deploymentDir, DependenciesDir, "lib")
+		if err := os.MkdirAll(path.Dir(target), os.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(dependencies, target); err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(contextDir)
+	if err != nil {
+		return nil, err
+	}
+	layer := path.Join(layersDir, jibApplicationDir)
+	if err := os.MkdirAll(path.Join(layer, deploymentDir), os.ModePerm); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		// The Dockerfile is only used by the other publish strategies
+		if entry.Name() == "Dockerfile" {
+			continue
+		}
+		if err := os.Rename(path.Join(contextDir, entry.Name()), path.Join(layer, deploymentDir, entry.Name())); err != nil {
+			return nil, err
+		}
+	}
+	layers = append(layers, layer)
+
+	return layers, nil
+}
+
+// jibProperties returns the Jib Maven plugin configuration.
+func jibProperties(baseImage string, image string, insecure bool, layers []string, platforms []string) map[string]string {
+	properties := map[string]string{
+		"jib.from.image":             baseImage,
+		"jib.to.image":               image,
+		"jib.container.entrypoint":   "INHERIT",
+		"jib.container.user":         "1000",
+		"jib.extraDirectories.paths": strings.Join(layers, ","),
+		"jib.console":                "plain",
+	}
+	if insecure {
+		properties["jib.allowInsecureRegistries"] = strconv.FormatBool(insecure)
+		properties["sendCredentialsOverHttp"] = strconv.FormatBool(insecure)
+	}
+	if len(platforms) > 0 {
+		// Jib builds an image per platform from the base image manifest list, and pushes them as a manifest list
+		properties["jib.from.platforms"] = strings.Join(platforms, ",")
+	}
+	return properties
+}
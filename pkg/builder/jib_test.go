@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareJibLayers(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "jib-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	contextDir := path.Join(tmpDir, ContextDir)
+	assert.Nil(t, os.MkdirAll(path.Join(contextDir, DependenciesDir, "lib", "main"), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path.Join(contextDir, DependenciesDir, "lib", "main", "camel-core.jar"), []byte{}, 0644))
+	assert.Nil(t, ioutil.WriteFile(path.Join(contextDir, DependenciesDir, "quarkus-run.jar"), []byte{}, 0644))
+	assert.Nil(t, ioutil.WriteFile(path.Join(contextDir, "Dockerfile"), []byte{}, 0644))
+
+	layersDir := path.Join(tmpDir, jibDir, jibLayersDir)
+	layers, err := prepareJibLayers(contextDir, layersDir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		path.Join(layersDir, jibDependenciesDir),
+		path.Join(layersDir, jibApplicationDir),
+	}, layers)
+
+	assert.FileExists(t, path.Join(layersDir, jibDependenciesDir, "deployments", DependenciesDir, "lib", "main", "camel-core.jar"))
+	assert.FileExists(t, path.Join(layersDir, jibApplicationDir, "deployments", DependenciesDir, "quarkus-run.jar"))
+	assert.NoFileExists(t, path.Join(layersDir, jibApplicationDir, "deployments", "Dockerfile"))
+}
+
+func TestPrepareJibLayersWithoutDependencies(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "jib-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	contextDir := path.Join(tmpDir, ContextDir)
+	assert.Nil(t, os.MkdirAll(contextDir, os.ModePerm))
+
+	layersDir := path.Join(tmpDir, jibDir, jibLayersDir)
+	layers, err := prepareJibLayers(contextDir, layersDir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{path.Join(layersDir, jibApplicationDir)}, layers)
+}
+
+func TestJibProperties(t *testing.T) {
+	properties := jibProperties("adoptopenjdk/openjdk11:slim", "registry/ns/kit:1", false, []string{"/layers/a", "/layers/b"}, nil)
+	assert.Equal(t, "adoptopenjdk/openjdk11:slim", properties["jib.from.image"])
+	assert.Equal(t, "registry/ns/kit:1", properties["jib.to.image"])
+	assert.Equal(t, "/layers/a,/layers/b", properties["jib.extraDirectories.paths"])
+	assert.NotContains(t, properties, "jib.allowInsecureRegistries")
+	assert.NotContains(t, properties, "jib.from.platforms")
+
+	properties = jibProperties("adoptopenjdk/openjdk11:slim", "registry/ns/kit:1", true, nil, nil)
+	assert.Equal(t, "true", properties["jib.allowInsecureRegistries"])
+
+	properties = jibProperties("adoptopenjdk/openjdk11:slim", "registry/ns/kit:1", false, nil, []string{"linux/amd64", "linux/arm64"})
+	assert.Equal(t, "linux/amd64,linux/arm64", properties["jib.from.platforms"])
+}
@@ -18,11 +18,24 @@ limitations under the License.
 package builder
 
 import (
+	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/jvm"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/maven"
 )
 
 func init() {
@@ -30,31 +43,45 @@ func init() {
 }
 
 type steps struct {
-	CleanUpBuildDir         Step
-	GenerateJavaKeystore    Step
-	GenerateProjectSettings Step
-	InjectDependencies      Step
-	SanitizeDependencies    Step
-	StandardImageContext    Step
-	IncrementalImageContext Step
+	CleanUpBuildDir           Step
+	PruneMavenCache           Step
+	MarkMavenCacheUsed        Step
+	GenerateJavaKeystore      Step
+	GenerateProjectSettings   Step
+	InjectDependencies        Step
+	SanitizeDependencies      Step
+	ManageDependencyOverrides Step
+	CheckOfflineDependencies  Step
+	StandardImageContext      Step
+	IncrementalImageContext   Step
+	VerifyArtifacts           Step
 }
 
 var Steps = steps{
-	CleanUpBuildDir:         NewStep(ProjectGenerationPhase-1, cleanUpBuildDir),
-	GenerateJavaKeystore:    NewStep(ProjectGenerationPhase, generateJavaKeystore),
-	GenerateProjectSettings: NewStep(ProjectGenerationPhase+1, generateProjectSettings),
-	InjectDependencies:      NewStep(ProjectGenerationPhase+2, injectDependencies),
-	SanitizeDependencies:    NewStep(ProjectGenerationPhase+3, sanitizeDependencies),
-	StandardImageContext:    NewStep(ApplicationPackagePhase, standardImageContext),
-	IncrementalImageContext: NewStep(ApplicationPackagePhase, incrementalImageContext),
+	CleanUpBuildDir:           NewStep(ProjectGenerationPhase-1, cleanUpBuildDir),
+	PruneMavenCache:           NewStep(ProjectGenerationPhase-1, pruneMavenCache),
+	MarkMavenCacheUsed:        NewStep(ProjectBuildPhase+3, markMavenCacheUsed),
+	GenerateJavaKeystore:      NewStep(ProjectGenerationPhase, generateJavaKeystore),
+	GenerateProjectSettings:   NewStep(ProjectGenerationPhase+1, generateProjectSettings),
+	InjectDependencies:        NewStep(ProjectGenerationPhase+2, injectDependencies),
+	SanitizeDependencies:      NewStep(ProjectGenerationPhase+3, sanitizeDependencies),
+	ManageDependencyOverrides: NewStep(ProjectGenerationPhase+4, manageDependencyOverrides),
+	CheckOfflineDependencies:  NewStep(ProjectGenerationPhase+5, checkOfflineDependencies),
+	StandardImageContext:      NewStep(ApplicationPackagePhase, standardImageContext),
+	IncrementalImageContext:   NewStep(ApplicationPackagePhase, incrementalImageContext),
+	VerifyArtifacts:           NewStep(ProjectBuildPhase+2, verifyArtifacts),
 }
 
 var DefaultSteps = []Step{
 	Steps.CleanUpBuildDir,
+	Steps.PruneMavenCache,
+	Steps.MarkMavenCacheUsed,
 	Steps.GenerateJavaKeystore,
 	Steps.GenerateProjectSettings,
 	Steps.InjectDependencies,
 	Steps.SanitizeDependencies,
+	Steps.ManageDependencyOverrides,
+	Steps.CheckOfflineDependencies,
 	Steps.IncrementalImageContext,
 }
 
@@ -71,6 +98,134 @@ func cleanUpBuildDir(ctx *builderContext) error {
 	return os.MkdirAll(ctx.Build.BuildDir, 0777)
 }
 
+// mavenCachePruneInterval is the minimum duration between two prunings of the Maven cache
+const mavenCachePruneInterval = time.Hour
+
+// pruneMavenCache removes the artifacts from the local Maven repository that are older,
+// or above the size, configured for the cache, before the project dependencies get resolved.
+// The builds sharing the cache prune it in turn, at most once per interval, as coordinated by a Lease.
+func pruneMavenCache(ctx *builderContext) error {
+	cache := ctx.Build.Maven.Cache
+	if cache == nil || ctx.Build.Maven.LocalRepository == "" {
+		return nil
+	}
+	if maven.IsOffline(ctx.Build.Maven.CLIOptions) {
+		// Pruned artifacts cannot be downloaded again in offline mode
+		return nil
+	}
+
+	var maxAge time.Duration
+	if cache.MaxAge != nil {
+		maxAge = cache.MaxAge.Duration
+	}
+	var maxSize int64
+	if cache.MaxSize != "" {
+		size, err := k8sresource.ParseQuantity(cache.MaxSize)
+		if err != nil {
+			return errors.Wrapf(err, "invalid Maven cache size %s", cache.MaxSize)
+		}
+		maxSize = size.Value()
+	}
+	if maxAge <= 0 && maxSize <= 0 {
+		return nil
+	}
+
+	acquired, err := acquireMavenCacheLease(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		// Another build owns the pruning of the cache
+		return nil
+	}
+
+	pruned, err := maven.PruneLocalRepository(ctx.Build.Maven.LocalRepository, maxAge, maxSize, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(pruned) > 0 {
+		log.Infof("Pruned %d directories from the local Maven repository", len(pruned))
+	}
+
+	return nil
+}
+
+// acquireMavenCacheLease returns whether the build acquired the Lease that grants the pruning of the Maven cache.
+// The Lease is not released once the cache is pruned, so that the other builds do not prune it again
+// before it expires.
+func acquireMavenCacheLease(ctx *builderContext, now time.Time) (bool, error) {
+	name := "camel-k-maven-cache"
+	if ctx.Build.Maven.Cache.PersistentVolumeClaim != "" {
+		name += "-" + ctx.Build.Maven.Cache.PersistentVolumeClaim
+	}
+	holder := ctx.BuildName
+	duration := int32(mavenCachePruneInterval.Seconds())
+	renewTime := metav1.NewMicroTime(now)
+
+	lease := coordinationv1.Lease{}
+	err := ctx.Client.Get(ctx.C, ctrl.ObjectKey{Namespace: ctx.Namespace, Name: name}, &lease)
+	switch {
+	case k8serrors.IsNotFound(err):
+		lease = coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ctx.Namespace,
+				Name:      name,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &duration,
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		}
+		if err := ctx.Client.Create(ctx.C, &lease); err != nil {
+			if k8serrors.IsAlreadyExists(err) {
+				return false, nil
+			}
+			return false, errors.Wrap(err, "cannot acquire the Maven cache lease")
+		}
+		return true, nil
+	case err != nil:
+		return false, errors.Wrap(err, "cannot get the Maven cache lease")
+	}
+
+	if lease.Spec.RenewTime != nil && lease.Spec.LeaseDurationSeconds != nil {
+		expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+		if now.Before(expiry) {
+			return false, nil
+		}
+	}
+
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.AcquireTime = &renewTime
+	lease.Spec.RenewTime = &renewTime
+	// The update fails on conflict when another build acquires the lease concurrently
+	if err := ctx.Client.Update(ctx.C, &lease); err != nil {
+		if k8serrors.IsConflict(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "cannot acquire the Maven cache lease")
+	}
+
+	return true, nil
+}
+
+// markMavenCacheUsed records the use of the project dependencies in the local Maven repository,
+// so that the artifacts still used by the builds are not pruned by age
+func markMavenCacheUsed(ctx *builderContext) error {
+	if ctx.Build.Maven.Cache == nil || ctx.Build.Maven.LocalRepository == "" {
+		return nil
+	}
+
+	artifacts := make([]string, 0, len(ctx.Artifacts))
+	for _, artifact := range ctx.Artifacts {
+		artifacts = append(artifacts, artifact.ID)
+	}
+
+	return maven.MarkLocalRepositoryUsed(ctx.Build.Maven.LocalRepository, artifacts, time.Now())
+}
+
 func generateJavaKeystore(ctx *builderContext) error {
 	if ctx.Build.Maven.CASecret == nil {
 		return nil
@@ -96,6 +251,14 @@ func generateProjectSettings(ctx *builderContext) error {
 		ctx.Maven.SettingsData = []byte(val)
 	}
 
+	val, err = kubernetes.ResolveValueSource(ctx.C, ctx.Client, ctx.Namespace, &ctx.Build.Maven.SettingsSecurity)
+	if err != nil {
+		return err
+	}
+	if val != "" {
+		ctx.Maven.SettingsSecurityData = []byte(val)
+	}
+
 	return nil
 }
 
@@ -107,3 +270,71 @@ func injectDependencies(ctx *builderContext) error {
 func sanitizeDependencies(ctx *builderContext) error {
 	return camel.SanitizeIntegrationDependencies(ctx.Maven.Project.Dependencies)
 }
+
+// manageDependencyOverrides excludes the requested transitive dependencies from all the
+// project dependencies, and pins the version of the overridden ones in the dependency management
+func manageDependencyOverrides(ctx *builderContext) error {
+	if len(ctx.Build.DependencyExclusions) > 0 {
+		exclusions := make([]maven.Exclusion, 0, len(ctx.Build.DependencyExclusions))
+		for _, e := range ctx.Build.DependencyExclusions {
+			ga := strings.Split(e, ":")
+			if len(ga) != 2 || ga[0] == "" || ga[1] == "" {
+				return fmt.Errorf("dependency exclusion must have groupId:artifactId format, it was %s", e)
+			}
+			exclusions = append(exclusions, maven.Exclusion{GroupID: ga[0], ArtifactID: ga[1]})
+		}
+
+		for i := range ctx.Maven.Project.Dependencies {
+			d := &ctx.Maven.Project.Dependencies[i]
+			if d.Exclusions == nil {
+				d.Exclusions = &[]maven.Exclusion{}
+			}
+			*d.Exclusions = append(*d.Exclusions, exclusions...)
+		}
+	}
+
+	if len(ctx.Build.DependencyOverrides) > 0 {
+		if ctx.Maven.Project.DependencyManagement == nil {
+			ctx.Maven.Project.DependencyManagement = &maven.DependencyManagement{Dependencies: make([]maven.Dependency, 0)}
+		}
+
+		overrides := make([]maven.Dependency, 0, len(ctx.Build.DependencyOverrides))
+		for _, o := range ctx.Build.DependencyOverrides {
+			gav := strings.Split(o, ":")
+			if len(gav) != 3 || gav[0] == "" || gav[1] == "" || gav[2] == "" {
+				return fmt.Errorf("dependency override must have groupId:artifactId:version format, it was %s", o)
+			}
+			overrides = append(overrides, maven.Dependency{GroupID: gav[0], ArtifactID: gav[1], Version: gav[2]})
+
+			// The version of direct dependencies is pinned as well, as it would otherwise win
+			// over the managed one
+			for i := range ctx.Maven.Project.Dependencies {
+				d := &ctx.Maven.Project.Dependencies[i]
+				if d.GroupID == gav[0] && d.ArtifactID == gav[1] {
+					d.Version = gav[2]
+				}
+			}
+		}
+
+		// Explicitly managed dependencies take precedence over the imported BOMs,
+		// they are added first nonetheless for readability of the generated POM
+		ctx.Maven.Project.DependencyManagement.Dependencies = append(overrides, ctx.Maven.Project.DependencyManagement.Dependencies...)
+	}
+
+	return nil
+}
+
+// checkOfflineDependencies fails the offline builds early, when an artifact of the project is missing from
+// the local Maven repository, rather than once Maven has resolved all the other dependencies
+func checkOfflineDependencies(ctx *builderContext) error {
+	if !maven.IsOffline(ctx.Build.Maven.CLIOptions) || ctx.Build.Maven.LocalRepository == "" {
+		return nil
+	}
+
+	if artifact, missing := maven.FindMissingLocalArtifact(ctx.Build.Maven.LocalRepository, ctx.Maven.Project); missing {
+		return fmt.Errorf("cannot build in offline mode and the artifact %s has not been downloaded to the local Maven repository %s",
+			artifact, ctx.Build.Maven.LocalRepository)
+	}
+
+	return nil
+}
@@ -18,15 +18,23 @@ limitations under the License.
 package builder
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/maven"
 	"github.com/apache/camel-k/pkg/util/test"
 )
 
@@ -123,3 +131,186 @@ func TestMavenSettingsFromSecret(t *testing.T) {
 
 	assert.Equal(t, []byte("setting-data"), ctx.Maven.SettingsData)
 }
+
+func TestMavenSettingsSecurityFromSecret(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	c, err := test.NewFakeClient(
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "maven-settings",
+			},
+			Data: map[string][]byte{
+				"settings.xml":          []byte("setting-data"),
+				"settings-security.xml": []byte("setting-security-data"),
+			},
+		},
+	)
+
+	assert.Nil(t, err)
+
+	ctx := builderContext{
+		Catalog:   catalog,
+		Client:    c,
+		Namespace: "ns",
+		Build: v1.BuilderTask{
+			Runtime: catalog.Runtime,
+			Maven: v1.MavenSpec{
+				Settings: v1.ValueSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "maven-settings",
+						},
+						Key: "settings.xml",
+					},
+				},
+				SettingsSecurity: v1.ValueSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "maven-settings",
+						},
+						Key: "settings-security.xml",
+					},
+				},
+			},
+		},
+	}
+
+	err = Steps.GenerateProjectSettings.execute(&ctx)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []byte("setting-data"), ctx.Maven.SettingsData)
+	assert.Equal(t, []byte("setting-security-data"), ctx.Maven.SettingsSecurityData)
+}
+
+func TestManageDependencyOverrides(t *testing.T) {
+	ctx := builderContext{
+		Build: v1.BuilderTask{
+			DependencyExclusions: []string{"commons-logging:commons-logging"},
+			DependencyOverrides:  []string{"com.fasterxml.jackson.core:jackson-databind:2.12.1"},
+		},
+	}
+	ctx.Maven.Project = GenerateQuarkusProjectCommon("1.0.0", "1.0.0", "1.0.0")
+	ctx.Maven.Project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-http", "")
+	ctx.Maven.Project.AddDependencyGAV("com.fasterxml.jackson.core", "jackson-databind", "2.11.0")
+
+	err := Steps.ManageDependencyOverrides.execute(&ctx)
+	assert.Nil(t, err)
+
+	for _, d := range ctx.Maven.Project.Dependencies {
+		assert.Contains(t, *d.Exclusions, maven.Exclusion{GroupID: "commons-logging", ArtifactID: "commons-logging"})
+	}
+	assert.Equal(t, "2.12.1", ctx.Maven.Project.Dependencies[1].Version)
+	assert.Equal(t, maven.Dependency{
+		GroupID:    "com.fasterxml.jackson.core",
+		ArtifactID: "jackson-databind",
+		Version:    "2.12.1",
+	}, ctx.Maven.Project.DependencyManagement.Dependencies[0])
+	assert.Len(t, ctx.Maven.Project.DependencyManagement.Dependencies, 3)
+}
+
+func TestManageInvalidDependencyOverrides(t *testing.T) {
+	ctx := builderContext{
+		Build: v1.BuilderTask{
+			DependencyExclusions: []string{"commons-logging"},
+		},
+	}
+	ctx.Maven.Project = GenerateQuarkusProjectCommon("1.0.0", "1.0.0", "1.0.0")
+
+	err := Steps.ManageDependencyOverrides.execute(&ctx)
+	assert.NotNil(t, err)
+
+	ctx.Build.DependencyExclusions = nil
+	ctx.Build.DependencyOverrides = []string{"com.fasterxml.jackson.core:jackson-databind"}
+
+	err = Steps.ManageDependencyOverrides.execute(&ctx)
+	assert.NotNil(t, err)
+}
+
+func TestAcquireMavenCacheLease(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	newContext := func(build string) *builderContext {
+		return &builderContext{
+			Client:    c,
+			C:         context.TODO(),
+			Namespace: "ns",
+			BuildName: build,
+			Build: v1.BuilderTask{
+				Maven: v1.MavenSpec{
+					LocalRepository: "/tmp/artifacts/m2",
+					Cache: &v1.MavenCacheSpec{
+						PersistentVolumeClaim: "maven-cache",
+					},
+				},
+			},
+		}
+	}
+
+	now := time.Now()
+	acquired, err := acquireMavenCacheLease(newContext("build-1"), now)
+	assert.Nil(t, err)
+	assert.True(t, acquired)
+
+	// The cache is pruned by a single build per interval
+	acquired, err = acquireMavenCacheLease(newContext("build-2"), now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.False(t, acquired)
+
+	acquired, err = acquireMavenCacheLease(newContext("build-2"), now.Add(mavenCachePruneInterval+time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, acquired)
+
+	lease := coordinationv1.Lease{}
+	err = c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "camel-k-maven-cache-maven-cache"}, &lease)
+	assert.Nil(t, err)
+	assert.Equal(t, "build-2", *lease.Spec.HolderIdentity)
+}
+
+func TestCheckOfflineDependencies(t *testing.T) {
+	repository, err := ioutil.TempDir("", "camel-k-m2-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(repository)
+
+	ctx := builderContext{
+		Build: v1.BuilderTask{
+			Maven: v1.MavenSpec{
+				LocalRepository: repository,
+				CLIOptions:      []string{"--offline"},
+			},
+		},
+	}
+	ctx.Maven.Project = GenerateQuarkusProjectCommon("1.0.0", "1.0.0", "1.0.0")
+	ctx.Maven.Project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-core", "")
+
+	err = Steps.CheckOfflineDependencies.execute(&ctx)
+	assert.NotNil(t, err)
+	artifact, ok := maven.MissingOfflineArtifact(err.Error())
+	assert.True(t, ok)
+	assert.Equal(t, "org.apache.camel.quarkus:camel-quarkus-bom:1.0.0", artifact)
+
+	// Seed the local repository with the project artifacts
+	for _, dir := range []string{
+		"org/apache/camel/quarkus/camel-quarkus-bom/1.0.0",
+		"org/apache/camel/k/camel-k-runtime-bom/1.0.0",
+		"io/quarkus/quarkus-maven-plugin/1.0.0",
+		"org/apache/camel/quarkus/camel-quarkus-core/2.0.0",
+	} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(repository, dir), 0755))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(repository, dir, "_remote.repositories"), []byte{}, 0644))
+	}
+
+	assert.Nil(t, Steps.CheckOfflineDependencies.execute(&ctx))
+
+	// The check is only performed in offline mode
+	assert.Nil(t, os.RemoveAll(filepath.Join(repository, "org")))
+	ctx.Build.Maven.CLIOptions = nil
+	assert.Nil(t, Steps.CheckOfflineDependencies.execute(&ctx))
+}
@@ -34,6 +34,16 @@ import (
 	"github.com/apache/camel-k/pkg/util/maven"
 )
 
+const (
+	// QuarkusNativePackageType is the Quarkus package type that produces a native executable
+	QuarkusNativePackageType = "native"
+	// QuarkusNativeSourcesPackageType is the Quarkus package type that produces the sources of the native executable,
+	// so that it can be compiled separately
+	QuarkusNativeSourcesPackageType = "native-sources"
+	// QuarkusNativeRunnerSuffix is the suffix of the native executable file name
+	QuarkusNativeRunnerSuffix = "-runner"
+)
+
 func init() {
 	registerSteps(quarkus)
 }
@@ -151,6 +161,7 @@ func GenerateQuarkusProjectCommon(camelQuarkusVersion string, runtimeVersion str
 func buildQuarkusRunner(ctx *builderContext) error {
 	mc := maven.NewContext(path.Join(ctx.Path, "maven"))
 	mc.SettingsContent = ctx.Maven.SettingsData
+	mc.SettingsSecurityContent = ctx.Maven.SettingsSecurityData
 	mc.LocalRepository = ctx.Build.Maven.LocalRepository
 
 	if ctx.Maven.TrustStoreName != "" {
@@ -160,6 +171,14 @@ func buildQuarkusRunner(ctx *builderContext) error {
 		)
 	}
 
+	if ctx.Build.Maven.Verification != nil {
+		// Fail the resolution of the artifacts whose checksums do not match, preferably using SHA-256
+		mc.AddArgument("--strict-checksums")
+		mc.AddSystemProperty("aether.checksums.algorithms", "SHA-256,SHA-1")
+	}
+
+	mc.AddArguments(ctx.Build.Maven.CLIOptions...)
+
 	err := BuildQuarkusRunnerCommon(ctx.C, mc, ctx.Maven.Project)
 	if err != nil {
 		return err
@@ -196,8 +215,30 @@ func BuildQuarkusRunnerCommon(ctx context.Context, mc maven.Context, project mav
 func computeQuarkusDependencies(ctx *builderContext) error {
 	mc := maven.NewContext(path.Join(ctx.Path, "maven"))
 	mc.SettingsContent = ctx.Maven.SettingsData
+	mc.SettingsSecurityContent = ctx.Maven.SettingsSecurityData
 	mc.LocalRepository = ctx.Build.Maven.LocalRepository
 
+	if ctx.Maven.Project.Properties["quarkus.package.type"] == QuarkusNativePackageType {
+		// The native executable embeds all the application dependencies
+		artifact, err := ProcessQuarkusNativeRunner(mc, ctx.Maven.Project)
+		if err != nil {
+			return err
+		}
+		ctx.Artifacts = append(ctx.Artifacts, artifact)
+
+		return nil
+	}
+	if ctx.Maven.Project.Properties["quarkus.package.type"] == QuarkusNativeSourcesPackageType {
+		// The native executable is compiled by the native task that follows
+		runner := ctx.Maven.Project.ArtifactID + "-" + ctx.Maven.Project.Version + QuarkusNativeRunnerSuffix
+		ctx.Artifacts = append(ctx.Artifacts, v1.Artifact{
+			ID:     runner,
+			Target: path.Join(DependenciesDir, runner),
+		})
+
+		return nil
+	}
+
 	// Process artifacts list and add it to existing artifacts.
 	artifacts, err := ProcessQuarkusTransitiveDependencies(mc)
 	if err != nil {
@@ -208,6 +249,28 @@ func computeQuarkusDependencies(ctx *builderContext) error {
 	return nil
 }
 
+// ProcessQuarkusNativeRunner returns the artifact for the native executable built for the project
+func ProcessQuarkusNativeRunner(mc maven.Context, project maven.Project) (v1.Artifact, error) {
+	runner := project.ArtifactID + "-" + project.Version + QuarkusNativeRunnerSuffix
+	runnerPath := path.Join(mc.Path, "target", runner)
+
+	if _, err := os.Stat(runnerPath); err != nil {
+		return v1.Artifact{}, errors.Wrap(err, "unable to find the native executable, make sure the native image tooling is available to the builder")
+	}
+
+	sha1, err := digest.ComputeSHA1(runnerPath)
+	if err != nil {
+		return v1.Artifact{}, err
+	}
+
+	return v1.Artifact{
+		ID:       runner,
+		Location: runnerPath,
+		Target:   path.Join(DependenciesDir, runner),
+		Checksum: "sha1:" + sha1,
+	}, nil
+}
+
 func ProcessQuarkusTransitiveDependencies(mc maven.Context) ([]v1.Artifact, error) {
 	var artifacts []v1.Artifact
 
@@ -32,6 +32,7 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/log"
 )
 
@@ -114,6 +115,7 @@ func (t *spectrumTask) Do(ctx context.Context) v1.BuildStatus {
 		PushConfigDir: registryConfigDir,
 		Base:          baseImage,
 		Target:        t.task.Image,
+		Annotations:   spectrumImageAnnotations(t.build, baseImage),
 		Stdout:        newStdW,
 		Stderr:        newStdW,
 		Recursive:     true,
@@ -132,6 +134,20 @@ func (t *spectrumTask) Do(ctx context.Context) v1.BuildStatus {
 	return status
 }
 
+// spectrumImageAnnotations returns the annotations of the image manifest, so that the published
+// images can be traced back to the kit they are built for, and to the image they are layered on.
+func spectrumImageAnnotations(build *v1.Build, baseImage string) map[string]string {
+	annotations := map[string]string{
+		"org.opencontainers.image.base.name": baseImage,
+		"camel.apache.org/kit":               build.Name,
+		"camel.apache.org/version":           defaults.Version,
+	}
+	if build.Namespace != "" {
+		annotations["camel.apache.org/kit.namespace"] = build.Namespace
+	}
+	return annotations
+}
+
 func readSpectrumLogs(newStdOut *os.File) {
 	scanner := bufio.NewScanner(newStdOut)
 
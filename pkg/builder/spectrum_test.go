@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/defaults"
+)
+
+func TestSpectrumImageAnnotations(t *testing.T) {
+	build := &v1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "kit-123456789",
+		},
+	}
+
+	annotations := spectrumImageAnnotations(build, "registry.example.com/ns/camel-k-kit-987654321:1")
+
+	assert.Equal(t, map[string]string{
+		"org.opencontainers.image.base.name": "registry.example.com/ns/camel-k-kit-987654321:1",
+		"camel.apache.org/kit":               "kit-123456789",
+		"camel.apache.org/kit.namespace":     "ns",
+		"camel.apache.org/version":           defaults.Version,
+	}, annotations)
+}
@@ -39,6 +39,12 @@ func (b *Build) Task(task v1.Task) Task {
 			build: b.build,
 			task:  task.Builder,
 		}
+	} else if task.Native != nil {
+		// The native compilation runs in a dedicated container, that is only executed with the pod strategy
+		return &unsupportedTask{
+			build: b.build,
+			name:  task.Native.Name,
+		}
 	} else if task.Buildah != nil {
 		return &unsupportedTask{
 			build: b.build,
@@ -49,6 +55,17 @@ func (b *Build) Task(task v1.Task) Task {
 			build: b.build,
 			name:  task.Kaniko.Name,
 		}
+	} else if task.BuildKit != nil {
+		return &unsupportedTask{
+			build: b.build,
+			name:  task.BuildKit.Name,
+		}
+	} else if task.Jib != nil {
+		return &jibTask{
+			c:     b.builder.client,
+			build: b.build,
+			task:  task.Jib,
+		}
 	} else if task.Spectrum != nil {
 		return &spectrumTask{
 			c:     b.builder.client,
@@ -61,6 +78,12 @@ func (b *Build) Task(task v1.Task) Task {
 			build: b.build,
 			task:  task.S2i,
 		}
+	} else if task.Custom != nil {
+		// Custom tasks run user provided containers, that are only executed with the pod strategy
+		return &unsupportedTask{
+			build: b.build,
+			name:  task.Custom.Name,
+		}
 	}
 	return &emptyTask{
 		build: b.build,
@@ -113,6 +136,11 @@ func (b *Build) TaskByName(name string) Task {
 				build: b.build,
 				task:  task.Builder,
 			}
+		} else if task.Native != nil && task.Native.Name == name {
+			return &unsupportedTask{
+				build: b.build,
+				name:  task.Native.Name,
+			}
 		} else if task.Buildah != nil && task.Buildah.Name == name {
 			return &unsupportedTask{
 				build: b.build,
@@ -123,6 +151,17 @@ func (b *Build) TaskByName(name string) Task {
 				build: b.build,
 				name:  task.Kaniko.Name,
 			}
+		} else if task.BuildKit != nil && task.BuildKit.Name == name {
+			return &unsupportedTask{
+				build: b.build,
+				name:  task.BuildKit.Name,
+			}
+		} else if task.Jib != nil && task.Jib.Name == name {
+			return &jibTask{
+				c:     b.builder.client,
+				build: b.build,
+				task:  task.Jib,
+			}
 		} else if task.Spectrum != nil && task.Spectrum.Name == name {
 			return &spectrumTask{
 				c:     b.builder.client,
@@ -135,6 +174,11 @@ func (b *Build) TaskByName(name string) Task {
 				build: b.build,
 				task:  task.S2i,
 			}
+		} else if task.Custom != nil && task.Custom.Name == name {
+			return &unsupportedTask{
+				build: b.build,
+				name:  task.Custom.Name,
+			}
 		}
 	}
 	return &missingTask{
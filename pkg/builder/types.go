@@ -77,16 +77,19 @@ type builderContext struct {
 	C                 context.Context
 	Catalog           *camel.RuntimeCatalog
 	Build             v1.BuilderTask
+	BuildName         string
 	BaseImage         string
 	Namespace         string
 	Path              string
 	Artifacts         []v1.Artifact
 	SelectedArtifacts []v1.Artifact
+	Verifications     []v1.ArtifactVerification
 	Resources         []resource
 	Maven             struct {
-		Project        maven.Project
-		SettingsData   []byte
-		TrustStoreName string
-		TrustStorePass string
+		Project              maven.Project
+		SettingsData         []byte
+		SettingsSecurityData []byte
+		TrustStoreName       string
+		TrustStorePass       string
 	}
 }
@@ -0,0 +1,339 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	// nolint: staticcheck
+	"golang.org/x/crypto/openpgp"
+	// nolint: staticcheck
+	"golang.org/x/crypto/openpgp/armor"
+	// nolint: staticcheck
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+	// nolint: staticcheck
+	"golang.org/x/crypto/openpgp/packet"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+// mavenArtifact is an artifact of the local Maven repository
+type mavenArtifact struct {
+	maven.Dependency
+	Path string
+}
+
+// verifyArtifacts computes the SHA-256 checksums of the artifacts, and verifies their signatures
+// when a keyring is configured, so that they can be checked when the artifacts are copied into the image
+func verifyArtifacts(ctx *builderContext) error {
+	verification := ctx.Build.Maven.Verification
+	if verification == nil {
+		return nil
+	}
+
+	verifications := make([]v1.ArtifactVerification, 0, len(ctx.Artifacts))
+	locations := make(map[string]string, len(ctx.Artifacts))
+	for _, artifact := range ctx.Artifacts {
+		if artifact.Location == "" {
+			// The artifact is produced by a subsequent task
+			continue
+		}
+		sha256, err := digest.ComputeSHA256(artifact.Location)
+		if err != nil {
+			return err
+		}
+		verifications = append(verifications, v1.ArtifactVerification{
+			ID:     artifact.ID,
+			SHA256: sha256,
+		})
+		locations[artifact.ID] = artifact.Location
+	}
+
+	keyring, err := kubernetes.ResolveValueSource(ctx.C, ctx.Client, ctx.Namespace, &verification.Keyring)
+	if err != nil {
+		return err
+	}
+	if keyring != "" {
+		if err := verifyArtifactSignatures(ctx, keyring, verifications, locations); err != nil {
+			return err
+		}
+	}
+
+	ctx.Verifications = verifications
+
+	return nil
+}
+
+func verifyArtifactSignatures(ctx *builderContext, keyring string, verifications []v1.ArtifactVerification, locations map[string]string) error {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyring))
+	if err != nil {
+		return errors.Wrap(err, "cannot read the PGP keyring")
+	}
+
+	repository := ctx.Build.Maven.LocalRepository
+	if repository == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		repository = path.Join(home, ".m2", "repository")
+	}
+	ids := make([]string, 0, len(verifications))
+	for _, v := range verifications {
+		ids = append(ids, v.ID)
+	}
+	artifacts, err := indexMavenArtifacts(repository, ids)
+	if err != nil {
+		return err
+	}
+
+	// Resolve the signatures that are not available in the local repository
+	unsigned := make([]maven.Dependency, 0)
+	for _, v := range verifications {
+		if artifact, ok := artifacts[v.ID]; ok {
+			if _, err := os.Stat(artifact.Path + ".asc"); os.IsNotExist(err) {
+				unsigned = append(unsigned, artifact.Dependency)
+			}
+		}
+	}
+	if len(unsigned) > 0 {
+		if err := resolveArtifactSignatures(ctx, unsigned); err != nil {
+			// Some artifacts may not be signed, which is reported below
+			log.Infof("Some artifact signatures cannot be resolved: %s", err.Error())
+		}
+	}
+
+	for i := range verifications {
+		v := &verifications[i]
+		artifact, ok := artifacts[v.ID]
+		if !ok {
+			// The artifact is generated by the build
+			v.Signature = v1.ArtifactSignatureNotApplicable
+			continue
+		}
+
+		keyID, err := checkArtifactSignature(entities, locations[v.ID], artifact.Path+".asc")
+		if os.IsNotExist(err) {
+			if ctx.Build.Maven.Verification.RequireSignatures {
+				return fmt.Errorf("missing signature for artifact %s", v.ID)
+			}
+			v.Signature = v1.ArtifactSignatureMissing
+			continue
+		}
+		if err == pgperrors.ErrUnknownIssuer {
+			if ctx.Build.Maven.Verification.RequireSignatures {
+				return fmt.Errorf("artifact %s is signed with key %s, that is not in the keyring", v.ID, keyID)
+			}
+			v.Signature = v1.ArtifactSignatureUntrusted
+			v.SignatureKeyID = keyID
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "invalid signature for artifact %s", v.ID)
+		}
+
+		v.Signature = v1.ArtifactSignatureVerified
+		v.SignatureKeyID = keyID
+	}
+
+	return nil
+}
+
+// checkArtifactSignature verifies the detached armored signature of the artifact, and returns the ID
+// of the key the artifact is signed with. The ID is also returned along with the ErrUnknownIssuer error,
+// when the key is not in the keyring.
+func checkArtifactSignature(keyring openpgp.EntityList, artifactPath string, signaturePath string) (string, error) {
+	signature, err := os.Open(signaturePath)
+	if err != nil {
+		return "", err
+	}
+	defer signature.Close()
+
+	artifact, err := os.Open(artifactPath)
+	if err != nil {
+		return "", err
+	}
+	defer artifact.Close()
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, artifact, signature)
+	if err == pgperrors.ErrUnknownIssuer {
+		keyID, issuerErr := signatureIssuer(signaturePath)
+		if issuerErr != nil {
+			return "", issuerErr
+		}
+		return keyID, err
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return signer.PrimaryKey.KeyIdString(), nil
+}
+
+// signatureIssuer returns the ID of the key that issued the detached armored signature
+func signatureIssuer(signaturePath string) (string, error) {
+	signature, err := os.Open(signaturePath)
+	if err != nil {
+		return "", err
+	}
+	defer signature.Close()
+
+	block, err := armor.Decode(signature)
+	if err != nil {
+		return "", err
+	}
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return "", err
+	}
+
+	switch sig := p.(type) {
+	case *packet.Signature:
+		if sig.IssuerKeyId != nil {
+			return fmt.Sprintf("%016X", *sig.IssuerKeyId), nil
+		}
+	case *packet.SignatureV3:
+		return fmt.Sprintf("%016X", sig.IssuerKeyId), nil
+	}
+
+	return "", pgperrors.ErrUnknownIssuer
+}
+
+// resolveArtifactSignatures downloads the signatures of the artifacts into the local repository
+func resolveArtifactSignatures(ctx *builderContext, artifacts []maven.Dependency) error {
+	p := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration-signatures", ctx.Maven.Project.Version)
+	p.Repositories = ctx.Maven.Project.Repositories
+	p.Dependencies = make([]maven.Dependency, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		d := artifact
+		d.Type += ".asc"
+		d.Exclusions = &[]maven.Exclusion{{GroupID: "*", ArtifactID: "*"}}
+		p.Dependencies = append(p.Dependencies, d)
+	}
+
+	mc := maven.NewContext(path.Join(ctx.Path, "maven-signatures"))
+	mc.SettingsContent = ctx.Maven.SettingsData
+	mc.SettingsSecurityContent = ctx.Maven.SettingsSecurityData
+	mc.LocalRepository = ctx.Build.Maven.LocalRepository
+	mc.AddArguments(ctx.Build.Maven.CLIOptions...)
+	mc.AddArgument("dependency:resolve")
+
+	return p.Command(mc).Do(ctx.C)
+}
+
+// indexMavenArtifacts locates the artifacts with the given IDs in the local repository, and indexes them by ID.
+// The IDs are the file names the artifacts are packaged with in the Quarkus application,
+// i.e. groupId.artifactId-version[-classifier].jar, so that the matching GAV is looked up by trying out
+// the possible group and artifact IDs, rather than walking the whole repository.
+func indexMavenArtifacts(repository string, ids []string) (map[string]mavenArtifact, error) {
+	artifacts := make(map[string]mavenArtifact)
+
+	for _, id := range ids {
+		artifact, ok, err := findMavenArtifact(repository, id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			artifacts[id] = artifact
+		}
+	}
+
+	return artifacts, nil
+}
+
+// findMavenArtifact looks up the artifact with the given ID in the local repository,
+// at the groupId/artifactId/version/artifactId-version[-classifier].jar location
+func findMavenArtifact(repository string, id string) (mavenArtifact, bool, error) {
+	if filepath.Ext(id) != ".jar" {
+		return mavenArtifact{}, false, nil
+	}
+
+	for i := strings.Index(id, "."); i > 0; i = nextIndex(id, ".", i) {
+		groupID := id[:i]
+		name := id[i+1:]
+		groupDir := filepath.Join(repository, filepath.FromSlash(strings.ReplaceAll(groupID, ".", "/")))
+		if _, err := os.Stat(groupDir); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return mavenArtifact{}, false, err
+		}
+
+		for j := strings.Index(name, "-"); j > 0; j = nextIndex(name, "-", j) {
+			artifactID := name[:j]
+			versions, err := ioutil.ReadDir(filepath.Join(groupDir, artifactID))
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return mavenArtifact{}, false, err
+			}
+
+			for _, version := range versions {
+				prefix := artifactID + "-" + version.Name()
+				if !version.IsDir() || !strings.HasPrefix(name, prefix) {
+					continue
+				}
+				classifier := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".jar")
+				if classifier != "" && !strings.HasPrefix(classifier, "-") {
+					continue
+				}
+				filePath := filepath.Join(groupDir, artifactID, version.Name(), name)
+				if _, err := os.Stat(filePath); err != nil {
+					if os.IsNotExist(err) {
+						continue
+					}
+					return mavenArtifact{}, false, err
+				}
+
+				return mavenArtifact{
+					Dependency: maven.Dependency{
+						GroupID:    groupID,
+						ArtifactID: artifactID,
+						Version:    version.Name(),
+						Type:       "jar",
+						Classifier: strings.TrimPrefix(classifier, "-"),
+					},
+					Path: filePath,
+				}, true, nil
+			}
+		}
+	}
+
+	return mavenArtifact{}, false, nil
+}
+
+// nextIndex returns the index of the next occurrence of sep in s after the given index, or -1
+func nextIndex(s string, sep string, index int) int {
+	next := strings.Index(s[index+1:], sep)
+	if next < 0 {
+		return -1
+	}
+	return index + 1 + next
+}
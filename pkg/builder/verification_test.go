@@ -0,0 +1,309 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	// nolint: staticcheck
+	"golang.org/x/crypto/openpgp"
+	// nolint: staticcheck
+	"golang.org/x/crypto/openpgp/armor"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestVerifyArtifacts(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "verification-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	entity, err := openpgp.NewEntity("camel-k", "", "camel-k@apache.org", nil)
+	assert.Nil(t, err)
+	keyring := new(bytes.Buffer)
+	w, err := armor.Encode(keyring, openpgp.PublicKeyType, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, entity.Serialize(w))
+	assert.Nil(t, w.Close())
+
+	// Signed artifact in the local repository
+	repository := path.Join(tmpDir, "repository")
+	artifactDir := path.Join(repository, "org", "apache", "camel", "camel-core", "3.9.0")
+	assert.Nil(t, os.MkdirAll(artifactDir, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path.Join(artifactDir, "camel-core-3.9.0.jar"), []byte("camel-core"), 0644))
+	signature, err := os.Create(path.Join(artifactDir, "camel-core-3.9.0.jar.asc"))
+	assert.Nil(t, err)
+	assert.Nil(t, openpgp.ArmoredDetachSign(signature, entity, bytes.NewReader([]byte("camel-core")), nil))
+	assert.Nil(t, signature.Close())
+
+	// Artifacts packaged in the Quarkus application
+	libDir := path.Join(tmpDir, "quarkus-app", "lib", "main")
+	assert.Nil(t, os.MkdirAll(libDir, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path.Join(libDir, "org.apache.camel.camel-core-3.9.0.jar"), []byte("camel-core"), 0644))
+	assert.Nil(t, ioutil.WriteFile(path.Join(tmpDir, "quarkus-app", "quarkus-run.jar"), []byte("quarkus-run"), 0644))
+
+	c, err := test.NewFakeClient(
+		&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "maven-keyring",
+			},
+			Data: map[string]string{
+				"keyring.asc": keyring.String(),
+			},
+		},
+	)
+	assert.Nil(t, err)
+
+	ctx := builderContext{
+		C:         context.TODO(),
+		Client:    c,
+		Namespace: "ns",
+		Path:      tmpDir,
+		Build: v1.BuilderTask{
+			Maven: v1.MavenSpec{
+				LocalRepository: repository,
+				Verification: &v1.MavenVerificationSpec{
+					Keyring: v1.ValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "maven-keyring",
+							},
+							Key: "keyring.asc",
+						},
+					},
+				},
+			},
+		},
+		Artifacts: []v1.Artifact{
+			{
+				ID:       "org.apache.camel.camel-core-3.9.0.jar",
+				Location: path.Join(libDir, "org.apache.camel.camel-core-3.9.0.jar"),
+				Target:   "dependencies/lib/main/org.apache.camel.camel-core-3.9.0.jar",
+			},
+			{
+				ID:       "quarkus-run.jar",
+				Location: path.Join(tmpDir, "quarkus-app", "quarkus-run.jar"),
+				Target:   "dependencies/quarkus-run.jar",
+			},
+		},
+	}
+
+	err = verifyArtifacts(&ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []v1.ArtifactVerification{
+		{
+			ID:             "org.apache.camel.camel-core-3.9.0.jar",
+			SHA256:         "e7f0750293a1a677c5055f1f2ceeedba6afdbaa5b19eeb8e2a63f4068bd635ad",
+			Signature:      v1.ArtifactSignatureVerified,
+			SignatureKeyID: entity.PrimaryKey.KeyIdString(),
+		},
+		{
+			ID:        "quarkus-run.jar",
+			SHA256:    "92fae5e326286d93e914e9dc266cfe5f647b69e4a0c359479689390957f85b15",
+			Signature: v1.ArtifactSignatureNotApplicable,
+		},
+	}, ctx.Verifications)
+
+	// The packaged artifact does not match the signed one
+	assert.Nil(t, ioutil.WriteFile(path.Join(libDir, "org.apache.camel.camel-core-3.9.0.jar"), []byte("tampered"), 0644))
+
+	err = verifyArtifacts(&ctx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid signature for artifact org.apache.camel.camel-core-3.9.0.jar")
+}
+
+func TestVerifyArtifactsUntrustedSignature(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "verification-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	trusted, err := openpgp.NewEntity("camel-k", "", "camel-k@apache.org", nil)
+	assert.Nil(t, err)
+	keyring := new(bytes.Buffer)
+	w, err := armor.Encode(keyring, openpgp.PublicKeyType, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, trusted.Serialize(w))
+	assert.Nil(t, w.Close())
+
+	// Artifact signed with a key that is not in the keyring
+	untrusted, err := openpgp.NewEntity("unknown", "", "unknown@example.com", nil)
+	assert.Nil(t, err)
+	repository := path.Join(tmpDir, "repository")
+	artifactDir := path.Join(repository, "com", "example", "example-lib", "1.0-SNAPSHOT")
+	assert.Nil(t, os.MkdirAll(artifactDir, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path.Join(artifactDir, "example-lib-1.0-SNAPSHOT-tests.jar"), []byte("example-lib"), 0644))
+	signature, err := os.Create(path.Join(artifactDir, "example-lib-1.0-SNAPSHOT-tests.jar.asc"))
+	assert.Nil(t, err)
+	assert.Nil(t, openpgp.ArmoredDetachSign(signature, untrusted, bytes.NewReader([]byte("example-lib")), nil))
+	assert.Nil(t, signature.Close())
+
+	libDir := path.Join(tmpDir, "quarkus-app", "lib", "main")
+	assert.Nil(t, os.MkdirAll(libDir, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path.Join(libDir, "com.example.example-lib-1.0-SNAPSHOT-tests.jar"), []byte("example-lib"), 0644))
+
+	c, err := test.NewFakeClient(
+		&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "maven-keyring",
+			},
+			Data: map[string]string{
+				"keyring.asc": keyring.String(),
+			},
+		},
+	)
+	assert.Nil(t, err)
+
+	verification := &v1.MavenVerificationSpec{
+		Keyring: v1.ValueSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "maven-keyring",
+				},
+				Key: "keyring.asc",
+			},
+		},
+	}
+	ctx := builderContext{
+		C:         context.TODO(),
+		Client:    c,
+		Namespace: "ns",
+		Path:      tmpDir,
+		Build: v1.BuilderTask{
+			Maven: v1.MavenSpec{
+				LocalRepository: repository,
+				Verification:    verification,
+			},
+		},
+		Artifacts: []v1.Artifact{
+			{
+				ID:       "com.example.example-lib-1.0-SNAPSHOT-tests.jar",
+				Location: path.Join(libDir, "com.example.example-lib-1.0-SNAPSHOT-tests.jar"),
+				Target:   "dependencies/lib/main/com.example.example-lib-1.0-SNAPSHOT-tests.jar",
+			},
+		},
+	}
+
+	err = verifyArtifacts(&ctx)
+	assert.Nil(t, err)
+	assert.Len(t, ctx.Verifications, 1)
+	assert.Equal(t, v1.ArtifactSignatureUntrusted, ctx.Verifications[0].Signature)
+	assert.Equal(t, untrusted.PrimaryKey.KeyIdString(), ctx.Verifications[0].SignatureKeyID)
+
+	verification.RequireSignatures = true
+
+	err = verifyArtifacts(&ctx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "is signed with key "+untrusted.PrimaryKey.KeyIdString()+", that is not in the keyring")
+}
+
+func TestIndexMavenArtifacts(t *testing.T) {
+	repository, err := ioutil.TempDir("", "repository-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(repository)
+
+	for _, file := range []string{
+		"org/apache/camel/camel-core/3.9.0/camel-core-3.9.0.jar",
+		"org/apache/camel/camel-core-engine/3.9.0/camel-core-engine-3.9.0.jar",
+		"io/quarkus/quarkus-core/1.13.0.Final/quarkus-core-1.13.0.Final.jar",
+		"org/apache/camel/camel-main/3.9.0/camel-main-3.9.0.jar",
+	} {
+		filePath := path.Join(repository, file)
+		assert.Nil(t, os.MkdirAll(path.Dir(filePath), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(filePath, []byte{}, 0644))
+	}
+
+	artifacts, err := indexMavenArtifacts(repository, []string{
+		"org.apache.camel.camel-core-engine-3.9.0.jar",
+		"io.quarkus.quarkus-core-1.13.0.Final.jar",
+		"com.example.example-lib-1.0.jar",
+		"quarkus-run.jar",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]mavenArtifact{
+		"org.apache.camel.camel-core-engine-3.9.0.jar": {
+			Dependency: maven.Dependency{
+				GroupID:    "org.apache.camel",
+				ArtifactID: "camel-core-engine",
+				Version:    "3.9.0",
+				Type:       "jar",
+			},
+			Path: path.Join(repository, "org/apache/camel/camel-core-engine/3.9.0/camel-core-engine-3.9.0.jar"),
+		},
+		"io.quarkus.quarkus-core-1.13.0.Final.jar": {
+			Dependency: maven.Dependency{
+				GroupID:    "io.quarkus",
+				ArtifactID: "quarkus-core",
+				Version:    "1.13.0.Final",
+				Type:       "jar",
+			},
+			Path: path.Join(repository, "io/quarkus/quarkus-core/1.13.0.Final/quarkus-core-1.13.0.Final.jar"),
+		},
+	}, artifacts)
+}
+
+func TestImageContextChecksumMismatch(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "verification-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	location := path.Join(tmpDir, "quarkus-run.jar")
+	assert.Nil(t, ioutil.WriteFile(location, []byte("quarkus-run"), 0644))
+
+	ctx := builderContext{
+		Path: tmpDir,
+		Artifacts: []v1.Artifact{
+			{
+				ID:       "quarkus-run.jar",
+				Location: location,
+				Target:   "dependencies/quarkus-run.jar",
+			},
+		},
+		Verifications: []v1.ArtifactVerification{
+			{
+				ID:     "quarkus-run.jar",
+				SHA256: "0000000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+	}
+
+	err = standardImageContext(&ctx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "SHA-256 checksum mismatch for artifact quarkus-run.jar")
+}
@@ -18,7 +18,14 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"strings"
+
 	"github.com/spf13/cobra"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
 )
 
 func newCmdCompletion(root *cobra.Command) *cobra.Command {
@@ -29,6 +36,8 @@ func newCmdCompletion(root *cobra.Command) *cobra.Command {
 
 	completion.AddCommand(newCmdCompletionBash(root))
 	completion.AddCommand(newCmdCompletionZsh(root))
+	completion.AddCommand(newCmdCompletionFish(root))
+	completion.AddCommand(newCmdCompletionPowerShell(root))
 
 	return &completion
 }
@@ -36,4 +45,57 @@ func newCmdCompletion(root *cobra.Command) *cobra.Command {
 func configureKnownCompletions(command *cobra.Command) {
 	configureKnownBashCompletions(command)
 	configureKnownZshCompletions(command)
+	configureKnownDynamicCompletions(command)
+}
+
+// configureKnownDynamicCompletions registers the completion functions used by the shells relying
+// on the cobra dynamic completion support (zsh, fish and PowerShell)
+func configureKnownDynamicCompletions(command *cobra.Command) {
+	if command.Flag("trait") != nil {
+		// nolint: errcheck
+		command.RegisterFlagCompletionFunc("trait", completeTraitProperties)
+	}
+}
+
+func completeTraitProperties(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	properties := trait.NewCatalog(context.TODO(), nil).ComputeTraitsProperties()
+	return filterCompletions(properties, toComplete), cobra.ShellCompDirectiveNoSpace
+}
+
+// completeIntegrationNames returns a function completing the command arguments with
+// the names of the integrations available in the current namespace
+func completeIntegrationNames(options *RootCmdOptions) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		c, err := options.GetCmdClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		namespace := options.Namespace
+		if namespace == "" {
+			if namespace, err = c.GetCurrentNamespace(options.KubeConfig); err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+		}
+
+		list := v1.NewIntegrationList()
+		if err := c.List(options.Context, &list, k8sclient.InNamespace(namespace)); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		names := make([]string, 0, len(list.Items))
+		for _, integration := range list.Items {
+			names = append(names, integration.Name)
+		}
+
+		return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func filterCompletions(candidates []string, toComplete string) []string {
+	completions := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			completions = append(completions, candidate)
+		}
+	}
+	return completions
 }
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// ******************************
+//
+//
+//
+// ******************************
+
+const fishCompletionCmdLongDescription = `
+To load completion run
+
+kamel completion fish | source
+
+To configure your fish shell to load completions for each session run
+
+kamel completion fish > ~/.config/fish/completions/kamel.fish
+`
+
+// ******************************
+//
+// COMMAND
+//
+// ******************************
+
+func newCmdCompletionFish(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fish",
+		Short: "Generates fish completion scripts",
+		Long:  fishCompletionCmdLongDescription,
+		Run: func(_ *cobra.Command, _ []string) {
+			err := root.GenFishCompletion(root.OutOrStdout(), true)
+			if err != nil {
+				fmt.Print(err.Error())
+			}
+		},
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterCompletions(t *testing.T) {
+	candidates := []string{"knative-service.enabled", "knative.enabled", "jolokia.enabled"}
+
+	assert.Equal(t, []string{"knative-service.enabled", "knative.enabled"}, filterCompletions(candidates, "knative"))
+	assert.Equal(t, candidates, filterCompletions(candidates, ""))
+	assert.Empty(t, filterCompletions(candidates, "unknown"))
+}
+
+func TestCompleteTraitProperties(t *testing.T) {
+	completions, _ := completeTraitProperties(nil, nil, "jolokia.")
+	assert.NotEmpty(t, completions)
+	assert.Contains(t, completions, "jolokia.enabled")
+}
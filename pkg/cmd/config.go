@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	p "github.com/gertd/go-pluralize"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultNamespaceConfigKey is the configuration key holding the namespace used when none is provided
+	DefaultNamespaceConfigKey = "kamel.config.default-namespace"
+)
+
+func newCmdConfig(rootCmdOptions *RootCmdOptions) (*cobra.Command, *configCmdOptions) {
+	options := configCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "config",
+		Short: "Configure the default values used by the kamel commands",
+		Long: `Configure the default values used by the kamel commands.
+
+The values are stored in the kamel configuration file in use or, when none is found, in the per-user
+configuration file ($HOME/.kamel/kamel-config.yaml). Any command flag can be given a default value
+with the --set option, using the <command>.<flag> syntax, e.g.:
+
+  kamel config --default-namespace my-project
+  kamel config --set run.trait=logging.json=true --set run.output=yaml
+  kamel config --set install.registry=quay.io
+  kamel config --unset run.output`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(cmd); err != nil {
+				return err
+			}
+			return options.run(cmd)
+		},
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().String("default-namespace", "", "Set the namespace used by the commands when none is provided")
+	cmd.Flags().StringArray("set", nil, "Set the default value of a command flag, using the <command>.<flag>=<value> syntax")
+	cmd.Flags().StringArray("unset", nil, "Remove the default value of a command flag, using the <command>.<flag> syntax")
+	cmd.Flags().Bool("list", false, "List the current configuration")
+
+	return &cmd, &options
+}
+
+type configCmdOptions struct {
+	*RootCmdOptions  `json:"-"`
+	DefaultNamespace string   `mapstructure:"default-namespace"`
+	Set              []string `mapstructure:"sets"`
+	Unset            []string `mapstructure:"unsets"`
+	List             bool     `mapstructure:"list"`
+}
+
+func (o *configCmdOptions) validate(cmd *cobra.Command) error {
+	for _, item := range o.Set {
+		key, _, ok := splitConfigEntry(item)
+		if !ok {
+			return fmt.Errorf("invalid configuration entry %s, expected <command>.<flag>=<value>", item)
+		}
+		if _, _, err := resolveConfigKey(cmd.Root(), key); err != nil {
+			return err
+		}
+	}
+	for _, key := range o.Unset {
+		if _, _, err := resolveConfigKey(cmd.Root(), key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *configCmdOptions) run(cmd *cobra.Command) error {
+	cfg, err := LoadUserConfiguration()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+
+	if cmd.Flags().Changed("default-namespace") {
+		if o.DefaultNamespace == "" {
+			cfg.Unset(DefaultNamespaceConfigKey)
+		} else {
+			cfg.Set(DefaultNamespaceConfigKey, o.DefaultNamespace)
+		}
+		changed = true
+	}
+
+	for _, key := range o.Unset {
+		configKey, _, err := resolveConfigKey(cmd.Root(), key)
+		if err != nil {
+			return err
+		}
+		cfg.Unset(configKey)
+		changed = true
+	}
+
+	for _, item := range o.Set {
+		key, value, _ := splitConfigEntry(item)
+		configKey, multiValued, err := resolveConfigKey(cmd.Root(), key)
+		if err != nil {
+			return err
+		}
+		if multiValued {
+			cfg.Set(configKey, appendConfigValue(cfg.Get(configKey), value))
+		} else {
+			cfg.Set(configKey, value)
+		}
+		changed = true
+	}
+
+	if changed {
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Configuration saved to %s\n", cfg.Location())
+	}
+
+	if o.List || !changed {
+		data, err := yaml.Marshal(cfg.Content())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "# %s\n%s", cfg.Location(), string(data))
+	}
+
+	return nil
+}
+
+func splitConfigEntry(item string) (string, string, bool) {
+	idx := strings.Index(item, "=")
+	if idx <= 0 {
+		return "", "", false
+	}
+	return item[:idx], item[idx+1:], true
+}
+
+// resolveConfigKey maps a <command>.<flag> key to the configuration key read by the command,
+// reporting whether the flag accepts multiple values
+func resolveConfigKey(root *cobra.Command, key string) (string, bool, error) {
+	idx := strings.LastIndex(key, ".")
+	if idx <= 0 || idx == len(key)-1 {
+		return "", false, fmt.Errorf("invalid configuration key %s, expected <command>.<flag>", key)
+	}
+
+	commandPath := key[:idx]
+	flagName := key[idx+1:]
+
+	target, rest, err := root.Find(strings.Split(commandPath, "."))
+	if err != nil || target == root || len(rest) > 0 {
+		return "", false, fmt.Errorf("unknown command %s in configuration key %s", strings.ReplaceAll(commandPath, ".", " "), key)
+	}
+
+	flag := target.Flags().Lookup(flagName)
+	if flag == nil {
+		return "", false, fmt.Errorf("unknown flag %s for command %s", flagName, strings.ReplaceAll(commandPath, ".", " "))
+	}
+
+	name := strings.ReplaceAll(flag.Name, "_", "-")
+	name = strings.ReplaceAll(name, ".", "-")
+
+	multiValued := isMultiValuedFlag(flag)
+	if multiValued {
+		name = p.NewClient().Plural(name)
+	}
+
+	return pathToRoot(target) + "." + name, multiValued, nil
+}
+
+func isMultiValuedFlag(flag *pflag.Flag) bool {
+	flagType := strings.ToUpper(flag.Value.Type())
+	return strings.Contains(flagType, "SLICE") || strings.Contains(flagType, "ARRAY")
+}
+
+func appendConfigValue(current interface{}, value string) []string {
+	values := make([]string, 0)
+	switch v := current.(type) {
+	case []string:
+		values = append(values, v...)
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+	case string:
+		values = append(values, v)
+	}
+
+	for _, item := range values {
+		if item == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveConfigKey(t *testing.T) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	addTestRunCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	key, multiValued, err := resolveConfigKey(rootCmd, "run.output")
+	assert.Nil(t, err)
+	assert.False(t, multiValued)
+	assert.Equal(t, "kamel.run.output", key)
+
+	key, multiValued, err = resolveConfigKey(rootCmd, "run.trait")
+	assert.Nil(t, err)
+	assert.True(t, multiValued)
+	assert.Equal(t, "kamel.run.traits", key)
+
+	_, _, err = resolveConfigKey(rootCmd, "run.unknown")
+	assert.NotNil(t, err)
+
+	_, _, err = resolveConfigKey(rootCmd, "unknown.output")
+	assert.NotNil(t, err)
+
+	_, _, err = resolveConfigKey(rootCmd, "output")
+	assert.NotNil(t, err)
+}
+
+func TestConfigSetAndUnset(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-config-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	location := filepath.Join(dir, DefaultConfigLocation)
+
+	cfg, err := LoadConfigurationFrom(location)
+	assert.Nil(t, err)
+	cfg.Set(DefaultNamespaceConfigKey, "my-project")
+	cfg.Set("kamel.run.traits", appendConfigValue(cfg.Get("kamel.run.traits"), "logging.json=true"))
+	cfg.Set("kamel.run.output", "yaml")
+	assert.Nil(t, cfg.Save())
+
+	cfg, err = LoadConfigurationFrom(location)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-project", cfg.Get(DefaultNamespaceConfigKey))
+	assert.Equal(t, []string{"logging.json=true", "jolokia.enabled=true"},
+		appendConfigValue(cfg.Get("kamel.run.traits"), "jolokia.enabled=true"))
+
+	cfg.Unset("kamel.run.output")
+	assert.Nil(t, cfg.Get("kamel.run.output"))
+	assert.Equal(t, "my-project", cfg.Get(DefaultNamespaceConfigKey))
+}
+
+func TestUserConfigLocation(t *testing.T) {
+	defer os.Setenv("KAMEL_CONFIG_PATH", os.Getenv("KAMEL_CONFIG_PATH"))
+	defer os.Setenv("KAMEL_CONFIG_NAME", os.Getenv("KAMEL_CONFIG_NAME"))
+
+	os.Setenv("KAMEL_CONFIG_PATH", "/etc/kamel")
+	os.Setenv("KAMEL_CONFIG_NAME", "")
+	assert.Equal(t, filepath.Join("/etc/kamel", DefaultConfigLocation), UserConfigLocation())
+
+	os.Setenv("KAMEL_CONFIG_NAME", "my-config")
+	assert.Equal(t, filepath.Join("/etc/kamel", "my-config.yaml"), UserConfigLocation())
+}
@@ -56,6 +56,9 @@ func newCmdDelete(rootCmdOptions *RootCmdOptions) (*cobra.Command, *deleteCmdOpt
 
 	cmd.Flags().Bool("all", false, "Delete all integrations")
 
+	// completion support
+	cmd.ValidArgsFunction = completeIntegrationNames(rootCmdOptions)
+
 	return &cmd, &options
 }
 
@@ -57,6 +57,9 @@ func newDescribeIntegrationCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command,
 
 	cmd.Flags().BoolVar(&options.showSourceContent, "show-source-content", false, "Print source content")
 
+	// completion support
+	cmd.ValidArgsFunction = completeIntegrationNames(rootCmdOptions)
+
 	return &cmd, &options
 }
 
@@ -0,0 +1,252 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/magiconair/properties"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+const exportRoutesDirectory = "src/main/resources/routes"
+
+func newCmdExport(rootCmdOptions *RootCmdOptions) (*cobra.Command, *exportCmdOptions) {
+	options := exportCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "export [integration files] --dir [directory]",
+		Short: "Export integrations as a standalone Camel Quarkus project",
+		Long: `Export integrations as a standalone Camel Quarkus Maven project, made of the integration sources,
+a pom.xml file, an application.properties file and a Dockerfile, that can be built without Camel K.`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().String("dir", "", "The directory where the project is generated")
+	cmd.Flags().String("name", "", "The project name, used as Maven artifact id")
+	cmd.Flags().String("group-id", "org.apache.camel.k.integration", "The Maven group id of the project")
+	cmd.Flags().String("version", "1.0.0-SNAPSHOT", "The Maven version of the project")
+	cmd.Flags().StringArrayP("dependency", "d", nil, "Add an additional dependency")
+	cmd.Flags().StringArrayP("property", "p", nil, "Add a runtime property or properties file (syntax: [my-key=my-value|file:/path/to/my-conf.properties])")
+	cmd.Flags().StringArray("maven-repository", nil, "Add a maven repository")
+	cmd.Flags().Bool("force", false, "Overwrite the content of a non empty directory")
+
+	return &cmd, &options
+}
+
+type exportCmdOptions struct {
+	*RootCmdOptions
+	Directory              string   `mapstructure:"dir"`
+	Name                   string   `mapstructure:"name"`
+	GroupID                string   `mapstructure:"group-id"`
+	Version                string   `mapstructure:"version"`
+	AdditionalDependencies []string `mapstructure:"dependencies"`
+	Properties             []string `mapstructure:"properties"`
+	MavenRepositories      []string `mapstructure:"maven-repositories"`
+	Force                  bool     `mapstructure:"force"`
+}
+
+func (o *exportCmdOptions) validate(args []string) error {
+	if err := validateIntegrationFiles(args); err != nil {
+		return err
+	}
+
+	if o.Directory == "" {
+		return errors.New("the directory where the project is generated must be set with the --dir flag")
+	}
+
+	if err := validateAdditionalDependencies(o.AdditionalDependencies); err != nil {
+		return err
+	}
+
+	if err := validatePropertyFiles(filterBuildPropertyFiles(o.Properties)); err != nil {
+		return err
+	}
+
+	if !o.Force {
+		if files, err := ioutil.ReadDir(o.Directory); err == nil && len(files) > 0 {
+			return fmt.Errorf("directory %s is not empty, use --force to overwrite its content", o.Directory)
+		}
+	}
+
+	return nil
+}
+
+func (o *exportCmdOptions) run(cmd *cobra.Command, args []string) error {
+	catalog, err := createCamelCatalog(o.Context)
+	if err != nil {
+		return err
+	}
+
+	if err := o.export(catalog, args); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Camel Quarkus project exported to %s\n", o.Directory)
+
+	return nil
+}
+
+func (o *exportCmdOptions) export(catalog *camel.RuntimeCatalog, sources []string) error {
+	props := properties.NewProperties()
+	for i, source := range sources {
+		data, _, _, err := loadTextContent(source, false)
+		if err != nil {
+			return err
+		}
+
+		name, err := sourceFileName(path.Base(source))
+		if err != nil {
+			return err
+		}
+		spec := v1.SourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    name,
+				Content: data,
+			},
+		}
+
+		if err := util.WriteFileWithContent(o.Directory, path.Join(exportRoutesDirectory, spec.Name), []byte(data)); err != nil {
+			return err
+		}
+
+		simpleName := spec.Name
+		if idx := strings.Index(simpleName, "."); idx >= 0 {
+			simpleName = simpleName[:idx]
+		}
+
+		if _, _, err := props.Set(fmt.Sprintf("camel.k.sources[%d].location", i), "classpath:routes/"+spec.Name); err != nil {
+			return err
+		}
+		if _, _, err := props.Set(fmt.Sprintf("camel.k.sources[%d].name", i), simpleName); err != nil {
+			return err
+		}
+		if _, _, err := props.Set(fmt.Sprintf("camel.k.sources[%d].language", i), string(spec.InferLanguage())); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range o.Properties {
+		p, err := extractProperties(item)
+		if err != nil {
+			return err
+		}
+		props.Merge(p)
+	}
+
+	project, err := o.generateProject(catalog, sources)
+	if err != nil {
+		return err
+	}
+
+	pom, err := project.MarshalBytes()
+	if err != nil {
+		return err
+	}
+
+	if err := util.WriteFileWithContent(o.Directory, "pom.xml", pom); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if _, err := props.Write(&buf, properties.UTF8); err != nil {
+		return err
+	}
+	if err := util.WriteFileWithContent(o.Directory, "src/main/resources/application.properties", buf.Bytes()); err != nil {
+		return err
+	}
+
+	return util.WriteFileWithContent(o.Directory, "Dockerfile", []byte(exportDockerfile()))
+}
+
+func (o *exportCmdOptions) generateProject(catalog *camel.RuntimeCatalog, sources []string) (maven.Project, error) {
+	dependencies, err := getTopLevelDependencies(catalog, sources)
+	if err != nil {
+		return maven.Project{}, err
+	}
+
+	dependencies = append(dependencies, o.AdditionalDependencies...)
+	for _, runtimeDep := range catalog.Runtime.Dependencies {
+		util.StringSliceUniqueAdd(&dependencies, runtimeDep.GetDependencyID())
+	}
+
+	project := builder.GenerateQuarkusProjectCommon(
+		catalog.CamelCatalogSpec.Runtime.Metadata["camel-quarkus.version"],
+		defaults.DefaultRuntimeVersion,
+		catalog.CamelCatalogSpec.Runtime.Metadata["quarkus.version"],
+	)
+
+	name := o.Name
+	if name == "" {
+		name = kubernetes.SanitizeName(sources[0])
+	}
+
+	project.GroupID = o.GroupID
+	project.ArtifactID = name
+	project.Version = o.Version
+
+	for i, repo := range o.MavenRepositories {
+		repository := maven.NewRepository(repo)
+		if repository.ID == "" {
+			repository.ID = fmt.Sprintf("repository-%03d", i)
+		}
+		project.Repositories = append(project.Repositories, repository)
+	}
+
+	if err := camel.ManageIntegrationDependencies(&project, dependencies, catalog); err != nil {
+		return maven.Project{}, err
+	}
+
+	return project, nil
+}
+
+func exportDockerfile() string {
+	return fmt.Sprintf(`FROM %s
+WORKDIR /deployments
+COPY target/quarkus-app/lib/ /deployments/lib/
+COPY target/quarkus-app/*.jar /deployments/
+COPY target/quarkus-app/app/ /deployments/app/
+COPY target/quarkus-app/quarkus/ /deployments/quarkus/
+EXPOSE 8080
+ENTRYPOINT ["java", "-jar", "/deployments/quarkus-run.jar"]
+`, defaults.BaseImage())
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportValidation(t *testing.T) {
+	options := exportCmdOptions{}
+
+	assert.NotNil(t, options.validate([]string{}))
+	assert.NotNil(t, options.validate([]string{"not-existing.java"}))
+}
+
+func TestExportProject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-export-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "Timer.java")
+	assert.Nil(t, ioutil.WriteFile(source, []byte(`
+import org.apache.camel.builder.RouteBuilder;
+
+public class Timer extends RouteBuilder {
+  @Override
+  public void configure() throws Exception {
+	  from("timer:tick").to("log:info");
+  }
+}
+`), 0644))
+
+	options := exportCmdOptions{
+		Directory:  filepath.Join(dir, "out"),
+		GroupID:    "org.example",
+		Version:    "1.0.0",
+		Properties: []string{"my.key=my-value"},
+	}
+	assert.Nil(t, options.validate([]string{source}))
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+	assert.Nil(t, options.export(catalog, []string{source}))
+
+	pom, err := ioutil.ReadFile(filepath.Join(options.Directory, "pom.xml"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(pom), "<groupId>org.example</groupId>")
+	assert.Contains(t, string(pom), "<artifactId>timer</artifactId>")
+	assert.Contains(t, string(pom), "camel-quarkus-timer")
+	assert.Contains(t, string(pom), "quarkus-maven-plugin")
+
+	props, err := ioutil.ReadFile(filepath.Join(options.Directory, "src", "main", "resources", "application.properties"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(props), "camel.k.sources[0].location = classpath:routes/Timer.java")
+	assert.Contains(t, string(props), "camel.k.sources[0].language = java")
+	assert.Contains(t, string(props), "my.key = my-value")
+
+	_, err = os.Stat(filepath.Join(options.Directory, exportRoutesDirectory, "Timer.java"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(options.Directory, "Dockerfile"))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, options.validate([]string{source}))
+	options.Force = true
+	assert.Nil(t, options.validate([]string{source}))
+}
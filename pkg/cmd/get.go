@@ -46,9 +46,22 @@ func newCmdGet(rootCmdOptions *RootCmdOptions) (*cobra.Command, *getCmdOptions)
 		RunE:    options.run,
 	}
 
+	cmd.AddCommand(newCmdGetKits(rootCmdOptions))
+
 	return &cmd, &options
 }
 
+// newCmdGetKits exposes the kit listing as "kamel get kits", for consistency with the integration listing
+func newCmdGetKits(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd, _ := newKitGetCmd(rootCmdOptions)
+	cmd.Use = "kits"
+	cmd.Aliases = []string{"kit", "integrationkits"}
+	cmd.Short = "Get the Integration Kits and the number of integrations using them"
+	cmd.Long = `Get the Integration Kits, along with their image, dependencies, age and the number of integrations using them.`
+
+	return cmd
+}
+
 func (o *getCmdOptions) run(cmd *cobra.Command, args []string) error {
 	c, err := o.GetCmdClient()
 	if err != nil {
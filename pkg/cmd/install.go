@@ -19,12 +19,14 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
 	"time"
 
 	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -32,8 +34,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/install"
@@ -95,10 +103,26 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *installCmdO
 	cmd.Flags().String("build-strategy", "", "Set the build strategy")
 	cmd.Flags().String("build-publish-strategy", "", "Set the build publish strategy")
 	cmd.Flags().String("build-timeout", "", "Set how long the build process can last")
+	cmd.Flags().Int32("max-running-builds", 0, "The maximum number of builds running concurrently in the namespace, builds running sequentially by default")
+	cmd.Flags().Int("build-max-retries", v1.BuildDefaultMaxRetries, "Set how many times a build that has failed with a transient error is retried")
+	cmd.Flags().Bool("build-offline", false, "To run the builds in offline mode, resolving the dependencies from the local Maven repository only")
 	cmd.Flags().String("trait-profile", "", "The profile to use for traits")
 	cmd.Flags().Bool("kaniko-build-cache", false, "To enable or disable the Kaniko cache")
+	cmd.Flags().String("kaniko-build-cache-size", "", "Set the size of the Kaniko cache persistent volume claim (default 1Gi)")
+	cmd.Flags().String("kaniko-build-cache-storage-class", "", "Set the storage class of the Kaniko cache persistent volume claim")
+	cmd.Flags().Bool("buildah-rootless", false, "To run Buildah as a non-root user, when using the Buildah publish strategy")
+	cmd.Flags().String("buildkit-address", "", "Set the address of the BuildKit daemon, when using the BuildKit publish strategy (the daemon runs rootless in the build pods by default)")
+	cmd.Flags().Bool("buildkit-cache", true, "To enable or disable the BuildKit layer cache export to the registry")
+	cmd.Flags().String("buildkit-cache-image", "", "Set the image the BuildKit layer cache is exported to")
+	cmd.Flags().StringArray("build-toleration", nil, "Add a Toleration to the build Pods")
+	cmd.Flags().StringArray("build-node-selector", nil, "Add a NodeSelector to the build Pods")
+	cmd.Flags().StringArray("build-platform", nil, "Add a platform the kit images are built for, e.g. linux/arm64, to build multi-architecture images (requires the Jib or BuildKit publish strategy)")
+	cmd.Flags().StringArray("build-resources", nil, "Define the resources requests and limits assigned to the build Pods as <requestType.requestResource=value> (ie, limits.memory=2Gi)")
 	cmd.Flags().String("http-proxy-secret", "", "Configure the source of the secret holding HTTP proxy server details "+
-		"(HTTP_PROXY|HTTPS_PROXY|NO_PROXY)")
+		"(HTTP_PROXY|HTTPS_PROXY|NO_PROXY), propagated to the operator and to the builds")
+	cmd.Flags().String("http-proxy", "", "Set the URL of the proxy server for HTTP requests (HTTP_PROXY), propagated to the operator and to the builds")
+	cmd.Flags().String("https-proxy", "", "Set the URL of the proxy server for HTTPS requests (HTTPS_PROXY), propagated to the operator and to the builds")
+	cmd.Flags().String("no-proxy", "", "Set the comma-separated list of hosts that are accessed without proxy (NO_PROXY), propagated to the operator and to the builds")
 
 	// OLM
 	cmd.Flags().Bool("olm", true, "Try to install everything via OLM (Operator Lifecycle Manager) if available")
@@ -111,20 +135,28 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *installCmdO
 		"from the channel")
 	cmd.Flags().String("olm-global-namespace", olm.DefaultGlobalNamespace, "A namespace containing an OperatorGroup that defines global scope for the "+
 		"operator (used in combination with the --global flag)")
+	cmd.Flags().String("olm-install-plan-approval", olm.DefaultInstallPlanApproval, "The approval strategy of the OLM install plans, "+
+		"use Manual to review operator upgrades before they are applied. One of: Automatic|Manual")
 
 	// Maven
 	cmd.Flags().String("maven-local-repository", "", "Path of the local Maven repository")
 	cmd.Flags().StringArray("maven-property", nil, "Add a Maven property")
 	cmd.Flags().StringArray("maven-extension", nil, "Add a Maven build extension")
 	cmd.Flags().String("maven-settings", "", "Configure the source of the Maven settings (configmap|secret:name[/key])")
+	cmd.Flags().String("maven-settings-file", "", "A local Maven settings file, used to create a ConfigMap holding the Maven settings")
+	cmd.Flags().String("maven-settings-security", "", "Configure the source of the Maven settings security, holding the encrypted master password "+
+		"used to decrypt the server passwords of the Maven settings (configmap|secret:name[/key])")
 	cmd.Flags().StringArray("maven-repository", nil, "Add a Maven repository")
 	cmd.Flags().String("maven-ca-secret", "", "Configure the secret key containing the Maven CA certificates (secret/key)")
+	cmd.Flags().String("maven-cache-pvc", "", "The persistent volume claim mounted as the local Maven repository by the builder pods")
+	cmd.Flags().String("maven-cache-max-age", "", "The age above which the artifacts of the local Maven repository are pruned (e.g. 168h)")
+	cmd.Flags().String("maven-cache-max-size", "", "The size above which the oldest artifacts of the local Maven repository are pruned (e.g. 10Gi)")
 
 	// health
 	cmd.Flags().Int("health-port", 8081, "The port of the health endpoint")
 
 	// monitoring
-	cmd.Flags().Bool("monitoring", false, "To enable or disable the operator monitoring")
+	cmd.Flags().Bool("monitoring", false, "To enable or disable the operator monitoring, and the integrations metrics scraping by default")
 	cmd.Flags().Int("monitoring-port", 8080, "The port of the metrics endpoint")
 
 	// Pod settings
@@ -132,6 +164,14 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *installCmdO
 	cmd.Flags().StringArray("node-selector", nil, "Add a NodeSelector to the operator Pod")
 	cmd.Flags().StringArray("operator-resources", nil, "Define the resources requests and limits assigned to the operator Pod as <requestType.requestResource=value> (ie, limits.memory=256Mi)")
 
+	// Operator tuning
+	cmd.Flags().String("operator-log-level", "", "The log level of the operator. One of: debug|info|warn|error")
+	cmd.Flags().Int("operator-max-concurrent-reconciles", 0, "The maximum number of resources each operator controller can reconcile concurrently")
+	cmd.Flags().Int("operator-max-running-builds", 0, "The maximum number of builds running concurrently across all the namespaces watched by the operator, unlimited by default")
+	cmd.Flags().Bool("knative", true, "Grant the operator access to the Knative resources when Knative is installed in the cluster, set to false on clusters that will never use Knative")
+	cmd.Flags().String("operator-id", "", "The id of the operator, only the resources assigned to this id with the "+v1.OperatorIDAnnotation+" annotation are reconciled")
+	cmd.Flags().Bool("operator-create-default-platform", false, "Let the operator create a default IntegrationPlatform in the namespaces where integrations are created and no platform exists")
+
 	// save
 	cmd.Flags().Bool("save", false, "Save the install parameters into the default kamel configuration file (kamel-config.yaml)")
 
@@ -157,6 +197,13 @@ type installCmdOptions struct {
 	ExampleSetup            bool     `mapstructure:"example"`
 	Global                  bool     `mapstructure:"global"`
 	KanikoBuildCache        bool     `mapstructure:"kaniko-build-cache"`
+	KanikoBuildCacheSize    string   `mapstructure:"kaniko-build-cache-size"`
+	KanikoBuildCacheStorage string   `mapstructure:"kaniko-build-cache-storage-class"`
+	BuildahRootless         bool     `mapstructure:"buildah-rootless"`
+	BuildKitAddress         string   `mapstructure:"buildkit-address"`
+	BuildKitCache           bool     `mapstructure:"buildkit-cache"`
+	BuildKitCacheImage      string   `mapstructure:"buildkit-cache-image"`
+	BuildOffline            bool     `mapstructure:"build-offline"`
 	Save                    bool     `mapstructure:"save" kamel:"omitsave"`
 	Force                   bool     `mapstructure:"force"`
 	Olm                     bool     `mapstructure:"olm"`
@@ -169,12 +216,23 @@ type installCmdOptions struct {
 	BuildStrategy           string   `mapstructure:"build-strategy"`
 	BuildPublishStrategy    string   `mapstructure:"build-publish-strategy"`
 	BuildTimeout            string   `mapstructure:"build-timeout"`
+	BuildMaxRetries         int      `mapstructure:"build-max-retries"`
+	MaxRunningBuilds        int32    `mapstructure:"max-running-builds"`
+	BuildTolerations        []string `mapstructure:"build-tolerations"`
+	BuildNodeSelectors      []string `mapstructure:"build-node-selectors"`
+	BuildPlatforms          []string `mapstructure:"build-platforms"`
+	BuildResources          []string `mapstructure:"build-resources"`
 	MavenExtensions         []string `mapstructure:"maven-extensions"`
 	MavenLocalRepository    string   `mapstructure:"maven-local-repository"`
 	MavenProperties         []string `mapstructure:"maven-properties"`
 	MavenRepositories       []string `mapstructure:"maven-repositories"`
 	MavenSettings           string   `mapstructure:"maven-settings"`
+	MavenSettingsFile       string   `mapstructure:"maven-settings-file"`
+	MavenSettingsSecurity   string   `mapstructure:"maven-settings-security"`
 	MavenCASecret           string   `mapstructure:"maven-ca-secret"`
+	MavenCachePVC           string   `mapstructure:"maven-cache-pvc"`
+	MavenCacheMaxAge        string   `mapstructure:"maven-cache-max-age"`
+	MavenCacheMaxSize       string   `mapstructure:"maven-cache-max-size"`
 	HealthPort              int32    `mapstructure:"health-port"`
 	Monitoring              bool     `mapstructure:"monitoring"`
 	MonitoringPort          int32    `mapstructure:"monitoring-port"`
@@ -182,7 +240,16 @@ type installCmdOptions struct {
 	Tolerations             []string `mapstructure:"tolerations"`
 	NodeSelectors           []string `mapstructure:"node-selectors"`
 	HTTPProxySecret         string   `mapstructure:"http-proxy-secret"`
+	HTTPProxy               string   `mapstructure:"http-proxy"`
+	HTTPSProxy              string   `mapstructure:"https-proxy"`
+	NoProxy                 string   `mapstructure:"no-proxy"`
 	ResourcesRequirements   []string `mapstructure:"operator-resources"`
+	OperatorLogLevel        string   `mapstructure:"operator-log-level"`
+	MaxConcurrentReconciles int      `mapstructure:"operator-max-concurrent-reconciles"`
+	MaxRunningBuildsTotal   int      `mapstructure:"operator-max-running-builds"`
+	OperatorID              string   `mapstructure:"operator-id"`
+	CreateDefaultPlatform   bool     `mapstructure:"operator-create-default-platform"`
+	Knative                 bool     `mapstructure:"knative"`
 
 	registry         v1.IntegrationPlatformRegistrySpec
 	registryAuth     registry.Auth
@@ -242,6 +309,20 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 		}
 	}
 
+	// Check the permissions of the global operator before any cluster-wide resource is set up
+	if o.Global && !o.ClusterSetupOnly && !o.SkipOperatorSetup && !installViaOLM && collection == nil {
+		c, err := clientProvider.Get()
+		if err != nil {
+			return err
+		}
+		if ok, err := install.HasPermissionToInstallGlobalOperator(o.Context, c); err != nil {
+			return errors.Wrap(err, "error while checking permissions to install the operator in global mode")
+		} else if !ok {
+			return errors.New("current user is not authorized to create the cluster roles required by the operator in global mode: " +
+				"please login as cluster-admin or install the operator without the --global flag")
+		}
+	}
+
 	if !o.SkipClusterSetup && !installViaOLM {
 		err := install.SetupClusterWideResourcesOrCollect(o.Context, clientProvider, collection, o.ClusterType, o.Force)
 		if err != nil && k8serrors.IsForbidden(err) {
@@ -280,9 +361,19 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 					Enabled: o.Monitoring,
 					Port:    o.MonitoringPort,
 				},
-				Tolerations:           o.Tolerations,
-				NodeSelectors:         o.NodeSelectors,
-				ResourcesRequirements: o.ResourcesRequirements,
+				Tolerations:             o.Tolerations,
+				NodeSelectors:           o.NodeSelectors,
+				ResourcesRequirements:   o.ResourcesRequirements,
+				HTTPProxySecret:         o.HTTPProxySecret,
+				HTTPProxy:               o.HTTPProxy,
+				HTTPSProxy:              o.HTTPSProxy,
+				NoProxy:                 o.NoProxy,
+				LogLevel:                o.OperatorLogLevel,
+				MaxConcurrentReconciles: o.MaxConcurrentReconciles,
+				MaxRunningBuilds:        o.MaxRunningBuildsTotal,
+				OperatorID:              o.OperatorID,
+				CreateDefaultPlatform:   o.CreateDefaultPlatform,
+				SkipKnative:             !o.Knative,
 			}
 			err = install.OperatorOrCollect(o.Context, c, cfg, collection, o.Force)
 			if err != nil {
@@ -312,10 +403,24 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 			fmt.Fprintln(cobraCmd.OutOrStdout(), "Camel K operator registry setup skipped")
 		}
 
+		if o.registry.Secret != "" && collection == nil {
+			secret := corev1.Secret{}
+			key := ctrl.ObjectKey{Namespace: namespace, Name: o.registry.Secret}
+			if err := c.Get(o.Context, key, &secret); err != nil && k8serrors.IsNotFound(err) {
+				fmt.Fprintf(cobraCmd.OutOrStdout(), "Warning: registry secret %s not found in namespace %s, "+
+					"it must be created before building integrations\n", o.registry.Secret, namespace)
+			} else if err != nil {
+				return err
+			}
+		}
+
 		platform, err := install.PlatformOrCollect(o.Context, c, o.ClusterType, namespace, o.SkipRegistrySetup, o.registry, collection)
 		if err != nil {
 			return err
 		}
+		if o.OperatorID != "" {
+			v1.SetOperatorIDAnnotation(platform, o.OperatorID)
+		}
 
 		if generatedSecretName != "" {
 			platform.Spec.Build.Registry.Secret = generatedSecretName
@@ -353,6 +458,22 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 		if o.MavenLocalRepository != "" {
 			platform.Spec.Build.Maven.LocalRepository = o.MavenLocalRepository
 		}
+		if o.MavenCachePVC != "" || o.MavenCacheMaxAge != "" || o.MavenCacheMaxSize != "" {
+			cache := v1.MavenCacheSpec{
+				PersistentVolumeClaim: o.MavenCachePVC,
+				MaxSize:               o.MavenCacheMaxSize,
+			}
+			if o.MavenCacheMaxAge != "" {
+				d, err := time.ParseDuration(o.MavenCacheMaxAge)
+				if err != nil {
+					return err
+				}
+				cache.MaxAge = &metav1.Duration{
+					Duration: d,
+				}
+			}
+			platform.Spec.Build.Maven.Cache = &cache
+		}
 		if o.RuntimeVersion != "" {
 			platform.Spec.Build.RuntimeVersion = o.RuntimeVersion
 		}
@@ -375,6 +496,37 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 				Duration: d,
 			}
 		}
+		if o.MaxRunningBuilds > 0 {
+			platform.Spec.Build.MaxRunningBuilds = o.MaxRunningBuilds
+		}
+		if len(o.BuildTolerations) > 0 {
+			tolerations, err := kubernetes.NewTolerations(o.BuildTolerations)
+			if err != nil {
+				return err
+			}
+			platform.Spec.Build.Tolerations = tolerations
+		}
+		if len(o.BuildNodeSelectors) > 0 {
+			nodeSelector, err := kubernetes.NewNodeSelectors(o.BuildNodeSelectors)
+			if err != nil {
+				return err
+			}
+			platform.Spec.Build.NodeSelector = nodeSelector
+		}
+		if len(o.BuildPlatforms) > 0 {
+			platform.Spec.Build.Platforms = o.BuildPlatforms
+		}
+		if len(o.BuildResources) > 0 {
+			resources, err := kubernetes.NewResourceRequirements(o.BuildResources)
+			if err != nil {
+				return err
+			}
+			platform.Spec.Build.Resources = &resources
+		}
+		buildMaxRetriesFlag := cobraCmd.Flags().Lookup("build-max-retries")
+		if buildMaxRetriesFlag.Changed {
+			platform.Spec.Build.MaxRetries = &o.BuildMaxRetries
+		}
 		if o.TraitProfile != "" {
 			platform.Spec.Profile = v1.TraitProfileByName(o.TraitProfile)
 		}
@@ -385,13 +537,26 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 			}
 		}
 
-		if o.MavenSettings != "" {
+		if o.MavenSettingsFile != "" {
+			mavenSettings, err := install.MavenSettingsFromFileOrCollect(o.Context, c, namespace, o.MavenSettingsFile, collection, o.Force)
+			if err != nil {
+				return err
+			}
+			platform.Spec.Build.Maven.Settings = mavenSettings
+		} else if o.MavenSettings != "" {
 			mavenSettings, err := decodeMavenSettings(o.MavenSettings)
 			if err != nil {
 				return err
 			}
 			platform.Spec.Build.Maven.Settings = mavenSettings
 		}
+		if o.MavenSettingsSecurity != "" {
+			mavenSettingsSecurity, err := decodeMavenSettingsSecurity(o.MavenSettingsSecurity)
+			if err != nil {
+				return err
+			}
+			platform.Spec.Build.Maven.SettingsSecurity = mavenSettingsSecurity
+		}
 
 		if o.MavenCASecret != "" {
 			secret, err := decodeSecretKeySelector(o.MavenCASecret)
@@ -404,6 +569,9 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 		if o.HTTPProxySecret != "" {
 			platform.Spec.Build.HTTPProxySecret = o.HTTPProxySecret
 		}
+		platform.Spec.Build.HTTPProxy = o.HTTPProxy
+		platform.Spec.Build.HTTPSProxy = o.HTTPSProxy
+		platform.Spec.Build.NoProxy = o.NoProxy
 
 		if o.ClusterType != "" {
 			for _, c := range v1.AllIntegrationPlatformClusters {
@@ -413,10 +581,40 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 			}
 		}
 
+		if o.Monitoring {
+			if err := o.configurePlatformMonitoring(cobraCmd, c, platform); err != nil {
+				return err
+			}
+		}
+
 		kanikoBuildCacheFlag := cobraCmd.Flags().Lookup("kaniko-build-cache")
 		if kanikoBuildCacheFlag.Changed {
 			platform.Spec.Build.KanikoBuildCache = &o.KanikoBuildCache
 		}
+		if o.KanikoBuildCacheSize != "" {
+			platform.Spec.Build.KanikoBuildCacheSize = o.KanikoBuildCacheSize
+		}
+		if o.KanikoBuildCacheStorage != "" {
+			platform.Spec.Build.KanikoBuildCacheStorageClass = o.KanikoBuildCacheStorage
+		}
+		buildahRootlessFlag := cobraCmd.Flags().Lookup("buildah-rootless")
+		if buildahRootlessFlag.Changed {
+			platform.Spec.Build.BuildahRootless = &o.BuildahRootless
+		}
+		if o.BuildKitAddress != "" {
+			platform.Spec.Build.BuildKitAddress = o.BuildKitAddress
+		}
+		buildKitCacheFlag := cobraCmd.Flags().Lookup("buildkit-cache")
+		if buildKitCacheFlag.Changed {
+			platform.Spec.Build.BuildKitCache = &o.BuildKitCache
+		}
+		if o.BuildKitCacheImage != "" {
+			platform.Spec.Build.BuildKitCacheImage = o.BuildKitCacheImage
+		}
+		buildOfflineFlag := cobraCmd.Flags().Lookup("build-offline")
+		if buildOfflineFlag.Changed {
+			platform.Spec.Build.Offline = &o.BuildOffline
+		}
 
 		// Always create a platform in the namespace where the operator is located
 		err = install.ObjectOrCollect(o.Context, c, namespace, collection, o.Force, platform)
@@ -458,6 +656,32 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// configurePlatformMonitoring enables the Prometheus trait by default on the platform,
+// so that the metrics of the integrations get scraped by the Prometheus Operator
+func (o *installCmdOptions) configurePlatformMonitoring(cmd *cobra.Command, c client.Client, platform *v1.IntegrationPlatform) error {
+	installed, err := kubernetes.IsAPIResourceInstalled(c, monitoringv1.SchemeGroupVersion.String(), monitoringv1.PodMonitorsKind)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		fmt.Fprintln(cmd.OutOrStdout(), "Warning: the Prometheus Operator is not installed, integration metrics scraping has not been enabled")
+		return nil
+	}
+
+	if platform.Spec.Traits == nil {
+		platform.Spec.Traits = make(map[string]v1.TraitSpec)
+	}
+	if _, ok := platform.Spec.Traits["prometheus"]; !ok {
+		platform.Spec.Traits["prometheus"] = v1.TraitSpec{
+			Configuration: v1.TraitConfiguration{
+				RawMessage: []byte(`{"enabled":true}`),
+			},
+		}
+	}
+
+	return nil
+}
+
 func (o *installCmdOptions) postRun(cmd *cobra.Command, _ []string) error {
 	if o.Save {
 		cfg, err := LoadConfiguration()
@@ -496,8 +720,13 @@ func (o *installCmdOptions) printOutput(collection *kubernetes.Collection) error
 
 // nolint:errcheck
 func (o *installCmdOptions) waitForPlatformReady(cmd *cobra.Command, platform *v1.IntegrationPlatform) error {
+	phase := platform.Status.Phase
+	var conditions []v1.IntegrationPlatformCondition
 	handler := func(i *v1.IntegrationPlatform) bool {
-		if i.Status.Phase == v1.IntegrationPlatformPhaseReady || i.Status.Phase == v1.IntegrationPlatformPhaseError {
+		phase = i.Status.Phase
+		conditions = i.Status.Conditions
+		switch i.Status.Phase {
+		case v1.IntegrationPlatformPhaseReady, v1.IntegrationPlatformPhaseError, v1.IntegrationPlatformPhaseDuplicate:
 			return false
 		}
 
@@ -509,7 +738,34 @@ func (o *installCmdOptions) waitForPlatformReady(cmd *cobra.Command, platform *v
 		return true
 	})
 
-	return watch.HandlePlatformStateChanges(o.Context, platform, handler)
+	if err := watch.HandlePlatformStateChanges(o.Context, platform, handler); err != nil {
+		return err
+	}
+
+	return platformReadinessError(platform.Name, phase, conditions)
+}
+
+// platformReadinessError returns an error describing why the platform is not ready, or nil when it is
+func platformReadinessError(name string, phase v1.IntegrationPlatformPhase, conditions []v1.IntegrationPlatformCondition) error {
+	if phase == v1.IntegrationPlatformPhaseReady {
+		return nil
+	}
+
+	messages := make([]string, 0)
+	for _, condition := range conditions {
+		if condition.Status == corev1.ConditionFalse && condition.Message != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		}
+	}
+
+	msg := fmt.Sprintf("integration platform %s is not ready (phase: %s)", name, phase)
+	if phase == v1.IntegrationPlatformPhaseNone {
+		msg = fmt.Sprintf("integration platform %s has not been reconciled", name)
+	}
+	if len(messages) > 0 {
+		msg += ": " + strings.Join(messages, ", ")
+	}
+	return errors.New(msg)
 }
 
 func (o *installCmdOptions) decode(cmd *cobra.Command, _ []string) error {
@@ -533,6 +789,7 @@ func (o *installCmdOptions) decode(cmd *cobra.Command, _ []string) error {
 	o.olmOptions.SourceNamespace = viper.GetString(path + ".olm-source-namespace")
 	o.olmOptions.StartingCSV = viper.GetString(path + ".olm-starting-csv")
 	o.olmOptions.GlobalNamespace = viper.GetString(path + ".olm-global-namespace")
+	o.olmOptions.InstallPlanApproval = viper.GetString(path + ".olm-install-plan-approval")
 
 	return nil
 }
@@ -545,6 +802,50 @@ func (o *installCmdOptions) validate(_ *cobra.Command, _ []string) error {
 		result = multierr.Append(result, err)
 	}
 
+	if len(o.MavenRepositories) > 0 && o.MavenSettingsFile != "" {
+		err := fmt.Errorf("incompatible options combinations: you cannot set both mavenRepository and mavenSettingsFile")
+		result = multierr.Append(result, err)
+	}
+
+	if o.MavenSettings != "" && o.MavenSettingsFile != "" {
+		err := fmt.Errorf("incompatible options combinations: you cannot set both mavenSettings and mavenSettingsFile")
+		result = multierr.Append(result, err)
+	}
+
+	if o.MavenSettingsSecurity != "" && o.MavenSettings == "" && o.MavenSettingsFile == "" {
+		err := fmt.Errorf("incompatible options combinations: you cannot set mavenSettingsSecurity without mavenSettings or mavenSettingsFile")
+		result = multierr.Append(result, err)
+	}
+
+	if o.MavenSettingsFile != "" {
+		if nfo, err := os.Stat(o.MavenSettingsFile); err != nil {
+			result = multierr.Append(result, err)
+		} else if nfo.IsDir() {
+			result = multierr.Append(result, fmt.Errorf("maven settings file cannot be a directory: %s", o.MavenSettingsFile))
+		}
+	}
+
+	for _, proxy := range []struct{ flag, value string }{{"http-proxy", o.HTTPProxy}, {"https-proxy", o.HTTPSProxy}} {
+		if proxy.value == "" {
+			continue
+		}
+		if u, err := url.Parse(proxy.value); err != nil || u.Scheme == "" || u.Host == "" {
+			result = multierr.Append(result, fmt.Errorf("invalid %s %s: must be an URL, e.g. http://proxy.example.com:3128", proxy.flag, proxy.value))
+		}
+	}
+
+	if o.MavenCacheMaxAge != "" {
+		if _, err := time.ParseDuration(o.MavenCacheMaxAge); err != nil {
+			result = multierr.Append(result, fmt.Errorf("invalid Maven cache max age %s: %v", o.MavenCacheMaxAge, err))
+		}
+	}
+
+	if o.MavenCacheMaxSize != "" {
+		if _, err := resource.ParseQuantity(o.MavenCacheMaxSize); err != nil {
+			result = multierr.Append(result, fmt.Errorf("invalid Maven cache max size %s: %v", o.MavenCacheMaxSize, err))
+		}
+	}
+
 	if o.TraitProfile != "" {
 		tp := v1.TraitProfileByName(o.TraitProfile)
 		if tp == v1.TraitProfile("") {
@@ -553,6 +854,50 @@ func (o *installCmdOptions) validate(_ *cobra.Command, _ []string) error {
 		}
 	}
 
+	if o.BuildMaxRetries < 0 {
+		result = multierr.Append(result, fmt.Errorf("invalid build max retries %d: must not be negative", o.BuildMaxRetries))
+	}
+
+	if _, err := kubernetes.NewTolerations(o.BuildTolerations); err != nil {
+		result = multierr.Append(result, fmt.Errorf("invalid build toleration: %v", err))
+	}
+
+	if _, err := kubernetes.NewNodeSelectors(o.BuildNodeSelectors); err != nil {
+		result = multierr.Append(result, fmt.Errorf("invalid build node selector: %v", err))
+	}
+
+	if _, err := kubernetes.NewResourceRequirements(o.BuildResources); err != nil {
+		result = multierr.Append(result, fmt.Errorf("invalid build resources: %v", err))
+	}
+
+	if o.KanikoBuildCacheSize != "" {
+		if _, err := resource.ParseQuantity(o.KanikoBuildCacheSize); err != nil {
+			result = multierr.Append(result, fmt.Errorf("invalid Kaniko cache size %s: %v", o.KanikoBuildCacheSize, err))
+		}
+	}
+
+	if o.OperatorLogLevel != "" {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(o.OperatorLogLevel)); err != nil {
+			result = multierr.Append(result, fmt.Errorf("unknown operator log level %s", o.OperatorLogLevel))
+		}
+	}
+
+	if o.MaxConcurrentReconciles < 0 {
+		err := fmt.Errorf("operator max concurrent reconciles must be a positive number, found: %d", o.MaxConcurrentReconciles)
+		result = multierr.Append(result, err)
+	}
+
+	if o.MaxRunningBuildsTotal < 0 {
+		err := fmt.Errorf("operator max running builds must be a positive number, found: %d", o.MaxRunningBuildsTotal)
+		result = multierr.Append(result, err)
+	}
+
+	if o.MaxRunningBuilds < 0 {
+		err := fmt.Errorf("max running builds must be a positive number, found: %d", o.MaxRunningBuilds)
+		result = multierr.Append(result, err)
+	}
+
 	if o.registry.Secret != "" && (o.registryAuth.IsSet() || o.RegistryAuthFile != "") {
 		err := fmt.Errorf("incompatible options combinations: you cannot set both registry-secret and registry-auth-[*] settings")
 		result = multierr.Append(result, err)
@@ -572,6 +917,15 @@ func (o *installCmdOptions) validate(_ *cobra.Command, _ []string) error {
 		}
 	}
 
+	if o.olmOptions.InstallPlanApproval != "" {
+		approval := operatorsv1alpha1.Approval(o.olmOptions.InstallPlanApproval)
+		if approval != operatorsv1alpha1.ApprovalAutomatic && approval != operatorsv1alpha1.ApprovalManual {
+			err := fmt.Errorf("unknown OLM install plan approval %s. One of [%s, %s] is expected",
+				o.olmOptions.InstallPlanApproval, operatorsv1alpha1.ApprovalAutomatic, operatorsv1alpha1.ApprovalManual)
+			result = multierr.Append(result, err)
+		}
+	}
+
 	if o.BuildStrategy != "" {
 		found := false
 		for _, s := range v1.IntegrationPlatformBuildStrategies {
@@ -652,6 +1006,21 @@ func decodeMavenSettings(mavenSettings string) (v1.ValueSource, error) {
 	return v1.ValueSource{}, fmt.Errorf("illegal maven setting definition, syntax: configmap|secret:resource-name[/settings path]")
 }
 
+// decodeMavenSettingsSecurity decodes the Maven settings security source, whose key defaults to settings-security.xml
+func decodeMavenSettingsSecurity(mavenSettingsSecurity string) (v1.ValueSource, error) {
+	source, err := decodeMavenSettings(mavenSettingsSecurity)
+	if err != nil {
+		return v1.ValueSource{}, fmt.Errorf("illegal maven settings security definition, syntax: configmap|secret:resource-name[/settings security path]")
+	}
+	if source.ConfigMapKeyRef != nil && source.ConfigMapKeyRef.Key == "" {
+		source.ConfigMapKeyRef.Key = "settings-security.xml"
+	}
+	if source.SecretKeyRef != nil && source.SecretKeyRef.Key == "" {
+		source.SecretKeyRef.Key = "settings-security.xml"
+	}
+	return source, nil
+}
+
 func decodeSecretKeySelector(secretKey string) (*corev1.SecretKeySelector, error) {
 	r := regexp.MustCompile(`^([a-zA-Z0-9-]*)/([a-zA-Z0-9].*)$`)
 
@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/olm"
@@ -73,6 +74,7 @@ func TestInstallNoFlag(t *testing.T) {
 	assert.Equal(t, olm.DefaultSource, installCmdOptions.olmOptions.Source)
 	assert.Equal(t, olm.DefaultSourceNamespace, installCmdOptions.olmOptions.SourceNamespace)
 	assert.Equal(t, olm.DefaultGlobalNamespace, installCmdOptions.olmOptions.GlobalNamespace)
+	assert.Equal(t, olm.DefaultInstallPlanApproval, installCmdOptions.olmOptions.InstallPlanApproval)
 	assert.Equal(t, int32(8081), installCmdOptions.HealthPort)
 	assert.Equal(t, false, installCmdOptions.Monitoring)
 	assert.Equal(t, int32(8080), installCmdOptions.MonitoringPort)
@@ -112,6 +114,13 @@ func TestInstallBuildTimeoutFlag(t *testing.T) {
 	assert.Equal(t, "10", installCmdOptions.BuildTimeout)
 }
 
+func TestInstallBuildMaxRetriesFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--build-max-retries", "2")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, installCmdOptions.BuildMaxRetries)
+}
+
 func TestInstallClusterSetupFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--cluster-setup")
@@ -167,6 +176,52 @@ func TestInstallKanikoBuildCacheFlag(t *testing.T) {
 	assert.Equal(t, true, installCmdOptions.KanikoBuildCache)
 }
 
+func TestInstallKanikoBuildCacheVolumeFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--kaniko-build-cache-size", "10Gi", "--kaniko-build-cache-storage-class", "fast")
+	assert.Nil(t, err)
+	assert.Equal(t, "10Gi", installCmdOptions.KanikoBuildCacheSize)
+	assert.Equal(t, "fast", installCmdOptions.KanikoBuildCacheStorage)
+}
+
+func TestInstallBuildahRootlessFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--buildah-rootless")
+	assert.Nil(t, err)
+	assert.Equal(t, true, installCmdOptions.BuildahRootless)
+}
+
+func TestInstallBuildKitFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--build-publish-strategy", "BuildKit",
+		"--buildkit-address", "tcp://buildkitd:1234",
+		"--buildkit-cache=false",
+		"--buildkit-cache-image", "registry/ns/cache")
+	assert.Nil(t, err)
+	assert.Equal(t, "BuildKit", installCmdOptions.BuildPublishStrategy)
+	assert.Equal(t, "tcp://buildkitd:1234", installCmdOptions.BuildKitAddress)
+	assert.Equal(t, false, installCmdOptions.BuildKitCache)
+	assert.Equal(t, "registry/ns/cache", installCmdOptions.BuildKitCacheImage)
+}
+
+func TestInstallBuildOfflineFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--build-offline")
+	assert.Nil(t, err)
+	assert.Equal(t, true, installCmdOptions.BuildOffline)
+}
+
+func TestInstallBuildPlatformFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--build-publish-strategy", "BuildKit",
+		"--build-platform", "linux/amd64",
+		"--build-platform", "linux/arm64")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, installCmdOptions.BuildPlatforms)
+}
+
 func TestInstallLocalRepositoryFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--maven-local-repository", "someString")
@@ -191,6 +246,61 @@ func TestInstallMavenSettingsFlag(t *testing.T) {
 	assert.Equal(t, "someString", installCmdOptions.MavenSettings)
 }
 
+func TestInstallMavenSettingsFileFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--maven-settings-file", "settings.xml")
+	assert.Nil(t, err)
+	assert.Equal(t, "settings.xml", installCmdOptions.MavenSettingsFile)
+}
+
+func TestInstallMavenSettingsFileValidation(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--maven-settings-file", "settings.xml",
+		"--maven-settings", "configmap:maven-settings")
+	assert.Nil(t, err)
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestInstallMavenSettingsSecurityFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--maven-settings", "secret:maven-settings/settings.xml",
+		"--maven-settings-security", "secret:maven-settings")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret:maven-settings", installCmdOptions.MavenSettingsSecurity)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+
+	val, err := decodeMavenSettingsSecurity(installCmdOptions.MavenSettingsSecurity)
+	assert.Nil(t, err)
+	assert.Equal(t, "maven-settings", val.SecretKeyRef.Name)
+	assert.Equal(t, "settings-security.xml", val.SecretKeyRef.Key)
+}
+
+func TestInstallMavenSettingsSecurityValidation(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--maven-settings-security", "secret:maven-settings")
+	assert.Nil(t, err)
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestInstallMavenCacheFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--maven-cache-pvc", "maven-cache",
+		"--maven-cache-max-age", "168h",
+		"--maven-cache-max-size", "10Gi")
+	assert.Nil(t, err)
+	assert.Equal(t, "maven-cache", installCmdOptions.MavenCachePVC)
+	assert.Equal(t, "168h", installCmdOptions.MavenCacheMaxAge)
+	assert.Equal(t, "10Gi", installCmdOptions.MavenCacheMaxSize)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+
+	installCmdOptions.MavenCacheMaxAge = "a week"
+	installCmdOptions.MavenCacheMaxSize = "big"
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+}
+
 func TestInstallMonitoringFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
@@ -217,6 +327,7 @@ func TestInstallOlmTrueNonDefaultFlag(t *testing.T) {
 		"--olm-package", "olmPackage",
 		"--olm-source", "olmSource",
 		"--olm-source-namespace", "olmSourceNamespace",
+		"--olm-install-plan-approval", "Manual",
 		"--olm-starting-csv", "olmStartingCSV")
 	assert.Nil(t, err)
 	assert.Equal(t, true, installCmdOptions.Olm)
@@ -227,6 +338,7 @@ func TestInstallOlmTrueNonDefaultFlag(t *testing.T) {
 	assert.Equal(t, "olmSource", installCmdOptions.olmOptions.Source)
 	assert.Equal(t, "olmSourceNamespace", installCmdOptions.olmOptions.SourceNamespace)
 	assert.Equal(t, "olmStartingCSV", installCmdOptions.olmOptions.StartingCSV)
+	assert.Equal(t, "Manual", installCmdOptions.olmOptions.InstallPlanApproval)
 }
 
 func TestInstallOperatorImageFlag(t *testing.T) {
@@ -243,6 +355,51 @@ func TestInstallOperatorImagePullPolicyFlag(t *testing.T) {
 	assert.Equal(t, "someString", installCmdOptions.OperatorImagePullPolicy)
 }
 
+func TestInstallOperatorTuningFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--operator-log-level", "debug",
+		"--operator-max-concurrent-reconciles", "5",
+		"--operator-max-running-builds", "3",
+		"--operator-id", "my-operator")
+	assert.Nil(t, err)
+	assert.Equal(t, "debug", installCmdOptions.OperatorLogLevel)
+	assert.Equal(t, 5, installCmdOptions.MaxConcurrentReconciles)
+	assert.Equal(t, 3, installCmdOptions.MaxRunningBuildsTotal)
+	assert.Equal(t, "my-operator", installCmdOptions.OperatorID)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+
+	installCmdOptions.OperatorLogLevel = "verbose"
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestInstallHttpProxyFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--http-proxy", "http://proxy.example.com:3128",
+		"--https-proxy", "http://proxy.example.com:3129",
+		"--no-proxy", ".cluster.local,.svc,10.0.0.0/16")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", installCmdOptions.HTTPProxy)
+	assert.Equal(t, "http://proxy.example.com:3129", installCmdOptions.HTTPSProxy)
+	assert.Equal(t, ".cluster.local,.svc,10.0.0.0/16", installCmdOptions.NoProxy)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+
+	installCmdOptions.HTTPSProxy = "proxy.example.com"
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestInstallMaxRunningBuildsFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--max-running-builds", "2")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), installCmdOptions.MaxRunningBuilds)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+
+	installCmdOptions.MaxRunningBuilds = -1
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+}
+
 func TestInstallOutputFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--output", "yaml")
@@ -293,6 +450,13 @@ func TestInstallRuntimeVersionFlag(t *testing.T) {
 	assert.Equal(t, "1.3.0", installCmdOptions.RuntimeVersion)
 }
 
+func TestInstallOperatorCreateDefaultPlatformFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--operator-create-default-platform")
+	assert.Nil(t, err)
+	assert.Equal(t, true, installCmdOptions.CreateDefaultPlatform)
+}
+
 func TestInstallSaveFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--save")
@@ -307,6 +471,18 @@ func TestInstallSkipClusterSetupFlag(t *testing.T) {
 	assert.Equal(t, true, installCmdOptions.SkipClusterSetup)
 }
 
+func TestInstallKnativeFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall)
+	assert.Nil(t, err)
+	assert.Equal(t, true, installCmdOptions.Knative)
+
+	installCmdOptions, rootCmd, _ = initializeInstallCmdOptions(t)
+	_, err = test.ExecuteCommand(rootCmd, cmdInstall, "--knative=false")
+	assert.Nil(t, err)
+	assert.Equal(t, false, installCmdOptions.Knative)
+}
+
 func TestInstallSkipOperatorSetupFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--skip-operator-setup")
@@ -382,6 +558,23 @@ func TestInstallTolerationFlag(t *testing.T) {
 	assert.Equal(t, "key2=value2:NoExecute", installCmdOptions.Tolerations[1])
 }
 
+func TestInstallBuildPodFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--build-toleration", "build=true:NoSchedule",
+		"--build-node-selector", "node-role.kubernetes.io/build=true",
+		"--build-resources", "requests.cpu=1",
+		"--build-resources", "limits.memory=4Gi")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"build=true:NoSchedule"}, installCmdOptions.BuildTolerations)
+	assert.Equal(t, []string{"node-role.kubernetes.io/build=true"}, installCmdOptions.BuildNodeSelectors)
+	assert.Equal(t, []string{"requests.cpu=1", "limits.memory=4Gi"}, installCmdOptions.BuildResources)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+
+	installCmdOptions.BuildResources = []string{"limits.memory=lots"}
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+}
+
 func TestInstallMavenExtension(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
@@ -389,3 +582,34 @@ func TestInstallMavenExtension(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "fi.yle.tools:aws-maven:1.4.2", installCmdOptions.MavenExtensions[0])
 }
+
+func TestInstallOlmInstallPlanApprovalValidation(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--olm-install-plan-approval", "Sometimes")
+	assert.Nil(t, err)
+	assert.NotNil(t, installCmdOptions.validate(nil, nil))
+
+	installCmdOptions.olmOptions.InstallPlanApproval = "Manual"
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestPlatformReadinessError(t *testing.T) {
+	assert.Nil(t, platformReadinessError("camel-k", v1.IntegrationPlatformPhaseReady, nil))
+
+	err := platformReadinessError("camel-k", v1.IntegrationPlatformPhaseError, []v1.IntegrationPlatformCondition{
+		{
+			Type:    "RegistryAvailable",
+			Status:  corev1.ConditionFalse,
+			Message: "registry address not set",
+		},
+		{
+			Type:    "CatalogAvailable",
+			Status:  corev1.ConditionTrue,
+			Message: "catalog found",
+		},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "integration platform camel-k is not ready (phase: Error): RegistryAvailable: registry address not set", err.Error())
+
+	assert.NotNil(t, platformReadinessError("camel-k", v1.IntegrationPlatformPhaseNone, nil))
+}
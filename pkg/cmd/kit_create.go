@@ -52,6 +52,7 @@ func newKitCreateCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kitCreate
 	cmd.Flags().StringArray("configmap", nil, "Add a ConfigMap")
 	cmd.Flags().StringArray("secret", nil, "Add a Secret")
 	cmd.Flags().StringArray("repository", nil, "Add a maven repository")
+	cmd.Flags().StringArray("source", nil, "Add the dependencies required by an integration source file")
 	cmd.Flags().StringArrayP("trait", "t", nil, "Configure a trait. E.g. \"-t service.enabled=false\"")
 
 	// completion support
@@ -69,6 +70,7 @@ type kitCreateCommandOptions struct {
 	Configmaps   []string `mapstructure:"configmaps"`
 	Secrets      []string `mapstructure:"secrets"`
 	Repositories []string `mapstructure:"repositories"`
+	Sources      []string `mapstructure:"sources"`
 	Traits       []string `mapstructure:"traits"`
 }
 
@@ -131,16 +133,23 @@ func (command *kitCreateCommandOptions) run(_ *cobra.Command, args []string) err
 		// Set the Image to be used by the kit
 		kit.Spec.Image = command.Image
 	}
-	for _, item := range command.Dependencies {
-		switch {
-		case strings.HasPrefix(item, "mvn:"):
-			kit.Spec.Dependencies = append(kit.Spec.Dependencies, item)
-		case strings.HasPrefix(item, "file:"):
-			kit.Spec.Dependencies = append(kit.Spec.Dependencies, item)
-		case strings.HasPrefix(item, "camel-quarkus-"):
-			kit.Spec.Dependencies = append(kit.Spec.Dependencies, "camel:"+strings.TrimPrefix(item, "camel-quarkus-"))
-		case strings.HasPrefix(item, "camel-"):
-			kit.Spec.Dependencies = append(kit.Spec.Dependencies, "camel:"+strings.TrimPrefix(item, "camel-"))
+
+	dependencies := append([]string{}, command.Dependencies...)
+	if len(command.Sources) > 0 {
+		// resolve the top level dependencies the same way the inspect command does
+		catalog, err := createCamelCatalog(command.Context)
+		if err != nil {
+			return err
+		}
+		sourceDependencies, err := getTopLevelDependencies(catalog, command.Sources)
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, sourceDependencies...)
+	}
+	for _, item := range dependencies {
+		if dependency := toKitDependency(item); dependency != "" {
+			util.StringSliceUniqueAdd(&kit.Spec.Dependencies, dependency)
 		}
 	}
 
@@ -194,6 +203,24 @@ func (command *kitCreateCommandOptions) run(_ *cobra.Command, args []string) err
 	return nil
 }
 
+// toKitDependency converts a user provided dependency to the format expected by the kit,
+// it returns an empty string if the dependency is not supported.
+func toKitDependency(item string) string {
+	switch {
+	case strings.HasPrefix(item, "mvn:"):
+		return item
+	case strings.HasPrefix(item, "file:"):
+		return item
+	case strings.HasPrefix(item, "camel:"), strings.HasPrefix(item, "camel-k:"), strings.HasPrefix(item, "camel-quarkus:"):
+		return item
+	case strings.HasPrefix(item, "camel-quarkus-"):
+		return "camel:" + strings.TrimPrefix(item, "camel-quarkus-")
+	case strings.HasPrefix(item, "camel-"):
+		return "camel:" + strings.TrimPrefix(item, "camel-")
+	}
+	return ""
+}
+
 func (*kitCreateCommandOptions) configureTraits(kit *v1.IntegrationKit, options []string, catalog *trait.Catalog) error {
 	traits, err := configureTraits(options, catalog)
 	if err != nil {
@@ -127,3 +127,24 @@ func TestKitCreateTraitFlag(t *testing.T) {
 	assert.Equal(t, "someString1", kitCreateCmdOptions.Traits[0])
 	assert.Equal(t, "someString2", kitCreateCmdOptions.Traits[1])
 }
+
+func TestKitCreateSourceFlag(t *testing.T) {
+	kitCreateCmdOptions, rootCmd, _ := initializeKitCreateCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, subCmdKit,
+		"--source", "Routes.java",
+		"--source", "routes.yaml")
+	assert.Nil(t, err)
+	assert.Len(t, kitCreateCmdOptions.Sources, 2)
+	assert.Equal(t, "Routes.java", kitCreateCmdOptions.Sources[0])
+	assert.Equal(t, "routes.yaml", kitCreateCmdOptions.Sources[1])
+}
+
+func TestKitDependency(t *testing.T) {
+	assert.Equal(t, "mvn:org.acme:lib:1.0", toKitDependency("mvn:org.acme:lib:1.0"))
+	assert.Equal(t, "file:lib.jar", toKitDependency("file:lib.jar"))
+	assert.Equal(t, "camel:log", toKitDependency("camel:log"))
+	assert.Equal(t, "camel:log", toKitDependency("camel-log"))
+	assert.Equal(t, "camel:log", toKitDependency("camel-quarkus-log"))
+	assert.Equal(t, "camel-k:knative", toKitDependency("camel-k:knative"))
+	assert.Equal(t, "", toKitDependency("unknown"))
+}
@@ -20,9 +20,13 @@ package cmd
 import (
 	"fmt"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/duration"
+
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -78,8 +82,13 @@ func (command *kitGetCommandOptions) run(cmd *cobra.Command) error {
 		return err
 	}
 
+	usages, err := command.kitUsages(c)
+	if err != nil {
+		return err
+	}
+
 	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
-	fmt.Fprintln(w, "NAME\tPHASE\tTYPE\tIMAGE")
+	fmt.Fprintln(w, "NAME\tPHASE\tTYPE\tIMAGE\tDEPENDENCIES\tAGE\tINTEGRATIONS")
 	for _, ctx := range kitList.Items {
 		t := ctx.Labels["camel.apache.org/kit.type"]
 		u := command.User && t == v1.IntegrationKitTypeUser
@@ -87,10 +96,40 @@ func (command *kitGetCommandOptions) run(cmd *cobra.Command) error {
 		p := command.Platform && t == v1.IntegrationKitTypePlatform
 
 		if u || e || p {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ctx.Name, string(ctx.Status.Phase), t, ctx.Status.Image)
+			age := "<unknown>"
+			if !ctx.CreationTimestamp.IsZero() {
+				age = duration.HumanDuration(time.Since(ctx.CreationTimestamp.Time))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%d\n", ctx.Name, string(ctx.Status.Phase), t, ctx.Status.Image,
+				len(ctx.Spec.Dependencies), age, usages[ctx.Namespace+"/"+ctx.Name])
 		}
 	}
 	w.Flush()
 
 	return nil
 }
+
+// kitUsages counts the integrations bound to each kit, keyed by the kit namespace and name.
+// Kits may be shared by integrations living in other namespaces, so all the namespaces are
+// looked up when permitted.
+func (command *kitGetCommandOptions) kitUsages(c k8sclient.Reader) (map[string]int, error) {
+	integrations := v1.NewIntegrationList()
+	err := c.List(command.Context, &integrations)
+	if err != nil && k8serrors.IsForbidden(err) {
+		err = c.List(command.Context, &integrations, k8sclient.InNamespace(command.Namespace))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]int)
+	for _, integration := range integrations.Items {
+		if integration.Status.IntegrationKit == nil {
+			continue
+		}
+		ns := integration.GetIntegrationKitNamespace(nil)
+		usages[ns+"/"+integration.Status.IntegrationKit.Name]++
+	}
+
+	return usages, nil
+}
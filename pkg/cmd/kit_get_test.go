@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestKitGetUsages(t *testing.T) {
+	used := v1.NewIntegrationKit("default", "kit-used")
+	used.Labels = map[string]string{"camel.apache.org/kit.type": v1.IntegrationKitTypePlatform}
+	used.Spec.Dependencies = []string{"camel:log", "camel:timer"}
+	used.Status.Phase = v1.IntegrationKitPhaseReady
+	used.Status.Image = "image-registry/default/camel-k-kit-used:1"
+
+	unused := v1.NewIntegrationKit("default", "kit-unused")
+	unused.Labels = map[string]string{"camel.apache.org/kit.type": v1.IntegrationKitTypePlatform}
+	unused.Status.Phase = v1.IntegrationKitPhaseReady
+
+	local := v1.NewIntegration("default", "local")
+	local.Status.IntegrationKit = &corev1.ObjectReference{Name: "kit-used"}
+
+	remote := v1.NewIntegration("other", "remote")
+	remote.Status.IntegrationKit = &corev1.ObjectReference{Namespace: "default", Name: "kit-used"}
+
+	c, err := test.NewFakeClient(&used, &unused, &local, &remote)
+	assert.Nil(t, err)
+
+	options := kitGetCommandOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "default",
+			_client:   c,
+		},
+		User:     true,
+		External: true,
+		Platform: true,
+	}
+
+	var out bytes.Buffer
+	cmd := cobra.Command{}
+	cmd.SetOut(&out)
+
+	assert.Nil(t, options.run(&cmd))
+	assert.Regexp(t, `kit-used\s+Ready\s+platform\s+image-registry/default/camel-k-kit-used:1\s+2\s+\S+\s+2\n`, out.String())
+	assert.Regexp(t, `kit-unused\s+Ready\s+platform\s+0\s+\S+\s+0\n`, out.String())
+}
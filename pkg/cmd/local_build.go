@@ -167,7 +167,7 @@ func (command *localBuildCmdOptions) run(cmd *cobra.Command, args []string) erro
 	var dependenciesList, propertyFilesList []string
 	routeFiles := args
 	if !command.BaseImage {
-		dependencies, err := getDependencies(command.Context, args, command.AdditionalDependencies, command.MavenRepositories, true)
+		dependencies, err := getDependencies(command.Context, args, command.AdditionalDependencies, command.MavenRepositories, nil, nil, true)
 		if err != nil {
 			return err
 		}
@@ -19,8 +19,13 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
 func newCmdLocalInspect(rootCmdOptions *RootCmdOptions) (*cobra.Command, *localInspectCmdOptions) {
@@ -33,21 +38,27 @@ func newCmdLocalInspect(rootCmdOptions *RootCmdOptions) (*cobra.Command, *localI
 		Short: "Generate dependencies list given integration files.",
 		Long: `Output dependencies for a list of integration files. By default this command returns the
 top level dependencies only. When --all-dependencies is enabled, the transitive dependencies
-will be generated by calling Maven and then printed in the selected output format.`,
+will be generated by calling Maven and then printed in the selected output format.
+Endpoints whose scheme is not known by the Camel catalog are reported in a warnings section
+on the standard error, and make the command fail when --strict is enabled.`,
 		PreRunE: decode(&options),
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := options.validate(args); err != nil {
 				return err
 			}
 			if err := options.init(); err != nil {
 				return err
 			}
-			if err := options.run(args); err != nil {
+			unknownSchemes, err := options.run(cmd, args)
+			if err != nil {
 				fmt.Println(err.Error())
 			}
 			if err := options.deinit(); err != nil {
 				return err
 			}
+			if options.Strict && len(unknownSchemes) > 0 {
+				return fmt.Errorf("unknown component schemes: %s", strings.Join(unknownSchemes, ", "))
+			}
 
 			return nil
 		},
@@ -60,6 +71,9 @@ will be generated by calling Maven and then printed in the selected output forma
 	cmd.Flags().StringArrayP("dependency", "d", nil, additionalDependencyUsageMessage)
 	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
 	cmd.Flags().StringArray("maven-repository", nil, "Use a maven repository")
+	cmd.Flags().Bool("platform-maven-settings", false, "Compute the transitive dependencies with the Maven settings of the integration platform, "+
+		"including its mirrors, proxies and repository credentials")
+	cmd.Flags().Bool("strict", false, "Fail if an endpoint uses a component scheme that is not known by the Camel catalog")
 
 	return &cmd, &options
 }
@@ -70,6 +84,8 @@ type localInspectCmdOptions struct {
 	OutputFormat           string   `mapstructure:"output"`
 	AdditionalDependencies []string `mapstructure:"dependencies"`
 	MavenRepositories      []string `mapstructure:"maven-repositories"`
+	PlatformMavenSettings  bool     `mapstructure:"platform-maven-settings"`
+	Strict                 bool     `mapstructure:"strict"`
 }
 
 func (command *localInspectCmdOptions) validate(args []string) error {
@@ -83,6 +99,11 @@ func (command *localInspectCmdOptions) validate(args []string) error {
 		return err
 	}
 
+	if command.PlatformMavenSettings && len(command.MavenRepositories) > 0 {
+		return errors.New("cannot use --maven-repository with --platform-maven-settings, " +
+			"the repositories are configured by the integration platform Maven settings")
+	}
+
 	return nil
 }
 
@@ -90,18 +111,72 @@ func (command *localInspectCmdOptions) init() error {
 	return createMavenWorkingDirectory()
 }
 
-func (command *localInspectCmdOptions) run(args []string) error {
-	dependencies, err := getDependencies(command.Context, args, command.AdditionalDependencies, command.MavenRepositories, command.AllDependencies)
+func (command *localInspectCmdOptions) run(cmd *cobra.Command, args []string) ([]string, error) {
+	var settings, settingsSecurity []byte
+	if command.PlatformMavenSettings && command.AllDependencies {
+		data, securityData, err := command.getPlatformMavenSettings()
+		if err != nil {
+			return nil, err
+		}
+		settings = []byte(data)
+		if securityData != "" {
+			settingsSecurity = []byte(securityData)
+		}
+	}
+
+	dependencies, err := getDependencies(command.Context, args, command.AdditionalDependencies, command.MavenRepositories, settings, settingsSecurity, command.AllDependencies)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = outputDependencies(dependencies, command.OutputFormat)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	catalog, err := createCamelCatalog(command.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	unknownSchemes, err := getUnknownSchemes(catalog, args)
+	if err != nil {
+		return nil, err
+	}
+
+	outputUnknownSchemes(cmd.ErrOrStderr(), unknownSchemes)
+
+	return unknownSchemes, nil
+}
+
+// getPlatformMavenSettings returns the Maven settings, and settings security, of the integration platform
+// in the current namespace. The settings are generated by the operator with the platform Maven configuration,
+// unless custom settings are provided.
+func (command *localInspectCmdOptions) getPlatformMavenSettings() (string, string, error) {
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return "", "", err
+	}
+	namespace := command.Namespace
+	if namespace == "" {
+		namespace, err = c.GetCurrentNamespace(command.KubeConfig)
+		if err != nil {
+			return "", "", errors.Wrap(err, "cannot get current namespace")
+		}
+	}
+	p, err := platform.GetCurrent(command.Context, c, namespace)
+	if err != nil {
+		return "", "", errors.Wrap(err, "cannot get the integration platform")
+	}
+	settings, err := kubernetes.ResolveValueSource(command.Context, c, namespace, &p.Status.Build.Maven.Settings)
+	if err != nil {
+		return "", "", err
+	}
+	settingsSecurity, err := kubernetes.ResolveValueSource(command.Context, c, namespace, &p.Status.Build.Maven.SettingsSecurity)
+	if err != nil {
+		return "", "", err
+	}
+	return settings, settingsSecurity, nil
 }
 
 func (command *localInspectCmdOptions) deinit() error {
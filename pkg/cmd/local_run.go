@@ -155,7 +155,7 @@ func (command *localRunCmdOptions) run(cmd *cobra.Command, args []string) error
 		}
 		dependencies = localBuildDependencies
 	} else {
-		computedDependencies, err := getDependencies(command.Context, args, command.AdditionalDependencies, command.MavenRepositories, true)
+		computedDependencies, err := getDependencies(command.Context, args, command.AdditionalDependencies, command.MavenRepositories, nil, nil, true)
 		if err != nil {
 			return err
 		}
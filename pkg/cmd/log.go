@@ -46,14 +46,22 @@ func newCmdLog(rootCmdOptions *RootCmdOptions) (*cobra.Command, *logCmdOptions)
 		RunE:    options.run,
 	}
 
+	cmd.Flags().Duration("since", 0, "Only print the log lines newer than a relative duration like 5s, 2m, or 3h")
+	cmd.Flags().Int64("tail", -1, "The number of lines from the end of the logs to print, all lines by default")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json")
+
 	// completion support
 	configureKnownCompletions(&cmd)
+	cmd.ValidArgsFunction = completeIntegrationNames(rootCmdOptions)
 
 	return &cmd, &options
 }
 
 type logCmdOptions struct {
 	*RootCmdOptions
+	Since        time.Duration `mapstructure:"since"`
+	Tail         int64         `mapstructure:"tail"`
+	OutputFormat string        `mapstructure:"output"`
 }
 
 func (o *logCmdOptions) validate(_ *cobra.Command, args []string) error {
@@ -64,12 +72,52 @@ func (o *logCmdOptions) validate(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+func (o *logCmdOptions) validateFlags() error {
+	if o.Since < 0 {
+		return fmt.Errorf("since must be a positive duration, found: %s", o.Since)
+	}
+	if o.OutputFormat != "" && o.OutputFormat != "json" {
+		return fmt.Errorf("invalid output format option '%s', should be: json", o.OutputFormat)
+	}
+
+	return nil
+}
+
+func (o *logCmdOptions) logOptions() k8slog.Options {
+	options := k8slog.Options{
+		JSON: o.OutputFormat == "json",
+	}
+	if o.Since > 0 {
+		seconds := int64(o.Since.Seconds())
+		if seconds == 0 {
+			seconds = 1
+		}
+		options.SinceSeconds = &seconds
+	}
+	if o.Tail >= 0 {
+		tail := o.Tail
+		options.TailLines = &tail
+	}
+
+	return options
+}
+
 func (o *logCmdOptions) run(cmd *cobra.Command, args []string) error {
+	if err := o.validateFlags(); err != nil {
+		return err
+	}
+
 	c, err := o.GetCmdClient()
 	if err != nil {
 		return err
 	}
 
+	// keep the standard output for the log lines when printing JSON, so that it can be piped
+	status := cmd.OutOrStdout()
+	if o.OutputFormat == "json" {
+		status = cmd.ErrOrStderr()
+	}
+
 	integrationId := args[0]
 
 	integration := v1.Integration{
@@ -99,7 +147,7 @@ func (o *logCmdOptions) run(cmd *cobra.Command, args []string) error {
 		// and checking if its different from the new message
 		//
 		if newLogMsg != currLogMsg {
-			fmt.Println(newLogMsg)
+			fmt.Fprintln(status, newLogMsg)
 			currLogMsg = newLogMsg
 		}
 
@@ -129,8 +177,8 @@ func (o *logCmdOptions) run(cmd *cobra.Command, args []string) error {
 			//
 			// Found the running integration so step over to scraping its pod log
 			//
-			fmt.Printf("Integration '%s' is now running. Showing log ...\n", integrationId)
-			if err := k8slog.Print(o.Context, c, &integration, cmd.OutOrStdout()); err != nil {
+			fmt.Fprintf(status, "Integration '%s' is now running. Showing log ...\n", integrationId)
+			if err := k8slog.PrintWithOptions(o.Context, c, &integration, o.logOptions(), cmd.OutOrStdout()); err != nil {
 				return false, err
 			} else {
 				return true, nil
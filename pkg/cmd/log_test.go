@@ -19,6 +19,10 @@ package cmd
 
 import (
 	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/apache/camel-k/pkg/util/test"
 )
@@ -37,3 +41,44 @@ func TestLogsAlias(t *testing.T) {
 		t.Fatalf("Expected error result for invalid alias `logs`")
 	}
 }
+
+func initializeLogCmdOptions(t *testing.T) (*logCmdOptions, *cobra.Command) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	logCmd, logOptions := newCmdLog(options)
+	logCmd.RunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	rootCmd.AddCommand(logCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return logOptions, rootCmd
+}
+
+func TestLogFlags(t *testing.T) {
+	logOptions, rootCmd := initializeLogCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, "log", "routes", "--since", "5m", "--tail", "10", "-o", "json")
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Minute, logOptions.Since)
+	assert.Equal(t, int64(10), logOptions.Tail)
+	assert.Equal(t, "json", logOptions.OutputFormat)
+	assert.Nil(t, logOptions.validateFlags())
+
+	opts := logOptions.logOptions()
+	assert.True(t, opts.JSON)
+	assert.Equal(t, int64(300), *opts.SinceSeconds)
+	assert.Equal(t, int64(10), *opts.TailLines)
+
+	logOptions.OutputFormat = "yaml"
+	assert.NotNil(t, logOptions.validateFlags())
+}
+
+func TestLogDefaultFlags(t *testing.T) {
+	logOptions, rootCmd := initializeLogCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, "log", "routes")
+	assert.Nil(t, err)
+
+	opts := logOptions.logOptions()
+	assert.False(t, opts.JSON)
+	assert.Nil(t, opts.SinceSeconds)
+	assert.Nil(t, opts.TailLines)
+}
@@ -121,6 +121,11 @@ func createKamelWithModelineCommand(ctx context.Context, args []string) (*cobra.
 		if err != nil {
 			return nil, nil, err
 		}
+		// private sources are fetched using the git secret credentials
+		ctx, err = withGitCredentialsFromFlags(ctx, fg)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	files := make([]string, 0, len(fg.Args())+len(additionalSources))
@@ -217,7 +222,12 @@ func extractModelineOptions(ctx context.Context, sources []string) ([]modeline.O
 }
 
 func extractModelineOptionsFromSource(resolvedSource Source) ([]modeline.Option, error) {
-	ops, err := modeline.Parse(resolvedSource.Location, resolvedSource.Content)
+	name := resolvedSource.Location
+	if resolvedSource.Language != "" {
+		// let the parser pick the comment syntax of the forced language
+		name += "." + string(resolvedSource.Language)
+	}
+	ops, err := modeline.Parse(name, resolvedSource.Content)
 	if err != nil {
 		return ops, errors.Wrapf(err, "cannot process file %s", resolvedSource.Location)
 	}
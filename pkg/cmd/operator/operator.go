@@ -28,6 +28,8 @@ import (
 	"strconv"
 	"time"
 
+	"go.uber.org/zap/zapcore"
+
 	coordination "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -74,16 +76,33 @@ func Run(healthPort, monitoringPort int32, leaderElection bool) {
 	// implementing the logr.Logger interface. This logger will
 	// be propagated through the whole operator, generating
 	// uniform and structured logs.
+	var logLevel zapcore.Level
+	logLevelErr := logLevel.UnmarshalText([]byte(platform.GetOperatorLogLevel()))
 	logf.SetLogger(zap.New(func(o *zap.Options) {
 		o.Development = false
+		if logLevelErr == nil {
+			o.Level = logLevel
+		}
 	}))
 
 	klog.SetLogger(log)
 
 	printVersion()
 
+	if logLevelErr != nil {
+		log.Info(fmt.Sprintf("Invalid log level %s, using the default one", platform.GetOperatorLogLevel()))
+	}
+
 	watchNamespace, err := getWatchNamespace()
 	exitOnError(err, "failed to get watch namespace")
+	if watchNamespace == "" {
+		log.Info("Watching all namespaces (global mode)")
+	} else {
+		log.Info(fmt.Sprintf("Watching namespace %s", watchNamespace))
+	}
+	if operatorID := platform.GetOperatorID(); operatorID != "" {
+		log.Info(fmt.Sprintf("Reconciling resources assigned to operator id %s", operatorID))
+	}
 
 	c, err := client.NewClient(false)
 	exitOnError(err, "cannot initialize client")
@@ -129,7 +148,7 @@ func Run(healthPort, monitoringPort int32, leaderElection bool) {
 		EventBroadcaster:              broadcaster,
 		LeaderElection:                leaderElection,
 		LeaderElectionNamespace:       operatorNamespace,
-		LeaderElectionID:              platform.OperatorLockName,
+		LeaderElectionID:              platform.GetOperatorLockName(),
 		LeaderElectionResourceLock:    resourcelock.LeasesResourceLock,
 		LeaderElectionReleaseOnCancel: true,
 		HealthProbeBindAddress:        ":" + strconv.Itoa(int(healthPort)),
@@ -145,7 +164,7 @@ func Run(healthPort, monitoringPort int32, leaderElection bool) {
 	log.Info("Installing operator resources")
 	installCtx, installCancel := context.WithTimeout(context.TODO(), 1*time.Minute)
 	defer installCancel()
-	install.OperatorStartupOptionalTools(installCtx, c, watchNamespace, operatorNamespace, log)
+	install.OperatorStartupOptionalTools(installCtx, c, watchNamespace, operatorNamespace, platform.GetOperatorID(), log)
 
 	log.Info("Starting the manager")
 	exitOnError(mgr.Start(signals.SetupSignalHandler()), "manager exited non-zero")
@@ -25,6 +25,7 @@ import (
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -42,6 +43,8 @@ func newCmdReset(rootCmdOptions *RootCmdOptions) (*cobra.Command, *resetCmdOptio
 
 	cmd.Flags().Bool("skip-kits", false, "Do not delete the integration kits")
 	cmd.Flags().Bool("skip-integrations", false, "Do not delete the integrations")
+	cmd.Flags().Bool("skip-kamelet-bindings", false, "Do not delete the kamelet bindings")
+	cmd.Flags().Bool("skip-builds", false, "Do not delete the builds")
 
 	return &cmd, &options
 }
@@ -51,6 +54,7 @@ type resetCmdOptions struct {
 	SkipKits            bool `mapstructure:"skip-kits"`
 	SkipIntegrations    bool `mapstructure:"skip-integrations"`
 	SkipKameletBindings bool `mapstructure:"skip-kamelet-bindings"`
+	SkipBuilds          bool `mapstructure:"skip-builds"`
 }
 
 func (o *resetCmdOptions) reset(_ *cobra.Command, _ []string) {
@@ -77,6 +81,14 @@ func (o *resetCmdOptions) reset(_ *cobra.Command, _ []string) {
 		fmt.Printf("%d integrations deleted from namespace %s\n", n, o.Namespace)
 	}
 
+	if !o.SkipBuilds {
+		if n, err = o.deleteAllBuilds(c); err != nil {
+			fmt.Print(err)
+			return
+		}
+		fmt.Printf("%d builds deleted from namespace %s\n", n, o.Namespace)
+	}
+
 	if !o.SkipKits {
 		if n, err = o.deleteAllIntegrationKits(c); err != nil {
 			fmt.Print(err)
@@ -121,6 +133,20 @@ func (o *resetCmdOptions) deleteAllIntegrationKits(c client.Client) (int, error)
 	return len(list.Items), nil
 }
 
+func (o *resetCmdOptions) deleteAllBuilds(c client.Client) (int, error) {
+	list := v1.NewBuildList()
+	if err := c.List(o.Context, &list, k8sclient.InNamespace(o.Namespace)); err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("could not retrieve builds from namespace %s", o.Namespace))
+	}
+	for _, i := range list.Items {
+		build := i
+		if err := c.Delete(o.Context, &build); err != nil && !k8serrors.IsNotFound(err) {
+			return 0, errors.Wrap(err, fmt.Sprintf("could not delete build %s from namespace %s", build.Name, build.Namespace))
+		}
+	}
+	return len(list.Items), nil
+}
+
 func (o *resetCmdOptions) deleteAllKameletBindings(c client.Client) (int, error) {
 	list := v1alpha1.NewKameletBindingList()
 	if err := c.List(o.Context, &list, k8sclient.InNamespace(o.Namespace)); err != nil {
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+const cmdReset = "reset"
+
+func initializeResetCmdOptions(t *testing.T) (*resetCmdOptions, *cobra.Command, RootCmdOptions) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	resetCmdOptions := addTestResetCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return resetCmdOptions, rootCmd, *options
+}
+
+func addTestResetCmd(options RootCmdOptions, rootCmd *cobra.Command) *resetCmdOptions {
+	//add a testing version of reset Command
+	resetCmd, resetOptions := newCmdReset(&options)
+	resetCmd.Run = func(c *cobra.Command, args []string) {}
+	resetCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(resetCmd)
+	return resetOptions
+}
+
+func TestResetNoFlag(t *testing.T) {
+	resetCmdOptions, rootCmd, _ := initializeResetCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdReset)
+	assert.Nil(t, err)
+	assert.False(t, resetCmdOptions.SkipIntegrations)
+	assert.False(t, resetCmdOptions.SkipKits)
+	assert.False(t, resetCmdOptions.SkipKameletBindings)
+	assert.False(t, resetCmdOptions.SkipBuilds)
+}
+
+func TestResetSkipFlags(t *testing.T) {
+	resetCmdOptions, rootCmd, _ := initializeResetCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdReset,
+		"--skip-integrations",
+		"--skip-kits",
+		"--skip-kamelet-bindings",
+		"--skip-builds")
+	assert.Nil(t, err)
+	assert.True(t, resetCmdOptions.SkipIntegrations)
+	assert.True(t, resetCmdOptions.SkipKits)
+	assert.True(t, resetCmdOptions.SkipKameletBindings)
+	assert.True(t, resetCmdOptions.SkipBuilds)
+}
@@ -95,12 +95,7 @@ func kamelPostAddCommandInit(cmd *cobra.Command) error {
 		return err
 	}
 
-	configName := os.Getenv("KAMEL_CONFIG_NAME")
-	if configName == "" {
-		configName = DefaultConfigName
-	}
-
-	viper.SetConfigName(configName)
+	viper.SetConfigName(ConfigName())
 
 	configPath := os.Getenv("KAMEL_CONFIG_PATH")
 	if configPath != "" {
@@ -149,6 +144,11 @@ func addKamelSubcommands(cmd *cobra.Command, options *RootCmdOptions) {
 	cmd.AddCommand(newCmdLocal(options))
 	cmd.AddCommand(cmdOnly(newCmdBind(options)))
 	cmd.AddCommand(newCmdKamelet(options))
+	cmd.AddCommand(cmdOnly(newCmdConfig(options)))
+	cmd.AddCommand(newCmdTrait(options))
+	cmd.AddCommand(cmdOnly(newCmdExport(options)))
+	cmd.AddCommand(cmdOnly(newCmdSource(options)))
+	cmd.AddCommand(cmdOnly(newCmdStatus(options)))
 }
 
 func addHelpSubCommands(cmd *cobra.Command, options *RootCmdOptions) error {
@@ -179,9 +179,12 @@ func (command *RootCmdOptions) preRun(cmd *cobra.Command, _ []string) error {
 			return errors.Wrap(err, "cannot get command client")
 		}
 		if command.Namespace == "" {
-			current, err := c.GetCurrentNamespace(command.KubeConfig)
-			if err != nil {
-				return errors.Wrap(err, "cannot get current namespace")
+			current := viper.GetString(DefaultNamespaceConfigKey)
+			if current == "" {
+				current, err = c.GetCurrentNamespace(command.KubeConfig)
+				if err != nil {
+					return errors.Wrap(err, "cannot get current namespace")
+				}
 			}
 			err = cmd.Flag("namespace").Value.Set(current)
 			if err != nil {
@@ -193,8 +196,8 @@ func (command *RootCmdOptions) preRun(cmd *cobra.Command, _ []string) error {
 		// which requires the operator is running and the IntegrationPlatform resource
 		// reconciled. Hence the compatibility check is skipped for the install and the operator command.
 		// Furthermore, there can be any incompatibilities, as the install command deploys
-		// the operator version it's compatible with.
-		if cmd.Use != installCommand && cmd.Use != operatorCommand {
+		// the operator version it's compatible with. The version command performs its own check.
+		if cmd.Use != installCommand && cmd.Use != operatorCommand && cmd.Use != versionCommand {
 			checkAndShowCompatibilityWarning(cmd, command.Context, c, command.Namespace)
 		}
 	}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/signal"
@@ -29,6 +30,7 @@ import (
 	"regexp"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/magiconair/properties"
 	"github.com/mitchellh/mapstructure"
@@ -51,6 +53,7 @@ import (
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	k8slog "github.com/apache/camel-k/pkg/util/kubernetes/log"
 	"github.com/apache/camel-k/pkg/util/property"
+	src "github.com/apache/camel-k/pkg/util/source"
 	"github.com/apache/camel-k/pkg/util/sync"
 	"github.com/apache/camel-k/pkg/util/watch"
 )
@@ -59,6 +62,9 @@ var (
 	traitConfigRegexp = regexp.MustCompile(`^([a-z0-9-]+)((?:\.[a-z0-9-]+)+)=(.*)$`)
 )
 
+// devModeReconnectInterval is the delay before re-establishing the watches after a connection drop in dev mode
+const devModeReconnectInterval = 5 * time.Second
+
 func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions) {
 	options := runCmdOptions{
 		RootCmdOptions: rootCmdOptions,
@@ -99,7 +105,11 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 	cmd.Flags().StringArrayP("env", "e", nil, "Set an environment variable in the integration container. E.g \"-e MY_VAR=my-value\"")
 	cmd.Flags().StringArray("property-file", nil, "[Deprecated] Bind a property file to the integration. E.g. \"--property-file integration.properties\"")
 	cmd.Flags().StringArray("label", nil, "Add a label to the integration. E.g. \"--label my.company=hello\"")
-	cmd.Flags().StringArray("source", nil, "Add source file to your integration, this is added to the list of files listed as arguments of the command")
+	cmd.Flags().StringArray("source", nil, "Add source file to your integration, this is added to the list of files listed as arguments of the command. "+
+		"The language of the source can be forced with the language parameter, e.g. --source routes.txt?language=groovy")
+	cmd.Flags().String("git-secret", "", "The secret holding the credentials used to fetch sources from private Git repositories, "+
+		"either a token in the \"token\" key or a basic-auth secret. SSH keys are not supported")
+	cmd.Flags().String("operator-id", "", "Assign the integration to the operator configured with the given id, when several operators are installed")
 	cmd.Flags().String("pod-template", "", "The path of the YAML file containing a PodSpec template to be used for the Integration pods")
 
 	cmd.Flags().Bool("save", false, "Save the run parameters into the default kamel configuration file (kamel-config.yaml)")
@@ -124,6 +134,8 @@ type runCmdOptions struct {
 	Profile         string   `mapstructure:"profile" yaml:",omitempty"`
 	OutputFormat    string   `mapstructure:"output" yaml:",omitempty"`
 	PodTemplate     string   `mapstructure:"pod-template" yaml:",omitempty"`
+	OperatorID      string   `mapstructure:"operator-id" yaml:",omitempty"`
+	GitSecret       string   `mapstructure:"git-secret" yaml:",omitempty"`
 	Connects        []string `mapstructure:"connects" yaml:",omitempty"`
 	Resources       []string `mapstructure:"resources" yaml:",omitempty"`
 	OpenAPIs        []string `mapstructure:"open-apis" yaml:",omitempty"`
@@ -206,11 +218,16 @@ func (o *runCmdOptions) decode(cmd *cobra.Command, args []string) error {
 	return o.validate()
 }
 
-func (o *runCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+func (o *runCmdOptions) validateArgs(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		return errors.New("run expects at least 1 argument, received 0")
 	}
 
+	// private sources can only be fetched once the git secret is loaded, when running the command
+	if flag := cmd.Flags().Lookup("git-secret"); flag != nil && flag.Value.String() != "" {
+		return nil
+	}
+
 	if _, err := ResolveSources(context.Background(), args, false); err != nil {
 		return errors.Wrap(err, "One of the provided sources is not reachable")
 	}
@@ -277,14 +294,8 @@ func (o *runCmdOptions) run(cmd *cobra.Command, args []string) error {
 	}
 
 	catalog := trait.NewCatalog(o.Context, c)
-	tp := catalog.ComputeTraitsProperties()
-	for _, t := range o.Traits {
-		kv := strings.SplitN(t, "=", 2)
-
-		if !util.StringSliceExists(tp, kv[0]) {
-			fmt.Printf("Error: %s is not a valid trait property\n", t)
-			return nil
-		}
+	if err := validateTraits(catalog, o.Traits); err != nil {
+		return err
 	}
 
 	integration, err := o.createOrUpdateIntegration(cmd, c, args, catalog)
@@ -318,16 +329,15 @@ func (o *runCmdOptions) run(cmd *cobra.Command, args []string) error {
 		}
 	}
 	if o.Logs || o.Dev || o.Wait {
-		// nolint: errcheck
-		go watch.HandleIntegrationEvents(o.Context, integration, func(event *corev1.Event) bool {
-			fmt.Fprintln(cmd.OutOrStdout(), event.Message)
-			return true
-		})
+		go o.handleIntegrationEvents(cmd, integration)
 	}
 	if o.Wait || o.Dev {
 		for {
 			integrationPhase, err := o.waitForIntegrationReady(cmd, integration)
 			if err != nil {
+				if o.Dev && kubernetes.IsTransientError(err) && o.waitBeforeReconnect(cmd, err) {
+					continue
+				}
 				return err
 			}
 
@@ -341,6 +351,9 @@ func (o *runCmdOptions) run(cmd *cobra.Command, args []string) error {
 			existing := v1.NewIntegration(integration.Namespace, integration.Name)
 			err = c.Get(o.Context, ctrl.ObjectKeyFromObject(&existing), &existing)
 			if err != nil {
+				if o.Dev && kubernetes.IsTransientError(err) && o.waitBeforeReconnect(cmd, err) {
+					continue
+				}
 				return err
 			}
 
@@ -403,10 +416,56 @@ func (o *runCmdOptions) waitForIntegrationReady(cmd *cobra.Command, integration
 	return watch.HandleIntegrationStateChanges(o.Context, integration, handler)
 }
 
+// handleIntegrationEvents prints the integration events, re-establishing the watch
+// in dev mode when the connection with the API server drops
+func (o *runCmdOptions) handleIntegrationEvents(cmd *cobra.Command, integration *v1.Integration) {
+	handler := newIntegrationEventsPrinter(cmd.OutOrStdout())
+	for {
+		err := watch.HandleIntegrationEvents(o.Context, integration, handler)
+		if !o.Dev || o.Context.Err() != nil {
+			return
+		}
+		if !o.waitBeforeReconnect(cmd, err) {
+			return
+		}
+	}
+}
+
+// newIntegrationEventsPrinter returns an handler printing the integration events, that skips the events
+// already printed, as they are replayed when the watch is re-established
+func newIntegrationEventsPrinter(out io.Writer) func(event *corev1.Event) bool {
+	printed := make(map[string]bool)
+	return func(event *corev1.Event) bool {
+		// The resource version changes when the event is updated, e.g. when its count is incremented
+		key := string(event.UID) + "/" + event.ResourceVersion
+		if printed[key] {
+			return true
+		}
+		printed[key] = true
+		fmt.Fprintln(out, event.Message)
+		return true
+	}
+}
+
+// waitBeforeReconnect waits before retrying an operation interrupted by a connection drop,
+// and returns false when the command is terminating
+func (o *runCmdOptions) waitBeforeReconnect(cmd *cobra.Command, err error) bool {
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Connection lost, reconnecting in %s: %v\n", devModeReconnectInterval, err)
+	}
+	select {
+	case <-o.Context.Done():
+		return false
+	case <-time.After(devModeReconnectInterval):
+		return true
+	}
+}
+
 func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sources []string, catalog *trait.Catalog) error {
 	// Let's watch all relevant files when in dev mode
 	var files []string
 	files = append(files, sources...)
+	files = append(files, o.Sources...)
 	files = append(files, filterFileLocation(o.Resources)...)
 	files = append(files, filterFileLocation(o.Configs)...)
 	files = append(files, filterFileLocation(o.Properties)...)
@@ -414,7 +473,12 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 	files = append(files, o.PropertyFiles...)
 	files = append(files, o.OpenAPIs...)
 
-	for _, s := range files {
+	for _, f := range files {
+		// strip the language override, if any, to watch the actual file
+		s, _, err := parseSourceLanguage(f)
+		if err != nil {
+			return err
+		}
 		ok, err := isLocalAndFileExists(s)
 		if err != nil {
 			return err
@@ -430,28 +494,23 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 					case <-o.Context.Done():
 						return
 					case <-changes:
-						// let's create a new command to parse modeline changes and update our integration
-						newCmd, _, err := createKamelWithModelineCommand(o.RootContext, os.Args[1:])
-						newCmd.SetOut(cmd.OutOrStdout())
-						newCmd.SetErr(cmd.ErrOrStderr())
-						if err != nil {
-							fmt.Println("Unable to sync integration: ", err.Error())
-							continue
-						}
-						newCmd.Args = o.validateArgs
-						newCmd.PreRunE = o.decode
-						newCmd.RunE = func(cmd *cobra.Command, args []string) error {
-							_, err := o.createOrUpdateIntegration(cmd, c, sources, catalog)
-							return err
-						}
-						newCmd.PostRunE = nil
-
-						// cancel the existing command to release watchers
-						o.ContextCancel()
-						// run the new one
-						err = newCmd.Execute()
-						if err != nil {
-							fmt.Println("Unable to sync integration: ", err.Error())
+						// run the new command, retrying while the API server is not reachable
+						// so that the changes made while disconnected are not lost
+						for {
+							err := o.resyncIntegration(cmd, c, sources, catalog)
+							if err == nil {
+								break
+							}
+							if !o.Dev || !kubernetes.IsTransientError(err) {
+								fmt.Println("Unable to sync integration: ", err.Error())
+								break
+							}
+							fmt.Fprintf(cmd.ErrOrStderr(), "Unable to sync integration, retrying in %s: %v\n", devModeReconnectInterval, err)
+							select {
+							case <-o.RootContext.Done():
+								return
+							case <-time.After(devModeReconnectInterval):
+							}
 						}
 					}
 				}
@@ -464,6 +523,28 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 	return nil
 }
 
+// resyncIntegration runs a new command that updates the integration, parsing modeline changes
+func (o *runCmdOptions) resyncIntegration(cmd *cobra.Command, c client.Client, sources []string, catalog *trait.Catalog) error {
+	newCmd, _, err := createKamelWithModelineCommand(o.RootContext, os.Args[1:])
+	if err != nil {
+		return err
+	}
+	newCmd.SetOut(cmd.OutOrStdout())
+	newCmd.SetErr(cmd.ErrOrStderr())
+	newCmd.Args = o.validateArgs
+	newCmd.PreRunE = o.decode
+	newCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		_, err := o.createOrUpdateIntegration(cmd, c, sources, catalog)
+		return err
+	}
+	newCmd.PostRunE = nil
+
+	// cancel the existing command to release watchers
+	o.ContextCancel()
+	// run the new one
+	return newCmd.Execute()
+}
+
 // nolint: gocyclo
 func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.Client, sources []string, catalog *trait.Catalog) (*v1.Integration, error) {
 	namespace := o.Namespace
@@ -510,6 +591,10 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		Profile:        v1.TraitProfileByName(o.Profile),
 	}
 
+	if o.OperatorID != "" {
+		v1.SetOperatorIDAnnotation(integration, o.OperatorID)
+	}
+
 	for _, label := range o.Labels {
 		parts := strings.Split(label, "=")
 		if len(parts) == 2 {
@@ -524,13 +609,38 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 	srcs = append(srcs, sources...)
 	srcs = append(srcs, o.Sources...)
 
-	resolvedSources, err := ResolveSources(context.Background(), srcs, o.Compression)
+	ctx := context.Background()
+	if o.GitSecret != "" {
+		credentials, err := src.LoadGitCredentials(o.Context, c, namespace, o.GitSecret)
+		if err != nil {
+			return nil, err
+		}
+		ctx = withGitCredentials(ctx, credentials)
+	}
+
+	resolvedSources, err := ResolveSources(ctx, srcs, o.Compression)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := compressLargeSources(cmd.ErrOrStderr(), resolvedSources); err != nil {
+		return nil, err
+	}
+
 	for _, source := range resolvedSources {
-		if o.UseFlows && !o.Compression && (strings.HasSuffix(source.Name, ".yaml") || strings.HasSuffix(source.Name, ".yml")) {
+		isYaml := source.Language == v1.LanguageYaml ||
+			(source.Language == "" && (strings.HasSuffix(source.Name, ".yaml") || strings.HasSuffix(source.Name, ".yml")))
+		if o.GitSecret != "" && !source.Local && source.Location != "" {
+			// Private remote sources are fetched by the operator, with the credentials of the git secret
+			integration.Spec.AddSources(v1.SourceSpec{
+				DataSpec: v1.DataSpec{
+					Name: source.Name,
+				},
+				Language:  source.Language,
+				Location:  source.Location,
+				GitSecret: o.GitSecret,
+			})
+		} else if o.UseFlows && !source.Compress && isYaml {
 			flows, err := dsl.FromYamlDSLString(source.Content)
 			if err != nil {
 				return nil, err
@@ -543,6 +653,7 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 					Content:     source.Content,
 					Compression: source.Compress,
 				},
+				Language: source.Language,
 			})
 		}
 	}
@@ -786,6 +897,42 @@ func resolvePodTemplate(ctx context.Context, templateSrc string, spec *v1.Integr
 	return err
 }
 
+// validateTraits checks the trait options against the trait catalog, suggesting
+// the closest known traits or properties in case of mismatch
+func validateTraits(catalog *trait.Catalog, options []string) error {
+	tp := catalog.ComputeTraitsProperties()
+	traitIDs := make([]string, 0)
+	for _, p := range tp {
+		util.StringSliceUniqueAdd(&traitIDs, strings.SplitN(p, ".", 2)[0])
+	}
+
+	for _, option := range options {
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("unrecognized trait format (expected \"<trait>.<prop>=<value>\"): %s", option)
+		}
+		if util.StringSliceExists(tp, kv[0]) {
+			continue
+		}
+
+		parts := strings.SplitN(kv[0], ".", 2)
+		if !util.StringSliceExists(traitIDs, parts[0]) {
+			return fmt.Errorf("%s is not a valid trait%s", parts[0], didYouMean(parts[0], traitIDs))
+		}
+
+		properties := make([]string, 0)
+		for _, p := range tp {
+			if strings.HasPrefix(p, parts[0]+".") {
+				properties = append(properties, p)
+			}
+		}
+
+		return fmt.Errorf("%s is not a valid trait property%s", kv[0], didYouMean(kv[0], properties))
+	}
+
+	return nil
+}
+
 func configureTraits(options []string, catalog *trait.Catalog) (map[string]v1.TraitSpec, error) {
 	traits := make(map[string]map[string]interface{})
 
@@ -21,119 +21,35 @@ import (
 	"context"
 	"fmt"
 	"path"
-	"regexp"
 	"strings"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/resource"
 )
 
-var invalidPaths = []string{"/etc/camel", "/deployments/dependencies"}
-
 // RunConfigOption represents a config option
-type RunConfigOption struct {
-	configType      configOptionType
-	resourceName    string
-	resourceKey     string
-	destinationPath string
-}
-
-// DestinationPath is the location where the resource will be stored on destination
-func (runConfigOption *RunConfigOption) DestinationPath() string {
-	return runConfigOption.destinationPath
-}
-
-// Type is the type, converted as string
-func (runConfigOption *RunConfigOption) Type() string {
-	return string(runConfigOption.configType)
-}
-
-// Name is the name of the resource
-func (runConfigOption *RunConfigOption) Name() string {
-	return runConfigOption.resourceName
-}
-
-// Key is the key specified for the resource
-func (runConfigOption *RunConfigOption) Key() string {
-	return runConfigOption.resourceKey
-}
-
-// Validate checks if the DestinationPath is correctly configured
-func (runConfigOption *RunConfigOption) Validate() error {
-	if runConfigOption.destinationPath == "" {
-		return nil
-	}
-
-	// Check for invalid path
-	for _, invalidPath := range invalidPaths {
-		if runConfigOption.destinationPath == invalidPath || strings.HasPrefix(runConfigOption.destinationPath, invalidPath+"/") {
-			return fmt.Errorf("you cannot mount a file under %s path", invalidPath)
-		}
-	}
-	return nil
-}
-
-type configOptionType string
+type RunConfigOption = resource.Config
 
 const (
 	// ConfigOptionTypeConfigmap --
-	ConfigOptionTypeConfigmap configOptionType = "configmap"
+	ConfigOptionTypeConfigmap = resource.StorageTypeConfigmap
 	// ConfigOptionTypeSecret --
-	ConfigOptionTypeSecret configOptionType = "secret"
+	ConfigOptionTypeSecret = resource.StorageTypeSecret
 	// ConfigOptionTypeFile --
-	ConfigOptionTypeFile configOptionType = "file"
+	ConfigOptionTypeFile = resource.StorageTypeFile
 )
 
-var validConfigSecretRegexp = regexp.MustCompile(`^(configmap|secret)\:([\w\.\-\_\:\/@]+)$`)
-var validFileRegexp = regexp.MustCompile(`^file\:([\w\.\-\_\:\/@" ]+)$`)
-var validResourceRegexp = regexp.MustCompile(`^([\w\.\-\_\:]+)(\/([\w\.\-\_\:]+))?(\@([\w\.\-\_\:\/]+))?$`)
-
-func newRunConfigOption(configType configOptionType, value string) *RunConfigOption {
-	rn, mk, mp := parseResourceValue(configType, value)
-	return &RunConfigOption{
-		configType:      configType,
-		resourceName:    rn,
-		resourceKey:     mk,
-		destinationPath: mp,
-	}
-}
-
-func parseResourceValue(configType configOptionType, value string) (resource string, maybeKey string, maybeDestinationPath string) {
-	if configType == ConfigOptionTypeFile {
-		resource, maybeDestinationPath = parseFileValue(value)
-		return resource, "", maybeDestinationPath
-	} else {
-		return parseCMOrSecretValue(value)
-	}
-}
-
-func parseFileValue(value string) (localPath string, maybeDestinationPath string) {
-	split := strings.SplitN(value, "@", 2)
-	if len(split) == 2 {
-		return split[0], split[1]
-	}
-	return value, ""
-}
-
-func parseCMOrSecretValue(value string) (resource string, maybeKey string, maybeDestinationPath string) {
-	if !validResourceRegexp.MatchString(value) {
-		return value, "", ""
-	}
-	// Must have 3 values
-	groups := validResourceRegexp.FindStringSubmatch(value)
-	return groups[1], groups[3], groups[5]
-}
-
 // ParseResourceOption will parse and return a runConfigOption
 func ParseResourceOption(item string) (*RunConfigOption, error) {
 	// Deprecated: ensure backward compatibility with `--resource filename` format until version 1.5.x
-	// then replace with parseOption() func directly
-	option, err := parseOption(item)
+	// then replace with resource.ParseConfig() func directly
+	option, err := resource.ParseConfig(item)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "could not match config, secret or file configuration") {
 			fmt.Printf("Warn: --resource %s has been deprecated. You should use --resource file:%s instead.\n", item, item)
-			return parseOption("file:" + item)
+			return resource.ParseConfig("file:" + item)
 		}
 		return nil, err
 	}
@@ -142,41 +58,12 @@ func ParseResourceOption(item string) (*RunConfigOption, error) {
 
 // ParseConfigOption will parse and return a runConfigOption
 func ParseConfigOption(item string) (*RunConfigOption, error) {
-	return parseOption(item)
-}
-
-func parseOption(item string) (*RunConfigOption, error) {
-	var cot configOptionType
-	var value string
-	if validConfigSecretRegexp.MatchString(item) {
-		// parse as secret/configmap
-		groups := validConfigSecretRegexp.FindStringSubmatch(item)
-		switch groups[1] {
-		case "configmap":
-			cot = ConfigOptionTypeConfigmap
-		case "secret":
-			cot = ConfigOptionTypeSecret
-		}
-		value = groups[2]
-	} else if validFileRegexp.MatchString(item) {
-		//parse as file
-		groups := validFileRegexp.FindStringSubmatch(item)
-		cot = ConfigOptionTypeFile
-		value = groups[1]
-	} else {
-		return nil, fmt.Errorf("could not match config, secret or file configuration as %s", item)
-	}
-
-	configurationOption := newRunConfigOption(cot, value)
-	if err := configurationOption.Validate(); err != nil {
-		return nil, err
-	}
-	return configurationOption, nil
+	return resource.ParseConfig(item)
 }
 
 func applyOption(config *RunConfigOption, integrationSpec *v1.IntegrationSpec,
 	c client.Client, namespace string, enableCompression bool, resourceType v1.ResourceType) error {
-	switch config.configType {
+	switch config.StorageType() {
 	case ConfigOptionTypeConfigmap:
 		cm := kubernetes.LookupConfigmap(context.Background(), c, namespace, config.Name())
 		if cm == nil {
@@ -192,7 +79,7 @@ func applyOption(config *RunConfigOption, integrationSpec *v1.IntegrationSpec,
 			fmt.Printf("Warn: %s Secret not found in %s namespace, make sure to provide it before the Integration can run\n",
 				config.Name(), namespace)
 		}
-		integrationSpec.AddConfigurationAsResource(string(config.configType), config.Name(), string(resourceType), config.DestinationPath(), config.Key())
+		integrationSpec.AddConfigurationAsResource(config.Type(), config.Name(), string(resourceType), config.DestinationPath(), config.Key())
 	case ConfigOptionTypeFile:
 		// Don't allow a file size longer than 1 MiB
 		fileSize, err := fileSize(config.Name())
@@ -217,7 +104,7 @@ func applyOption(config *RunConfigOption, integrationSpec *v1.IntegrationSpec,
 		integrationSpec.AddResources(resourceSpec)
 	default:
 		// Should never reach this
-		return fmt.Errorf("invalid option type %s", config.configType)
+		return fmt.Errorf("invalid option type %s", config.Type())
 	}
 
 	return nil
@@ -271,7 +158,7 @@ func filterFileLocation(maybeFileLocations []string) []string {
 	filteredOptions := make([]string, 0)
 	for _, option := range maybeFileLocations {
 		if strings.HasPrefix(option, "file:") {
-			localPath, _ := parseFileValue(strings.Replace(option, "file:", "", 1))
+			localPath, _ := resource.ParseFileValue(strings.Replace(option, "file:", "", 1))
 			filteredOptions = append(filteredOptions, localPath)
 		}
 	}
@@ -32,21 +32,21 @@ func TestParseConfigOption(t *testing.T) {
 
 	configmap, err := ParseConfigOption(validConfigMap)
 	assert.Nil(t, err)
-	assert.Equal(t, ConfigOptionTypeConfigmap, configmap.configType)
+	assert.Equal(t, ConfigOptionTypeConfigmap, configmap.StorageType())
 	assert.Equal(t, "my-config_map", configmap.Name())
 	secret, err := ParseConfigOption(validSecret)
 	assert.Nil(t, err)
-	assert.Equal(t, ConfigOptionTypeSecret, secret.configType)
+	assert.Equal(t, ConfigOptionTypeSecret, secret.StorageType())
 	assert.Equal(t, "my-secret", secret.Name())
 	file, err := ParseConfigOption(validFile)
 	assert.Nil(t, err)
-	assert.Equal(t, ConfigOptionTypeFile, file.configType)
+	assert.Equal(t, ConfigOptionTypeFile, file.StorageType())
 	assert.Equal(t, "/tmp/my-file.txt", file.Name())
 	_, err = ParseConfigOption(notValid)
 	assert.NotNil(t, err)
 	location, err := ParseConfigOption(validLocation)
 	assert.Nil(t, err)
-	assert.Equal(t, ConfigOptionTypeFile, location.configType)
+	assert.Equal(t, ConfigOptionTypeFile, location.StorageType())
 	assert.Equal(t, "my-file.txt", location.Name())
 	assert.Equal(t, "/tmp/another-name.xml", location.DestinationPath())
 }
@@ -18,7 +18,9 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -28,6 +30,8 @@ import (
 	"github.com/apache/camel-k/pkg/util/test"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const cmdRun = "run"
@@ -154,6 +158,20 @@ func TestRunLabelWrongFormatFlag(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRunGitSecretFlag(t *testing.T) {
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdRun, "--git-secret", "my-secret", integrationSource)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-secret", runCmdOptions.GitSecret)
+}
+
+func TestRunOperatorIDFlag(t *testing.T) {
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdRun, "--operator-id", "my-operator", integrationSource)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-operator", runCmdOptions.OperatorID)
+}
+
 func TestRunLogsFlag(t *testing.T) {
 	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdRun, "--logs", integrationSource)
@@ -379,6 +397,26 @@ func TestConfigureTraits(t *testing.T) {
 	assertTraitConfiguration(t, traits, "prometheus", `{"podMonitor":false}`)
 }
 
+func TestValidateTraits(t *testing.T) {
+	catalog := trait.NewCatalog(context.TODO(), nil)
+
+	assert.Nil(t, validateTraits(catalog, []string{"affinity.pod-affinity=false", "knative-service.min-scale=1"}))
+
+	err := validateTraits(catalog, []string{"knative-servce.min-scale=1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "knative-servce is not a valid trait, did you mean knative-service?", err.Error())
+
+	err = validateTraits(catalog, []string{"knative-service.min-scal=1"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "knative-service.min-scal is not a valid trait property, did you mean knative-service.min-scale")
+
+	err = validateTraits(catalog, []string{"knative-service.foo=1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "knative-service.foo is not a valid trait property", err.Error())
+
+	assert.NotNil(t, validateTraits(catalog, []string{"affinity.pod-affinity"}))
+}
+
 func assertTraitConfiguration(t *testing.T, traits map[string]v1.TraitSpec, trait string, expected string) {
 	assert.Contains(t, traits, trait)
 	assert.Equal(t, expected, string(traits[trait].Configuration.RawMessage))
@@ -607,3 +645,35 @@ func TestFilterBuildPropertyFiles(t *testing.T) {
 	assert.Equal(t, len(outputValues), 1)
 	assert.Equal(t, outputValues[0], "/tmp/test")
 }
+
+func TestRunDevModeWaitBeforeReconnect(t *testing.T) {
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runCmdOptions.Context = ctx
+	cancel()
+
+	assert.False(t, runCmdOptions.waitBeforeReconnect(rootCmd, errors.New("connection refused")))
+}
+
+func TestIntegrationEventsPrinter(t *testing.T) {
+	out := new(bytes.Buffer)
+	handler := newIntegrationEventsPrinter(out)
+	event := func(uid string, resourceVersion string, message string) *corev1.Event {
+		e := &corev1.Event{Message: message}
+		e.UID = types.UID(uid)
+		e.ResourceVersion = resourceVersion
+		return e
+	}
+
+	assert.True(t, handler(event("1", "10", "Integration created")))
+	assert.True(t, handler(event("2", "11", "Integration building")))
+	// The events are replayed when the watch is re-established
+	assert.True(t, handler(event("1", "10", "Integration created")))
+	assert.True(t, handler(event("2", "11", "Integration building")))
+	// The event is updated with the same timestamp
+	assert.True(t, handler(event("2", "12", "Integration building")))
+	assert.True(t, handler(event("3", "13", "Integration running")))
+
+	assert.Equal(t, "Integration created\nIntegration building\nIntegration building\nIntegration running\n", out.String())
+}
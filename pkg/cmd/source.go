@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/dsl"
+	"github.com/apache/camel-k/pkg/util/gzip"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+const flowsSourceName = "flows.yaml"
+
+func newCmdSource(rootCmdOptions *RootCmdOptions) (*cobra.Command, *sourceCmdOptions) {
+	options := sourceCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "source <integration>",
+		Short: "Print the sources of an integration",
+		Long: `Print the sources stored in an integration, including the ones generated by the operator,
+or save them into a local directory.`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().String("dir", "", "Save the sources into the given directory instead of printing them")
+	cmd.Flags().Bool("skip-generated", false, "Do not include the sources generated by the operator")
+
+	// completion support
+	cmd.ValidArgsFunction = completeIntegrationNames(rootCmdOptions)
+
+	return &cmd, &options
+}
+
+type sourceCmdOptions struct {
+	*RootCmdOptions
+	Directory     string `mapstructure:"dir"`
+	SkipGenerated bool   `mapstructure:"skip-generated"`
+}
+
+func (o *sourceCmdOptions) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("source expects an integration name argument")
+	}
+	return nil
+}
+
+func (o *sourceCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integration := v1.NewIntegration(o.Namespace, args[0])
+	key := k8sclient.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      args[0],
+	}
+	if err := c.Get(o.Context, key, &integration); err != nil {
+		return errors.Wrapf(err, "cannot get integration %s", args[0])
+	}
+
+	sources := integration.Spec.Sources
+	if !o.SkipGenerated {
+		sources = integration.Sources()
+	}
+
+	// flows are turned into a generated source during the initialization of the integration
+	if len(integration.Spec.Flows) > 0 && (o.SkipGenerated || len(integration.Status.GeneratedSources) == 0) {
+		content, err := dsl.ToYamlDSL(integration.Spec.Flows)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, v1.SourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    flowsSourceName,
+				Content: string(content),
+			},
+		})
+	}
+
+	for _, source := range sources {
+		content, err := sourceContent(o.Context, c, integration.Namespace, source)
+		if err != nil {
+			return err
+		}
+
+		if o.Directory != "" {
+			name, err := sourceFileName(source.Name)
+			if err != nil {
+				return err
+			}
+			if err := util.WriteFileWithContent(o.Directory, name, content); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Source %s saved to %s\n", source.Name, filepath.Join(o.Directory, name))
+			continue
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "# %s\n%s\n", source.Name, string(content))
+	}
+
+	return nil
+}
+
+// sourceFileName returns the name of the file the source is saved into, rejecting the names
+// that would resolve outside of the target directory, e.g. `../../routes.groovy`
+func sourceFileName(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid source name %q: it must be a file name", name)
+	}
+	return name, nil
+}
+
+// sourceContent returns the uncompressed content of the source, resolving it from the
+// referenced ConfigMap when needed
+func sourceContent(ctx context.Context, c k8sclient.Reader, namespace string, source v1.SourceSpec) ([]byte, error) {
+	content := source.Content
+
+	if source.ContentRef != "" {
+		cm, err := kubernetes.GetConfigMap(ctx, c, source.ContentRef, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		contentKey := source.ContentKey
+		if contentKey == "" {
+			contentKey = "content"
+		}
+		content = cm.Data[contentKey]
+	}
+
+	if source.Compression {
+		return gzip.UncompressBase64([]byte(content))
+	}
+
+	return []byte(content), nil
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/gzip"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestSourceContent(t *testing.T) {
+	compressed, err := gzip.CompressBase64([]byte("from('timer:compressed')"))
+	assert.Nil(t, err)
+
+	integration := v1.NewIntegration("default", "my-it")
+	integration.Spec.Sources = []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "plain.groovy", Content: "from('timer:plain')"}},
+		{DataSpec: v1.DataSpec{Name: "compressed.groovy", Content: string(compressed), Compression: true}},
+	}
+	integration.Status.GeneratedSources = []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "openapi.xml", ContentRef: "my-it-openapi-000", ContentKey: "openapi.xml"}},
+	}
+
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-it-openapi-000",
+		},
+		Data: map[string]string{
+			"openapi.xml": "<rests/>",
+		},
+	}
+
+	c, err := test.NewFakeClient(&integration, &cm)
+	assert.Nil(t, err)
+
+	options := sourceCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "default",
+			_client:   c,
+		},
+	}
+
+	var out bytes.Buffer
+	cmd := cobra.Command{}
+	cmd.SetOut(&out)
+
+	assert.Nil(t, options.run(&cmd, []string{"my-it"}))
+	assert.Contains(t, out.String(), "# plain.groovy\nfrom('timer:plain')")
+	assert.Contains(t, out.String(), "# compressed.groovy\nfrom('timer:compressed')")
+	assert.Contains(t, out.String(), "# openapi.xml\n<rests/>")
+
+	out.Reset()
+	options.SkipGenerated = true
+	assert.Nil(t, options.run(&cmd, []string{"my-it"}))
+	assert.NotContains(t, out.String(), "openapi.xml")
+
+	assert.NotNil(t, options.run(&cmd, []string{"missing"}))
+}
+
+func TestSourceFileName(t *testing.T) {
+	name, err := sourceFileName("routes.groovy")
+	assert.Nil(t, err)
+	assert.Equal(t, "routes.groovy", name)
+
+	for _, invalid := range []string{"", ".", "..", "../../routes.groovy", "/etc/routes.groovy", "dir/routes.groovy", `..\\routes.groovy`} {
+		_, err := sourceFileName(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
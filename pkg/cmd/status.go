@@ -0,0 +1,197 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+func newCmdStatus(rootCmdOptions *RootCmdOptions) (*cobra.Command, *statusCmdOptions) {
+	options := statusCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "status",
+		Short: "Summarize the status of Camel K resources",
+		Long: `Summarize the status of the integration platforms, the integrations and the pending builds,
+along with the failing conditions, in a single table.`,
+		PreRunE: decode(&options),
+		RunE:    options.run,
+	}
+
+	cmd.Flags().BoolP("all-namespaces", "A", false, "Summarize the resources of all namespaces")
+
+	return &cmd, &options
+}
+
+type statusCmdOptions struct {
+	*RootCmdOptions
+	AllNamespaces bool `mapstructure:"all-namespaces"`
+}
+
+type statusEntry struct {
+	namespace string
+	kind      string
+	name      string
+	phase     string
+	message   string
+}
+
+func (o *statusCmdOptions) run(cmd *cobra.Command, _ []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	options := make([]k8sclient.ListOption, 0)
+	if !o.AllNamespaces {
+		options = append(options, k8sclient.InNamespace(o.Namespace))
+	}
+
+	entries := make([]statusEntry, 0)
+	integrationPhases := make(map[string]int)
+
+	platforms := v1.NewIntegrationPlatformList()
+	if err := c.List(o.Context, &platforms, options...); err != nil {
+		return err
+	}
+	for _, p := range platforms.Items {
+		p := p
+		entries = append(entries, statusEntry{
+			namespace: p.Namespace,
+			kind:      v1.IntegrationPlatformKind,
+			name:      p.Name,
+			phase:     string(p.Status.Phase),
+			message:   failingConditions(p.Status.GetConditions()),
+		})
+	}
+
+	integrations := v1.NewIntegrationList()
+	if err := c.List(o.Context, &integrations, options...); err != nil {
+		return err
+	}
+	for _, it := range integrations.Items {
+		it := it
+		entries = append(entries, statusEntry{
+			namespace: it.Namespace,
+			kind:      v1.IntegrationKind,
+			name:      it.Name,
+			phase:     string(it.Status.Phase),
+			message:   failingConditions(it.Status.GetConditions(), string(v1.IntegrationConditionReady)),
+		})
+		integrationPhases[string(it.Status.Phase)]++
+	}
+
+	builds := v1.NewBuildList()
+	if err := c.List(o.Context, &builds, options...); err != nil {
+		return err
+	}
+	for _, b := range builds.Items {
+		if !isPendingBuild(b) {
+			continue
+		}
+		entries = append(entries, statusEntry{
+			namespace: b.Namespace,
+			kind:      v1.BuildKind,
+			name:      b.Name,
+			phase:     string(b.Status.Phase),
+		})
+	}
+
+	return printStatus(cmd.OutOrStdout(), entries, integrationPhases, o.AllNamespaces)
+}
+
+func isPendingBuild(build v1.Build) bool {
+	switch build.Status.Phase {
+	case v1.BuildPhaseSucceeded, v1.BuildPhaseFailed, v1.BuildPhaseError, v1.BuildPhaseInterrupted:
+		return false
+	default:
+		return true
+	}
+}
+
+// failingConditions reports the conditions that are not satisfied, restricted to the given
+// condition types if any
+func failingConditions(conditions []v1.ResourceCondition, types ...string) string {
+	messages := make([]string, 0)
+	for _, condition := range conditions {
+		if condition.GetStatus() != corev1.ConditionFalse {
+			continue
+		}
+		if len(types) > 0 && !util.StringSliceExists(types, condition.GetType()) {
+			continue
+		}
+		message := condition.GetReason()
+		if condition.GetMessage() != "" {
+			message = fmt.Sprintf("%s: %s", message, condition.GetMessage())
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func printStatus(out io.Writer, entries []statusEntry, integrationPhases map[string]int, allNamespaces bool) error {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	if allNamespaces {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "KIND\tNAME\tPHASE\tMESSAGE")
+	for _, e := range entries {
+		if allNamespaces {
+			fmt.Fprintf(w, "%s\t", e.namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.kind, e.name, e.phase, e.message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(integrationPhases) > 0 {
+		phases := make([]string, 0, len(integrationPhases))
+		for phase := range integrationPhases {
+			phases = append(phases, phase)
+		}
+		sort.Strings(phases)
+
+		summary := make([]string, 0, len(phases))
+		for _, phase := range phases {
+			name := phase
+			if name == "" {
+				name = "Unknown"
+			}
+			summary = append(summary, fmt.Sprintf("%d %s", integrationPhases[phase], name))
+		}
+		fmt.Fprintf(out, "\nIntegrations: %s\n", strings.Join(summary, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestStatus(t *testing.T) {
+	platform := v1.NewIntegrationPlatform("default", "camel-k")
+	platform.Status.Phase = v1.IntegrationPlatformPhaseReady
+
+	running := v1.NewIntegration("default", "running")
+	running.Status.Phase = v1.IntegrationPhaseRunning
+	running.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionTrue, "DeploymentReady", "1/1 ready replicas")
+
+	failing := v1.NewIntegration("default", "failing")
+	failing.Status.Phase = v1.IntegrationPhaseError
+	failing.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionFalse, "Error", "back-off restarting failed container")
+
+	other := v1.NewIntegration("other", "elsewhere")
+	other.Status.Phase = v1.IntegrationPhaseRunning
+
+	pending := v1.NewBuild("default", "kit-pending")
+	pending.Status.Phase = v1.BuildPhaseRunning
+
+	done := v1.NewBuild("default", "kit-done")
+	done.Status.Phase = v1.BuildPhaseSucceeded
+
+	c, err := test.NewFakeClient(&platform, &running, &failing, &other, &pending, &done)
+	assert.Nil(t, err)
+
+	options := statusCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "default",
+			_client:   c,
+		},
+	}
+
+	var out bytes.Buffer
+	cmd := cobra.Command{}
+	cmd.SetOut(&out)
+
+	assert.Nil(t, options.run(&cmd, nil))
+	assert.Regexp(t, `IntegrationPlatform\s+camel-k\s+Ready`, out.String())
+	assert.Regexp(t, `Integration\s+running\s+Running\s*\n`, out.String())
+	assert.Regexp(t, `Integration\s+failing\s+Error\s+Error: back-off restarting failed container`, out.String())
+	assert.Regexp(t, `Build\s+kit-pending\s+Running`, out.String())
+	assert.NotContains(t, out.String(), "kit-done")
+	assert.NotContains(t, out.String(), "elsewhere")
+	assert.Contains(t, out.String(), "Integrations: 1 Error, 1 Running")
+
+	out.Reset()
+	options.AllNamespaces = true
+	assert.Nil(t, options.run(&cmd, nil))
+	assert.Regexp(t, `other\s+Integration\s+elsewhere\s+Running`, out.String())
+	assert.Contains(t, out.String(), "Integrations: 1 Error, 2 Running")
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newTraitDescribeCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *traitDescribeCommandOptions) {
+	options := traitDescribeCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:     "describe <trait>",
+		Short:   "Describe a trait",
+		Long:    `Describe a trait, with the profiles it applies to, its properties, their types and default values.`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			descriptions, err := loadTraitDescriptions(options.Context, "")
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			names := make([]string, 0, len(descriptions))
+			for _, td := range descriptions {
+				names = append(names, string(td.Name))
+			}
+			return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of json, yaml")
+
+	return &cmd, &options
+}
+
+type traitDescribeCommandOptions struct {
+	*RootCmdOptions
+	OutputFormat string `mapstructure:"output"`
+}
+
+func (command *traitDescribeCommandOptions) validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("describe expects exactly one trait name, received %d", len(args))
+	}
+	return nil
+}
+
+func (command *traitDescribeCommandOptions) run(cmd *cobra.Command, args []string) error {
+	traitDescriptions, err := loadTraitDescriptions(command.Context, strings.TrimSpace(args[0]))
+	if err != nil {
+		return err
+	}
+
+	return printTraitDescriptions(cmd, traitDescriptions, command.OutputFormat)
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -98,19 +99,35 @@ func (command *traitHelpCommandOptions) validate(args []string) error {
 }
 
 func (command *traitHelpCommandOptions) run(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	traitDescriptions, err := loadTraitDescriptions(command.Context, name)
+	if err != nil {
+		return err
+	}
+
+	return printTraitDescriptions(cmd, traitDescriptions, command.OutputFormat)
+}
+
+// loadTraitDescriptions computes the description of the trait with the given name, or of all the traits
+// when the name is empty, from the trait catalog and the traits metadata
+func loadTraitDescriptions(ctx context.Context, name string) ([]*traitDescription, error) {
 	var traitDescriptions []*traitDescription
-	var catalog = trait.NewCatalog(command.Context, nil)
+	var catalog = trait.NewCatalog(ctx, nil)
 
 	var traitMetaData = &traitMetaData{}
 	err := yaml.Unmarshal(resources.Resource("/traits.yaml"), traitMetaData)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, tp := range v1.AllTraitProfiles {
 		traits := catalog.TraitsForProfile(tp)
 		for _, t := range traits {
-			if len(args) == 1 && trait.ID(args[0]) != t.ID() {
+			if name != "" && trait.ID(name) != t.ID() {
 				continue
 			}
 
@@ -138,11 +155,15 @@ func (command *traitHelpCommandOptions) run(cmd *cobra.Command, args []string) e
 		}
 	}
 
-	if len(args) == 1 && len(traitDescriptions) == 0 {
-		return fmt.Errorf("no trait named '%s' exists", args[0])
+	if name != "" && len(traitDescriptions) == 0 {
+		return nil, fmt.Errorf("no trait named '%s' exists", name)
 	}
 
-	switch strings.ToUpper(command.OutputFormat) {
+	return traitDescriptions, nil
+}
+
+func printTraitDescriptions(cmd *cobra.Command, traitDescriptions []*traitDescription, outputFormat string) error {
+	switch strings.ToUpper(outputFormat) {
 	case "JSON":
 		res, err := json.Marshal(traitDescriptions)
 		if err != nil {
@@ -242,6 +263,9 @@ func outputTraits(descriptions []*traitDescription) (string, error) {
 			w.Write(0, "Name:\t%s\n", td.Name)
 			w.Write(0, "Profiles:\t%s\n", strings.Join(td.Profiles, ","))
 			w.Write(0, "Platform:\t%t\n", td.Platform)
+			if td.Description != "" {
+				w.Write(0, "Description:\t%s\n", firstLine(td.Description))
+			}
 			w.Write(0, "Properties:\n")
 			for _, p := range td.Properties {
 				w.Write(1, "%s:\n", p.Name)
@@ -249,6 +273,9 @@ func outputTraits(descriptions []*traitDescription) (string, error) {
 				if p.DefaultValue != nil {
 					w.Write(2, "Default Value:\t%v\n", p.DefaultValue)
 				}
+				if p.Description != "" {
+					w.Write(2, "Description:\t%s\n", firstLine(p.Description))
+				}
 			}
 			w.Writeln(0, "")
 		}
@@ -256,3 +283,11 @@ func outputTraits(descriptions []*traitDescription) (string, error) {
 		return nil
 	})
 }
+
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if idx := strings.Index(text, "\n"); idx >= 0 {
+		return text[:idx]
+	}
+	return text
+}
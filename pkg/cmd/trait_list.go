@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newTraitListCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *traitListCommandOptions) {
+	options := traitListCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:     "list",
+		Short:   "List the available traits",
+		Long:    `List the available traits, along with the profiles they apply to.`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.run(cmd)
+		},
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of json, yaml")
+	cmd.Flags().Bool("platform", true, "Include platform traits")
+
+	return &cmd, &options
+}
+
+type traitListCommandOptions struct {
+	*RootCmdOptions
+	OutputFormat string `mapstructure:"output"`
+	Platform     bool   `mapstructure:"platform"`
+}
+
+func (command *traitListCommandOptions) run(cmd *cobra.Command) error {
+	descriptions, err := loadTraitDescriptions(command.Context, "")
+	if err != nil {
+		return err
+	}
+
+	traitDescriptions := make([]*traitDescription, 0, len(descriptions))
+	for _, td := range descriptions {
+		if td.Platform && !command.Platform {
+			continue
+		}
+		traitDescriptions = append(traitDescriptions, td)
+	}
+
+	sort.SliceStable(traitDescriptions, func(i, j int) bool {
+		return traitDescriptions[i].Name < traitDescriptions[j].Name
+	})
+
+	if command.OutputFormat != "" {
+		return printTraitDescriptions(cmd, traitDescriptions, command.OutputFormat)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tPLATFORM\tPROFILES\tDESCRIPTION")
+	for _, td := range traitDescriptions {
+		fmt.Fprintf(w, "%s\t%t\t%s\t%s\n",
+			td.Name,
+			td.Platform,
+			strings.Join(td.Profiles, ","),
+			firstSentence(td.Description))
+	}
+
+	return w.Flush()
+}
+
+func firstSentence(text string) string {
+	text = firstLine(text)
+	if idx := strings.Index(text, ". "); idx >= 0 {
+		return text[:idx+1]
+	}
+	return text
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func initializeTraitCmd(t *testing.T) *cobra.Command {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	rootCmd.AddCommand(newCmdTrait(options))
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return rootCmd
+}
+
+func TestTraitList(t *testing.T) {
+	rootCmd := initializeTraitCmd(t)
+
+	output, err := test.ExecuteCommand(rootCmd, "trait", "list")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "NAME")
+	assert.Contains(t, output, "knative-service")
+	assert.Regexp(t, `(?m)^builder\s`, output)
+
+	output, err = test.ExecuteCommand(rootCmd, "trait", "list", "--platform=false")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "knative-service")
+	assert.NotRegexp(t, `(?m)^builder\s`, output)
+}
+
+func TestTraitDescribe(t *testing.T) {
+	rootCmd := initializeTraitCmd(t)
+
+	output, err := test.ExecuteCommand(rootCmd, "trait", "describe", "knative-service")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "knative-service")
+	assert.Contains(t, output, "autoscaling-class")
+	assert.Contains(t, output, "min-scale")
+
+	_, err = test.ExecuteCommand(rootCmd, "trait", "describe", "foobar")
+	assert.NotNil(t, err)
+
+	_, err = test.ExecuteCommand(rootCmd, "trait", "describe")
+	assert.NotNil(t, err)
+}
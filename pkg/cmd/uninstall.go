@@ -32,6 +32,7 @@ import (
 
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/util/kubernetes/customclient"
@@ -62,6 +63,7 @@ func newCmdUninstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *uninstall
 	cmd.Flags().Bool("skip-config-maps", false, "Do not uninstall the Camel K Config Maps in the current namespace")
 	cmd.Flags().Bool("skip-registry-secret", false, "Do not uninstall the Camel K Registry Secret in the current namespace")
 	cmd.Flags().Bool("skip-kamelets", false, "Do not uninstall the Kamelets in the current namespace")
+	cmd.Flags().Bool("skip-integration-kits", false, "Do not uninstall the Integration Kits not used by any Integration in the current namespace")
 	cmd.Flags().Bool("global", false, "Indicates that a global installation is going to be uninstalled (affects OLM)")
 	cmd.Flags().Bool("olm", true, "Try to uninstall via OLM (Operator Lifecycle Manager) if available")
 	cmd.Flags().String("olm-operator-name", olm.DefaultOperatorName, "Name of the Camel K operator in the OLM source or marketplace")
@@ -86,6 +88,7 @@ type uninstallCmdOptions struct {
 	SkipConfigMaps          bool `mapstructure:"skip-config-maps"`
 	SkipRegistrySecret      bool `mapstructure:"skip-registry-secret"`
 	SkipKamelets            bool `mapstructure:"skip-kamelets"`
+	SkipIntegrationKits     bool `mapstructure:"skip-integration-kits"`
 	Global                  bool `mapstructure:"global"`
 	OlmEnabled              bool `mapstructure:"olm"`
 	UninstallAll            bool `mapstructure:"all"`
@@ -270,6 +273,13 @@ func (o *uninstallCmdOptions) uninstallNamespaceResources(ctx context.Context, c
 		fmt.Printf("Camel K Registry Secret removed from namespace %s\n", o.Namespace)
 	}
 
+	if !o.SkipIntegrationKits || o.UninstallAll {
+		if err := o.uninstallUnusedIntegrationKits(ctx, c); err != nil {
+			return err
+		}
+		fmt.Printf("Camel K unused Integration Kits removed from namespace %s\n", o.Namespace)
+	}
+
 	if !o.SkipKamelets {
 		if err := o.uninstallKamelets(ctx, c); err != nil {
 			return err
@@ -491,6 +501,41 @@ func (o *uninstallCmdOptions) uninstallKamelets(ctx context.Context, c client.Cl
 	return nil
 }
 
+func (o *uninstallCmdOptions) uninstallUnusedIntegrationKits(ctx context.Context, c client.Client) error {
+	integrationList := v1.NewIntegrationList()
+	if err := c.List(ctx, &integrationList, k8sclient.InNamespace(o.Namespace)); err != nil {
+		return err
+	}
+
+	// kits used by integrations are kept so that running workloads are not affected
+	used := make(map[string]bool)
+	for _, integration := range integrationList.Items {
+		if ref := integration.Status.IntegrationKit; ref != nil {
+			used[ref.Name] = true
+		}
+		if ref := integration.Spec.IntegrationKit; ref != nil {
+			used[ref.Name] = true
+		}
+	}
+
+	kitList := v1.NewIntegrationKitList()
+	if err := c.List(ctx, &kitList, k8sclient.InNamespace(o.Namespace)); err != nil {
+		return err
+	}
+
+	for _, kit := range kitList.Items {
+		if used[kit.Name] {
+			continue
+		}
+		kit := kit
+		if err := c.Delete(ctx, &kit); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func createActionNotAuthorizedError() error {
 	fmt.Println("Current user is not authorized to remove cluster-wide objects like custom resource definitions or cluster roles")
 	msg := `login as cluster-admin and execute "kamel uninstall" or use flags "--skip-crd --skip-cluster-roles --skip-cluster-role-bindings"`
@@ -58,11 +58,12 @@ func TestUninstallSkipFlags(t *testing.T) {
 
 	kamelTestPostAddCommandInit(t, cmd)
 
-	_, err := test.ExecuteCommand(cmd, "uninstall", "--skip-crd", "--skip-cluster-roles", "--skip-integration-platform")
+	_, err := test.ExecuteCommand(cmd, "uninstall", "--skip-crd", "--skip-cluster-roles", "--skip-integration-platform", "--skip-integration-kits")
 	assert.Nil(t, err)
 	assert.True(t, uninstallCmdOptions.SkipCrd)
 	assert.True(t, uninstallCmdOptions.SkipClusterRoles)
 	assert.True(t, uninstallCmdOptions.SkipIntegrationPlatform)
+	assert.True(t, uninstallCmdOptions.SkipIntegrationKits)
 }
 
 func TestUninstallAllFlag(t *testing.T) {
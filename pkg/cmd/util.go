@@ -25,6 +25,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/apache/camel-k/pkg/util/gzip"
@@ -97,8 +98,7 @@ func bindPFlags(cmd *cobra.Command) error {
 		// this is a little bit of an hack to register plural version of properties
 		// based on the naming conventions used by the flag type because it is not
 		// possible to know what is the type of a flag
-		flagType := strings.ToUpper(flag.Value.Type())
-		if strings.Contains(flagType, "SLICE") || strings.Contains(flagType, "ARRAY") {
+		if isMultiValuedFlag(flag) {
 			if err := viper.BindPFlag(prefix+"."+pl.Plural(name), flag); err != nil {
 				log.Printf("error binding plural flag %s with prefix %s to viper: %v", flag.Name, prefix, err)
 			}
@@ -283,3 +283,68 @@ func hasSupportedScheme(uri string) bool {
 
 	return false
 }
+
+// didYouMean returns a suggestion message listing the candidates closest to the given value, if any
+func didYouMean(value string, candidates []string) string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+
+	matches := make([]match, 0)
+	for _, candidate := range candidates {
+		distance := levenshteinDistance(value, candidate)
+		if distance <= 2 || strings.HasPrefix(candidate, value) {
+			matches = append(matches, match{candidate: candidate, distance: distance})
+		}
+	}
+
+	if len(matches) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	suggestions := make([]string, 0, 3)
+	for i := 0; i < len(matches) && i < 3; i++ {
+		suggestions = append(suggestions, matches[i].candidate)
+	}
+
+	return fmt.Sprintf(", did you mean %s?", strings.Join(suggestions, ", "))
+}
+
+func levenshteinDistance(s, t string) int {
+	d := make([]int, len(t)+1)
+	for j := range d {
+		d[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		prev := d[0]
+		d[0] = i
+		for j := 1; j <= len(t); j++ {
+			current := d[j]
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[j] = min3(d[j]+1, d[j-1]+1, prev+cost)
+			prev = current
+		}
+	}
+
+	return d[len(t)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
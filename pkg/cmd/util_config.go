@@ -55,13 +55,52 @@ type Config struct {
 
 // LoadConfiguration loads a kamel configuration file
 func LoadConfiguration() (*Config, error) {
-	config := Config{
-		location: viper.ConfigFileUsed(),
-		content:  make(map[string]interface{}),
+	location := viper.ConfigFileUsed()
+	if location == "" {
+		location = DefaultConfigLocation
+	}
+
+	return LoadConfigurationFrom(location)
+}
+
+// LoadUserConfiguration loads the kamel configuration file in use, falling back
+// to the per-user configuration file when no file has been found
+func LoadUserConfiguration() (*Config, error) {
+	location := viper.ConfigFileUsed()
+	if location == "" {
+		location = UserConfigLocation()
+	}
+
+	return LoadConfigurationFrom(location)
+}
+
+// UserConfigLocation returns the location of the per-user kamel configuration file
+func UserConfigLocation() string {
+	location := ConfigName() + ".yaml"
+	if configPath := os.Getenv("KAMEL_CONFIG_PATH"); configPath != "" {
+		return filepath.Join(configPath, location)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return location
 	}
+	return filepath.Join(home, ".kamel", location)
+}
 
-	if config.location == "" {
-		config.location = DefaultConfigLocation
+// ConfigName returns the name of the kamel configuration file, without extension,
+// that can be overridden with the KAMEL_CONFIG_NAME environment variable
+func ConfigName() string {
+	if configName := os.Getenv("KAMEL_CONFIG_NAME"); configName != "" {
+		return configName
+	}
+	return DefaultConfigName
+}
+
+// LoadConfigurationFrom loads the kamel configuration file at the given location
+func LoadConfigurationFrom(location string) (*Config, error) {
+	config := Config{
+		location: location,
+		content:  make(map[string]interface{}),
 	}
 
 	if _, err := os.Stat(config.location); os.IsNotExist(err) {
@@ -127,6 +166,41 @@ func (cfg *Config) SetNode(nodeID string, nodeValues map[string]interface{}) {
 	}
 }
 
+// Location returns the path of the configuration file
+func (cfg *Config) Location() string {
+	return cfg.location
+}
+
+// Get returns the value stored at the given path, if any
+func (cfg *Config) Get(path string) interface{} {
+	parent, key := cfg.split(path)
+	node := cfg.node(parent, false)
+	if node == nil {
+		return nil
+	}
+	return node[key]
+}
+
+// Set stores a single value at the given path, creating the intermediate nodes if needed
+func (cfg *Config) Set(path string, value interface{}) {
+	parent, key := cfg.split(path)
+	node := cfg.node(parent, true)
+	node[key] = value
+}
+
+// Unset removes the value stored at the given path
+func (cfg *Config) Unset(path string) {
+	parent, key := cfg.split(path)
+	if node := cfg.node(parent, false); node != nil {
+		delete(node, key)
+	}
+}
+
+// Content returns the raw configuration content
+func (cfg *Config) Content() map[string]interface{} {
+	return cfg.content
+}
+
 // Delete allows to remove a sub tree from the kamel content
 func (cfg *Config) Delete(path string) {
 	leaf := cfg.navigate(cfg.content, path, false)
@@ -184,6 +258,20 @@ func (cfg *Config) navigate(values map[string]interface{}, prefix string, create
 	return values
 }
 
+func (cfg *Config) node(path string, create bool) map[string]interface{} {
+	if path == "" {
+		return cfg.content
+	}
+	return cfg.navigate(cfg.content, path, create)
+}
+
+func (cfg *Config) split(path string) (string, string) {
+	if idx := strings.LastIndex(path, "."); idx >= 0 {
+		return path[:idx], path[idx+1:]
+	}
+	return "", path
+}
+
 func (cfg *Config) convert(m map[interface{}]interface{}) map[string]interface{} {
 	res := make(map[string]interface{})
 	for k, v := range m {
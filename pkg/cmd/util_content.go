@@ -18,13 +18,15 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
-	"regexp"
 	"strings"
+
+	src "github.com/apache/camel-k/pkg/util/source"
 )
 
 const (
@@ -59,11 +61,11 @@ func loadRawContent(source string) ([]byte, string, error) {
 
 		switch u.Scheme {
 		case "github":
-			content, err = loadContentGitHub(u)
+			content, err = src.LoadContentGitHub(context.Background(), u, nil)
 		case "http":
-			content, err = loadContentHTTP(u)
+			content, err = src.LoadContentHTTP(context.Background(), u, nil)
 		case "https":
-			content, err = loadContentHTTP(u)
+			content, err = src.LoadContentHTTP(context.Background(), u, nil)
 		default:
 			return nil, "", fmt.Errorf("missing file or unsupported scheme %s", u.Scheme)
 		}
@@ -96,48 +98,3 @@ func loadTextContent(source string, base64Compression bool) (string, string, boo
 
 	return string(content), contentType, false, nil
 }
-
-func loadContentHTTP(u *url.URL) ([]byte, error) {
-	// nolint: gosec
-	resp, err := http.Get(u.String())
-	if err != nil {
-		return []byte{}, err
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode != 200 {
-		return []byte{}, fmt.Errorf("the provided URL %s is not reachable, error code is %d", u.String(), resp.StatusCode)
-	}
-
-	content, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	return content, nil
-}
-
-func loadContentGitHub(u *url.URL) ([]byte, error) {
-	src := u.Scheme + ":" + u.Opaque
-	re := regexp.MustCompile(`^github:([^/]+)/([^/]+)/(.+)$`)
-
-	items := re.FindStringSubmatch(src)
-	if len(items) != 4 {
-		return []byte{}, fmt.Errorf("malformed github url: %s", src)
-	}
-
-	branch := u.Query().Get("branch")
-	if branch == "" {
-		branch = "master"
-	}
-
-	srcURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", items[1], items[2], branch, items[3])
-	rawURL, err := url.Parse(srcURL)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	return loadContentHTTP(rawURL)
-}
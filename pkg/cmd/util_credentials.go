@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/source"
+)
+
+type gitCredentialsKey struct{}
+
+// withGitCredentials returns a context carrying the credentials used to fetch remote sources
+func withGitCredentials(ctx context.Context, credentials *source.GitCredentials) context.Context {
+	return context.WithValue(ctx, gitCredentialsKey{}, credentials)
+}
+
+// gitCredentialsFrom returns the credentials carried by the context, if any
+func gitCredentialsFrom(ctx context.Context) *source.GitCredentials {
+	if ctx == nil {
+		return nil
+	}
+	if credentials, ok := ctx.Value(gitCredentialsKey{}).(*source.GitCredentials); ok {
+		return credentials
+	}
+	return nil
+}
+
+// withGitCredentialsFromFlags loads the credentials of the git secret set in the given flags, before
+// the command options are decoded, e.g. when reading the modeline of private sources
+func withGitCredentialsFromFlags(ctx context.Context, flags *pflag.FlagSet) (context.Context, error) {
+	secret, err := flags.GetString("git-secret")
+	if err != nil || secret == "" {
+		return ctx, nil
+	}
+	kubeConfig, err := flags.GetString("kube-config")
+	if err != nil {
+		return ctx, err
+	}
+	namespace, err := flags.GetString("namespace")
+	if err != nil {
+		return ctx, err
+	}
+
+	c, err := client.NewOutOfClusterClient(kubeConfig)
+	if err != nil {
+		return ctx, err
+	}
+	if namespace == "" {
+		namespace = viper.GetString(DefaultNamespaceConfigKey)
+	}
+	if namespace == "" {
+		if namespace, err = c.GetCurrentNamespace(kubeConfig); err != nil {
+			return ctx, err
+		}
+	}
+
+	credentials, err := source.LoadGitCredentials(ctx, c, namespace, secret)
+	if err != nil {
+		return ctx, err
+	}
+
+	return withGitCredentials(ctx, credentials), nil
+}
@@ -20,9 +20,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -30,6 +32,7 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/metadata"
 	"github.com/apache/camel-k/pkg/trait"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/camel"
@@ -43,7 +46,7 @@ var additionalDependencyUsageMessage = `Additional top-level dependencies are sp
 <type>:<dependency-name>
 where <type> is one of {` + strings.Join(acceptedDependencyTypes, "|") + `}.`
 
-func getDependencies(ctx context.Context, args []string, additionalDependencies []string, repositories []string, allDependencies bool) ([]string, error) {
+func getDependencies(ctx context.Context, args []string, additionalDependencies []string, repositories []string, settings []byte, settingsSecurity []byte, allDependencies bool) ([]string, error) {
 	// Fetch existing catalog or create new one if one does not already exist
 	catalog, err := createCamelCatalog(ctx)
 
@@ -68,7 +71,7 @@ func getDependencies(ctx context.Context, args []string, additionalDependencies
 			util.StringSliceUniqueAdd(&dependencies, runtimeDep.GetDependencyID())
 		}
 
-		dependencies, err = getTransitiveDependencies(ctx, catalog, dependencies, repositories)
+		dependencies, err = getTransitiveDependencies(ctx, catalog, dependencies, repositories, settings, settingsSecurity)
 		if err != nil {
 			return nil, err
 		}
@@ -102,7 +105,33 @@ func getTopLevelDependencies(catalog *camel.RuntimeCatalog, args []string) ([]st
 	return dependencies.List(), nil
 }
 
-func getTransitiveDependencies(ctx context.Context, catalog *camel.RuntimeCatalog, dependencies []string, repositories []string) ([]string, error) {
+// getUnknownSchemes returns the endpoint schemes used by the given sources that are not known by the catalog
+func getUnknownSchemes(catalog *camel.RuntimeCatalog, args []string) ([]string, error) {
+	schemes := strset.New()
+
+	for _, source := range args {
+		data, _, _, err := loadTextContent(source, false)
+		if err != nil {
+			return []string{}, err
+		}
+
+		sourceSpec := v1.SourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    path.Base(source),
+				Content: data,
+			},
+		}
+
+		schemes.Merge(metadata.Extract(catalog, sourceSpec).UnknownSchemes)
+	}
+
+	unknown := schemes.List()
+	sort.Strings(unknown)
+
+	return unknown, nil
+}
+
+func getTransitiveDependencies(ctx context.Context, catalog *camel.RuntimeCatalog, dependencies []string, repositories []string, settings []byte, settingsSecurity []byte) ([]string, error) {
 	project := builder.GenerateQuarkusProjectCommon(
 		catalog.CamelCatalogSpec.Runtime.Metadata["camel-quarkus.version"],
 		defaults.DefaultRuntimeVersion,
@@ -117,7 +146,10 @@ func getTransitiveDependencies(ctx context.Context, catalog *camel.RuntimeCatalo
 	mc := maven.NewContext(util.MavenWorkingDirectory)
 	mc.LocalRepository = ""
 
-	if len(repositories) > 0 {
+	if len(settings) > 0 {
+		mc.SettingsContent = settings
+		mc.SettingsSecurityContent = settingsSecurity
+	} else if len(repositories) > 0 {
 		var repoList []v1.Repository
 		var mirrors []maven.Mirror
 		for i, repo := range repositories {
@@ -221,7 +253,7 @@ func generateCatalog(ctx context.Context) (*camel.RuntimeCatalog, error) {
 	}
 	var providerDependencies []maven.Dependency
 	var caCert []byte
-	catalog, err := camel.GenerateCatalogCommon(ctx, settings, caCert, mvn, runtime, providerDependencies)
+	catalog, err := camel.GenerateCatalogCommon(ctx, settings, "", caCert, mvn, runtime, providerDependencies)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +296,18 @@ func outputDependencies(dependencies []string, format string) error {
 	return nil
 }
 
+func outputUnknownSchemes(w io.Writer, schemes []string) {
+	if len(schemes) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "warnings:")
+	fmt.Fprintln(w, "  unknown component schemes, no dependency could be inferred for them:")
+	for _, scheme := range schemes {
+		fmt.Fprintf(w, "  - %s\n", scheme)
+	}
+}
+
 func printDependencies(format string, dependencies []string) error {
 	switch format {
 	case "yaml":
@@ -18,12 +18,15 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/util/camel"
 )
 
 func TestValidatePropertyFiles_ShouldSucceed(t *testing.T) {
@@ -66,3 +69,30 @@ func TestValidatePropertyFiles_ShouldFailNotFound(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.True(t, strings.HasPrefix(err.Error(), "unable to access property file"))
 }
+
+func TestGetUnknownSchemes(t *testing.T) {
+	var tmpFile *os.File
+	var err error
+	if tmpFile, err = ioutil.TempFile("", "camel-k-*.groovy"); err != nil {
+		t.Error(err)
+	}
+
+	assert.Nil(t, tmpFile.Close())
+	assert.Nil(t, ioutil.WriteFile(tmpFile.Name(), []byte(`from("timer:tick").to("foo:bar").to("log:info").to("baz:qux")`), 0644))
+	defer os.Remove(tmpFile.Name())
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	schemes, err := getUnknownSchemes(catalog, []string{tmpFile.Name()})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"baz", "foo"}, schemes)
+
+	var buf bytes.Buffer
+	outputUnknownSchemes(&buf, schemes)
+	assert.Equal(t, "warnings:\n  unknown component schemes, no dependency could be inferred for them:\n  - baz\n  - foo\n", buf.String())
+
+	buf.Reset()
+	outputUnknownSchemes(&buf, nil)
+	assert.Empty(t, buf.String())
+}
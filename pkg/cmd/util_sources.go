@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -27,7 +28,9 @@ import (
 	"path"
 	"strings"
 
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util"
+	src "github.com/apache/camel-k/pkg/util/source"
 
 	"golang.org/x/oauth2"
 
@@ -35,6 +38,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// githubAPIURL is the URL of the GitHub APIs, used to fetch the gists
+var githubAPIURL = &url.URL{Scheme: httpsScheme, Host: "api.github.com"}
+
 // Source ---
 type Source struct {
 	Origin   string
@@ -43,6 +49,7 @@ type Source struct {
 	Content  string
 	Compress bool
 	Local    bool
+	Language v1.Language
 }
 
 func (s *Source) setContent(content []byte) error {
@@ -60,11 +67,104 @@ func (s *Source) setContent(content []byte) error {
 	return nil
 }
 
+// sourcesCompressionThreshold is the total size of the sources above which they get compressed,
+// to keep the Integration resource and the generated ConfigMaps within the API server limits
+const sourcesCompressionThreshold = Megabyte / 2
+
+// sourcesMaxSize is the maximum total size of the sources, once compressed, so that the Integration resource
+// and the ConfigMaps generated from its sources are accepted by the API server
+const sourcesMaxSize = Megabyte
+
+// compressLargeSources compresses the sources when their total size is too large to be stored as is,
+// and fails with a meaningful message when the sources cannot fit into the Integration even compressed,
+// rather than with the API server request size error
+func compressLargeSources(out io.Writer, sources []Source) error {
+	total := 0
+	for _, s := range sources {
+		total += len(s.Content)
+	}
+
+	if total > sourcesCompressionThreshold {
+		for i := range sources {
+			if sources[i].Compress {
+				continue
+			}
+			fmt.Fprintf(out, "Compressing source %s as the integration sources exceed %d KB\n", sources[i].Name, sourcesCompressionThreshold/Kilobyte)
+			content := sources[i].Content
+			sources[i].Compress = true
+			if err := sources[i].setContent([]byte(content)); err != nil {
+				return err
+			}
+		}
+	}
+
+	total = 0
+	for _, s := range sources {
+		if len(s.Content) > sourcesMaxSize {
+			return fmt.Errorf("source %s is too large (%.2f MB once compressed), the maximum size is 1 MB: "+
+				"consider splitting it or packaging it as a dependency", s.Name, float64(len(s.Content))/Megabyte)
+		}
+		total += len(s.Content)
+	}
+	if total > sourcesMaxSize {
+		return fmt.Errorf("the integration sources are too large (%.2f MB once compressed), the maximum total size is 1 MB: "+
+			"consider packaging some of them as a dependency", float64(total)/Megabyte)
+	}
+
+	return nil
+}
+
+// sourceLanguageParam is the query parameter that can be appended to a source location
+// to force its language, e.g. routes.txt?language=groovy
+const sourceLanguageParam = "language"
+
+// parseSourceLanguage strips the language override, if any, from the given source location
+func parseSourceLanguage(location string) (string, v1.Language, error) {
+	i := strings.LastIndex(location, "?")
+	if i < 0 {
+		return location, "", nil
+	}
+
+	query, err := url.ParseQuery(location[i+1:])
+	if _, ok := query[sourceLanguageParam]; err != nil || !ok {
+		// not a language override, the query belongs to the location
+		return location, "", nil
+	}
+
+	value := query.Get(sourceLanguageParam)
+	language := v1.Language(value)
+	valid := false
+	for _, l := range v1.Languages {
+		if l == language {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return location, "", fmt.Errorf("unsupported language %q for source %s, supported languages are: %v", value, location[:i], v1.Languages)
+	}
+
+	query.Del(sourceLanguageParam)
+	stripped := location[:i]
+	if len(query) > 0 {
+		stripped += "?" + query.Encode()
+	}
+
+	return stripped, language, nil
+}
+
 // ResolveSources ---
 func ResolveSources(ctx context.Context, locations []string, compress bool) ([]Source, error) {
 	sources := make([]Source, 0, len(locations))
 
-	for _, location := range locations {
+	for _, l := range locations {
+		location, language, err := parseSourceLanguage(l)
+		if err != nil {
+			return sources, err
+		}
+
+		start := len(sources)
+
 		ok, err := isLocalAndFileExists(location)
 		if err != nil {
 			return sources, err
@@ -87,7 +187,11 @@ func ResolveSources(ctx context.Context, locations []string, compress bool) ([]S
 			case u.Scheme == gistScheme || strings.HasPrefix(location, "https://gist.github.com/"):
 				var tc *http.Client
 
-				if token, ok := os.LookupEnv("GITHUB_TOKEN"); ok {
+				// the token is only sent to the GitHub APIs if the git secret allows it
+				if credentials := gitCredentialsFrom(ctx); credentials.Allows(githubAPIURL) && credentials.Token != "" {
+					ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: credentials.Token})
+					tc = oauth2.NewClient(ctx, ts)
+				} else if token, ok := os.LookupEnv("GITHUB_TOKEN"); ok {
 					ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 					tc = oauth2.NewClient(ctx, ts)
 
@@ -141,7 +245,7 @@ func ResolveSources(ctx context.Context, locations []string, compress bool) ([]S
 					Compress: compress,
 				}
 
-				content, err := loadContentGitHub(u)
+				content, err := src.LoadContentGitHub(ctx, u, gitCredentialsFrom(ctx))
 				if err != nil {
 					return sources, err
 				}
@@ -157,7 +261,7 @@ func ResolveSources(ctx context.Context, locations []string, compress bool) ([]S
 					Compress: compress,
 				}
 
-				content, err := loadContentHTTP(u)
+				content, err := src.LoadContentHTTP(ctx, u, gitCredentialsFrom(ctx))
 				if err != nil {
 					return sources, err
 				}
@@ -173,7 +277,7 @@ func ResolveSources(ctx context.Context, locations []string, compress bool) ([]S
 					Compress: compress,
 				}
 
-				content, err := loadContentHTTP(u)
+				content, err := src.LoadContentHTTP(ctx, u, gitCredentialsFrom(ctx))
 				if err != nil {
 					return sources, err
 				}
@@ -185,6 +289,10 @@ func ResolveSources(ctx context.Context, locations []string, compress bool) ([]S
 				return sources, fmt.Errorf("Missing file or unsupported scheme in %s", location)
 			}
 		}
+
+		for i := start; i < len(sources); i++ {
+			sources[i].Language = language
+		}
 	}
 
 	return sources, nil
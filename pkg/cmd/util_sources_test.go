@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestCompressLargeSources(t *testing.T) {
+	var out bytes.Buffer
+
+	small := []Source{{Name: "small.groovy", Content: "from('timer:tick').to('log:info')"}}
+	assert.Nil(t, compressLargeSources(&out, small))
+	assert.False(t, small[0].Compress)
+	assert.Empty(t, out.String())
+
+	large := []Source{
+		{Name: "small.groovy", Content: "from('timer:tick').to('log:info')"},
+		{Name: "large.groovy", Content: strings.Repeat("from('timer:tick').to('log:info')\n", Megabyte/16)},
+	}
+	assert.Nil(t, compressLargeSources(&out, large))
+	assert.True(t, large[0].Compress)
+	assert.True(t, large[1].Compress)
+	assert.Less(t, len(large[1].Content), Megabyte)
+	assert.Contains(t, out.String(), "Compressing source large.groovy")
+
+	random := make([]byte, 2*Megabyte)
+	_, err := rand.Read(random)
+	assert.Nil(t, err)
+	incompressible := []Source{{Name: "random.groovy", Content: base64.StdEncoding.EncodeToString(random)}}
+	err = compressLargeSources(&out, incompressible)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "source random.groovy is too large")
+
+	random = make([]byte, 3*Megabyte/8)
+	_, err = rand.Read(random)
+	assert.Nil(t, err)
+	incompressible = []Source{
+		{Name: "random1.groovy", Content: base64.StdEncoding.EncodeToString(random)},
+		{Name: "random2.groovy", Content: base64.StdEncoding.EncodeToString(random)},
+		{Name: "random3.groovy", Content: base64.StdEncoding.EncodeToString(random)},
+	}
+	err = compressLargeSources(&out, incompressible)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the integration sources are too large")
+}
+
+func TestParseSourceLanguage(t *testing.T) {
+	location, language, err := parseSourceLanguage("routes.txt?language=groovy")
+	assert.Nil(t, err)
+	assert.Equal(t, "routes.txt", location)
+	assert.Equal(t, v1.LanguageGroovy, language)
+
+	location, language, err = parseSourceLanguage("https://example.com/routes?token=abc&language=xml")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://example.com/routes?token=abc", location)
+	assert.Equal(t, v1.LanguageXML, language)
+
+	location, language, err = parseSourceLanguage("https://example.com/routes.java?token=abc")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://example.com/routes.java?token=abc", location)
+	assert.Empty(t, language)
+
+	_, _, err = parseSourceLanguage("routes.txt?language=cobol")
+	assert.NotNil(t, err)
+}
+
+func TestResolveSourcesWithLanguage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := path.Join(dir, "routes.txt")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("from('timer:tick').to('log:info')"), 0644))
+
+	sources, err := ResolveSources(context.Background(), []string{file + "?language=groovy"}, false)
+	assert.Nil(t, err)
+	assert.Len(t, sources, 1)
+	assert.Equal(t, "routes.txt", sources[0].Name)
+	assert.Equal(t, file, sources[0].Location)
+	assert.Equal(t, v1.LanguageGroovy, sources[0].Language)
+
+	opts, err := extractModelineOptionsFromSource(sources[0])
+	assert.Nil(t, err)
+	assert.Empty(t, opts)
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/apache/camel-k/pkg/util/defaults"
 )
 
+const versionCommand = "version"
+
 // VersionVariant may be overridden at build time
 var VersionVariant = ""
 
@@ -40,7 +42,7 @@ func newCmdVersion(rootCmdOptions *RootCmdOptions) (*cobra.Command, *versionCmdO
 	}
 
 	cmd := cobra.Command{
-		Use:               "version",
+		Use:               versionCommand,
 		Short:             "Display client version",
 		Long:              `Display Camel K client version.`,
 		PersistentPreRunE: decode(&options),
@@ -50,13 +52,15 @@ func newCmdVersion(rootCmdOptions *RootCmdOptions) (*cobra.Command, *versionCmdO
 	}
 
 	cmd.Flags().Bool("operator", false, "Display Operator version")
+	cmd.Flags().Bool("fail-on-incompatible", false, "Exit with an error if the Operator version is not compatible with the client one (used in combination with the --operator flag)")
 
 	return &cmd, &options
 }
 
 type versionCmdOptions struct {
 	*RootCmdOptions
-	Operator bool `mapstructure:"operator"`
+	Operator           bool `mapstructure:"operator"`
+	FailOnIncompatible bool `mapstructure:"fail-on-incompatible"`
 }
 
 func (o *versionCmdOptions) preRunE(cmd *cobra.Command, args []string) error {
@@ -73,7 +77,14 @@ func (o *versionCmdOptions) run(_ *cobra.Command, _ []string) error {
 		if err != nil {
 			return err
 		}
-		displayOperatorVersion(o.Context, c, o.Namespace)
+		version := displayOperatorVersion(o.Context, c, o.Namespace)
+		if version != "" && !compatibleVersions(version, defaults.Version) {
+			fmt.Printf("Warning: Camel K Operator %s is not compatible with Camel K Client %s, "+
+				"it's recommended to use the same version to improve compatibility\n", version, defaults.Version)
+			if o.FailOnIncompatible {
+				return fmt.Errorf("incompatible versions: Camel K Client %s, Camel K Operator %s", defaults.Version, version)
+			}
+		}
 	} else {
 		displayClientVersion()
 	}
@@ -88,17 +99,18 @@ func displayClientVersion() {
 	}
 }
 
-func displayOperatorVersion(ctx context.Context, c client.Client, namespace string) {
+func displayOperatorVersion(ctx context.Context, c client.Client, namespace string) string {
 	operatorVersion, err := operatorVersion(ctx, c, namespace)
 	if err != nil {
 		fmt.Printf("Unable to retrieve operator version: %s\n", err)
 	} else {
 		if operatorVersion == "" {
-		  fmt.Printf("Unable to retrieve operator version: The IntegrationPlatform resource hasn't been reconciled yet!")
+			fmt.Printf("Unable to retrieve operator version: The IntegrationPlatform resource hasn't been reconciled yet!")
 		} else {
-		  fmt.Printf("Camel K Operator %s\n", operatorVersion)
+			fmt.Printf("Camel K Operator %s\n", operatorVersion)
 		}
 	}
+	return operatorVersion
 }
 
 func operatorVersion(ctx context.Context, c client.Client, namespace string) (string, error) {
@@ -118,12 +130,12 @@ func operatorVersion(ctx context.Context, c client.Client, namespace string) (st
 func compatibleVersions(aVersion, bVersion string) bool {
 	a, err := semver.NewVersion(aVersion)
 	if err != nil {
-		fmt.Printf("Could not parse %s (error: %s)\n", a, err)
+		fmt.Printf("Could not parse %s (error: %s)\n", aVersion, err)
 		return false
 	}
 	b, err := semver.NewVersion(bVersion)
 	if err != nil {
-		fmt.Printf("Could not parse %s (error: %s)\n", b, err)
+		fmt.Printf("Could not parse %s (error: %s)\n", bVersion, err)
 		return false
 	}
 	// We consider compatible when major and minor are equals
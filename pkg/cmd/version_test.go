@@ -62,6 +62,14 @@ func TestVersionOperatorFlag(t *testing.T) {
 	assert.Equal(t, true, versionCmdOptions.Operator)
 }
 
+func TestVersionFailOnIncompatibleFlag(t *testing.T) {
+	versionCmdOptions, rootCmd, _ := initializeVersionCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdVersion, "--operator", "--fail-on-incompatible")
+	assert.Nil(t, err)
+	assert.Equal(t, true, versionCmdOptions.Operator)
+	assert.Equal(t, true, versionCmdOptions.FailOnIncompatible)
+}
+
 func TestCompatibleVersions(t *testing.T) {
 	assert.Equal(t, true, compatibleVersions("1.3.0", "1.3.0"))
 	assert.Equal(t, true, compatibleVersions("1.3.0", "1.3.1"))
@@ -37,12 +37,26 @@ import (
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/envvar"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
 const (
 	builderDir    = "/builder"
 	builderVolume = "camel-k-builder"
+
+	// The volume holding the local Maven repository shared across builds
+	mavenCacheVolume = "camel-k-maven-cache"
+
+	// The user the Buildah container runs as in rootless mode
+	buildahRootlessUser = int64(1000)
+
+	// The user the BuildKit rootless image runs as
+	buildKitRootlessUser = int64(1000)
+	// The state directory of the BuildKit daemon, when it runs in the build pod
+	buildKitStateDir = "/home/user/.local/share/buildkit"
+	// The file BuildKit writes the build result metadata into, e.g. the digest of the pushed image
+	buildKitMetadataFile = "/tmp/buildkit-metadata.json"
 )
 
 type registryConfigMap struct {
@@ -114,6 +128,24 @@ var (
 	}
 )
 
+var (
+	plainDockerBuildKitRegistrySecret = registrySecret{
+		fileName:    "config.json",
+		mountPath:   "/home/user/.docker",
+		destination: "config.json",
+	}
+	standardDockerBuildKitRegistrySecret = registrySecret{
+		fileName:    corev1.DockerConfigJsonKey,
+		mountPath:   "/home/user/.docker",
+		destination: "config.json",
+	}
+
+	buildKitRegistrySecrets = []registrySecret{
+		plainDockerBuildKitRegistrySecret,
+		standardDockerBuildKitRegistrySecret,
+	}
+)
+
 func newBuildPod(ctx context.Context, c client.Client, build *v1.Build) (*corev1.Pod, error) {
 	pod := &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -131,6 +163,9 @@ func newBuildPod(ctx context.Context, c client.Client, build *v1.Build) (*corev1
 		Spec: corev1.PodSpec{
 			ServiceAccountName: platform.BuilderServiceAccount,
 			RestartPolicy:      corev1.RestartPolicyNever,
+			Tolerations:        build.Spec.Tolerations,
+			NodeSelector:       build.Spec.NodeSelector,
+			PriorityClassName:  build.Spec.PriorityClassName,
 		},
 	}
 
@@ -142,6 +177,10 @@ func newBuildPod(ctx context.Context, c client.Client, build *v1.Build) (*corev1
 			if err != nil {
 				return nil, err
 			}
+		} else if task.Native != nil {
+			addNativeTaskToPod(build, task.Native, pod)
+		} else if task.Custom != nil {
+			addCustomTaskToPod(build, task.Custom, pod)
 		} else if task.Buildah != nil {
 			err := addBuildahTaskToPod(ctx, c, build, task.Buildah, pod)
 			if err != nil {
@@ -152,6 +191,16 @@ func newBuildPod(ctx context.Context, c client.Client, build *v1.Build) (*corev1
 			if err != nil {
 				return nil, err
 			}
+		} else if task.BuildKit != nil {
+			err := addBuildKitTaskToPod(ctx, c, build, task.BuildKit, pod)
+			if err != nil {
+				return nil, err
+			}
+		} else if task.Jib != nil {
+			err := addBuildTaskToPod(ctx, c, build, task.Jib.Name, pod)
+			if err != nil {
+				return nil, err
+			}
 		} else if task.S2i != nil {
 			err := addBuildTaskToPod(ctx, c, build, task.S2i.Name, pod)
 			if err != nil {
@@ -165,6 +214,11 @@ func newBuildPod(ctx context.Context, c client.Client, build *v1.Build) (*corev1
 		}
 	}
 
+	// Propagate the proxy settings, so that the build containers access the network through the proxy
+	for i := range pod.Spec.InitContainers {
+		addProxyToContainer(build, &pod.Spec.InitContainers[i])
+	}
+
 	// Make sure there is one container defined
 	pod.Spec.Containers = pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1 : len(pod.Spec.InitContainers)]
 	pod.Spec.InitContainers = pod.Spec.InitContainers[:len(pod.Spec.InitContainers)-1]
@@ -246,10 +300,89 @@ func addBuildTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, task
 		WorkingDir: path.Join(builderDir, build.Name),
 	}
 
+	if build.Spec.Resources != nil {
+		container.Resources = *build.Spec.Resources
+	}
+
+	addMavenCacheToPod(build, &container, pod)
 	addContainerToPod(build, container, pod)
 	return nil
 }
 
+// addMavenCacheToPod mounts the persistent volume claim configured for the Maven cache,
+// if any, as the local Maven repository of the container
+func addMavenCacheToPod(build *v1.Build, container *corev1.Container, pod *corev1.Pod) {
+	var maven *v1.MavenSpec
+	for _, task := range build.Spec.Tasks {
+		if task.Builder != nil {
+			maven = &task.Builder.Maven
+		}
+	}
+	if maven == nil || maven.Cache == nil || maven.Cache.PersistentVolumeClaim == "" || maven.LocalRepository == "" {
+		return
+	}
+
+	if !hasVolume(pod, mavenCacheVolume) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: mavenCacheVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: maven.Cache.PersistentVolumeClaim,
+				},
+			},
+		})
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      mavenCacheVolume,
+		MountPath: maven.LocalRepository,
+	})
+}
+
+func addNativeTaskToPod(build *v1.Build, task *v1.NativeTask, pod *corev1.Pod) {
+	buildDir := path.Join(builderDir, build.Name)
+	sourcesDir := path.Join(buildDir, builder.NativeSourcesDir)
+	targetDir := path.Join(buildDir, builder.ContextDir, builder.DependenciesDir)
+
+	// Compile the native executable, and add it to the image build context
+	script := fmt.Sprintf("native-image $(cat %s) && mkdir -p %s && cp *%s %s",
+		builder.NativeImageArgsFile, targetDir, builder.QuarkusNativeRunnerSuffix, targetDir)
+
+	container := corev1.Container{
+		Name:            task.Name,
+		Image:           task.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"/bin/sh", "-c", script},
+		WorkingDir:      sourcesDir,
+	}
+
+	if task.Resources != nil {
+		container.Resources = *task.Resources
+	} else if build.Spec.Resources != nil {
+		container.Resources = *build.Spec.Resources
+	}
+
+	addContainerToPod(build, container, pod)
+}
+
+func addCustomTaskToPod(build *v1.Build, task *v1.CustomTask, pod *corev1.Pod) {
+	container := corev1.Container{
+		Name:            task.Name,
+		Image:           task.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"/bin/sh", "-c", task.Command},
+		WorkingDir:      path.Join(builderDir, build.Name),
+	}
+
+	if task.Resources != nil {
+		container.Resources = *task.Resources
+	} else if build.Spec.Resources != nil {
+		container.Resources = *build.Spec.Resources
+	}
+
+	addContainerToPod(build, container, pod)
+}
+
 func addBuildahTaskToPod(ctx context.Context, c client.Client, build *v1.Build, task *v1.BuildahTask, pod *corev1.Pod) error {
 	bud := []string{
 		"buildah",
@@ -316,6 +449,30 @@ func addBuildahTaskToPod(ctx context.Context, c client.Client, build *v1.Build,
 
 	env = append(env, proxySecretEnvVars(task.HttpProxySecret)...)
 
+	var securityContext *corev1.SecurityContext
+	if task.Rootless != nil && *task.Rootless {
+		// Unprivileged users cannot create the namespaces required by the default OCI isolation,
+		// and the storage is located in the home directory, which must be writable
+		env = append(env,
+			corev1.EnvVar{
+				Name:  "BUILDAH_ISOLATION",
+				Value: "chroot",
+			},
+			corev1.EnvVar{
+				Name:  "HOME",
+				Value: "/tmp/buildah",
+			},
+		)
+		user := buildahRootlessUser
+		nonRoot := true
+		privilegeEscalation := false
+		securityContext = &corev1.SecurityContext{
+			RunAsUser:                &user,
+			RunAsNonRoot:             &nonRoot,
+			AllowPrivilegeEscalation: &privilegeEscalation,
+		}
+	}
+
 	args := []string{
 		strings.Join(bud, " "),
 		strings.Join(push, " "),
@@ -333,6 +490,7 @@ func addBuildahTaskToPod(ctx context.Context, c client.Client, build *v1.Build,
 		Env:             env,
 		WorkingDir:      path.Join(builderDir, build.Name, builder.ContextDir),
 		VolumeMounts:    volumeMounts,
+		SecurityContext: securityContext,
 	}
 
 	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
@@ -354,6 +512,8 @@ func addKanikoTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, tas
 		"--destination=" + task.Image,
 		"--cache=" + strconv.FormatBool(cache),
 		"--cache-dir=" + builder.KanikoCacheDir,
+		// Report the digest of the pushed image
+		"--digest-file=/dev/termination-log",
 	}
 
 	if task.Verbose != nil && *task.Verbose {
@@ -455,6 +615,111 @@ func addKanikoTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, tas
 	return nil
 }
 
+func addBuildKitTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, task *v1.BuildKitTask, pod *corev1.Pod) error {
+	contextDir := path.Join(builderDir, build.Name, builder.ContextDir)
+
+	output := "type=image,name=" + task.Image + ",push=true"
+	if task.Registry.Insecure {
+		output += ",registry.insecure=true"
+	}
+
+	buildctl := []string{"buildctl-daemonless.sh"}
+	if task.Address != "" {
+		buildctl = []string{"buildctl", "--addr", task.Address}
+	}
+	if task.Verbose != nil && *task.Verbose {
+		buildctl = append(buildctl, "--debug")
+	}
+	buildctl = append(buildctl,
+		"build",
+		"--frontend=dockerfile.v0",
+		"--local=context="+contextDir,
+		"--local=dockerfile="+contextDir,
+		"--output="+output,
+		"--metadata-file="+buildKitMetadataFile,
+	)
+	if len(task.Platforms) > 0 {
+		// Build the image for all the platforms, and push them as a manifest list
+		buildctl = append(buildctl, "--opt=platform="+strings.Join(task.Platforms, ","))
+	}
+	if task.CacheImage != "" {
+		// Export all the intermediate layers, for the dependencies layers to be reused by the next builds
+		buildctl = append(buildctl,
+			"--export-cache=type=registry,ref="+task.CacheImage+",mode=max",
+			"--import-cache=type=registry,ref="+task.CacheImage,
+		)
+	}
+
+	// Report the digest of the pushed image
+	digest := fmt.Sprintf(`sed -n 's/.*"containerimage.digest": *"\([^"]*\)".*/\1/p' %s > /dev/termination-log`,
+		buildKitMetadataFile)
+
+	env := make([]corev1.EnvVar, 0)
+	volumes := make([]corev1.Volume, 0)
+	volumeMounts := make([]corev1.VolumeMount, 0)
+
+	if task.Registry.Secret != "" {
+		secret, err := getRegistrySecret(ctx, c, build.Namespace, task.Registry.Secret, buildKitRegistrySecrets)
+		if err != nil {
+			return err
+		}
+		addRegistrySecret(task.Registry.Secret, secret, &volumes, &volumeMounts, &env)
+	}
+
+	env = append(env, proxySecretEnvVars(task.HttpProxySecret)...)
+
+	user := buildKitRootlessUser
+	securityContext := &corev1.SecurityContext{
+		RunAsUser:  &user,
+		RunAsGroup: &user,
+	}
+
+	if task.Address == "" {
+		// The rootless daemon cannot mount the process sandbox filesystems, and requires
+		// the system calls and the mounts that are denied by the default profiles
+		env = append(env, corev1.EnvVar{
+			Name:  "BUILDKITD_FLAGS",
+			Value: "--oci-worker-no-process-sandbox",
+		})
+		securityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeUnconfined,
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations["container.apparmor.security.beta.kubernetes.io/"+task.Name] = "unconfined"
+
+		volumes = append(volumes, corev1.Volume{
+			Name: "buildkit-state",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "buildkit-state",
+			MountPath: buildKitStateDir,
+		})
+	}
+
+	container := corev1.Container{
+		Name:            task.Name,
+		Image:           fmt.Sprintf("moby/buildkit:v%s-rootless", defaults.BuildKitVersion),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"/bin/sh", "-c"},
+		Args:            []string{strings.Join(buildctl, " ") + " && " + digest},
+		Env:             env,
+		WorkingDir:      contextDir,
+		VolumeMounts:    volumeMounts,
+		SecurityContext: securityContext,
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+
+	addContainerToPod(build, container, pod)
+
+	return nil
+}
+
 func addContainerToPod(build *v1.Build, container corev1.Container, pod *corev1.Pod) {
 	if hasBuilderVolume(pod) {
 		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
@@ -467,8 +732,12 @@ func addContainerToPod(build *v1.Build, container corev1.Container, pod *corev1.
 }
 
 func hasBuilderVolume(pod *corev1.Pod) bool {
+	return hasVolume(pod, builderVolume)
+}
+
+func hasVolume(pod *corev1.Pod, name string) bool {
 	for _, volume := range pod.Spec.Volumes {
-		if volume.Name == builderVolume {
+		if volume.Name == name {
 			return true
 		}
 	}
@@ -558,6 +827,20 @@ func addRegistrySecret(name string, secret registrySecret, volumes *[]corev1.Vol
 	}
 }
 
+// addProxyToContainer sets the proxy environment variables of the container, overriding
+// the ones from the HTTP proxy secret, if any
+func addProxyToContainer(build *v1.Build, container *corev1.Container) {
+	if build.Spec.HTTPProxy != "" {
+		envvar.SetVal(&container.Env, "HTTP_PROXY", build.Spec.HTTPProxy)
+	}
+	if build.Spec.HTTPSProxy != "" {
+		envvar.SetVal(&container.Env, "HTTPS_PROXY", build.Spec.HTTPSProxy)
+	}
+	if build.Spec.NoProxy != "" {
+		envvar.SetVal(&container.Env, "NO_PROXY", build.Spec.NoProxy)
+	}
+}
+
 func proxySecretEnvVars(secret string) []corev1.EnvVar {
 	if secret == "" {
 		return []corev1.EnvVar{}
@@ -0,0 +1,213 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestAddMavenCacheToPod(t *testing.T) {
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{Name: "builder"},
+						Maven: v1.MavenSpec{
+							LocalRepository: "/tmp/artifacts/m2",
+							Cache: &v1.MavenCacheSpec{
+								PersistentVolumeClaim: "maven-cache",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	pod := &corev1.Pod{}
+
+	for i := 0; i < 2; i++ {
+		container := corev1.Container{}
+		addMavenCacheToPod(build, &container, pod)
+		assert.Equal(t, []corev1.VolumeMount{{Name: mavenCacheVolume, MountPath: "/tmp/artifacts/m2"}}, container.VolumeMounts)
+	}
+
+	assert.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, mavenCacheVolume, pod.Spec.Volumes[0].Name)
+	assert.Equal(t, "maven-cache", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+}
+
+func TestAddMavenCacheToPodWithoutPersistentVolumeClaim(t *testing.T) {
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{Name: "builder"},
+						Maven: v1.MavenSpec{
+							LocalRepository: "/tmp/artifacts/m2",
+						},
+					},
+				},
+			},
+		},
+	}
+	pod := &corev1.Pod{}
+	container := corev1.Container{}
+
+	addMavenCacheToPod(build, &container, pod)
+
+	assert.Empty(t, container.VolumeMounts)
+	assert.Empty(t, pod.Spec.Volumes)
+}
+
+func TestAddBuildKitTaskToPod(t *testing.T) {
+	build := &v1.Build{}
+	build.Name = "my-build"
+	task := &v1.BuildKitTask{
+		BaseTask: v1.BaseTask{Name: "buildkit"},
+		PublishTask: v1.PublishTask{
+			Image: "registry/ns/camel-k-kit:1",
+			Registry: v1.IntegrationPlatformRegistrySpec{
+				Insecure: true,
+			},
+		},
+		CacheImage: "registry/ns/camel-k-buildkit-cache",
+	}
+	pod := &corev1.Pod{}
+
+	err := addBuildKitTaskToPod(context.TODO(), nil, build, task, pod)
+
+	assert.Nil(t, err)
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	container := pod.Spec.InitContainers[0]
+	script := container.Args[0]
+	assert.True(t, strings.HasPrefix(script, "buildctl-daemonless.sh build"))
+	assert.Contains(t, script, "--output=type=image,name=registry/ns/camel-k-kit:1,push=true,registry.insecure=true")
+	assert.Contains(t, script, "--export-cache=type=registry,ref=registry/ns/camel-k-buildkit-cache,mode=max")
+	assert.Contains(t, script, "--import-cache=type=registry,ref=registry/ns/camel-k-buildkit-cache")
+	assert.NotContains(t, script, "platform")
+	assert.Equal(t, corev1.SeccompProfileTypeUnconfined, container.SecurityContext.SeccompProfile.Type)
+	assert.Equal(t, "unconfined", pod.Annotations["container.apparmor.security.beta.kubernetes.io/buildkit"])
+	assert.Len(t, pod.Spec.Volumes, 1)
+}
+
+func TestAddBuildKitTaskToPodWithAddress(t *testing.T) {
+	build := &v1.Build{}
+	build.Name = "my-build"
+	task := &v1.BuildKitTask{
+		BaseTask: v1.BaseTask{Name: "buildkit"},
+		PublishTask: v1.PublishTask{
+			Image:     "registry/ns/camel-k-kit:1",
+			Platforms: []string{"linux/amd64", "linux/arm64"},
+		},
+		Address: "tcp://buildkitd:1234",
+	}
+	pod := &corev1.Pod{}
+
+	err := addBuildKitTaskToPod(context.TODO(), nil, build, task, pod)
+
+	assert.Nil(t, err)
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	container := pod.Spec.InitContainers[0]
+	script := container.Args[0]
+	assert.True(t, strings.HasPrefix(script, "buildctl --addr tcp://buildkitd:1234 build"))
+	assert.Contains(t, script, "--opt=platform=linux/amd64,linux/arm64")
+	assert.NotContains(t, script, "cache")
+	assert.Nil(t, container.SecurityContext.SeccompProfile)
+	assert.Empty(t, pod.Annotations)
+	assert.Empty(t, pod.Spec.Volumes)
+}
+
+func TestAddCustomTaskToPod(t *testing.T) {
+	build := &v1.Build{}
+	build.Name = "my-build"
+	build.Spec.Resources = &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	task := &v1.CustomTask{
+		BaseTask: v1.BaseTask{Name: "sign"},
+		Image:    "quay.io/example/signer:1.0",
+		Command:  "sign-jars context/dependencies",
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: builderVolume,
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+
+	addCustomTaskToPod(build, task, pod)
+
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	container := pod.Spec.InitContainers[0]
+	assert.Equal(t, "sign", container.Name)
+	assert.Equal(t, "quay.io/example/signer:1.0", container.Image)
+	assert.Equal(t, []string{"/bin/sh", "-c", "sign-jars context/dependencies"}, container.Command)
+	assert.Equal(t, "/builder/my-build", container.WorkingDir)
+	assert.Equal(t, *build.Spec.Resources, container.Resources)
+	assert.Len(t, container.VolumeMounts, 1)
+	assert.Equal(t, builderVolume, container.VolumeMounts[0].Name)
+	assert.Equal(t, "/builder/my-build", container.VolumeMounts[0].MountPath)
+}
+
+func TestNewBuildPodWithProxy(t *testing.T) {
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Custom: &v1.CustomTask{
+						BaseTask: v1.BaseTask{Name: "scan"},
+						Image:    "quay.io/example/scanner:1.0",
+						Command:  "scan context",
+					},
+				},
+			},
+			HTTPProxy: "http://proxy.example.com:3128",
+			NoProxy:   ".cluster.local,.svc",
+		},
+	}
+	build.Name = "my-build"
+
+	pod, err := newBuildPod(context.TODO(), nil, build)
+
+	assert.Nil(t, err)
+	assert.Len(t, pod.Spec.Containers, 1)
+	env := pod.Spec.Containers[0].Env
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "NO_PROXY", Value: ".cluster.local,.svc"},
+	}, env)
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/registry"
+)
+
+// imageDigestTimeout bounds the time spent resolving the image digest from the registry, so that an unresponsive
+// registry does not block the build reconciliation
+const imageDigestTimeout = 30 * time.Second
+
+// resolveImageDigest resolves the digest of the published image from the registry, when the publish strategy
+// has not reported it, so that the kit image is addressed by digest rather than by a mutable tag
+func resolveImageDigest(ctx context.Context, c ctrl.Reader, build *v1.Build, status *v1.BuildStatus) error {
+	if status.Digest != "" || status.Image == "" || strings.Contains(status.Image, "@") {
+		return nil
+	}
+
+	publish := getPublishTask(build)
+	if publish == nil {
+		// The image is not published to a registry the operator can access, e.g. with S2I
+		return nil
+	}
+
+	var config []byte
+	if publish.Registry.Secret != "" {
+		secret := corev1.Secret{}
+		err := c.Get(ctx, ctrl.ObjectKey{Namespace: build.Namespace, Name: publish.Registry.Secret}, &secret)
+		if err != nil {
+			return err
+		}
+		if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+			config = data
+		} else if data, ok := secret.Data["config.json"]; ok {
+			config = data
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, imageDigestTimeout)
+	defer cancel()
+
+	digest, err := registry.ResolveDigest(ctx, status.Image, config, publish.Registry.Insecure)
+	if err != nil {
+		return err
+	}
+	status.Digest = digest
+
+	return nil
+}
+
+func getPublishTask(build *v1.Build) *v1.PublishTask {
+	for _, task := range build.Spec.Tasks {
+		switch {
+		case task.Buildah != nil:
+			return &task.Buildah.PublishTask
+		case task.Kaniko != nil:
+			return &task.Kaniko.PublishTask
+		case task.BuildKit != nil:
+			return &task.BuildKit.PublishTask
+		case task.Spectrum != nil:
+			return &task.Spectrum.PublishTask
+		case task.Jib != nil:
+			return &task.Jib.PublishTask
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestResolveImageDigest(t *testing.T) {
+	server := httptest.NewServer(containerregistry.New())
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	image := address + "/camel-k/camel-k-kit-123:1"
+	ref, err := name.ParseReference(image, name.Insecure)
+	assert.Nil(t, err)
+	img, err := random.Image(1024, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, remote.Write(ref, img))
+	expected, err := img.Digest()
+	assert.Nil(t, err)
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{Name: "builder"},
+					},
+				},
+				{
+					Kaniko: &v1.KanikoTask{
+						BaseTask: v1.BaseTask{Name: "kaniko"},
+						PublishTask: v1.PublishTask{
+							Image: image,
+							Registry: v1.IntegrationPlatformRegistrySpec{
+								Address:  address,
+								Insecure: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	status := v1.BuildStatus{Image: image}
+	assert.Nil(t, resolveImageDigest(context.TODO(), c, build, &status))
+	assert.Equal(t, expected.String(), status.Digest)
+
+	// The digest reported by the publish strategy is kept
+	status = v1.BuildStatus{Image: image, Digest: "sha256:reported"}
+	assert.Nil(t, resolveImageDigest(context.TODO(), c, build, &status))
+	assert.Equal(t, "sha256:reported", status.Digest)
+}
+
+func TestGetPublishTaskJib(t *testing.T) {
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{Name: "builder"},
+					},
+				},
+				{
+					Jib: &v1.JibTask{
+						BaseTask: v1.BaseTask{Name: "jib"},
+						PublishTask: v1.PublishTask{
+							Image: "registry/camel-k/camel-k-kit-123:1",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	publish := getPublishTask(build)
+	assert.NotNil(t, publish)
+	assert.Equal(t, "registry/camel-k/camel-k-kit-123:1", publish.Image)
+}
@@ -21,9 +21,11 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/client-go/kubernetes/scheme"
@@ -39,6 +41,10 @@ import (
 
 const timeoutAnnotation = "camel.apache.org/timeout"
 
+// timeoutGracePeriod is the duration the build pod is given to terminate, once the termination
+// signal has been sent, before it gets deleted
+const timeoutGracePeriod = 1 * time.Minute
+
 func newMonitorPodAction() Action {
 	return &monitorPodAction{}
 }
@@ -94,7 +100,22 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1.Build) (*v
 		if action.isPodScheduled(pod) {
 			build.Status.Phase = v1.BuildPhaseRunning
 		}
-		if time.Now().Sub(build.Status.StartedAt.Time) > build.Spec.Timeout.Duration {
+		if elapsed := time.Now().Sub(build.Status.StartedAt.Time); elapsed > build.Spec.Timeout.Duration {
+			if !action.isPodScheduled(pod) || elapsed > build.Spec.Timeout.Duration+timeoutGracePeriod {
+				// The Pod has no running containers to signal, or has not terminated
+				// within the grace period, so it's deleted to release the resources
+				if err = action.client.Delete(ctx, pod, ctrl.GracePeriodSeconds(0)); err != nil && !k8serrors.IsNotFound(err) {
+					return nil, errors.Wrap(err, "cannot delete timed out build pod")
+				}
+				build.Status.TimedOut(build.Spec.Timeout)
+				duration := time.Now().Sub(build.Status.StartedAt.Time)
+				build.Status.Duration = duration.String()
+
+				// Account for the Build metrics
+				observeBuildResult(build, build.Status.Phase, duration)
+
+				return build, nil
+			}
 			// Patch the Pod with an annotation, to identify termination signal
 			// has been sent because the Build has timed out
 			if err = action.addTimeoutAnnotation(ctx, pod, metav1.Now()); err != nil {
@@ -121,22 +142,20 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1.Build) (*v
 		// Account for the Build metrics
 		observeBuildResult(build, build.Status.Phase, duration)
 
-		for _, task := range build.Spec.Tasks {
-			if t := task.Buildah; t != nil {
-				build.Status.Image = t.Image
-				break
-			} else if t := task.Kaniko; t != nil {
-				build.Status.Image = t.Image
-				break
-			}
+		if publish := getPublishTask(build); publish != nil {
+			build.Status.Image = publish.Image
 		}
 		// Reconcile image digest from build container status if available
 		for _, container := range pod.Status.ContainerStatuses {
-			if container.Name == "buildah" {
-				build.Status.Digest = container.State.Terminated.Message
+			if container.Name == "buildah" || container.Name == "kaniko" || container.Name == "buildkit" {
+				build.Status.Digest = strings.TrimSpace(container.State.Terminated.Message)
 				break
 			}
 		}
+		// Otherwise resolve it from the registry
+		if err := resolveImageDigest(ctx, action.client, build, &build.Status); err != nil {
+			action.L.Errorf(err, "Cannot resolve the digest of image %s, the kit image is addressed by tag", build.Status.Image)
+		}
 
 	case corev1.PodFailed:
 		phase := v1.BuildPhaseFailed
@@ -147,8 +166,6 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1.Build) (*v
 		if pod.DeletionTimestamp != nil {
 			phase = v1.BuildPhaseInterrupted
 			message = "Pod deleted"
-		} else if _, ok := pod.GetAnnotations()[timeoutAnnotation]; ok {
-			message = "Build timeout"
 		}
 		// Do not override errored build
 		if build.Status.Phase == v1.BuildPhaseError {
@@ -156,6 +173,9 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1.Build) (*v
 		}
 		build.Status.Phase = phase
 		build.Status.Error = message
+		if _, ok := pod.GetAnnotations()[timeoutAnnotation]; ok && phase == v1.BuildPhaseFailed {
+			build.Status.TimedOut(build.Spec.Timeout)
+		}
 		finishedAt := action.getTerminatedTime(pod)
 		duration := finishedAt.Sub(build.Status.StartedAt.Time)
 		build.Status.Duration = duration.String()
@@ -107,11 +107,11 @@ tasks:
 			if ctxWithTimeout.Err() == context.Canceled {
 				// Context canceled
 				status.Phase = v1.BuildPhaseInterrupted
+				status.Error = ctxWithTimeout.Err().Error()
 			} else {
 				// Context timeout
-				status.Phase = v1.BuildPhaseFailed
+				status.TimedOut(build.Spec.Timeout)
 			}
-			status.Error = ctxWithTimeout.Err().Error()
 			break tasks
 
 		default:
@@ -128,12 +128,24 @@ tasks:
 					defer os.RemoveAll(tmpDir)
 				}
 				buildDir = t.BuildDir
+			} else if t := task.Native; t != nil && t.BuildDir == "" {
+				if buildDir == "" {
+					status.Failed(fmt.Errorf("cannot determine build directory for task %s", t.Name))
+					break tasks
+				}
+				t.BuildDir = buildDir
 			} else if t := task.Spectrum; t != nil && t.ContextDir == "" {
 				if buildDir == "" {
 					status.Failed(fmt.Errorf("cannot determine context directory for task %s", t.Name))
 					break tasks
 				}
 				t.ContextDir = path.Join(buildDir, builder.ContextDir)
+			} else if t := task.Jib; t != nil && t.ContextDir == "" {
+				if buildDir == "" {
+					status.Failed(fmt.Errorf("cannot determine context directory for task %s", t.Name))
+					break tasks
+				}
+				t.ContextDir = path.Join(buildDir, builder.ContextDir)
 			} else if t := task.S2i; t != nil && t.ContextDir == "" {
 				if buildDir == "" {
 					status.Failed(fmt.Errorf("cannot determine context directory for task %s", t.Name))
@@ -151,6 +163,9 @@ tasks:
 				status.Phase == v1.BuildPhaseInterrupted
 			if lastTask && !taskFailed {
 				status.Phase = v1.BuildPhaseSucceeded
+				if err := resolveImageDigest(ctx, action.client, build, &status); err != nil {
+					action.L.Errorf(err, "Cannot resolve the digest of image %s, the kit image is addressed by tag", status.Image)
+				}
 			}
 
 			if lastTask || taskFailed {
@@ -167,6 +182,11 @@ tasks:
 		}
 	}
 
+	if ctxWithTimeout.Err() == context.DeadlineExceeded && status.Phase == v1.BuildPhaseFailed {
+		// The running task, e.g. the Maven process, has been cancelled when the deadline exceeded
+		status.TimedOut(build.Spec.Timeout)
+	}
+
 	duration := metav1.Now().Sub(build.Status.StartedAt.Time)
 	status.Duration = duration.String()
 	// Account for the Build metrics
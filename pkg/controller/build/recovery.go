@@ -19,13 +19,16 @@ package build
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/jpillora/backoff"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/maven"
 )
 
 func newErrorRecoveryAction() Action {
@@ -40,6 +43,17 @@ func newErrorRecoveryAction() Action {
 	}
 }
 
+// transientFailurePatterns match the errors of failed builds that are worth retrying
+var transientFailurePatterns = []*regexp.Regexp{
+	// Network errors
+	regexp.MustCompile(`(?i)(connection (reset|refused|timed out)|i/o timeout|tls handshake timeout|unexpected eof|no such host|temporary failure in name resolution|read timed out)`),
+	// Maven artifacts download errors
+	regexp.MustCompile(`(?i)(could not transfer artifact|transfer failed for)`),
+	// Registry and repository server errors
+	regexp.MustCompile(`(?i)(internal server error|bad gateway|service unavailable|gateway time-?out|too many requests)`),
+	regexp.MustCompile(`(?i)(status( code)?|http)[ :]*(429|5[0-9]{2})\b`),
+}
+
 type errorRecoveryAction struct {
 	baseAction
 	backOff backoff.Backoff
@@ -60,37 +74,80 @@ func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1.Build)
 			Time:   metav1.Now(),
 			Recovery: v1.FailureRecovery{
 				Attempt:    0,
-				AttemptMax: 5,
+				AttemptMax: build.Spec.GetMaxRetries(),
 			},
 		}
+	}
+
+	recovery := &build.Status.Failure.Recovery
+	if len(recovery.History) <= recovery.Attempt {
+		// Record the failed attempt
+		recovery.History = append(recovery.History, v1.FailureRecoveryAttempt{
+			Attempt: recovery.Attempt,
+			Reason:  build.Status.Error,
+			Time:    metav1.Now(),
+		})
 		return build, nil
 	}
 
-	if build.Status.Failure.Recovery.Attempt >= build.Status.Failure.Recovery.AttemptMax {
+	if artifact, ok := maven.MissingOfflineArtifact(build.Status.Error); ok {
+		// Retrying cannot succeed until the artifact is added to the local repository
+		action.L.Infof("Offline build failed with missing artifact: %s", artifact)
+		build.Status = build.Status.ArtifactMissing(artifact)
+		return build, nil
+	}
+
+	if !isTransientFailure(build) {
+		action.L.Infof("Build failure is not recoverable: %s", build.Status.Error)
 		build.Status.Phase = v1.BuildPhaseError
 		return build, nil
 	}
 
-	lastAttempt := build.Status.Failure.Recovery.AttemptTime.Time
+	if recovery.Attempt >= recovery.AttemptMax {
+		build.Status.Phase = v1.BuildPhaseError
+		return build, nil
+	}
+
+	lastAttempt := recovery.AttemptTime.Time
 	if lastAttempt.IsZero() {
 		lastAttempt = build.Status.Failure.Time.Time
 	}
 
 	elapsed := time.Since(lastAttempt).Seconds()
-	elapsedMin := action.backOff.ForAttempt(float64(build.Status.Failure.Recovery.Attempt)).Seconds()
+	elapsedMin := action.backOff.ForAttempt(float64(recovery.Attempt)).Seconds()
 
 	if elapsed < elapsedMin {
 		return nil, nil
 	}
 
 	build.Status.Phase = v1.BuildPhaseInitialization
-	build.Status.Failure.Recovery.Attempt++
-	build.Status.Failure.Recovery.AttemptTime = metav1.Now()
+	build.Status.RemoveCondition(v1.BuildConditionTimedOut)
+	recovery.Attempt++
+	recovery.AttemptTime = metav1.Now()
 
 	action.L.Infof("Recovery attempt (%d/%d)",
-		build.Status.Failure.Recovery.Attempt,
-		build.Status.Failure.Recovery.AttemptMax,
+		recovery.Attempt,
+		recovery.AttemptMax,
 	)
 
 	return build, nil
 }
+
+// isTransientFailure returns whether the build has failed with an error that may not occur when
+// the build is retried, e.g. a network error or a registry or Maven repository server error
+func isTransientFailure(build *v1.Build) bool {
+	if condition := build.Status.GetCondition(v1.BuildConditionTimedOut); condition != nil && condition.Status == corev1.ConditionTrue {
+		// Retrying would most likely exceed the deadline again
+		return false
+	}
+	if build.Status.Error == "" || build.Status.Error == "Pod failed" {
+		// The cause of the failure is unknown
+		return true
+	}
+	for _, pattern := range transientFailurePatterns {
+		if pattern.MatchString(build.Status.Error) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+func TestTransientFailure(t *testing.T) {
+	transient := []string{
+		"",
+		"Pod failed",
+		"Failed to execute goal on project camel-k-integration: Could not resolve dependencies: Could not transfer artifact org.apache.camel:camel-core:jar:3.11.1 from/to central: Connection reset: exit status 1",
+		"error pushing image: received unexpected HTTP status: 503 Service Unavailable",
+		"Get \"https://registry/v2/\": net/http: TLS handshake timeout",
+		"unexpected status code 502",
+	}
+	for _, message := range transient {
+		build := &v1.Build{Status: v1.BuildStatus{Phase: v1.BuildPhaseFailed, Error: message}}
+		assert.True(t, isTransientFailure(build), message)
+	}
+
+	permanent := []string{
+		"Failed to execute goal org.apache.maven.plugins:maven-compiler-plugin:3.8.1:compile: Compilation failure: exit status 1",
+		"unable to find the base image: UNAUTHORIZED: authentication required",
+	}
+	for _, message := range permanent {
+		build := &v1.Build{Status: v1.BuildStatus{Phase: v1.BuildPhaseFailed, Error: message}}
+		assert.False(t, isTransientFailure(build), message)
+	}
+
+	build := &v1.Build{Status: v1.BuildStatus{Phase: v1.BuildPhaseFailed}}
+	build.Status.TimedOut(metav1.Duration{Duration: time.Minute})
+	assert.False(t, isTransientFailure(build))
+}
+
+func TestErrorRecoveryRetriesTransientFailure(t *testing.T) {
+	action := newErrorRecoveryAction()
+	action.InjectLogger(log.Log)
+
+	maxRetries := 2
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			MaxRetries: &maxRetries,
+		},
+		Status: v1.BuildStatus{
+			Phase: v1.BuildPhaseFailed,
+			Error: "service unavailable",
+		},
+	}
+
+	// The first failure is recorded
+	build, err := action.Handle(context.TODO(), build)
+	assert.Nil(t, err)
+	assert.Equal(t, v1.BuildPhaseFailed, build.Status.Phase)
+	assert.Equal(t, 2, build.Status.Failure.Recovery.AttemptMax)
+	assert.Len(t, build.Status.Failure.Recovery.History, 1)
+	assert.Equal(t, "service unavailable", build.Status.Failure.Recovery.History[0].Reason)
+
+	// The build is retried once the backoff delay has elapsed
+	build.Status.Failure.Time = metav1.NewTime(time.Now().Add(-time.Minute))
+	build, err = action.Handle(context.TODO(), build)
+	assert.Nil(t, err)
+	assert.Equal(t, v1.BuildPhaseInitialization, build.Status.Phase)
+	assert.Equal(t, 1, build.Status.Failure.Recovery.Attempt)
+
+	// The second failure is recorded as well
+	build.Status.Phase = v1.BuildPhaseFailed
+	build.Status.Error = "connection refused"
+	build, err = action.Handle(context.TODO(), build)
+	assert.Nil(t, err)
+	assert.Len(t, build.Status.Failure.Recovery.History, 2)
+	assert.Equal(t, 1, build.Status.Failure.Recovery.History[1].Attempt)
+	assert.Equal(t, "connection refused", build.Status.Failure.Recovery.History[1].Reason)
+}
+
+func TestErrorRecoveryDoesNotRetryPermanentFailure(t *testing.T) {
+	action := newErrorRecoveryAction()
+	action.InjectLogger(log.Log)
+
+	build := &v1.Build{
+		Status: v1.BuildStatus{
+			Phase: v1.BuildPhaseFailed,
+			Error: "Compilation failure",
+		},
+	}
+
+	build, err := action.Handle(context.TODO(), build)
+	assert.Nil(t, err)
+	assert.Equal(t, v1.BuildDefaultMaxRetries, build.Status.Failure.Recovery.AttemptMax)
+
+	build, err = action.Handle(context.TODO(), build)
+	assert.Nil(t, err)
+	assert.Equal(t, v1.BuildPhaseError, build.Status.Phase)
+	assert.Equal(t, 0, build.Status.Failure.Recovery.Attempt)
+	assert.Len(t, build.Status.Failure.Recovery.History, 1)
+	assert.Nil(t, build.Status.GetCondition(v1.BuildConditionTimedOut))
+}
+
+func TestErrorRecoveryFailsFastOnOfflineMissingArtifact(t *testing.T) {
+	action := newErrorRecoveryAction()
+	action.InjectLogger(log.Log)
+
+	build := &v1.Build{
+		Status: v1.BuildStatus{
+			Phase: v1.BuildPhaseFailed,
+			Error: "Failed to execute goal on project camel-k-integration: Could not resolve dependencies for project org.apache.camel.k.integration:camel-k-integration:jar:1.6.0: " +
+				"Cannot access central (https://repo.maven.apache.org/maven2) in offline mode and the artifact org.apache.camel:camel-core:jar:3.11.1 has not been downloaded from it before.: exit status 1",
+		},
+	}
+
+	build, err := action.Handle(context.TODO(), build)
+	assert.Nil(t, err)
+
+	build, err = action.Handle(context.TODO(), build)
+	assert.Nil(t, err)
+	assert.Equal(t, v1.BuildPhaseError, build.Status.Phase)
+	assert.Equal(t, 0, build.Status.Failure.Recovery.Attempt)
+
+	condition := build.Status.GetCondition(v1.BuildConditionArtifactMissing)
+	assert.NotNil(t, condition)
+	assert.Equal(t, v1.BuildConditionArtifactMissingReason, condition.Reason)
+	assert.Contains(t, condition.Message, "org.apache.camel:camel-core:jar:3.11.1")
+}
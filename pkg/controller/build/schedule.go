@@ -19,6 +19,7 @@ package build
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -28,8 +29,17 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/event"
+	"github.com/apache/camel-k/pkg/platform"
 )
 
+// defaultMaxRunningBuildsPerNamespace is the maximum number of Builds running concurrently in a namespace,
+// when not configured by the IntegrationPlatform.
+// This is currently necessary for the incremental build to work as expected.
+const defaultMaxRunningBuildsPerNamespace = 1
+
+// schedulingLock serializes the scheduling of the Builds across the concurrent reconciliations
+var schedulingLock sync.Mutex
+
 func newScheduleAction(reader ctrl.Reader) Action {
 	return &scheduleAction{
 		reader: reader,
@@ -38,7 +48,6 @@ func newScheduleAction(reader ctrl.Reader) Action {
 
 type scheduleAction struct {
 	baseAction
-	lock   sync.Mutex
 	reader ctrl.Reader
 }
 
@@ -55,26 +64,44 @@ func (action *scheduleAction) CanHandle(build *v1.Build) bool {
 // Handle handles the builds
 func (action *scheduleAction) Handle(ctx context.Context, build *v1.Build) (*v1.Build, error) {
 	// Enter critical section
-	action.lock.Lock()
-	defer action.lock.Unlock()
+	schedulingLock.Lock()
+	defer schedulingLock.Unlock()
 
 	builds := &v1.BuildList{}
 	// We use the non-caching client as informers cache is not invalidated nor updated
 	// atomically by write operations
-	err := action.reader.List(ctx, builds, ctrl.InNamespace(build.Namespace))
+	err := action.reader.List(ctx, builds, ctrl.InNamespace(platform.GetOperatorWatchNamespace()))
 	if err != nil {
 		return nil, err
 	}
 
-	// Emulate a serialized working queue to only allow one build to run at a given time.
-	// This is currently necessary for the incremental build to work as expected.
-	// We may want to explicitly manage build priority as opposed to relying on
-	// the reconcile loop to handle the queuing
+	// Only account for the Builds reconciled by the same operator
+	operatorID := v1.GetOperatorIDAnnotation(build)
+	candidates := make([]v1.Build, 0, len(builds.Items))
+	maxPerNamespace := make(map[string]int)
 	for _, b := range builds.Items {
-		if b.Status.Phase == v1.BuildPhasePending || b.Status.Phase == v1.BuildPhaseRunning {
-			// Let's requeue the build in case one is already running
-			return nil, nil
+		if v1.GetOperatorIDAnnotation(&b) != operatorID {
+			continue
+		}
+		candidates = append(candidates, b)
+		if _, ok := maxPerNamespace[b.Namespace]; !ok {
+			maxPerNamespace[b.Namespace] = action.getMaxRunningBuilds(ctx, &b)
+		}
+	}
+
+	scheduled, position := scheduleBuild(build, candidates, maxPerNamespace, platform.GetOperatorMaxRunningBuilds())
+	if !scheduled {
+		if position > 0 && position != build.Status.QueuePosition {
+			// Report the position of the Build in the build queue
+			err = action.patchBuildStatus(ctx, build, func(b *v1.Build) {
+				b.Status.QueuePosition = position
+			})
+			if err != nil {
+				return nil, err
+			}
 		}
+		// Let's requeue the build until it's its turn
+		return nil, nil
 	}
 
 	// Reset the Build status, and transition it to pending phase.
@@ -99,6 +126,76 @@ func (action *scheduleAction) Handle(ctx context.Context, build *v1.Build) (*v1.
 	return nil, nil
 }
 
+// getMaxRunningBuilds returns the maximum number of Builds running concurrently in the namespace of the Build,
+// as configured by its IntegrationPlatform
+func (action *scheduleAction) getMaxRunningBuilds(ctx context.Context, build *v1.Build) int {
+	pl, err := platform.GetOrFind(ctx, action.client, build.Namespace, build.Status.Platform, true)
+	if err != nil || pl == nil || pl.Status.Build.MaxRunningBuilds <= 0 {
+		return defaultMaxRunningBuildsPerNamespace
+	}
+	return int(pl.Status.Build.MaxRunningBuilds)
+}
+
+// scheduleBuild returns whether the build can start, given the other builds and the maximum number
+// of builds running concurrently per namespace, and overall when max is positive. Otherwise, it returns
+// the position of the build in the build queue.
+//
+// The queued builds are ordered by priority, then by the number of builds running in their namespace,
+// so that the namespaces get a fair share of the overall capacity, and then in FIFO order. The builds
+// are started in that order, as long as the running builds do not exceed the limits.
+func scheduleBuild(build *v1.Build, builds []v1.Build, maxPerNamespace map[string]int, max int) (bool, int) {
+	running := make(map[string]int)
+	total := 0
+	queue := make([]v1.Build, 0)
+	for _, b := range builds {
+		switch b.Status.Phase {
+		case v1.BuildPhasePending, v1.BuildPhaseRunning:
+			running[b.Namespace]++
+			total++
+		case v1.BuildPhaseScheduling:
+			queue = append(queue, b)
+		}
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		if pi, pj := queue[i].GetPriority(), queue[j].GetPriority(); pi != pj {
+			return pi > pj
+		}
+		if ri, rj := running[queue[i].Namespace], running[queue[j].Namespace]; ri != rj {
+			return ri < rj
+		}
+		if ti, tj := getBuildQueuingTime(&queue[i]), getBuildQueuingTime(&queue[j]); !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		if queue[i].Namespace != queue[j].Namespace {
+			return queue[i].Namespace < queue[j].Namespace
+		}
+		return queue[i].Name < queue[j].Name
+	})
+
+	for i := range queue {
+		b := &queue[i]
+		current := b.Namespace == build.Namespace && b.Name == build.Name
+		limit, ok := maxPerNamespace[b.Namespace]
+		if !ok {
+			limit = defaultMaxRunningBuildsPerNamespace
+		}
+		if running[b.Namespace] < limit && (max <= 0 || total < max) {
+			if current {
+				return true, 0
+			}
+			// The build ahead in the queue is going to be started first
+			running[b.Namespace]++
+			total++
+		} else if current {
+			return false, i + 1
+		}
+	}
+
+	// The build is not waiting to be scheduled anymore
+	return false, 0
+}
+
 func (action *scheduleAction) patchBuildStatus(ctx context.Context, build *v1.Build, mutate func(b *v1.Build)) error {
 	target := build.DeepCopy()
 	mutate(target)
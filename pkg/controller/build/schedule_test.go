@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func newTestBuild(namespace string, name string, phase v1.BuildPhase, created time.Time) v1.Build {
+	build := v1.NewBuild(namespace, name)
+	build.CreationTimestamp = metav1.NewTime(created)
+	build.Status.Phase = phase
+	return build
+}
+
+func TestScheduleBuildFIFO(t *testing.T) {
+	now := time.Now()
+	first := newTestBuild("ns", "first", v1.BuildPhaseScheduling, now.Add(-2*time.Minute))
+	second := newTestBuild("ns", "second", v1.BuildPhaseScheduling, now.Add(-time.Minute))
+	builds := []v1.Build{second, first}
+
+	scheduled, _ := scheduleBuild(&first, builds, nil, 0)
+	assert.True(t, scheduled)
+
+	scheduled, position := scheduleBuild(&second, builds, nil, 0)
+	assert.False(t, scheduled)
+	assert.Equal(t, 2, position)
+}
+
+func TestScheduleBuildPerNamespaceLimit(t *testing.T) {
+	now := time.Now()
+	running := newTestBuild("ns", "running", v1.BuildPhaseRunning, now.Add(-2*time.Minute))
+	queued := newTestBuild("ns", "queued", v1.BuildPhaseScheduling, now.Add(-time.Minute))
+	other := newTestBuild("other", "queued", v1.BuildPhaseScheduling, now)
+	builds := []v1.Build{running, queued, other}
+
+	scheduled, position := scheduleBuild(&queued, builds, nil, 0)
+	assert.False(t, scheduled)
+	assert.Equal(t, 2, position)
+
+	// Builds in other namespaces are not blocked
+	scheduled, _ = scheduleBuild(&other, builds, nil, 0)
+	assert.True(t, scheduled)
+
+	scheduled, _ = scheduleBuild(&queued, builds, map[string]int{"ns": 2}, 0)
+	assert.True(t, scheduled)
+}
+
+func TestScheduleBuildOverallLimit(t *testing.T) {
+	now := time.Now()
+	running := newTestBuild("ns1", "running", v1.BuildPhasePending, now.Add(-3*time.Minute))
+	busy := newTestBuild("ns1", "queued", v1.BuildPhaseScheduling, now.Add(-2*time.Minute))
+	idle := newTestBuild("ns2", "queued", v1.BuildPhaseScheduling, now.Add(-time.Minute))
+	builds := []v1.Build{running, busy, idle}
+
+	scheduled, position := scheduleBuild(&idle, builds, map[string]int{"ns1": 2}, 1)
+	assert.False(t, scheduled)
+	assert.Equal(t, 1, position)
+
+	// The namespace with no running builds comes first
+	scheduled, _ = scheduleBuild(&idle, builds, map[string]int{"ns1": 2}, 2)
+	assert.True(t, scheduled)
+	scheduled, position = scheduleBuild(&busy, builds, map[string]int{"ns1": 2}, 2)
+	assert.False(t, scheduled)
+	assert.Equal(t, 2, position)
+}
+
+func TestScheduleBuildPriority(t *testing.T) {
+	now := time.Now()
+	first := newTestBuild("ns", "first", v1.BuildPhaseScheduling, now.Add(-time.Minute))
+	urgent := newTestBuild("ns", "urgent", v1.BuildPhaseScheduling, now)
+	urgent.Annotations = map[string]string{v1.BuildPriorityAnnotation: "10"}
+	builds := []v1.Build{first, urgent}
+
+	scheduled, _ := scheduleBuild(&urgent, builds, nil, 0)
+	assert.True(t, scheduled)
+
+	scheduled, position := scheduleBuild(&first, builds, nil, 0)
+	assert.False(t, scheduled)
+	assert.Equal(t, 2, position)
+}
+
+func TestScheduleBuildNotQueued(t *testing.T) {
+	build := newTestBuild("ns", "build", v1.BuildPhasePending, time.Now())
+
+	scheduled, position := scheduleBuild(&build, []v1.Build{build}, nil, 0)
+	assert.False(t, scheduled)
+	assert.Equal(t, 0, position)
+}
+
+func TestScheduleBuildSequentialNamespace(t *testing.T) {
+	now := time.Now()
+	running := newTestBuild("sequential", "running", v1.BuildPhaseRunning, now.Add(-2*time.Minute))
+	queued := newTestBuild("sequential", "queued", v1.BuildPhaseScheduling, now.Add(-time.Minute))
+	builds := []v1.Build{running, queued}
+
+	scheduled, _ := scheduleBuild(&queued, builds, map[string]int{"sequential": 1, "parallel": 3}, 0)
+	assert.False(t, scheduled)
+
+	running.Namespace = "parallel"
+	queued.Namespace = "parallel"
+	builds = []v1.Build{running, queued}
+	scheduled, _ = scheduleBuild(&queued, builds, map[string]int{"sequential": 1, "parallel": 3}, 0)
+	assert.True(t, scheduled)
+}
@@ -84,6 +84,20 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1.Integr
 		}
 
 		if kit.Status.Phase == v1.IntegrationKitPhaseError {
+			if kit.IsNative() {
+				// Fall back to the fast-jar kit, if the integration accepts it
+				fallback, err := action.fallbackToFastJarKit(ctx, integration)
+				if err != nil {
+					return nil, err
+				}
+				if fallback != nil {
+					action.L.Infof("Native integration kit %s failed, falling back to fast-jar integration kit %s", kit.Name, fallback.Name)
+					integration.SetIntegrationKit(fallback)
+
+					return integration, nil
+				}
+			}
+
 			integration.Status.Image = kit.Status.Image
 			integration.Status.Phase = v1.IntegrationPhaseError
 			integration.SetIntegrationKit(kit)
@@ -111,6 +125,31 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1.Integr
 		return nil, nil
 	}
 
+	packageTypes, err := trait.GetQuarkusPackageTypes(integration.Spec.Traits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a kit for each of the Quarkus package types, the kit corresponding to the
+	// first package type is set on the integration, while the others are built concurrently,
+	// so that the integration can switch to the native kit once it's ready.
+	var kits []*v1.IntegrationKit
+	for _, packageType := range packageTypes {
+		platformKit, err := action.createKit(ctx, integration, packageType)
+		if err != nil {
+			return nil, err
+		}
+		kits = append(kits, platformKit)
+	}
+
+	// Set the kit name so the next handle loop, will fall through the
+	// same path as integration with a user defined kit
+	integration.SetIntegrationKit(kits[0])
+
+	return integration, nil
+}
+
+func (action *buildKitAction) createKit(ctx context.Context, integration *v1.Integration, packageType string) (*v1.IntegrationKit, error) {
 	pl, err := platform.GetCurrent(ctx, action.client, integration.Namespace)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return nil, err
@@ -125,28 +164,61 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1.Integr
 		"camel.apache.org/kit.type":           v1.IntegrationKitTypePlatform,
 		"camel.apache.org/runtime.version":    integration.Status.RuntimeVersion,
 		"camel.apache.org/runtime.provider":   string(integration.Status.RuntimeProvider),
+		v1.IntegrationKitLayoutLabel:          packageType,
 		kubernetes.CamelCreatorLabelKind:      v1.IntegrationKind,
 		kubernetes.CamelCreatorLabelName:      integration.Name,
 		kubernetes.CamelCreatorLabelNamespace: integration.Namespace,
 		kubernetes.CamelCreatorLabelVersion:   integration.ResourceVersion,
 	}
 
+	// The kit is reconciled by the same operator as the integration
+	v1.SetOperatorIDAnnotation(&platformKit, v1.GetOperatorIDAnnotation(integration))
+
+	// The kit is built with the priority requested for the integration
+	if priority, ok := integration.Annotations[v1.BuildPriorityAnnotation]; ok {
+		if platformKit.Annotations == nil {
+			platformKit.Annotations = make(map[string]string)
+		}
+		platformKit.Annotations[v1.BuildPriorityAnnotation] = priority
+	}
+
+	// The kit only retains the package type it is built for
+	traits, err := trait.WithQuarkusPackageType(action.filterKitTraits(ctx, integration.Spec.Traits), packageType)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set the kit to have the same characteristics as the integrations
 	platformKit.Spec = v1.IntegrationKitSpec{
 		Dependencies: integration.Status.Dependencies,
 		Repositories: integration.Spec.Repositories,
-		Traits:       action.filterKitTraits(ctx, integration.Spec.Traits),
+		Traits:       traits,
 	}
 
 	if err := action.client.Create(ctx, &platformKit); err != nil {
 		return nil, err
 	}
 
-	// Set the kit name so the next handle loop, will fall through the
-	// same path as integration with a user defined kit
-	integration.SetIntegrationKit(&platformKit)
+	return &platformKit, nil
+}
 
-	return integration, nil
+// fallbackToFastJarKit returns the fast-jar kit for the integration, that is created if needed,
+// or nil if the integration does not accept the fast-jar package type
+func (action *buildKitAction) fallbackToFastJarKit(ctx context.Context, integration *v1.Integration) (*v1.IntegrationKit, error) {
+	packageTypes, err := trait.GetQuarkusPackageTypes(integration.Spec.Traits)
+	if err != nil {
+		return nil, err
+	}
+	if !util.StringSliceExists(packageTypes, v1.IntegrationKitLayoutFastJar) {
+		return nil, nil
+	}
+
+	kit, err := action.lookupKitForLayouts(ctx, action.client, integration, []string{v1.IntegrationKitLayoutFastJar})
+	if err != nil || kit != nil {
+		return kit, err
+	}
+
+	return action.createKit(ctx, integration, v1.IntegrationKitLayoutFastJar)
 }
 
 func (action *buildKitAction) filterKitTraits(ctx context.Context, in map[string]v1.TraitSpec) map[string]v1.TraitSpec {
@@ -176,6 +248,17 @@ func (action *buildKitAction) lookupKitForIntegration(ctx context.Context, c ctr
 		return kit, nil
 	}
 
+	packageTypes, err := trait.GetQuarkusPackageTypes(integration.Spec.Traits)
+	if err != nil {
+		return nil, err
+	}
+
+	return action.lookupKitForLayouts(ctx, c, integration, packageTypes)
+}
+
+// lookupKitForLayouts looks up a kit, with one of the given layouts, that is compatible with the integration.
+// Ready kits are preferred over kits that are still building, and native kits over fast-jar ones.
+func (action *buildKitAction) lookupKitForLayouts(ctx context.Context, c ctrl.Reader, integration *v1.Integration, layouts []string) (*v1.IntegrationKit, error) {
 	pl, err := platform.GetCurrent(ctx, c, integration.Namespace)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return nil, err
@@ -198,6 +281,7 @@ func (action *buildKitAction) lookupKitForIntegration(ctx context.Context, c ctr
 		return nil, err
 	}
 
+	var candidates []*v1.IntegrationKit
 	for _, kit := range kits.Items {
 		kit := kit // pin
 
@@ -205,6 +289,10 @@ func (action *buildKitAction) lookupKitForIntegration(ctx context.Context, c ctr
 			continue
 		}
 
+		if !util.StringSliceExists(layouts, kit.Layout()) {
+			continue
+		}
+
 		/*
 			TODO: moved to label selector
 			if kit.Status.RuntimeVersion != integration.Status.RuntimeVersion {
@@ -244,11 +332,27 @@ func (action *buildKitAction) lookupKitForIntegration(ctx context.Context, c ctr
 			continue
 		}
 		if util.StringSliceContains(kit.Spec.Dependencies, integration.Status.Dependencies) {
-			return &kit, nil
+			candidates = append(candidates, &kit)
 		}
 	}
 
-	return nil, nil
+	return selectKit(candidates), nil
+}
+
+// selectKit returns the ready native kit if any, then the first ready kit, then the first kit
+func selectKit(kits []*v1.IntegrationKit) *v1.IntegrationKit {
+	var selected *v1.IntegrationKit
+	for _, kit := range kits {
+		ready := kit.Status.Phase == v1.IntegrationKitPhaseReady
+		if ready && kit.IsNative() {
+			return kit
+		}
+		if selected == nil || (ready && selected.Status.Phase != v1.IntegrationKitPhaseReady) {
+			selected = kit
+		}
+	}
+
+	return selected
 }
 
 // hasMatchingTraits compares traits defined on kit against those defined on integration
@@ -290,6 +394,13 @@ func (action *buildKitAction) hasMatchingTraits(ctx context.Context, kit *v1.Int
 				// in integration trait
 				return false, nil
 			}
+			if name == "quarkus" && ck == "packageTypes" {
+				// The kit is built for a subset of the package types declared on integration
+				if !subset(cv, iv) {
+					return false, nil
+				}
+				continue
+			}
 			if !equal(iv, cv) {
 				// skip it because trait configured on kit has a value that differs from
 				// the one configured on integration
@@ -331,3 +442,25 @@ func sliceEqual(a, b []interface{}) bool {
 	}
 	return true
 }
+
+// subset checks whether a is a slice whose elements are all contained in the b slice
+func subset(a, b interface{}) bool {
+	aSlice, aOk := a.([]interface{})
+	bSlice, bOk := b.([]interface{})
+	if !aOk || !bOk {
+		return false
+	}
+	for _, v := range aSlice {
+		found := false
+		for _, w := range bSlice {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -329,3 +329,231 @@ func TestHasMatchingTraits_KitSameTraitShouldBePicked(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, ok)
 }
+
+func TestHasMatchingTraits_KitWithSubsetOfPackageTypesShouldBePicked(t *testing.T) {
+	integration := &v1.Integration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"quarkus": test.TraitSpecFromMap(t, map[string]interface{}{
+					"packageTypes": []string{"fast-jar", "native"},
+				}),
+			},
+		},
+	}
+
+	kit := &v1.IntegrationKit{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKitKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-kit",
+		},
+		Spec: v1.IntegrationKitSpec{
+			Traits: map[string]v1.TraitSpec{
+				"quarkus": test.TraitSpecFromMap(t, map[string]interface{}{
+					"packageTypes": []string{"native"},
+				}),
+			},
+		},
+	}
+
+	a := buildKitAction{}
+	a.InjectLogger(log.Log)
+
+	ok, err := a.hasMatchingTraits(context.TODO(), kit, integration)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	kit.Spec.Traits["quarkus"] = test.TraitSpecFromMap(t, map[string]interface{}{
+		"packageTypes": []string{"native", "legacy-jar"},
+	})
+
+	ok, err = a.hasMatchingTraits(context.TODO(), kit, integration)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestLookupKitForIntegration_PreferReadyNativeKit(t *testing.T) {
+	newKit := func(name string, layout string, phase v1.IntegrationKitPhase) *v1.IntegrationKit {
+		return &v1.IntegrationKit{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKitKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Labels: map[string]string{
+					"camel.apache.org/kit.type":  v1.IntegrationKitTypePlatform,
+					v1.IntegrationKitLayoutLabel: layout,
+				},
+			},
+			Spec: v1.IntegrationKitSpec{
+				Dependencies: []string{
+					"camel-core",
+				},
+				Traits: map[string]v1.TraitSpec{
+					"quarkus": test.TraitSpecFromMap(t, map[string]interface{}{
+						"packageTypes": []string{layout},
+					}),
+				},
+			},
+			Status: v1.IntegrationKitStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	newIntegration := func(packageTypes ...string) *v1.Integration {
+		return &v1.Integration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-integration",
+			},
+			Spec: v1.IntegrationSpec{
+				Traits: map[string]v1.TraitSpec{
+					"quarkus": test.TraitSpecFromMap(t, map[string]interface{}{
+						"packageTypes": packageTypes,
+					}),
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Dependencies: []string{
+					"camel-core",
+				},
+			},
+		}
+	}
+
+	c, err := test.NewFakeClient(
+		newKit("my-kit-jvm", v1.IntegrationKitLayoutFastJar, v1.IntegrationKitPhaseReady),
+		newKit("my-kit-native", v1.IntegrationKitLayoutNative, v1.IntegrationKitPhaseReady),
+	)
+	assert.Nil(t, err)
+
+	a := buildKitAction{}
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+
+	kit, err := a.lookupKitForIntegration(context.TODO(), c, newIntegration("fast-jar", "native"))
+	assert.Nil(t, err)
+	assert.NotNil(t, kit)
+	assert.Equal(t, "my-kit-native", kit.Name)
+
+	kit, err = a.lookupKitForIntegration(context.TODO(), c, newIntegration("fast-jar"))
+	assert.Nil(t, err)
+	assert.NotNil(t, kit)
+	assert.Equal(t, "my-kit-jvm", kit.Name)
+
+	// The native kit is still building, so the ready fast-jar kit is used meanwhile
+	c, err = test.NewFakeClient(
+		newKit("my-kit-native", v1.IntegrationKitLayoutNative, v1.IntegrationKitPhaseBuildRunning),
+		newKit("my-kit-jvm", v1.IntegrationKitLayoutFastJar, v1.IntegrationKitPhaseReady),
+	)
+	assert.Nil(t, err)
+	a.InjectClient(c)
+
+	kit, err = a.lookupKitForIntegration(context.TODO(), c, newIntegration("native", "fast-jar"))
+	assert.Nil(t, err)
+	assert.NotNil(t, kit)
+	assert.Equal(t, "my-kit-jvm", kit.Name)
+}
+
+func TestCreateKit_CopyBuildPriority(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	a := buildKitAction{}
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+
+	kit, err := a.createKit(context.TODO(), &v1.Integration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+			Annotations: map[string]string{
+				v1.BuildPriorityAnnotation: "10",
+			},
+		},
+	}, v1.IntegrationKitLayoutFastJar)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, kit)
+	assert.Equal(t, "10", kit.Annotations[v1.BuildPriorityAnnotation])
+}
+
+func TestCreateKit_KeepBuildTolerations(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	a := buildKitAction{}
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+
+	kit, err := a.createKit(context.TODO(), &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"toleration": test.TraitSpecFromMap(t, map[string]interface{}{
+					"taints": []string{"node-role.kubernetes.io/build:NoSchedule"},
+					"build":  true,
+				}),
+				"service": test.TraitSpecFromMap(t, map[string]interface{}{
+					"enabled": false,
+				}),
+			},
+		},
+	}, v1.IntegrationKitLayoutFastJar)
+
+	assert.Nil(t, err)
+	assert.Contains(t, kit.Spec.Traits, "toleration")
+	assert.NotContains(t, kit.Spec.Traits, "service")
+}
+
+func TestCreateKit_KeepBuildPriorityClass(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	a := buildKitAction{}
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+
+	kit, err := a.createKit(context.TODO(), &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"priority-class": test.TraitSpecFromMap(t, map[string]interface{}{
+					"name":  "high-priority",
+					"build": true,
+				}),
+			},
+		},
+	}, v1.IntegrationKitLayoutFastJar)
+
+	assert.Nil(t, err)
+	assert.Contains(t, kit.Spec.Traits, "priority-class")
+}
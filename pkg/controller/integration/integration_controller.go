@@ -78,7 +78,10 @@ func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler, cl client.Client) error {
 	// Create a new controller
-	c, err := controller.New("integration-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("integration-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: platform.GetOperatorMaxConcurrentReconciles(),
+	})
 	if err != nil {
 		return err
 	}
@@ -108,7 +111,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler, cl client.Client) error {
 
 	// Watch for IntegrationKit phase transitioning to ready or error and
 	// enqueue requests for any integrations that are in phase waiting for
-	// kit
+	// kit, or that are running with a former image of the kit
 	err = c.Watch(&source.Kind{Type: &v1.IntegrationKit{}},
 		handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
 			kit := a.(*v1.IntegrationKit)
@@ -137,6 +140,14 @@ func add(mgr manager.Manager, r reconcile.Reconciler, cl client.Client) error {
 								Name:      integration.Name,
 							},
 						})
+					} else if integration.Status.Phase == v1.IntegrationPhaseRunning && hasKitImageChanged(&integration, kit) {
+						log.Infof("Kit %s image changed, redeploy integration: %s", kit.Name, integration.Name)
+						requests = append(requests, reconcile.Request{
+							NamespacedName: types.NamespacedName{
+								Namespace: integration.Namespace,
+								Name:      integration.Name,
+							},
+						})
 					}
 				}
 
@@ -301,6 +312,12 @@ func (r *reconcileIntegration) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	// Only process resources assigned to the operator
+	if !platform.IsOperatorHandler(&instance) {
+		rlog.Info("Ignoring request because resource is not assigned to current operator")
+		return reconcile.Result{}, nil
+	}
+
 	target := instance.DeepCopy()
 	targetLog := rlog.ForIntegration(target)
 
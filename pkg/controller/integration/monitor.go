@@ -20,13 +20,17 @@ package integration
 import (
 	"context"
 
+	"github.com/pkg/errors"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/digest"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
@@ -63,6 +67,32 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integra
 		return integration, nil
 	}
 
+	// Switch to the native kit once it's ready, if the integration has requested it
+	if kit, err := action.lookupNativeKit(ctx, integration); err != nil {
+		return nil, err
+	} else if kit != nil {
+		action.L.Infof("Switching to native integration kit %s", kit.Name)
+
+		integration.Status.Image = kit.Status.Image
+		integration.SetIntegrationKit(kit)
+		integration.Status.Phase = v1.IntegrationPhaseDeploying
+
+		return integration, nil
+	}
+
+	// Roll out the kit image when it has changed, e.g. when the kit has been rebuilt,
+	// as the image is addressed by digest and the tag may have been overwritten
+	if kit, err := action.lookupKit(ctx, integration); err != nil {
+		return nil, err
+	} else if kit != nil && hasKitImageChanged(integration, kit) {
+		action.L.Infof("Integration kit %s image has changed from %s to %s", kit.Name, integration.Status.Image, kit.Status.Image)
+
+		integration.Status.Image = kit.Status.Image
+		integration.Status.Phase = v1.IntegrationPhaseDeploying
+
+		return integration, nil
+	}
+
 	// Run traits that are enabled for the running phase
 	_, err = trait.Apply(ctx, action.client, integration, nil)
 	if err != nil {
@@ -154,3 +184,61 @@ func findLatestReplicaSet(list *appsv1.ReplicaSetList) *appsv1.ReplicaSet {
 	}
 	return &latest
 }
+
+// lookupKit returns the kit the integration currently runs from, or nil if it does not exist anymore
+func (action *monitorAction) lookupKit(ctx context.Context, integration *v1.Integration) (*v1.IntegrationKit, error) {
+	if integration.Status.IntegrationKit == nil || integration.Status.IntegrationKit.Name == "" {
+		return nil, nil
+	}
+
+	kit, err := kubernetes.GetIntegrationKit(ctx, action.client, integration.Status.IntegrationKit.Name, integration.Status.IntegrationKit.Namespace)
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "unable to find integration kit %s/%s", integration.Status.IntegrationKit.Namespace, integration.Status.IntegrationKit.Name)
+	}
+
+	return kit, nil
+}
+
+// hasKitImageChanged returns whether the integration kit is ready with another image than the one the integration is deployed with
+func hasKitImageChanged(integration *v1.Integration, kit *v1.IntegrationKit) bool {
+	if integration.Status.IntegrationKit == nil ||
+		integration.Status.IntegrationKit.Name != kit.Name ||
+		integration.Status.IntegrationKit.Namespace != kit.Namespace {
+		return false
+	}
+	return kit.Status.Phase == v1.IntegrationKitPhaseReady && kit.Status.Image != "" && kit.Status.Image != integration.Status.Image
+}
+
+// lookupNativeKit returns a ready native kit for the integration, if it requests the native
+// package type and currently runs from a fast-jar kit
+func (action *monitorAction) lookupNativeKit(ctx context.Context, integration *v1.Integration) (*v1.IntegrationKit, error) {
+	if integration.Status.IntegrationKit == nil || integration.Status.IntegrationKit.Name == "" {
+		return nil, nil
+	}
+
+	packageTypes, err := trait.GetQuarkusPackageTypes(integration.Spec.Traits)
+	if err != nil {
+		return nil, err
+	}
+	if !util.StringSliceExists(packageTypes, v1.IntegrationKitLayoutNative) {
+		return nil, nil
+	}
+
+	current, err := kubernetes.GetIntegrationKit(ctx, action.client, integration.Status.IntegrationKit.Name, integration.Status.IntegrationKit.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to find integration kit %s/%s", integration.Status.IntegrationKit.Namespace, integration.Status.IntegrationKit.Name)
+	}
+	if current.IsNative() {
+		return nil, nil
+	}
+
+	lookup := buildKitAction{baseAction: action.baseAction}
+	kit, err := lookup.lookupKitForLayouts(ctx, action.client, integration, []string{v1.IntegrationKitLayoutNative})
+	if err != nil || kit == nil || kit.Status.Phase != v1.IntegrationKitPhaseReady {
+		return nil, err
+	}
+
+	return kit, nil
+}
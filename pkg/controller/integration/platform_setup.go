@@ -20,6 +20,7 @@ package integration
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -78,6 +79,11 @@ func determineBestProfile(ctx context.Context, c client.Client, integration *v1.
 		// Use platform profile if set
 		return p.Status.Profile
 	}
+	if cond := p.Status.GetCondition(v1.IntegrationPlatformConditionKnativeAvailable); cond != nil &&
+		cond.Status == corev1.ConditionFalse && p.Namespace == integration.Namespace {
+		// Knative has been detected as not available by the platform
+		return platform.GetProfile(p)
+	}
 	if knative.IsEnabledInNamespace(ctx, c, integration.Namespace) {
 		return v1.TraitProfileKnative
 	}
@@ -21,9 +21,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -34,6 +36,9 @@ import (
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
+// nativeBuildTimeout is the minimum timeout for native kit builds
+const nativeBuildTimeout = 10 * time.Minute
+
 // NewBuildAction creates a new build request handling action for the kit
 func NewBuildAction() Action {
 	return &buildAction{}
@@ -82,6 +87,7 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1.Int
 			return nil, errors.New("undefined camel catalog")
 		}
 
+		maxRetries := env.Platform.Status.Build.GetMaxRetries()
 		build = &v1.Build{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: v1.SchemeGroupVersion.String(),
@@ -93,11 +99,29 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1.Int
 				Labels:    kubernetes.FilterCamelCreatorLabels(kit.Labels),
 			},
 			Spec: v1.BuildSpec{
-				Tasks:   env.BuildTasks,
-				Timeout: env.Platform.Status.Build.GetTimeout(),
+				Tasks:             env.BuildTasks,
+				Timeout:           getBuildTimeout(env, kit),
+				MaxRetries:        &maxRetries,
+				Tolerations:       env.BuildTolerations,
+				NodeSelector:      env.BuildNodeSelector,
+				Resources:         env.BuildResources,
+				PriorityClassName: env.BuildPriorityClassName,
+				HTTPProxy:         env.Platform.Status.Build.HTTPProxy,
+				HTTPSProxy:        env.Platform.Status.Build.HTTPSProxy,
+				NoProxy:           env.Platform.Status.Build.NoProxy,
 			},
 		}
 
+		// Propagate the priority of the kit build
+		if priority, ok := kit.Annotations[v1.BuildPriorityAnnotation]; ok {
+			build.Annotations = map[string]string{
+				v1.BuildPriorityAnnotation: priority,
+			}
+		}
+
+		// The build is reconciled by the same operator as the kit
+		v1.SetOperatorIDAnnotation(build, v1.GetOperatorIDAnnotation(kit))
+
 		// Set the integration kit instance as the owner and controller
 		if err := controllerutil.SetControllerReference(kit, build, action.client.GetScheme()); err != nil {
 			return nil, err
@@ -186,9 +210,26 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1.Integ
 		// Let's copy the build failure to the integration kit status
 		kit.Status.Failure = build.Status.Failure
 		kit.Status.Phase = v1.IntegrationKitPhaseError
+		if condition := build.Status.GetCondition(v1.BuildConditionArtifactMissing); condition != nil && condition.Status == corev1.ConditionTrue {
+			kit.Status.SetCondition(v1.IntegrationKitConditionArtifactMissing, corev1.ConditionTrue, condition.Reason, condition.Message)
+		}
 
 		return kit, nil
 	}
 
 	return nil, nil
 }
+
+// getBuildTimeout returns the build timeout set by the traits, or the platform build timeout,
+// that is extended for native kits as native compilation takes considerably longer than building JVM kits
+func getBuildTimeout(env *trait.Environment, kit *v1.IntegrationKit) metav1.Duration {
+	if env.BuildTimeout != nil {
+		return *env.BuildTimeout
+	}
+	timeout := env.Platform.Status.Build.GetTimeout()
+	if kit.IsNative() && timeout.Duration < nativeBuildTimeout {
+		timeout.Duration = nativeBuildTimeout
+	}
+
+	return timeout
+}
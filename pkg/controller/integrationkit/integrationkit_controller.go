@@ -73,7 +73,10 @@ func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("integrationkit-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("integrationkit-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: platform.GetOperatorMaxConcurrentReconciles(),
+	})
 	if err != nil {
 		return err
 	}
@@ -202,6 +205,12 @@ func (r *reconcileIntegrationKit) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, err
 	}
 
+	// Only process resources assigned to the operator
+	if !platform.IsOperatorHandler(&instance) {
+		rlog.Info("Ignoring request because resource is not assigned to current operator")
+		return reconcile.Result{}, nil
+	}
+
 	target := instance.DeepCopy()
 	targetLog := rlog.ForIntegrationKit(target)
 
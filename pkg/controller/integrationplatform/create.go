@@ -55,7 +55,7 @@ func (action *createAction) Handle(ctx context.Context, platform *v1.Integration
 
 	if defaults.InstallDefaultKamelets() {
 		// Kamelet Catalog installed on platform reconciliation for cases where users install a global operator
-		if err := install.KameletCatalog(ctx, action.client, platform.Namespace); err != nil {
+		if err := install.KameletCatalog(ctx, action.client, platform.Namespace, v1.GetOperatorIDAnnotation(platform)); err != nil {
 			return nil, err
 		}
 	}
@@ -20,6 +20,8 @@ package integrationplatform
 import (
 	"context"
 
+	"github.com/pkg/errors"
+
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -69,6 +71,8 @@ func (action *initializeAction) Handle(ctx context.Context, platform *v1.Integra
 		return nil, err
 	}
 
+	setKnativeCondition(ctx, action.client, platform)
+
 	if platform.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyKaniko {
 		if platform.Status.Build.IsKanikoCacheEnabled() {
 			// Create the persistent volume claim used by the Kaniko cache
@@ -112,9 +116,9 @@ func (action *initializeAction) isDuplicate(ctx context.Context, thisPlatform *v
 }
 
 func createPersistentVolumeClaim(ctx context.Context, client client.Client, platform *v1.IntegrationPlatform) error {
-	volumeSize, err := resource.ParseQuantity("1Gi")
+	volumeSize, err := resource.ParseQuantity(platform.Status.Build.KanikoBuildCacheSize)
 	if err != nil {
-		return err
+		return errors.Wrapf(err, "invalid Kaniko cache size %s", platform.Status.Build.KanikoBuildCacheSize)
 	}
 
 	pvc := &corev1.PersistentVolumeClaim{
@@ -141,6 +145,10 @@ func createPersistentVolumeClaim(ctx context.Context, client client.Client, plat
 		},
 	}
 
+	if platform.Status.Build.KanikoBuildCacheStorageClass != "" {
+		pvc.Spec.StorageClassName = &platform.Status.Build.KanikoBuildCacheStorageClass
+	}
+
 	err = client.Create(ctx, pvc)
 	// Skip the error in case the PVC already exists
 	if err != nil && !k8serrors.IsAlreadyExists(err) {
@@ -30,7 +30,10 @@ import (
 	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/log"
 	"github.com/apache/camel-k/pkg/util/test"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestTimeouts_Default(t *testing.T) {
@@ -141,3 +144,151 @@ func TestDefaultMavenSettingsApplied(t *testing.T) {
 	assert.Equal(t, "test-platform-maven-settings", answer.Status.Build.Maven.Settings.ConfigMapKeyRef.Name)
 	assert.Equal(t, "settings.xml", answer.Status.Build.Maven.Settings.ConfigMapKeyRef.Key)
 }
+
+func TestDefaultMavenSettingsWithCredentials(t *testing.T) {
+	ip := v1.IntegrationPlatform{}
+	ip.Namespace = "ns"
+	ip.Name = "test-platform"
+	ip.Spec.Cluster = v1.IntegrationPlatformClusterOpenShift
+	ip.Spec.Profile = v1.TraitProfileOpenShift
+	ip.Spec.Build.Maven.Mirrors = []v1.Mirror{
+		{
+			ID:       "nexus",
+			URL:      "https://nexus.example.com/repository/maven-public",
+			MirrorOf: "*",
+		},
+	}
+	ip.Spec.Build.Maven.Proxies = []v1.MavenProxy{
+		{
+			Host: "proxy.example.com",
+			Port: 3128,
+		},
+	}
+	ip.Spec.Build.Maven.Servers = []v1.MavenServer{
+		{
+			ID: "nexus",
+			Username: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "nexus-credentials"},
+				Key:                  "username",
+			},
+			Password: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "nexus-credentials"},
+				Key:                  "password",
+			},
+		},
+	}
+
+	credentials := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "nexus-credentials",
+		},
+		Data: map[string][]byte{
+			"username": []byte("nexus-user"),
+			"password": []byte("nexus-password"),
+		},
+	}
+
+	c, err := test.NewFakeClient(&ip, &credentials)
+	assert.Nil(t, err)
+
+	assert.Nil(t, platform.ConfigureDefaults(context.TODO(), c, &ip, false))
+
+	assert.Nil(t, ip.Status.Build.Maven.Settings.ConfigMapKeyRef)
+	assert.NotNil(t, ip.Status.Build.Maven.Settings.SecretKeyRef)
+	assert.Equal(t, "test-platform-maven-settings", ip.Status.Build.Maven.Settings.SecretKeyRef.Name)
+
+	var secret corev1.Secret
+	err = c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "test-platform-maven-settings"}, &secret)
+	assert.Nil(t, err)
+
+	settings := string(secret.Data["settings.xml"])
+	assert.Contains(t, settings, "<mirrorOf>*</mirrorOf>")
+	assert.Contains(t, settings, "<id>proxy-000</id>")
+	assert.Contains(t, settings, "<protocol>http</protocol>")
+	assert.Contains(t, settings, "<username>nexus-user</username>")
+	assert.Contains(t, settings, "<password>nexus-password</password>")
+}
+
+func TestKanikoCachePersistentVolumeClaim(t *testing.T) {
+	ip := v1.IntegrationPlatform{}
+	ip.Namespace = "ns"
+	ip.Name = "test-platform"
+	ip.Spec.Cluster = v1.IntegrationPlatformClusterKubernetes
+	ip.Spec.Profile = v1.TraitProfileKubernetes
+	ip.Spec.Build.PublishStrategy = v1.IntegrationPlatformBuildPublishStrategyKaniko
+	ip.Spec.Build.Registry.Address = "registry.example.com"
+	cache := true
+	ip.Spec.Build.KanikoBuildCache = &cache
+	ip.Spec.Build.KanikoBuildCacheStorageClass = "fast"
+
+	c, err := test.NewFakeClient(&ip)
+	assert.Nil(t, err)
+
+	assert.Nil(t, platform.ConfigureDefaults(context.TODO(), c, &ip, false))
+	assert.Equal(t, "1Gi", ip.Status.Build.KanikoBuildCacheSize)
+
+	ip.Spec.Build.KanikoBuildCacheSize = "10Gi"
+	assert.Nil(t, platform.ConfigureDefaults(context.TODO(), c, &ip, false))
+
+	h := NewInitializeAction()
+	h.InjectLogger(log.Log)
+	h.InjectClient(c)
+
+	answer, err := h.Handle(context.TODO(), &ip)
+	assert.Nil(t, err)
+	assert.NotNil(t, answer)
+	assert.Equal(t, v1.IntegrationPlatformPhaseWarming, answer.Status.Phase)
+
+	pvc := corev1.PersistentVolumeClaim{}
+	err = c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "test-platform"}, &pvc)
+	assert.Nil(t, err)
+	assert.Equal(t, "fast", *pvc.Spec.StorageClassName)
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, "10Gi", size.String())
+}
+
+func TestKnativeCondition_NotAvailable(t *testing.T) {
+	ip := v1.IntegrationPlatform{}
+	ip.Namespace = "ns"
+	ip.Name = xid.New().String()
+	ip.Spec.Cluster = v1.IntegrationPlatformClusterOpenShift
+	ip.Spec.Profile = v1.TraitProfileOpenShift
+
+	c, err := test.NewFakeClient(&ip)
+	assert.Nil(t, err)
+
+	h := NewInitializeAction()
+	h.InjectLogger(log.Log)
+	h.InjectClient(c)
+
+	answer, err := h.Handle(context.TODO(), &ip)
+	assert.Nil(t, err)
+	assert.NotNil(t, answer)
+
+	cond := answer.Status.GetCondition(v1.IntegrationPlatformConditionKnativeAvailable)
+	assert.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+	assert.Equal(t, v1.IntegrationPlatformConditionKnativeNotAvailableReason, cond.Reason)
+}
+
+func TestKnativeCondition_Cached(t *testing.T) {
+	ip := v1.IntegrationPlatform{}
+	ip.Namespace = "knative-cached"
+	ip.Name = xid.New().String()
+
+	c, err := test.NewFakeClient(&ip)
+	assert.Nil(t, err)
+	clientset := c.(*test.FakeClient).Interface.(*fakeclientset.Clientset)
+
+	setKnativeCondition(context.TODO(), c, &ip)
+	checks := len(clientset.Actions())
+	assert.NotZero(t, checks)
+
+	setKnativeCondition(context.TODO(), c, &ip)
+	assert.Equal(t, checks, len(clientset.Actions()))
+
+	cond := ip.Status.GetCondition(v1.IntegrationPlatformConditionKnativeAvailable)
+	assert.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/knative"
+)
+
+// The Knative availability is checked at most once per minute for each namespace, as Knative
+// is rarely installed or removed, and the check queries the API server
+const knativeCheckInterval = time.Minute
+
+type knativeCheck struct {
+	available bool
+	time      time.Time
+}
+
+var (
+	knativeChecks     = make(map[string]knativeCheck)
+	knativeChecksLock sync.Mutex
+)
+
+// setKnativeCondition records on the platform whether Knative can be used in its namespace,
+// that is the Knative APIs are installed and the operator is granted access to them
+func setKnativeCondition(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform) {
+	if isKnativeAvailable(ctx, c, platform.Namespace) {
+		platform.Status.SetCondition(
+			v1.IntegrationPlatformConditionKnativeAvailable,
+			corev1.ConditionTrue,
+			v1.IntegrationPlatformConditionKnativeAvailableReason,
+			"Knative resources are available")
+	} else {
+		platform.Status.SetCondition(
+			v1.IntegrationPlatformConditionKnativeAvailable,
+			corev1.ConditionFalse,
+			v1.IntegrationPlatformConditionKnativeNotAvailableReason,
+			"Knative is not installed or the operator is not granted access to its resources")
+	}
+}
+
+// isKnativeAvailable returns the cached Knative availability in the namespace, or checks it when
+// the cached value is older than knativeCheckInterval. The Knative APIs are first looked up with
+// the discovery client, so that the namespace resources are listed only when Knative is installed.
+func isKnativeAvailable(ctx context.Context, c client.Client, namespace string) bool {
+	knativeChecksLock.Lock()
+	defer knativeChecksLock.Unlock()
+
+	if check, ok := knativeChecks[namespace]; ok && time.Since(check.time) < knativeCheckInterval {
+		return check.available
+	}
+
+	available := false
+	if installed, err := knative.IsInstalled(ctx, c); err != nil {
+		Log.Debugf("Cannot discover the Knative APIs: %v", err)
+	} else if installed {
+		available = knative.IsEnabledInNamespace(ctx, c, namespace)
+	}
+
+	knativeChecks[namespace] = knativeCheck{
+		available: available,
+		time:      time.Now(),
+	}
+
+	return available
+}
@@ -54,5 +54,8 @@ func (action *monitorAction) Handle(ctx context.Context, platform *v1.Integratio
 		return nil, err
 	}
 
+	// Refresh the Knative capability, that may have been installed afterwards
+	setKnativeCondition(ctx, action.client, platform)
+
 	return platform, nil
 }
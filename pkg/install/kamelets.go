@@ -29,6 +29,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/util/defaults"
@@ -39,7 +40,7 @@ const kameletDirEnv = "KAMELET_CATALOG_DIR"
 const defaultKameletDir = "/kamelets/"
 
 // KameletCatalog installs the bundled KameletCatalog into one namespace
-func KameletCatalog(ctx context.Context, c client.Client, namespace string) error {
+func KameletCatalog(ctx context.Context, c client.Client, namespace string, operatorID string) error {
 	kameletDir := os.Getenv(kameletDirEnv)
 	if kameletDir == "" {
 		kameletDir = defaultKameletDir
@@ -87,6 +88,7 @@ func KameletCatalog(ctx context.Context, c client.Client, namespace string) erro
 					k.SetAnnotations(make(map[string]string))
 				}
 				k.GetAnnotations()[kamelVersionAnnotation] = defaults.Version
+				v1.SetOperatorIDAnnotation(k, operatorID)
 
 				if k.GetLabels() == nil {
 					k.SetLabels(make(map[string]string))
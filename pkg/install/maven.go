@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+const (
+	mavenSettingsConfigMapName = "camel-k-maven-settings"
+	mavenSettingsKey           = "settings.xml"
+)
+
+// MavenSettingsFromFileOrCollect generates a ConfigMap from a Maven settings file and creates it on the cluster (or appends it to the collection).
+// It returns the value source referencing the settings, suitable for the platform Maven configuration.
+func MavenSettingsFromFileOrCollect(ctx context.Context, c client.Client, namespace string, file string, collection *kubernetes.Collection, force bool) (v1.ValueSource, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return v1.ValueSource{}, err
+	}
+
+	settings := maven.Settings{}
+	if err := xml.Unmarshal(data, &settings); err != nil {
+		return v1.ValueSource{}, fmt.Errorf("invalid Maven settings file %s: %v", file, err)
+	}
+
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mavenSettingsConfigMapName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": "camel-k",
+			},
+		},
+		Data: map[string]string{
+			mavenSettingsKey: string(data),
+		},
+	}
+
+	if err := ObjectOrCollect(ctx, c, namespace, collection, force, &cm); err != nil {
+		return v1.ValueSource{}, err
+	}
+
+	return v1.ValueSource{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: mavenSettingsConfigMapName,
+			},
+			Key: mavenSettingsKey,
+		},
+	}, nil
+}
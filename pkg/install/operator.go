@@ -20,6 +20,7 @@ package install
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -47,16 +48,26 @@ import (
 
 // OperatorConfiguration --
 type OperatorConfiguration struct {
-	CustomImage           string
-	CustomImagePullPolicy string
-	Namespace             string
-	Global                bool
-	ClusterType           string
-	Health                OperatorHealthConfiguration
-	Monitoring            OperatorMonitoringConfiguration
-	Tolerations           []string
-	NodeSelectors         []string
-	ResourcesRequirements []string
+	CustomImage             string
+	CustomImagePullPolicy   string
+	Namespace               string
+	Global                  bool
+	ClusterType             string
+	Health                  OperatorHealthConfiguration
+	Monitoring              OperatorMonitoringConfiguration
+	Tolerations             []string
+	NodeSelectors           []string
+	ResourcesRequirements   []string
+	HTTPProxySecret         string
+	HTTPProxy               string
+	HTTPSProxy              string
+	NoProxy                 string
+	LogLevel                string
+	MaxConcurrentReconciles int
+	MaxRunningBuilds        int
+	OperatorID              string
+	CreateDefaultPlatform   bool
+	SkipKnative             bool
 }
 
 // OperatorHealthConfiguration --
@@ -140,6 +151,76 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 			}
 		}
 
+		if cfg.LogLevel != "" {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					envvar.SetVal(&d.Spec.Template.Spec.Containers[0].Env, "LOG_LEVEL", cfg.LogLevel)
+				}
+			}
+		}
+
+		if cfg.MaxConcurrentReconciles > 0 {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					envvar.SetVal(&d.Spec.Template.Spec.Containers[0].Env, "MAX_CONCURRENT_RECONCILES",
+						strconv.Itoa(cfg.MaxConcurrentReconciles))
+				}
+			}
+		}
+
+		if cfg.MaxRunningBuilds > 0 {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					envvar.SetVal(&d.Spec.Template.Spec.Containers[0].Env, "MAX_RUNNING_BUILDS",
+						strconv.Itoa(cfg.MaxRunningBuilds))
+				}
+			}
+		}
+
+		if cfg.OperatorID != "" {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					envvar.SetVal(&d.Spec.Template.Spec.Containers[0].Env, "KAMEL_OPERATOR_ID", cfg.OperatorID)
+				}
+			}
+		}
+
+		if cfg.CreateDefaultPlatform {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					envvar.SetVal(&d.Spec.Template.Spec.Containers[0].Env, "KAMEL_CREATE_DEFAULT_PLATFORM", "true")
+				}
+			}
+		}
+
+		if cfg.HTTPProxySecret != "" {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					// Propagate the proxy settings so that Maven builds and image pulls go through the proxy
+					for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"} {
+						envvar.SetVar(&d.Spec.Template.Spec.Containers[0].Env, proxySecretEnvVar(name, cfg.HTTPProxySecret))
+					}
+				}
+			}
+		}
+
+		if cfg.HTTPProxy != "" || cfg.HTTPSProxy != "" || cfg.NoProxy != "" {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					// The proxy URLs override the ones from the HTTP proxy secret, if any
+					for _, v := range []struct{ name, value string }{
+						{"HTTP_PROXY", cfg.HTTPProxy},
+						{"HTTPS_PROXY", cfg.HTTPSProxy},
+						{"NO_PROXY", cfg.NoProxy},
+					} {
+						if v.value != "" {
+							envvar.SetVal(&d.Spec.Template.Spec.Containers[0].Env, v.name, v.value)
+						}
+					}
+				}
+			}
+		}
+
 		if cfg.Global {
 			if d, ok := o.(*appsv1.Deployment); ok {
 				if d.Labels["camel.apache.org/component"] == "operator" {
@@ -150,7 +231,7 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 
 			// Turn Role & RoleBinding into their equivalent cluster types
 			if r, ok := o.(*rbacv1.Role); ok {
-				if strings.HasPrefix(r.Name, "camel-k-operator") {
+				if strings.HasPrefix(r.Name, "camel-k-operator") || r.Name == "camel-k-keda" {
 					o = &rbacv1.ClusterRole{
 						ObjectMeta: metav1.ObjectMeta{
 							Namespace: cfg.Namespace,
@@ -165,8 +246,10 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 			}
 
 			if rb, ok := o.(*rbacv1.RoleBinding); ok {
-				if strings.HasPrefix(rb.Name, "camel-k-operator") {
-					rb.Subjects[0].Namespace = cfg.Namespace
+				if strings.HasPrefix(rb.Name, "camel-k-operator") || rb.Name == "camel-k-keda" {
+					if rb.Subjects[0].Namespace == "" {
+						rb.Subjects[0].Namespace = cfg.Namespace
+					}
 
 					o = &rbacv1.ClusterRoleBinding{
 						ObjectMeta: metav1.ObjectMeta{
@@ -217,10 +300,13 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 		return err
 	}
 
-	// Additionally, install Knative resources (roles and bindings)
-	isKnative, err := knative.IsInstalled(ctx, c)
-	if err != nil {
-		return err
+	// Additionally, install Knative resources (roles and bindings), unless opted out
+	isKnative := false
+	if !cfg.SkipKnative {
+		isKnative, err = knative.IsInstalled(ctx, c)
+		if err != nil {
+			return err
+		}
 	}
 	if isKnative {
 		if err := installKnative(ctx, c, cfg.Namespace, customizer, collection, force); err != nil {
@@ -242,6 +328,13 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 		fmt.Println("Warning: the operator will not be able to create PodMonitor resources. Try installing as cluster-admin.")
 	}
 
+	if errmtr := installIstioBindings(ctx, c, cfg.Namespace, customizer, collection, force); errmtr != nil {
+		if k8serrors.IsAlreadyExists(errmtr) {
+			return errmtr
+		}
+		fmt.Println("Warning: the operator will not be able to create Istio routing resources. Try installing as cluster-admin.")
+	}
+
 	if errmtr := installStrimziBindings(ctx, c, cfg.Namespace, customizer, collection, force); errmtr != nil {
 		if k8serrors.IsAlreadyExists(errmtr) {
 			return errmtr
@@ -249,6 +342,13 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 		fmt.Println("Warning: the operator will not be able to lookup strimzi kafka resources. Try installing as cluster-admin to allow the lookup of strimzi kafka resources.")
 	}
 
+	if errmtr := installKedaBindings(ctx, c, cfg.Namespace, customizer, collection, force); errmtr != nil {
+		if k8serrors.IsAlreadyExists(errmtr) {
+			return errmtr
+		}
+		fmt.Println("Warning: the operator will not be able to create KEDA resources. Try installing as cluster-admin.")
+	}
+
 	if errmtr := installLeaseBindings(ctx, c, cfg.Namespace, customizer, collection, force); errmtr != nil {
 		if k8serrors.IsAlreadyExists(errmtr) {
 			return errmtr
@@ -278,6 +378,35 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 	return nil
 }
 
+func proxySecretEnvVar(name string, secret string) corev1.EnvVar {
+	optional := true
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret,
+				},
+				Key:      name,
+				Optional: &optional,
+			},
+		},
+	}
+}
+
+// HasPermissionToInstallGlobalOperator checks if the current user is allowed to create the cluster roles and bindings
+// required by an operator watching all namespaces
+func HasPermissionToInstallGlobalOperator(ctx context.Context, c client.Client) (bool, error) {
+	for _, resource := range []string{"clusterroles", "clusterrolebindings"} {
+		if ok, err := kubernetes.CheckPermission(ctx, c, rbacv1.GroupName, resource, "", "", "create"); err != nil {
+			return false, err
+		} else if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func installOpenShiftClusterRoleBinding(ctx context.Context, c client.Client, collection *kubernetes.Collection, namespace string) error {
 	var target *rbacv1.ClusterRoleBinding
 	existing, err := c.RbacV1().ClusterRoleBindings().Get(ctx, "camel-k-operator-openshift", metav1.GetOptions{})
@@ -380,6 +509,13 @@ func installPodMonitors(ctx context.Context, c client.Client, namespace string,
 	)
 }
 
+func installIstioBindings(ctx context.Context, c client.Client, namespace string, customizer ResourceCustomizer, collection *kubernetes.Collection, force bool) error {
+	return ResourcesOrCollect(ctx, c, namespace, collection, force, customizer,
+		"/rbac/operator-role-istio.yaml",
+		"/rbac/operator-role-binding-istio.yaml",
+	)
+}
+
 func installStrimziBindings(ctx context.Context, c client.Client, namespace string, customizer ResourceCustomizer, collection *kubernetes.Collection, force bool) error {
 	return ResourcesOrCollect(ctx, c, namespace, collection, force, customizer,
 		"/rbac/operator-role-strimzi.yaml",
@@ -387,6 +523,15 @@ func installStrimziBindings(ctx context.Context, c client.Client, namespace stri
 	)
 }
 
+func installKedaBindings(ctx context.Context, c client.Client, namespace string, customizer ResourceCustomizer, collection *kubernetes.Collection, force bool) error {
+	return ResourcesOrCollect(ctx, c, namespace, collection, force, customizer,
+		"/rbac/operator-role-keda.yaml",
+		"/rbac/operator-role-binding-keda.yaml",
+		"/rbac/keda-role.yaml",
+		"/rbac/keda-role-binding.yaml",
+	)
+}
+
 func installMonitoringResources(ctx context.Context, c client.Client, namespace string, customizer ResourceCustomizer, collection *kubernetes.Collection, force bool) error {
 	return ResourcesOrCollect(ctx, c, namespace, collection, force, customizer,
 		"/prometheus/operator-pod-monitor.yaml",
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestHasPermissionToInstallGlobalOperator(t *testing.T) {
+	for _, tc := range []struct {
+		allowed  map[string]bool
+		expected bool
+	}{
+		{map[string]bool{"clusterroles": true, "clusterrolebindings": true}, true},
+		{map[string]bool{"clusterroles": true, "clusterrolebindings": false}, false},
+		{map[string]bool{"clusterroles": false, "clusterrolebindings": true}, false},
+	} {
+		c, err := test.NewFakeClient()
+		assert.Nil(t, err)
+		allowed := tc.allowed
+		c.(*test.FakeClient).Interface.(*fakeclientset.Clientset).PrependReactor("create", "selfsubjectaccessreviews",
+			func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				review.Status.Allowed = allowed[review.Spec.ResourceAttributes.Resource]
+				return true, review, nil
+			})
+
+		ok, err := HasPermissionToInstallGlobalOperator(context.TODO(), c)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, ok)
+	}
+}
+
+func TestOperatorProxy(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+	collection := kubernetes.NewCollection()
+	cfg := OperatorConfiguration{
+		Namespace:  "ns",
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://proxy.example.com:3129",
+		NoProxy:    ".cluster.local,.svc",
+		// Knative is not available in the fake cluster
+		SkipKnative: true,
+	}
+
+	err = OperatorOrCollect(context.TODO(), c, cfg, collection, false)
+	assert.Nil(t, err)
+
+	deployment := collection.GetDeployment(func(d *appsv1.Deployment) bool {
+		return d.Labels["camel.apache.org/component"] == "operator"
+	})
+	assert.NotNil(t, deployment)
+	env := deployment.Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "http://proxy.example.com:3128", envvar.Get(env, "HTTP_PROXY").Value)
+	assert.Equal(t, "http://proxy.example.com:3129", envvar.Get(env, "HTTPS_PROXY").Value)
+	assert.Equal(t, ".cluster.local,.svc", envvar.Get(env, "NO_PROXY").Value)
+}
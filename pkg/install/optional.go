@@ -28,7 +28,7 @@ import (
 )
 
 // OperatorStartupOptionalTools tries to install optional tools at operator startup and warns if something goes wrong
-func OperatorStartupOptionalTools(ctx context.Context, c client.Client, namespace string, operatorNamespace string, log logr.Logger) {
+func OperatorStartupOptionalTools(ctx context.Context, c client.Client, namespace string, operatorNamespace string, operatorID string, log logr.Logger) {
 
 	// Try to register the OpenShift CLI Download link if possible
 	if err := OpenShiftConsoleDownloadLink(ctx, c); err != nil {
@@ -48,7 +48,7 @@ func OperatorStartupOptionalTools(ctx context.Context, c client.Client, namespac
 
 	if kameletNamespace != "" {
 		if defaults.InstallDefaultKamelets() {
-			if err := KameletCatalog(ctx, c, kameletNamespace); err != nil {
+			if err := KameletCatalog(ctx, c, kameletNamespace, operatorID); err != nil {
 				log.Info("Cannot install bundled Kamelet Catalog: skipping.")
 				log.V(8).Info("Error while installing bundled Kamelet Catalog", "error", err)
 			}
@@ -47,6 +47,9 @@ func RegistrySecretFromFileOrCollect(ctx context.Context, c client.Client, names
 	if err != nil {
 		return "", err
 	}
+	if err := registry.ValidateDockerConfig(secretData); err != nil {
+		return "", err
+	}
 
 	return registrySecretFromDataOrCollect(ctx, c, namespace, secretData, collection, force)
 }
@@ -54,6 +54,7 @@ func merge(m1 src.Metadata, m2 src.Metadata) src.Metadata {
 		ToURIs:               t,
 		Dependencies:         strset.Union(m1.Dependencies, m2.Dependencies),
 		RequiredCapabilities: strset.Union(m1.RequiredCapabilities, m2.RequiredCapabilities),
+		UnknownSchemes:       strset.Union(m1.UnknownSchemes, m2.UnknownSchemes),
 		ExposesHTTPServices:  m1.ExposesHTTPServices || m2.ExposesHTTPServices,
 		PassiveEndpoints:     m1.PassiveEndpoints && m2.PassiveEndpoints,
 	}
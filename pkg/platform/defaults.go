@@ -38,6 +38,7 @@ import (
 	"github.com/apache/camel-k/pkg/install"
 	"github.com/apache/camel-k/pkg/kamelet/repository"
 	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/log"
 	"github.com/apache/camel-k/pkg/util/maven"
 	"github.com/apache/camel-k/pkg/util/openshift"
@@ -47,6 +48,11 @@ import (
 // BuilderServiceAccount --
 const BuilderServiceAccount = "camel-k-builder"
 
+const defaultKanikoBuildCacheSize = "1Gi"
+
+// defaultMaxRunningBuilds runs the builds sequentially, as it's currently necessary for the incremental build to work as expected
+const defaultMaxRunningBuilds = 1
+
 // ConfigureDefaults fills with default values all missing details about the integration platform.
 // Defaults are set in the status fields, not in the spec.
 func ConfigureDefaults(ctx context.Context, c client.Client, p *v1.IntegrationPlatform, verbose bool) error {
@@ -78,7 +84,8 @@ func ConfigureDefaults(ctx context.Context, c client.Client, p *v1.IntegrationPl
 	if p.Status.Build.BuildStrategy == "" {
 		// Use the fastest strategy that they support (routine when possible)
 		if p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyS2I ||
-			p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategySpectrum {
+			p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategySpectrum ||
+			p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyJib {
 			p.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyRoutine
 		} else {
 			// The build output has to be shared via a volume
@@ -184,6 +191,15 @@ func setPlatformDefaults(ctx context.Context, c client.Client, p *v1.Integration
 		}
 	}
 
+	if p.Status.Build.MaxRunningBuilds <= 0 {
+		p.Status.Build.MaxRunningBuilds = defaultMaxRunningBuilds
+	}
+
+	if p.Status.Build.MaxRetries == nil {
+		maxRetries := p.Status.Build.GetMaxRetries()
+		p.Status.Build.MaxRetries = &maxRetries
+	}
+
 	if p.Status.Build.Maven.Settings.ConfigMapKeyRef == nil && p.Status.Build.Maven.Settings.SecretKeyRef == nil {
 		var repositories []v1.Repository
 		var mirrors []maven.Mirror
@@ -205,18 +221,45 @@ func setPlatformDefaults(ctx context.Context, c client.Client, p *v1.Integration
 			}
 		}
 
+		mirrors = append(mirrors, p.Status.Build.Maven.Mirrors...)
 		settings := maven.NewDefaultSettings(repositories, mirrors)
 
-		err := createDefaultMavenSettingsConfigMap(ctx, c, p, settings)
+		proxies, err := resolveMavenProxies(ctx, c, p)
 		if err != nil {
 			return err
 		}
+		settings.Proxies = proxies
+		servers, err := resolveMavenServers(ctx, c, p)
+		if err != nil {
+			return err
+		}
+		settings.Servers = servers
 
-		p.Status.Build.Maven.Settings.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{
-			LocalObjectReference: corev1.LocalObjectReference{
-				Name: p.Name + "-maven-settings",
-			},
-			Key: "settings.xml",
+		if hasMavenCredentials(p.Status.Build.Maven) {
+			// The settings contain the credentials, so they must not be stored in a ConfigMap
+			err = createDefaultMavenSettingsSecret(ctx, c, p, settings)
+			if err != nil {
+				return err
+			}
+
+			p.Status.Build.Maven.Settings.SecretKeyRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: p.Name + "-maven-settings",
+				},
+				Key: "settings.xml",
+			}
+		} else {
+			err = createDefaultMavenSettingsConfigMap(ctx, c, p, settings)
+			if err != nil {
+				return err
+			}
+
+			p.Status.Build.Maven.Settings.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: p.Name + "-maven-settings",
+				},
+				Key: "settings.xml",
+			}
 		}
 	}
 
@@ -230,6 +273,9 @@ func setPlatformDefaults(ctx context.Context, c client.Client, p *v1.Integration
 			log.Log.Infof("Kaniko cache set to %t", *p.Status.Build.KanikoBuildCache)
 		}
 	}
+	if p.Status.Build.IsKanikoCacheEnabled() && p.Status.Build.KanikoBuildCacheSize == "" {
+		p.Status.Build.KanikoBuildCacheSize = defaultKanikoBuildCacheSize
+	}
 
 	if len(p.Status.Kamelet.Repositories) == 0 {
 		p.Status.Kamelet.Repositories = append(p.Status.Kamelet.Repositories, v1.IntegrationPlatformKameletRepositorySpec{
@@ -282,6 +328,118 @@ func createDefaultMavenSettingsConfigMap(ctx context.Context, client client.Clie
 	return nil
 }
 
+func createDefaultMavenSettingsSecret(ctx context.Context, client client.Client, p *v1.IntegrationPlatform, settings maven.Settings) error {
+	secret, err := maven.SettingsSecret(p.Namespace, p.Name, settings)
+	if err != nil {
+		return err
+	}
+
+	err = client.Create(ctx, secret)
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	} else if k8serrors.IsAlreadyExists(err) {
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: secret.Namespace,
+				Name:      secret.Name,
+			},
+		}
+		err = client.Get(ctx, ctrl.ObjectKeyFromObject(existing), existing)
+		if err != nil {
+			return err
+		}
+
+		p, err := patch.PositiveMergePatch(existing, secret)
+		if err != nil {
+			return err
+		} else if len(p) != 0 {
+			err = client.Patch(ctx, secret, ctrl.RawPatch(types.MergePatchType, p))
+			if err != nil {
+				return errors.Wrap(err, "error during patch resource")
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasMavenCredentials returns whether credentials, stored in Secrets, are configured for the Maven proxies or servers
+func hasMavenCredentials(spec v1.MavenSpec) bool {
+	for _, proxy := range spec.Proxies {
+		if proxy.Username != nil || proxy.Password != nil {
+			return true
+		}
+	}
+	for _, server := range spec.Servers {
+		if server.Username != nil || server.Password != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveMavenProxies(ctx context.Context, c client.Client, p *v1.IntegrationPlatform) ([]maven.Proxy, error) {
+	proxies := make([]maven.Proxy, 0, len(p.Status.Build.Maven.Proxies))
+	for i, spec := range p.Status.Build.Maven.Proxies {
+		proxy := maven.Proxy{
+			ID:            spec.ID,
+			Active:        true,
+			Protocol:      spec.Protocol,
+			Host:          spec.Host,
+			Port:          spec.Port,
+			NonProxyHosts: spec.NonProxyHosts,
+		}
+		if proxy.ID == "" {
+			proxy.ID = fmt.Sprintf("proxy-%03d", i)
+		}
+		if proxy.Protocol == "" {
+			proxy.Protocol = "http"
+		}
+		username, password, err := resolveMavenCredentials(ctx, c, p.Namespace, spec.Username, spec.Password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot resolve the credentials of Maven proxy %s", proxy.ID)
+		}
+		proxy.Username = username
+		proxy.Password = password
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
+
+func resolveMavenServers(ctx context.Context, c client.Client, p *v1.IntegrationPlatform) ([]maven.Server, error) {
+	servers := make([]maven.Server, 0, len(p.Status.Build.Maven.Servers))
+	for _, spec := range p.Status.Build.Maven.Servers {
+		username, password, err := resolveMavenCredentials(ctx, c, p.Namespace, spec.Username, spec.Password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot resolve the credentials of Maven server %s", spec.ID)
+		}
+		servers = append(servers, maven.Server{
+			ID:       spec.ID,
+			Username: username,
+			Password: password,
+		})
+	}
+	return servers, nil
+}
+
+func resolveMavenCredentials(ctx context.Context, c client.Client, namespace string, usernameRef *corev1.SecretKeySelector, passwordRef *corev1.SecretKeySelector) (string, string, error) {
+	var username, password string
+	var err error
+	if usernameRef != nil {
+		username, err = kubernetes.GetSecretRefValue(ctx, c, namespace, usernameRef)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if passwordRef != nil {
+		password, err = kubernetes.GetSecretRefValue(ctx, c, namespace, passwordRef)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return username, password, nil
+}
+
 func createServiceCaBundleConfigMap(ctx context.Context, client client.Client, p *v1.IntegrationPlatform) (*corev1.ConfigMap, error) {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -21,16 +21,25 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strconv"
 	"strings"
 
+	camelv1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+
 	coordination "k8s.io/api/coordination/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const OperatorWatchNamespaceEnvVariable = "WATCH_NAMESPACE"
+const OperatorLogLevelEnvVariable = "LOG_LEVEL"
+const OperatorMaxConcurrentReconcilesEnvVariable = "MAX_CONCURRENT_RECONCILES"
+const OperatorMaxRunningBuildsEnvVariable = "MAX_RUNNING_BUILDS"
+const OperatorIDEnvVariable = "KAMEL_OPERATOR_ID"
+const OperatorCreateDefaultPlatformEnvVariable = "KAMEL_CREATE_DEFAULT_PLATFORM"
 const operatorNamespaceEnvVariable = "NAMESPACE"
 const operatorPodNameEnvVariable = "POD_NAME"
 
@@ -72,6 +81,70 @@ func GetOperatorWatchNamespace() string {
 	return ""
 }
 
+// GetOperatorLogLevel returns the log level configured for the operator, if any
+func GetOperatorLogLevel() string {
+	if level, envSet := os.LookupEnv(OperatorLogLevelEnvVariable); envSet {
+		return strings.TrimSpace(level)
+	}
+	return ""
+}
+
+// GetOperatorMaxConcurrentReconciles returns the maximum number of concurrent reconciliations each controller can run
+func GetOperatorMaxConcurrentReconciles() int {
+	if value, envSet := os.LookupEnv(OperatorMaxConcurrentReconcilesEnvVariable); envSet {
+		if max, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && max > 0 {
+			return max
+		}
+	}
+	return 1
+}
+
+// GetOperatorMaxRunningBuilds returns the maximum number of builds the operator runs concurrently,
+// across all the namespaces it watches, or 0 when unlimited
+func GetOperatorMaxRunningBuilds() int {
+	if value, envSet := os.LookupEnv(OperatorMaxRunningBuildsEnvVariable); envSet {
+		if max, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && max > 0 {
+			return max
+		}
+	}
+	return 0
+}
+
+// GetOperatorID returns the id of the current operator, used to select the resources it reconciles
+func GetOperatorID() string {
+	if id, envSet := os.LookupEnv(OperatorIDEnvVariable); envSet {
+		return strings.TrimSpace(id)
+	}
+	return ""
+}
+
+// IsOperatorCreatingDefaultPlatform returns true if the operator is configured to create a default platform
+// in the namespaces where integrations are created and no platform exists
+func IsOperatorCreatingDefaultPlatform() bool {
+	if value, envSet := os.LookupEnv(OperatorCreateDefaultPlatformEnvVariable); envSet {
+		if create, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return create
+		}
+	}
+	return false
+}
+
+// GetOperatorLockName returns the name of the lease held by the current operator, so that
+// operators with different ids do not compete for the same lock
+func GetOperatorLockName() string {
+	if id := GetOperatorID(); id != "" {
+		return OperatorLockName + "-" + id
+	}
+	return OperatorLockName
+}
+
+// IsOperatorHandler returns true if the current operator is in charge of reconciling the given resource,
+// that is the resource operator id annotation matches the current operator id. Resources with no
+// operator id are reconciled by the operators that are not configured with an id.
+func IsOperatorHandler(object metav1.Object) bool {
+	return camelv1.GetOperatorIDAnnotation(object) == GetOperatorID()
+}
+
 // GetOperatorNamespace returns the namespace where the current operator is located (if set)
 func GetOperatorNamespace() string {
 	if podNamespace, envSet := os.LookupEnv(operatorNamespaceEnvVariable); envSet {
@@ -49,13 +49,26 @@ type affinityTrait struct {
 	// Defines a set of pods (namely those matching the label selector, relative to the given namespace) that the
 	// integration pod(s) should not be co-located with.
 	PodAntiAffinityLabels []string `property:"pod-anti-affinity-labels" json:"podAntiAffinityLabels,omitempty"`
+	// The node label used to determine the topology domain of the pod affinity and anti-affinity rules,
+	// e.g. `topology.kubernetes.io/zone` to spread the replicas of the integration across zones
+	// (default `kubernetes.io/hostname`).
+	TopologyKey string `property:"topology-key" json:"topologyKey,omitempty"`
+	// Whether the pod affinity and anti-affinity rules are preferences that the scheduler tries to enforce,
+	// rather than requirements that must be met for the integration pod(s) to be scheduled (default *false*).
+	Preferred *bool `property:"preferred" json:"preferred,omitempty"`
 }
 
+const (
+	defaultAffinityTopologyKey = "kubernetes.io/hostname"
+	preferredAffinityWeight    = 100
+)
+
 func newAffinityTrait() Trait {
 	return &affinityTrait{
 		BaseTrait:       NewBaseTrait("affinity", 1300),
 		PodAffinity:     BoolP(false),
 		PodAntiAffinity: BoolP(false),
+		TopologyKey:     defaultAffinityTopologyKey,
 	}
 }
 
@@ -166,15 +179,17 @@ func (t *affinityTrait) addPodAffinity(e *Environment, podSpec *corev1.PodSpec)
 		})
 	}
 
-	podAffinity := &corev1.PodAffinity{
-		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+	podAffinity := &corev1.PodAffinity{}
+	term := t.podAffinityTerm(labelSelectorRequirements)
+	if IsTrue(t.Preferred) {
+		podAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []corev1.WeightedPodAffinityTerm{
 			{
-				LabelSelector: &metav1.LabelSelector{
-					MatchExpressions: labelSelectorRequirements,
-				},
-				TopologyKey: "kubernetes.io/hostname",
+				Weight:          preferredAffinityWeight,
+				PodAffinityTerm: term,
 			},
-		},
+		}
+	} else {
+		podAffinity.RequiredDuringSchedulingIgnoredDuringExecution = []corev1.PodAffinityTerm{term}
 	}
 
 	podSpec.Affinity.PodAffinity = podAffinity
@@ -217,21 +232,37 @@ func (t *affinityTrait) addPodAntiAffinity(e *Environment, podSpec *corev1.PodSp
 		})
 	}
 
-	podAntiAffinity := &corev1.PodAntiAffinity{
-		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+	podAntiAffinity := &corev1.PodAntiAffinity{}
+	term := t.podAffinityTerm(labelSelectorRequirements)
+	if IsTrue(t.Preferred) {
+		podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []corev1.WeightedPodAffinityTerm{
 			{
-				LabelSelector: &metav1.LabelSelector{
-					MatchExpressions: labelSelectorRequirements,
-				},
-				TopologyKey: "kubernetes.io/hostname",
+				Weight:          preferredAffinityWeight,
+				PodAffinityTerm: term,
 			},
-		},
+		}
+	} else {
+		podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = []corev1.PodAffinityTerm{term}
 	}
 
 	podSpec.Affinity.PodAntiAffinity = podAntiAffinity
 	return nil
 }
 
+func (t *affinityTrait) podAffinityTerm(requirements []metav1.LabelSelectorRequirement) corev1.PodAffinityTerm {
+	topologyKey := t.TopologyKey
+	if topologyKey == "" {
+		topologyKey = defaultAffinityTopologyKey
+	}
+
+	return corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchExpressions: requirements,
+		},
+		TopologyKey: topologyKey,
+	}
+}
+
 func operatorToNodeSelectorOperator(operator selection.Operator) (corev1.NodeSelectorOperator, error) {
 	switch operator {
 	case selection.In, selection.Equals, selection.DoubleEquals:
@@ -179,6 +179,42 @@ func testApplyPodAffinityLabelsDoesSucceed(t *testing.T, trait *affinityTrait, e
 	assert.ElementsMatch(t, [1]string{"integration-name"}, integrationRequirement.Values)
 }
 
+func TestApplyPreferredPodAntiAffinityAcrossZonesDoesSucceed(t *testing.T) {
+	affinityTrait := createNominalAffinityTest()
+	affinityTrait.PodAntiAffinity = BoolP(true)
+	affinityTrait.Preferred = BoolP(true)
+	affinityTrait.TopologyKey = "topology.kubernetes.io/zone"
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	err := affinityTrait.Apply(environment)
+
+	assert.Nil(t, err)
+	podAntiAffinity := deployment.Spec.Template.Spec.Affinity.PodAntiAffinity
+	assert.NotNil(t, podAntiAffinity)
+	assert.Empty(t, podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	assert.Len(t, podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	term := podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0]
+	assert.Equal(t, int32(100), term.Weight)
+	assert.Equal(t, "topology.kubernetes.io/zone", term.PodAffinityTerm.TopologyKey)
+	integrationRequirement := term.PodAffinityTerm.LabelSelector.MatchExpressions[0]
+	assert.Equal(t, v1.IntegrationLabel, integrationRequirement.Key)
+	assert.ElementsMatch(t, [1]string{"integration-name"}, integrationRequirement.Values)
+}
+
+func TestApplyPodAffinityDefaultTopologyKey(t *testing.T) {
+	affinityTrait := createNominalAffinityTest()
+	affinityTrait.PodAffinity = BoolP(true)
+	affinityTrait.TopologyKey = ""
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	err := affinityTrait.Apply(environment)
+
+	assert.Nil(t, err)
+	podAffinity := deployment.Spec.Template.Spec.Affinity.PodAffinity
+	assert.Len(t, podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1)
+	assert.Equal(t, "kubernetes.io/hostname", podAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey)
+}
+
 func createNominalAffinityTest() *affinityTrait {
 	trait := newAffinityTrait().(*affinityTrait)
 	trait.Enabled = BoolP(true)
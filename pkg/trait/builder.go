@@ -19,26 +19,72 @@ package trait
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/apache/camel-k/pkg/util/property"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util/camel"
 	mvn "github.com/apache/camel-k/pkg/util/maven"
 )
 
+// The Camel catalog runtime metadata holding the Java version required by the runtime
+const catalogJavaVersionMetadata = "java.version"
+
+// The Java version required by the Quarkus major versions, for the catalogs that do not hold the Java version
+var quarkusJavaVersions = map[string]int{
+	"1": 8,
+	"2": 11,
+	"3": 17,
+}
+
+// Matches the Java version in the most common base image names, e.g.
+// `adoptopenjdk/openjdk11:slim`, `registry.access.redhat.com/ubi8/openjdk-17` or `gcr.io/distroless/java17`
+var imageJavaVersionRegexp = regexp.MustCompile(`(?i)(?:jdk|jre|java)[-_:]?(\d+)(?:\.(\d+))?`)
+
 // The builder trait is internally used to determine the best strategy to
 // build and configure IntegrationKits.
 //
 // +camel-k:trait=builder
 type builderTrait struct {
 	BaseTrait `property:",squash"`
-	// Enable verbose logging on build components that support it (e.g. Kaniko build pod).
+	// Enable verbose logging on build components that support it (e.g. Kaniko build pod, Maven).
 	Verbose *bool `property:"verbose" json:"verbose,omitempty"`
 	// A list of properties to be provided to the build task
 	Properties []string `property:"properties" json:"properties,omitempty"`
+	// Run Maven in offline mode, so that the dependencies are only resolved from the local repository.
+	// It overrides the offline mode configured on the platform.
+	Offline *bool `property:"offline" json:"offline,omitempty"`
+	// A list of options to be appended to the Maven command line, e.g. `--debug` or `--errors`.
+	// They are added to the CLI options configured on the platform.
+	MavenOptions []string `property:"maven-options" json:"mavenOptions,omitempty"`
+	// The minimum amount of CPU required by the build pod (only applies with the pod build strategy).
+	RequestCPU string `property:"request-cpu" json:"requestCPU,omitempty"`
+	// The minimum amount of memory required by the build pod (only applies with the pod build strategy).
+	RequestMemory string `property:"request-memory" json:"requestMemory,omitempty"`
+	// The maximum amount of CPU required by the build pod (only applies with the pod build strategy).
+	LimitCPU string `property:"limit-cpu" json:"limitCPU,omitempty"`
+	// The maximum amount of memory required by the build pod (only applies with the pod build strategy).
+	LimitMemory string `property:"limit-memory" json:"limitMemory,omitempty"`
+	// The base image of the IntegrationKit image, overriding the one configured on the platform,
+	// e.g. to select another JDK version, or a distroless image.
+	// The base image Java version, when it can be inferred from the image name, must be greater than, or equal to,
+	// the one required by the Camel catalog.
+	BaseImage string `property:"base-image" json:"baseImage,omitempty"`
+	// A list of custom tasks, in the `name;image;command` format, that run in the given container image once the application is built,
+	// and before the IntegrationKit image is published, e.g. to sign the artifacts, or scan the image build context.
+	// The command is executed with the shell of the container image, from the build directory,
+	// that contains the image build context in the `context` directory.
+	// Custom tasks run after the Maven build, so they cannot contribute sources or dependencies to the application.
+	// They run in the order they are declared, and require the pod build strategy.
+	Tasks []string `property:"tasks" json:"tasks,omitempty"`
 }
 
 func newBuilderTrait() Trait {
@@ -77,7 +123,65 @@ func (t *builderTrait) Apply(e *Environment) error {
 		return nil
 	}
 
+	resources, err := t.buildResources()
+	if err != nil {
+		e.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseError
+		e.IntegrationKit.Status.SetCondition("IntegrationKitResourcesFormatValid", corev1.ConditionFalse,
+			"IntegrationKitResourcesFormatValid", fmt.Sprintf("One or more build resources where not formatted as expected: %s", err.Error()))
+		if err := e.Client.Status().Update(e.C, e.IntegrationKit); err != nil {
+			return err
+		}
+		return nil
+	}
+	e.BuildResources = mergeBuildResources(e.Platform.Status.Build.Resources, resources)
+	// The platform scheduling constraints of the build pods, the toleration trait may add more tolerations
+	tolerations := make([]corev1.Toleration, 0, len(e.Platform.Status.Build.Tolerations)+len(e.BuildTolerations))
+	tolerations = append(tolerations, e.Platform.Status.Build.Tolerations...)
+	e.BuildTolerations = append(tolerations, e.BuildTolerations...)
+	e.BuildNodeSelector = e.Platform.Status.Build.NodeSelector
+
+	baseImage := e.Platform.Status.Build.BaseImage
+	if t.BaseImage != "" {
+		// The build does not reuse the images of other kits, that may be built from another base image
+		baseImage = t.BaseImage
+		builderTask.BaseImage = baseImage
+	}
+	// The native executable does not run on the JVM
+	if !e.IntegrationKit.IsNative() {
+		if err := validateBaseImageJavaVersion(baseImage, e.CamelCatalog); err != nil {
+			e.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseError
+			e.IntegrationKit.Status.SetCondition("IntegrationKitBaseImageValid", corev1.ConditionFalse,
+				"IntegrationKitBaseImageValid", err.Error())
+			if err := e.Client.Status().Update(e.C, e.IntegrationKit); err != nil {
+				return err
+			}
+			return nil
+		}
+	}
+
+	if err := validateBuildPlatforms(e); err != nil {
+		e.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseError
+		e.IntegrationKit.Status.SetCondition("IntegrationKitBuildPlatformsValid", corev1.ConditionFalse,
+			"IntegrationKitBuildPlatformsValid", err.Error())
+		if err := e.Client.Status().Update(e.C, e.IntegrationKit); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	customTasks, err := t.customTasks(e)
+	if err != nil {
+		e.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseError
+		e.IntegrationKit.Status.SetCondition("IntegrationKitTasksValid", corev1.ConditionFalse,
+			"IntegrationKitTasksValid", err.Error())
+		if err := e.Client.Status().Update(e.C, e.IntegrationKit); err != nil {
+			return err
+		}
+		return nil
+	}
+
 	e.BuildTasks = append(e.BuildTasks, v1.Task{Builder: builderTask})
+	e.BuildTasks = append(e.BuildTasks, customTasks...)
 
 	switch e.Platform.Status.Build.PublishStrategy {
 	case v1.IntegrationPlatformBuildPublishStrategySpectrum:
@@ -86,12 +190,25 @@ func (t *builderTrait) Apply(e *Environment) error {
 				Name: "spectrum",
 			},
 			PublishTask: v1.PublishTask{
-				BaseImage: e.Platform.Status.Build.BaseImage,
+				BaseImage: baseImage,
 				Image:     getImageName(e),
 				Registry:  e.Platform.Status.Build.Registry,
 			},
 		}})
 
+	case v1.IntegrationPlatformBuildPublishStrategyJib:
+		e.BuildTasks = append(e.BuildTasks, v1.Task{Jib: &v1.JibTask{
+			BaseTask: v1.BaseTask{
+				Name: "jib",
+			},
+			PublishTask: v1.PublishTask{
+				BaseImage: baseImage,
+				Image:     getImageName(e),
+				Registry:  e.Platform.Status.Build.Registry,
+				Platforms: e.Platform.Status.Build.Platforms,
+			},
+		}})
+
 	case v1.IntegrationPlatformBuildPublishStrategyS2I:
 		e.BuildTasks = append(e.BuildTasks, v1.Task{S2i: &v1.S2iTask{
 			BaseTask: v1.BaseTask{
@@ -111,6 +228,7 @@ func (t *builderTrait) Apply(e *Environment) error {
 			},
 			HttpProxySecret: e.Platform.Status.Build.HTTPProxySecret,
 			Verbose:         t.Verbose,
+			Rootless:        e.Platform.Status.Build.BuildahRootless,
 		}})
 
 	case v1.IntegrationPlatformBuildPublishStrategyKaniko:
@@ -129,6 +247,25 @@ func (t *builderTrait) Apply(e *Environment) error {
 			HttpProxySecret: e.Platform.Status.Build.HTTPProxySecret,
 			Verbose:         t.Verbose,
 		}})
+
+	case v1.IntegrationPlatformBuildPublishStrategyBuildKit:
+		task := &v1.BuildKitTask{
+			BaseTask: v1.BaseTask{
+				Name: "buildkit",
+			},
+			PublishTask: v1.PublishTask{
+				Image:     getImageName(e),
+				Registry:  e.Platform.Status.Build.Registry,
+				Platforms: e.Platform.Status.Build.Platforms,
+			},
+			Address:         e.Platform.Status.Build.BuildKitAddress,
+			HttpProxySecret: e.Platform.Status.Build.HTTPProxySecret,
+			Verbose:         t.Verbose,
+		}
+		if e.Platform.Status.Build.IsBuildKitCacheEnabled() {
+			task.CacheImage = getBuildKitCacheImageName(e)
+		}
+		e.BuildTasks = append(e.BuildTasks, v1.Task{BuildKit: task})
 	}
 
 	return nil
@@ -150,8 +287,11 @@ func (t *builderTrait) builderTask(e *Environment) (*v1.BuilderTask, error) {
 		Maven:        maven,
 	}
 
-	if task.Maven.Properties == nil {
-		task.Maven.Properties = make(map[string]string)
+	// Copy the platform Maven properties, so that the ones contributed by traits
+	// do not leak into the platform
+	task.Maven.Properties = make(map[string]string, len(maven.Properties))
+	for k, v := range maven.Properties {
+		task.Maven.Properties[k] = v
 	}
 	// User provided Maven properties
 	if t.Properties != nil {
@@ -165,12 +305,46 @@ func (t *builderTrait) builderTask(e *Environment) (*v1.BuilderTask, error) {
 		}
 	}
 
+	// Maven CLI options, the ones configured on the trait are appended to the platform ones
+	task.Maven.CLIOptions = append([]string{}, maven.CLIOptions...)
+	if IsTrue(t.Verbose) {
+		task.Maven.CLIOptions = append(task.Maven.CLIOptions, "--debug")
+	}
+	offline := e.Platform.Status.Build.IsOffline()
+	if t.Offline != nil {
+		offline = *t.Offline
+	}
+	if offline && !mvn.IsOffline(task.Maven.CLIOptions) {
+		task.Maven.CLIOptions = append(task.Maven.CLIOptions, "--offline")
+	}
+	task.Maven.CLIOptions = append(task.Maven.CLIOptions, t.MavenOptions...)
+
+	// Dependencies exclusions and overrides, when configured for the IntegrationKit
+	if dependencies, ok := e.Catalog.GetTrait("dependencies").(*dependenciesTrait); ok && IsNilOrTrue(dependencies.Enabled) {
+		task.DependencyExclusions = dependencies.Excludes
+		task.DependencyOverrides = dependencies.Overrides
+	}
+
 	steps := make([]builder.Step, 0)
 	steps = append(steps, builder.DefaultSteps...)
 
 	quarkus := e.Catalog.GetTrait("quarkus").(*quarkusTrait)
 	quarkus.addBuildSteps(&steps)
 
+	// Multi-architecture images do not reuse the images of other kits, that may not be built for all the platforms
+	if len(e.Platform.Status.Build.Platforms) > 0 {
+		for i, step := range steps {
+			if step == builder.Steps.IncrementalImageContext {
+				steps[i] = builder.Steps.StandardImageContext
+			}
+		}
+	}
+
+	// Verify the artifacts when it's configured on the platform
+	if task.Maven.Verification != nil {
+		steps = append(steps, builder.Steps.VerifyArtifacts)
+	}
+
 	// sort steps by phase
 	sort.SliceStable(steps, func(i, j int) bool {
 		return steps[i].Phase() < steps[j].Phase()
@@ -181,6 +355,202 @@ func (t *builderTrait) builderTask(e *Environment) (*v1.BuilderTask, error) {
 	return task, nil
 }
 
+// customTasks returns the user provided tasks, from the `name;image;command` entries configured on the trait
+func (t *builderTrait) customTasks(e *Environment) ([]v1.Task, error) {
+	if len(t.Tasks) == 0 {
+		return nil, nil
+	}
+	if strategy := e.Platform.Status.Build.BuildStrategy; strategy != v1.IntegrationPlatformBuildStrategyPod {
+		return nil, fmt.Errorf("custom tasks are not supported by the %s build strategy, use the %s build strategy",
+			strategy, v1.IntegrationPlatformBuildStrategyPod)
+	}
+
+	// The names of the tasks contributed by the operator
+	names := map[string]bool{
+		"builder":  true,
+		"native":   true,
+		"spectrum": true,
+		"jib":      true,
+		"s2i":      true,
+		"buildah":  true,
+		"kaniko":   true,
+		"buildkit": true,
+	}
+	tasks := make([]v1.Task, 0, len(t.Tasks))
+	for _, entry := range t.Tasks {
+		parts := strings.SplitN(entry, ";", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || strings.TrimSpace(parts[2]) == "" {
+			return nil, fmt.Errorf("custom task must have name;image;command format, it was %v", entry)
+		}
+		name := parts[0]
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid custom task name %s: %s", name, strings.Join(errs, ", "))
+		}
+		if names[name] {
+			return nil, fmt.Errorf("custom task name %s is already used by another task", name)
+		}
+		names[name] = true
+
+		tasks = append(tasks, v1.Task{Custom: &v1.CustomTask{
+			BaseTask: v1.BaseTask{
+				Name: name,
+			},
+			Image:   parts[1],
+			Command: parts[2],
+		}})
+	}
+
+	return tasks, nil
+}
+
+func (t *builderTrait) buildResources() (*corev1.ResourceRequirements, error) {
+	return parseBuildResources("", t.RequestCPU, t.RequestMemory, t.LimitCPU, t.LimitMemory)
+}
+
+// parseBuildResources returns the compute resources of a build container, or nil if none is set.
+// The prefix is that of the trait properties, used to report invalid quantities.
+func parseBuildResources(prefix string, requestCPU string, requestMemory string, limitCPU string, limitMemory string) (*corev1.ResourceRequirements, error) {
+	if requestCPU == "" && requestMemory == "" && limitCPU == "" && limitMemory == "" {
+		return nil, nil
+	}
+
+	resources := corev1.ResourceRequirements{
+		Requests: make(corev1.ResourceList),
+		Limits:   make(corev1.ResourceList),
+	}
+	for _, r := range []struct {
+		list  corev1.ResourceList
+		name  corev1.ResourceName
+		value string
+		key   string
+	}{
+		{resources.Requests, corev1.ResourceCPU, requestCPU, prefix + "request-cpu"},
+		{resources.Requests, corev1.ResourceMemory, requestMemory, prefix + "request-memory"},
+		{resources.Limits, corev1.ResourceCPU, limitCPU, prefix + "limit-cpu"},
+		{resources.Limits, corev1.ResourceMemory, limitMemory, prefix + "limit-memory"},
+	} {
+		if r.value == "" {
+			continue
+		}
+		v, err := resource.ParseQuantity(r.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a valid quantity, it was %v", r.key, r.value)
+		}
+		r.list[r.name] = v
+	}
+
+	return &resources, nil
+}
+
+// mergeBuildResources returns the compute resources configured on the platform, overridden with the ones
+// configured with the trait, or nil if none is set
+func mergeBuildResources(platform *corev1.ResourceRequirements, trait *corev1.ResourceRequirements) *corev1.ResourceRequirements {
+	if platform == nil {
+		return trait
+	}
+	resources := platform.DeepCopy()
+	if trait == nil {
+		return resources
+	}
+	if resources.Requests == nil {
+		resources.Requests = make(corev1.ResourceList)
+	}
+	if resources.Limits == nil {
+		resources.Limits = make(corev1.ResourceList)
+	}
+	for name, quantity := range trait.Requests {
+		resources.Requests[name] = quantity
+	}
+	for name, quantity := range trait.Limits {
+		resources.Limits[name] = quantity
+	}
+
+	return resources
+}
+
+// validateBuildPlatforms checks the multi-architecture images configured on the platform can be built for the kit
+func validateBuildPlatforms(e *Environment) error {
+	platforms := e.Platform.Status.Build.Platforms
+	if len(platforms) == 0 {
+		return nil
+	}
+	for _, p := range platforms {
+		if parts := strings.Split(p, "/"); len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid build platform %s: must have os/arch[/variant] format, e.g. linux/arm64", p)
+		}
+	}
+	if e.IntegrationKit.IsNative() {
+		return fmt.Errorf("multi-architecture images are not supported for native kits, the native executable is built for the builder architecture only")
+	}
+	switch strategy := e.Platform.Status.Build.PublishStrategy; strategy {
+	case v1.IntegrationPlatformBuildPublishStrategyJib, v1.IntegrationPlatformBuildPublishStrategyBuildKit:
+		return nil
+	default:
+		return fmt.Errorf("multi-architecture images are not supported by the %s publish strategy, use the Jib or BuildKit publish strategy", strategy)
+	}
+}
+
+// validateBaseImageJavaVersion checks the Java version of the base image, when it can be inferred
+// from the image name, is compatible with the Java version required by the Camel catalog, if any
+func validateBaseImageJavaVersion(image string, catalog *camel.RuntimeCatalog) error {
+	if catalog == nil {
+		return nil
+	}
+	requiredVersion, ok, err := catalogJavaVersion(catalog)
+	if err != nil || !ok {
+		return err
+	}
+	version, ok := imageJavaVersion(image)
+	if !ok {
+		return nil
+	}
+	if version < requiredVersion {
+		return fmt.Errorf("base image %s provides Java %d, while the Camel catalog %s requires Java %d or greater",
+			image, version, catalog.Runtime.Version, requiredVersion)
+	}
+
+	return nil
+}
+
+// catalogJavaVersion returns the Java version required by the Camel catalog, either from its metadata,
+// or derived from the Quarkus version of the runtime
+func catalogJavaVersion(catalog *camel.RuntimeCatalog) (int, bool, error) {
+	if required, ok := catalog.Runtime.Metadata[catalogJavaVersionMetadata]; ok {
+		version, err := strconv.Atoi(required)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid Java version %s in the Camel catalog", required)
+		}
+		return version, true, nil
+	}
+	if catalog.Runtime.Provider != v1.RuntimeProviderQuarkus {
+		return 0, false, nil
+	}
+	quarkusVersion := catalog.Runtime.Metadata["quarkus.version"]
+	version, ok := quarkusJavaVersions[strings.SplitN(quarkusVersion, ".", 2)[0]]
+
+	return version, ok, nil
+}
+
+// imageJavaVersion infers the Java major version from the image name, e.g. 11 for `adoptopenjdk/openjdk11:slim`
+func imageJavaVersion(image string) (int, bool) {
+	match := imageJavaVersionRegexp.FindStringSubmatch(image)
+	if match == nil {
+		return 0, false
+	}
+	version, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	// Legacy version scheme, e.g. 1.8
+	if version == 1 && match[2] != "" {
+		if version, err = strconv.Atoi(match[2]); err != nil {
+			return 0, false
+		}
+	}
+
+	return version, true
+}
+
 func getImageName(e *Environment) string {
 	organization := e.Platform.Status.Build.Registry.Organization
 	if organization == "" {
@@ -188,3 +558,16 @@ func getImageName(e *Environment) string {
 	}
 	return e.Platform.Status.Build.Registry.Address + "/" + organization + "/camel-k-" + e.IntegrationKit.Name + ":" + e.IntegrationKit.ResourceVersion
 }
+
+// getBuildKitCacheImageName returns the image the BuildKit layer cache is exported to, shared by all the kits,
+// so that the layers of the base image and of the common dependencies are reused across builds
+func getBuildKitCacheImageName(e *Environment) string {
+	if e.Platform.Status.Build.BuildKitCacheImage != "" {
+		return e.Platform.Status.Build.BuildKitCacheImage
+	}
+	organization := e.Platform.Status.Build.Registry.Organization
+	if organization == "" {
+		organization = e.Platform.Namespace
+	}
+	return e.Platform.Status.Build.Registry.Address + "/" + organization + "/camel-k-buildkit-cache"
+}
@@ -24,12 +24,15 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
 )
 
 func TestBuilderTraitNotAppliedBecauseOfNilKit(t *testing.T) {
@@ -100,6 +103,56 @@ func TestKanikoBuilderTrait(t *testing.T) {
 	assert.NotNil(t, env.BuildTasks[1].Kaniko)
 }
 
+func TestBuildahRootlessBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyBuildah)
+	env.Platform.Status.Build.BuildahRootless = BoolP(true)
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.NotNil(t, env.BuildTasks[1].Buildah)
+	assert.True(t, *env.BuildTasks[1].Buildah.Rootless)
+}
+
+func TestJibBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib)
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.NotNil(t, env.BuildTasks[1].Jib)
+	assert.Equal(t, "jib", env.BuildTasks[1].Jib.Name)
+	assert.Equal(t, "registry", env.BuildTasks[1].Jib.Registry.Address)
+}
+
+func TestBuildKitBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyBuildKit)
+	env.Platform.Namespace = "ns"
+	env.Platform.Status.Build.BuildKitAddress = "tcp://buildkitd:1234"
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.NotNil(t, env.BuildTasks[1].BuildKit)
+	assert.Equal(t, "buildkit", env.BuildTasks[1].BuildKit.Name)
+	assert.Equal(t, "tcp://buildkitd:1234", env.BuildTasks[1].BuildKit.Address)
+	assert.Equal(t, "registry/ns/camel-k-buildkit-cache", env.BuildTasks[1].BuildKit.CacheImage)
+}
+
+func TestBuildKitWithoutCacheBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyBuildKit)
+	env.Platform.Status.Build.BuildKitCache = BoolP(false)
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[1].BuildKit)
+	assert.Empty(t, env.BuildTasks[1].BuildKit.CacheImage)
+}
+
 func createBuilderTestEnv(cluster v1.IntegrationPlatformCluster, strategy v1.IntegrationPlatformBuildPublishStrategy) *Environment {
 	c, err := camel.DefaultCatalog()
 	if err != nil {
@@ -162,6 +215,314 @@ func TestMavenPropertyBuilderTrait(t *testing.T) {
 	assert.Equal(t, "build-time-value1", env.BuildTasks[0].Builder.Maven.Properties["build-time-prop1"])
 }
 
+func TestMavenOptionsBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.Maven.CLIOptions = []string{"--no-transfer-progress"}
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.Verbose = BoolP(true)
+	builderTrait.Offline = BoolP(true)
+	builderTrait.MavenOptions = []string{"--fail-fast"}
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--no-transfer-progress", "--debug", "--offline", "--fail-fast"}, env.BuildTasks[0].Builder.Maven.CLIOptions)
+	assert.Equal(t, []string{"--no-transfer-progress"}, env.Platform.Status.Build.Maven.CLIOptions)
+	assert.Nil(t, env.BuildResources)
+}
+
+func TestPlatformOfflineBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.Offline = BoolP(true)
+	builderTrait := createNominalBuilderTraitTest()
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--offline"}, env.BuildTasks[0].Builder.Maven.CLIOptions)
+
+	env = createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.Offline = BoolP(true)
+	builderTrait = createNominalBuilderTraitTest()
+	builderTrait.Offline = BoolP(false)
+
+	err = builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.BuildTasks[0].Builder.Maven.CLIOptions, "--offline")
+}
+
+func TestArtifactsVerificationBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	builderTrait := createNominalBuilderTraitTest()
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.BuildTasks[0].Builder.Steps, builder.StepIDsFor(builder.Steps.VerifyArtifacts)[0])
+
+	env = createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.Maven.Verification = &v1.MavenVerificationSpec{}
+	builderTrait = createNominalBuilderTraitTest()
+
+	err = builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.BuildTasks[0].Builder.Maven.Verification)
+	assert.Contains(t, env.BuildTasks[0].Builder.Steps, builder.StepIDsFor(builder.Steps.VerifyArtifacts)[0])
+}
+
+func TestMultiArchitectureBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib)
+	env.Platform.Status.Build.Platforms = []string{"linux/amd64", "linux/arm64"}
+	builderTrait := createNominalBuilderTraitTest()
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, env.BuildTasks[1].Jib.Platforms)
+	assert.Contains(t, env.BuildTasks[0].Builder.Steps, builder.StepIDsFor(builder.Steps.StandardImageContext)[0])
+	assert.NotContains(t, env.BuildTasks[0].Builder.Steps, builder.StepIDsFor(builder.Steps.IncrementalImageContext)[0])
+}
+
+func TestValidateBuildPlatforms(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	assert.Nil(t, validateBuildPlatforms(env))
+
+	env.Platform.Status.Build.Platforms = []string{"linux/amd64", "linux/arm64"}
+	err := validateBuildPlatforms(env)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not supported by the Kaniko publish strategy")
+
+	env = createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyBuildKit)
+	env.Platform.Status.Build.Platforms = []string{"linux/amd64", "linux/arm/v7"}
+	assert.Nil(t, validateBuildPlatforms(env))
+
+	env.Platform.Status.Build.Platforms = []string{"arm64"}
+	err = validateBuildPlatforms(env)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid build platform arm64")
+
+	env.Platform.Status.Build.Platforms = []string{"linux/arm64"}
+	env.IntegrationKit.Labels = map[string]string{v1.IntegrationKitLayoutLabel: v1.IntegrationKitLayoutNative}
+	err = validateBuildPlatforms(env)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not supported for native kits")
+}
+
+func TestCustomTasksBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.Tasks = []string{
+		"scan;quay.io/example/scanner:1.0;scan context",
+		"sign;quay.io/example/signer:1.0;sign-jars context/dependencies; echo signed",
+	}
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 4)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.Equal(t, &v1.CustomTask{
+		BaseTask: v1.BaseTask{Name: "scan"},
+		Image:    "quay.io/example/scanner:1.0",
+		Command:  "scan context",
+	}, env.BuildTasks[1].Custom)
+	assert.Equal(t, &v1.CustomTask{
+		BaseTask: v1.BaseTask{Name: "sign"},
+		Image:    "quay.io/example/signer:1.0",
+		Command:  "sign-jars context/dependencies; echo signed",
+	}, env.BuildTasks[2].Custom)
+	assert.NotNil(t, env.BuildTasks[3].Kaniko)
+}
+
+func TestInvalidCustomTasksBuilderTrait(t *testing.T) {
+	for _, tc := range []struct {
+		strategy v1.IntegrationPlatformBuildStrategy
+		tasks    []string
+		message  string
+	}{
+		{v1.IntegrationPlatformBuildStrategyRoutine, []string{"sign;signer;sign-jars"}, "not supported by the routine build strategy"},
+		{v1.IntegrationPlatformBuildStrategyPod, []string{"sign;signer"}, "must have name;image;command format"},
+		{v1.IntegrationPlatformBuildStrategyPod, []string{"Sign;signer;sign-jars"}, "invalid custom task name Sign"},
+		{v1.IntegrationPlatformBuildStrategyPod, []string{"kaniko;signer;sign-jars"}, "name kaniko is already used"},
+		{v1.IntegrationPlatformBuildStrategyPod, []string{"sign;signer;sign-jars", "sign;signer;sign-jars"}, "name sign is already used"},
+	} {
+		env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+		env.Platform.Status.Build.BuildStrategy = tc.strategy
+		env.IntegrationKit.Name = "my-kit"
+		client, _ := test.NewFakeClient(env.IntegrationKit)
+		env.Client = client
+		builderTrait := createNominalBuilderTraitTest()
+		builderTrait.Tasks = tc.tasks
+
+		err := builderTrait.Apply(env)
+
+		assert.Nil(t, err)
+		assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+		condition := env.IntegrationKit.Status.GetCondition("IntegrationKitTasksValid")
+		assert.NotNil(t, condition)
+		assert.Contains(t, condition.Message, tc.message)
+		assert.Empty(t, env.BuildTasks)
+	}
+}
+
+func TestDependencyOverridesBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	dependencies := env.Catalog.GetTrait("dependencies").(*dependenciesTrait)
+	dependencies.Excludes = []string{"commons-logging:commons-logging"}
+	dependencies.Overrides = []string{"com.fasterxml.jackson.core:jackson-databind:2.12.1"}
+	builderTrait := createNominalBuilderTraitTest()
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"commons-logging:commons-logging"}, env.BuildTasks[0].Builder.DependencyExclusions)
+	assert.Equal(t, []string{"com.fasterxml.jackson.core:jackson-databind:2.12.1"}, env.BuildTasks[0].Builder.DependencyOverrides)
+	assert.Contains(t, env.BuildTasks[0].Builder.Steps, builder.StepIDsFor(builder.Steps.ManageDependencyOverrides)[0])
+}
+
+func TestBuildResourcesBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.RequestCPU = "500m"
+	builderTrait.LimitMemory = "2Gi"
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.BuildResources)
+	assert.Equal(t, resource.MustParse("500m"), env.BuildResources.Requests[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("2Gi"), env.BuildResources.Limits[corev1.ResourceMemory])
+	_, hasLimitCPU := env.BuildResources.Limits[corev1.ResourceCPU]
+	assert.False(t, hasLimitCPU)
+}
+
+func TestPlatformBuildPodBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+	}
+	env.Platform.Status.Build.NodeSelector = map[string]string{"node-role.kubernetes.io/build": ""}
+	env.Platform.Status.Build.Tolerations = []corev1.Toleration{
+		{Key: "build", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	}
+	env.BuildTolerations = []corev1.Toleration{
+		{Key: "kit", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	}
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.LimitMemory = "2Gi"
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.BuildResources)
+	assert.Equal(t, resource.MustParse("1"), env.BuildResources.Requests[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("1Gi"), env.BuildResources.Requests[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("2Gi"), env.BuildResources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("4Gi"), env.Platform.Status.Build.Resources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/build": ""}, env.BuildNodeSelector)
+	assert.Len(t, env.BuildTolerations, 2)
+	assert.Equal(t, "build", env.BuildTolerations[0].Key)
+	assert.Equal(t, "kit", env.BuildTolerations[1].Key)
+}
+
+func TestInvalidBuildResourcesBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.IntegrationKit.Name = "my-kit"
+	client, _ := test.NewFakeClient(env.IntegrationKit)
+	env.Client = client
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.LimitMemory = "two gigs"
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+	assert.Empty(t, env.BuildTasks)
+}
+
+func TestBaseImageBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib)
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.BaseImage = "gcr.io/distroless/java17-debian11"
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.Equal(t, "gcr.io/distroless/java17-debian11", env.BuildTasks[0].Builder.BaseImage)
+	assert.Equal(t, "gcr.io/distroless/java17-debian11", env.BuildTasks[1].Jib.BaseImage)
+	assert.NotEqual(t, "gcr.io/distroless/java17-debian11", env.Platform.Status.Build.BaseImage)
+}
+
+func TestBaseImageJavaVersionBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.IntegrationKit.Name = "my-kit"
+	client, _ := test.NewFakeClient(env.IntegrationKit)
+	env.Client = client
+	metadata := map[string]string{catalogJavaVersionMetadata: "17"}
+	for k, v := range env.CamelCatalog.Runtime.Metadata {
+		metadata[k] = v
+	}
+	env.CamelCatalog.Runtime.Metadata = metadata
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.BaseImage = "adoptopenjdk/openjdk11:slim"
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+	assert.Empty(t, env.BuildTasks)
+
+	env.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseBuildSubmitted
+	builderTrait.BaseImage = "registry.access.redhat.com/ubi8/openjdk-17"
+
+	err = builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Equal(t, v1.IntegrationKitPhaseBuildSubmitted, env.IntegrationKit.Status.Phase)
+	assert.Len(t, env.BuildTasks, 2)
+}
+
+func TestBaseImageJavaVersionDefaultCatalog(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	// The Java version is derived from the Quarkus version of the shipped catalog
+	version, ok, err := catalogJavaVersion(catalog)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 11, version)
+
+	assert.NotNil(t, validateBaseImageJavaVersion("adoptopenjdk/openjdk8:slim", catalog))
+	assert.Nil(t, validateBaseImageJavaVersion("adoptopenjdk/openjdk11:slim", catalog))
+	assert.Nil(t, validateBaseImageJavaVersion("registry.access.redhat.com/ubi8/openjdk-17", catalog))
+}
+
+func TestImageJavaVersion(t *testing.T) {
+	for image, expected := range map[string]int{
+		"adoptopenjdk/openjdk11:slim":                11,
+		"registry.access.redhat.com/ubi8/openjdk-17": 17,
+		"gcr.io/distroless/java17-debian11":          17,
+		"openjdk:8u292-jre":                          8,
+		"azul/zulu-openjdk:1.8":                      8,
+		"eclipse-temurin:17":                         0,
+	} {
+		version, ok := imageJavaVersion(image)
+		assert.Equal(t, expected != 0, ok, image)
+		assert.Equal(t, expected, version, image)
+	}
+}
+
 func createNominalBuilderTraitTest() *builderTrait {
 	builderTrait := newBuilderTrait().(*builderTrait)
 	builderTrait.Enabled = BoolP(true)
@@ -36,6 +36,9 @@ type camelTrait struct {
 	BaseTrait `property:",squash"`
 	// The camel-k-runtime version to use for the integration. It overrides the default version set in the Integration Platform.
 	RuntimeVersion string `property:"runtime-version" json:"runtimeVersion,omitempty"`
+	// The Apache Camel version, or semver constraint (e.g. `~3.11.0`), the integration runtime must provide.
+	// When no runtime version is set, the most recent runtime providing a matching Apache Camel version is used.
+	Version string `property:"version" json:"version,omitempty"`
 }
 
 func newCamelTrait() Trait {
@@ -56,12 +59,29 @@ func (t *camelTrait) Apply(e *Environment) error {
 	rv := t.determineRuntimeVersion(e)
 
 	if e.CamelCatalog == nil {
-		err := t.loadOrCreateCatalog(e, rv)
+		var err error
+		if t.Version != "" && t.RuntimeVersion == "" {
+			err = t.loadCatalogForCamelVersion(e, rv)
+		} else {
+			err = t.loadOrCreateCatalog(e, rv)
+		}
 		if err != nil {
 			return err
 		}
 	}
 
+	if t.Version != "" {
+		if !camel.MatchesCamelVersion(e.CamelCatalog.CamelCatalogSpec, t.Version) {
+			return fmt.Errorf("runtime version %s provides Camel version %s, that does not match the required version %s",
+				e.CamelCatalog.Runtime.Version,
+				e.CamelCatalog.Runtime.Metadata["camel.version"],
+				t.Version)
+		}
+		if t.RuntimeVersion == "" {
+			rv = e.CamelCatalog.Runtime.Version
+		}
+	}
+
 	e.RuntimeVersion = rv
 
 	if e.Integration != nil {
@@ -135,6 +155,39 @@ func (t *camelTrait) loadOrCreateCatalog(e *Environment, runtimeVersion string)
 	return nil
 }
 
+func (t *camelTrait) loadCatalogForCamelVersion(e *Environment, runtimeVersion string) error {
+	ns := e.DetermineCatalogNamespace()
+	if ns == "" {
+		return errors.New("unable to determine namespace")
+	}
+
+	// Favor the runtime version the integration has already been resolved to,
+	// so that it does not change unexpectedly when new catalogs are created
+	catalog, err := camel.LoadCatalog(e.C, e.Client, ns, v1.RuntimeSpec{
+		Version:  runtimeVersion,
+		Provider: v1.RuntimeProviderQuarkus,
+	})
+	if err != nil {
+		return err
+	}
+	if catalog == nil || !camel.MatchesCamelVersion(catalog.CamelCatalogSpec, t.Version) {
+		catalog, err = camel.LoadCatalogForCamelVersion(e.C, e.Client, ns, v1.RuntimeProviderQuarkus, t.Version)
+		if err != nil {
+			return err
+		}
+	}
+
+	if catalog == nil {
+		return fmt.Errorf("unable to find catalog matching version requirement: camel=%s, provider=%s",
+			t.Version,
+			v1.RuntimeProviderQuarkus)
+	}
+
+	e.CamelCatalog = catalog
+
+	return nil
+}
+
 func (t *camelTrait) determineRuntimeVersion(e *Environment) string {
 	if t.RuntimeVersion != "" {
 		return t.RuntimeVersion
@@ -68,6 +68,46 @@ func TestApplyCamelTraitWithoutEnvironmentCatalogAndUnmatchableVersionFails(t *t
 	assert.Equal(t, "unable to find catalog matching version requirement: runtime=Unmatchable version, provider=quarkus", err.Error())
 }
 
+func TestApplyCamelTraitWithCamelVersion(t *testing.T) {
+	trait, environment := createNominalCamelTest()
+	environment.CamelCatalog = nil
+	environment.Integration.Status.RuntimeVersion = ""
+	environment.Platform = &v1.IntegrationPlatform{}
+	trait.Version = "~3.11.0"
+	trait.Client, _ = test.NewFakeClient(
+		newCamelTestCatalog("1.7.0", "3.9.0"),
+		newCamelTestCatalog("1.8.0", "3.11.0"),
+	)
+	environment.Client = trait.Client
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.8.0", environment.RuntimeVersion)
+	assert.Equal(t, "1.8.0", environment.Integration.Status.RuntimeVersion)
+}
+
+func TestApplyCamelTraitWithUnmatchableCamelVersionFails(t *testing.T) {
+	trait, environment := createNominalCamelTest()
+	environment.CamelCatalog.Runtime.Metadata = map[string]string{"camel.version": "3.9.0"}
+	trait.Version = "3.11.0"
+
+	err := trait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "runtime version 0.0.1 provides Camel version 3.9.0, that does not match the required version 3.11.0", err.Error())
+}
+
+func newCamelTestCatalog(runtimeVersion string, camelVersion string) *v1.CamelCatalog {
+	catalog := v1.NewCamelCatalog("namespace", "camel-catalog-"+runtimeVersion)
+	catalog.Spec.Runtime = v1.RuntimeSpec{
+		Version:  runtimeVersion,
+		Provider: v1.RuntimeProviderQuarkus,
+		Metadata: map[string]string{
+			"camel.version": camelVersion,
+		},
+	}
+	return &catalog
+}
+
 func createNominalCamelTest() (*camelTrait, *Environment) {
 	client, _ := test.NewFakeClient()
 
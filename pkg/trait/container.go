@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	appsv1 "k8s.io/api/apps/v1"
@@ -28,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	serving "knative.dev/serving/pkg/apis/serving/v1"
 
@@ -79,8 +82,16 @@ type containerTrait struct {
 	Name string `property:"name" json:"name,omitempty"`
 	// The main container image
 	Image string `property:"image" json:"image,omitempty"`
+	// The pull policy of the main container image, either `Always`, `Never` or `IfNotPresent`.
+	ImagePullPolicy string `property:"image-pull-policy" json:"imagePullPolicy,omitempty"`
+	// Additional named ports exposed by the container, in the form `name:port[/protocol]`,
+	// e.g. `management:9000` to declare a separate management port.
+	// The protocol is `TCP` by default. They are not exposed on Knative services, that only support a single port.
+	// The names must be unique, and differ from the name of the main port.
+	Ports []string `property:"ports" json:"ports,omitempty"`
 
 	// ProbesEnabled enable/disable probes on the container (default `false`)
+	// Deprecated: replaced by the health trait, that can configure each probe independently.
 	ProbesEnabled *bool `property:"probes-enabled" json:"probesEnabled,omitempty"`
 	// Scheme to use when connecting. Defaults to HTTP. Applies to the liveness probe.
 	LivenessScheme string `property:"liveness-scheme" json:"livenessScheme,omitempty"`
@@ -134,6 +145,15 @@ func (t *containerTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	switch corev1.PullPolicy(t.ImagePullPolicy) {
+	case "", corev1.PullAlways, corev1.PullNever, corev1.PullIfNotPresent:
+	default:
+		return false, fmt.Errorf("unsupported image pull policy: %s", t.ImagePullPolicy)
+	}
+	if _, err := t.getAdditionalPorts(); err != nil {
+		return false, err
+	}
+
 	if IsNilOrTrue(t.Auto) {
 		if t.Expose == nil {
 			e := e.Resources.GetServiceForIntegration(e.Integration) != nil
@@ -189,6 +209,8 @@ func (t *containerTrait) configureDependencies(e *Environment) error {
 				kubernetes.CamelCreatorLabelVersion:   e.Integration.ResourceVersion,
 			}
 
+			v1.SetOperatorIDAnnotation(&kit, v1.GetOperatorIDAnnotation(e.Integration))
+
 			t.L.Infof("image %s", kit.Spec.Image)
 			e.Resources.Add(&kit)
 			e.Integration.SetIntegrationKit(&kit)
@@ -215,9 +237,10 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 	}
 
 	container := corev1.Container{
-		Name:  t.Name,
-		Image: e.Integration.Status.Image,
-		Env:   make([]corev1.EnvVar, 0),
+		Name:            t.Name,
+		Image:           e.Integration.Status.Image,
+		ImagePullPolicy: corev1.PullPolicy(t.ImagePullPolicy),
+		Env:             make([]corev1.EnvVar, 0),
 	}
 
 	// combine Environment of integration with platform, kit, integration
@@ -242,6 +265,10 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 	if portName == "" {
 		portName = defaultContainerPortName
 	}
+	additionalPorts, err := t.getAdditionalPorts()
+	if err != nil {
+		return err
+	}
 	// Deployment
 	if err := e.Resources.VisitDeploymentE(func(deployment *appsv1.Deployment) error {
 		if IsTrue(t.ProbesEnabled) && portName == defaultContainerPortName {
@@ -262,6 +289,8 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 			&container.VolumeMounts,
 		)
 
+		container.Ports = append(container.Ports, additionalPorts...)
+
 		deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, container)
 
 		return nil
@@ -328,6 +357,8 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 			&container.VolumeMounts,
 		)
 
+		container.Ports = append(container.Ports, additionalPorts...)
+
 		cron.Spec.JobTemplate.Spec.Template.Spec.Containers = append(cron.Spec.JobTemplate.Spec.Template.Spec.Containers, container)
 
 		return nil
@@ -361,6 +392,11 @@ func (t *containerTrait) configureService(e *Environment, container *corev1.Cont
 		Protocol:   corev1.ProtocolTCP,
 		TargetPort: intstr.FromString(name),
 	}
+	if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if st, ok := e.Catalog.GetTrait(serviceTraitID).(*serviceTrait); ok && st.NodePortNumber != nil {
+			servicePort.NodePort = *st.NodePortNumber
+		}
+	}
 
 	e.Integration.Status.SetCondition(
 		v1.IntegrationConditionServiceAvailable,
@@ -380,6 +416,57 @@ func (t *containerTrait) configureService(e *Environment, container *corev1.Cont
 	service.Labels["camel.apache.org/service.type"] = v1.ServiceTypeUser
 }
 
+func (t *containerTrait) getAdditionalPorts() ([]corev1.ContainerPort, error) {
+	mainPortName := t.PortName
+	if mainPortName == "" {
+		mainPortName = defaultContainerPortName
+	}
+	names := map[string]bool{
+		mainPortName: true,
+	}
+
+	ports := make([]corev1.ContainerPort, 0, len(t.Ports))
+	for _, p := range t.Ports {
+		parts := strings.SplitN(p, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("container port must have name:port[/protocol] format, it was %v", p)
+		}
+		name, value := parts[0], parts[1]
+		protocol := corev1.ProtocolTCP
+		if i := strings.Index(value, "/"); i >= 0 {
+			protocol = corev1.Protocol(strings.ToUpper(value[i+1:]))
+			value = value[:i]
+		}
+		number, err := strconv.Atoi(value)
+		if name == "" || err != nil || number < 1 || number > 65535 {
+			return nil, fmt.Errorf("container port must have name:port[/protocol] format, it was %v", p)
+		}
+		switch protocol {
+		case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+		default:
+			return nil, fmt.Errorf("unsupported protocol %s for container port %s", protocol, name)
+		}
+		if errs := validation.IsValidPortName(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid container port name %s: %s", name, strings.Join(errs, ", "))
+		}
+		if names[name] {
+			return nil, fmt.Errorf("container port name %s is already used by another port", name)
+		}
+		names[name] = true
+		if number == t.Port && protocol == corev1.ProtocolTCP {
+			return nil, fmt.Errorf("container port %s clashes with the %s port %d", name, mainPortName, t.Port)
+		}
+
+		ports = append(ports, corev1.ContainerPort{
+			Name:          name,
+			ContainerPort: int32(number),
+			Protocol:      protocol,
+		})
+	}
+
+	return ports, nil
+}
+
 func (t *containerTrait) configureResources(_ *Environment, container *corev1.Container) {
 	// Requests
 	if container.Resources.Requests == nil {
@@ -152,6 +152,96 @@ func TestContainerWithCustomName(t *testing.T) {
 	assert.Equal(t, trait["name"], d.Spec.Template.Spec.Containers[0].Name)
 }
 
+func TestContainerWithPullPolicyAndAdditionalPorts(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	traitCatalog := NewCatalog(context.TODO(), nil)
+
+	environment := Environment{
+		CamelCatalog: catalog,
+		Catalog:      traitCatalog,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ServiceTestName,
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Traits: map[string]v1.TraitSpec{
+					"container": test.TraitSpecFromMap(t, map[string]interface{}{
+						"imagePullPolicy": "Always",
+						"ports":           []string{"management:9000", "metrics:9779/udp"},
+					}),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterOpenShift,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	err = traitCatalog.apply(&environment)
+	assert.Nil(t, err)
+
+	d := environment.Resources.GetDeploymentForIntegration(environment.Integration)
+	assert.NotNil(t, d)
+	assert.Len(t, d.Spec.Template.Spec.Containers, 1)
+
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, corev1.PullAlways, container.ImagePullPolicy)
+	assert.Equal(t, []corev1.ContainerPort{
+		{Name: "management", ContainerPort: 9000, Protocol: corev1.ProtocolTCP},
+		{Name: "metrics", ContainerPort: 9779, Protocol: corev1.ProtocolUDP},
+	}, container.Ports)
+}
+
+func TestContainerWithInvalidPullPolicyAndPorts(t *testing.T) {
+	environment := &Environment{
+		Integration: &v1.Integration{
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+
+	for _, trait := range []*containerTrait{
+		{ImagePullPolicy: "Sometimes"},
+		{Ports: []string{"management"}},
+		{Ports: []string{"management:port"}},
+		{Ports: []string{"management:70000"}},
+		{Ports: []string{"management:9000/http"}},
+		{Ports: []string{"Management_Port:9000"}},
+		{Ports: []string{"management:9000", "management:9001"}},
+		{Ports: []string{"http:9000"}},
+		{PortName: "web", Ports: []string{"web:9000"}},
+		{Port: 8080, Ports: []string{"management:8080"}},
+	} {
+		configured, err := trait.Configure(environment)
+
+		assert.NotNil(t, err)
+		assert.False(t, configured)
+	}
+}
+
 func TestContainerWithCustomImage(t *testing.T) {
 	catalog, err := camel.DefaultCatalog()
 	assert.Nil(t, err)
@@ -79,6 +79,13 @@ type cronTrait struct {
 	// Optional deadline in seconds for starting the job if it misses scheduled
 	// time for any reason.  Missed jobs executions will be counted as failed ones.
 	StartingDeadlineSeconds *int64 `property:"starting-deadline-seconds" json:"startingDeadlineSeconds,omitempty"`
+	// Specifies the duration in seconds, relative to the start time, that the job
+	// may be continuously active before it is considered to be failed.
+	// It defaults to 60s.
+	ActiveDeadlineSeconds *int64 `property:"active-deadline-seconds" json:"activeDeadlineSeconds,omitempty"`
+	// Specifies the number of retries before marking the job failed.
+	// It defaults to 2.
+	BackoffLimit *int32 `property:"backoff-limit" json:"backoffLimit,omitempty"`
 }
 
 var _ ControllerStrategySelector = &cronTrait{}
@@ -129,6 +136,19 @@ func (t *cronTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	switch v1beta1.ConcurrencyPolicy(t.ConcurrencyPolicy) {
+	case "", v1beta1.AllowConcurrent, v1beta1.ForbidConcurrent, v1beta1.ReplaceConcurrent:
+	default:
+		err := fmt.Errorf("unsupported concurrency policy %q, must be one of %q, %q or %q",
+			t.ConcurrencyPolicy, v1beta1.AllowConcurrent, v1beta1.ForbidConcurrent, v1beta1.ReplaceConcurrent)
+		e.Integration.Status.SetErrorCondition(
+			v1.IntegrationConditionCronJobAvailable,
+			v1.IntegrationConditionCronJobNotAvailableReason,
+			err,
+		)
+		return false, err
+	}
+
 	if _, ok := e.CamelCatalog.Runtime.Capabilities[v1.CapabilityCron]; !ok {
 		e.Integration.Status.SetCondition(
 			v1.IntegrationConditionCronJobAvailable,
@@ -167,6 +187,16 @@ func (t *cronTrait) Configure(e *Environment) (bool, error) {
 			t.ConcurrencyPolicy = string(v1beta1.ForbidConcurrent)
 		}
 
+		if t.ActiveDeadlineSeconds == nil {
+			var defaultActiveDeadlineSeconds int64 = 60
+			t.ActiveDeadlineSeconds = &defaultActiveDeadlineSeconds
+		}
+
+		if t.BackoffLimit == nil {
+			var defaultBackoffLimit int32 = 2
+			t.BackoffLimit = &defaultBackoffLimit
+		}
+
 		if (t.Schedule == "" && t.Components == "") && t.Fallback == nil {
 			// If there's at least a `cron` endpoint, add a fallback implementation
 			fromURIs, err := t.getSourcesFromURIs(e)
@@ -290,6 +320,8 @@ func (t *cronTrait) getCronJobFor(e *Environment) *v1beta1.CronJob {
 			StartingDeadlineSeconds: t.StartingDeadlineSeconds,
 			JobTemplate: v1beta1.JobTemplateSpec{
 				Spec: batchv1.JobSpec{
+					ActiveDeadlineSeconds: t.ActiveDeadlineSeconds,
+					BackoffLimit:          t.BackoffLimit,
 					Template: corev1.PodTemplateSpec{
 						ObjectMeta: metav1.ObjectMeta{
 							Labels:      labels,
@@ -25,6 +25,7 @@ import (
 	passert "github.com/magiconair/properties/assert"
 	"github.com/stretchr/testify/assert"
 
+	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -438,3 +439,95 @@ func TestCronWithQuarkus(t *testing.T) {
 	assert.Nil(t, ct.Fallback)
 	assert.Contains(t, environment.Interceptors, "cron")
 }
+
+func TestCronJobSpec(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	traitCatalog := NewCatalog(context.TODO(), nil)
+
+	environment := Environment{
+		CamelCatalog: catalog,
+		Catalog:      traitCatalog,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.java",
+							Content: `from("cron:tab?schedule=0 0/2 * * ?").to("log:test")`,
+						},
+						Language: v1.LanguageJavaSource,
+					},
+				},
+				Resources: []v1.ResourceSpec{},
+				Traits: map[string]v1.TraitSpec{
+					"cron": test.TraitSpecFromMap(t, map[string]interface{}{
+						"concurrencyPolicy":     "Replace",
+						"activeDeadlineSeconds": 120,
+					}),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterKubernetes,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyKaniko,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+				Profile: v1.TraitProfileKubernetes,
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      k8sutils.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	c, err := NewFakeClient("ns")
+	assert.Nil(t, err)
+
+	tc := NewCatalog(context.TODO(), c)
+
+	err = tc.apply(&environment)
+	assert.Nil(t, err)
+
+	cronJob := environment.Resources.GetCronJob(func(job *v1beta1.CronJob) bool { return job.Name == "test" })
+	assert.NotNil(t, cronJob)
+	assert.Equal(t, "0 0/2 * * ?", cronJob.Spec.Schedule)
+	assert.Equal(t, v1beta1.ReplaceConcurrent, cronJob.Spec.ConcurrencyPolicy)
+	assert.Equal(t, int64(120), *cronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, int32(2), *cronJob.Spec.JobTemplate.Spec.BackoffLimit)
+}
+
+func TestCronInvalidConcurrencyPolicy(t *testing.T) {
+	environment := Environment{
+		Integration: &v1.Integration{
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+
+	ct := newCronTrait().(*cronTrait)
+	ct.ConcurrencyPolicy = "Sometimes"
+
+	ok, err := ct.Configure(&environment)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, corev1.ConditionFalse, environment.Integration.Status.GetCondition(v1.IntegrationConditionCronJobAvailable).Status)
+}
@@ -18,6 +18,9 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/scylladb/go-set/strset"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -29,9 +32,17 @@ import (
 // The Dependencies trait is internally used to automatically add runtime dependencies based on the
 // integration that the user wants to run.
 //
+// It can also be used to exclude, or pin the version of, transitive dependencies, e.g. to resolve
+// classpath conflicts, without changing the Camel catalog.
+//
 // +camel-k:trait=dependencies
 type dependenciesTrait struct {
 	BaseTrait `property:",squash"`
+	// A list of transitive dependencies to exclude from the integration classpath,
+	// in the `groupId:artifactId` format.
+	Excludes []string `property:"excludes" json:"excludes,omitempty"`
+	// A list of dependencies whose version is pinned, in the `groupId:artifactId:version` format.
+	Overrides []string `property:"overrides" json:"overrides,omitempty"`
 }
 
 func newDependenciesTrait() Trait {
@@ -45,6 +56,17 @@ func (t *dependenciesTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	for _, exclude := range t.Excludes {
+		if ga := strings.Split(exclude, ":"); len(ga) != 2 || ga[0] == "" || ga[1] == "" {
+			return false, fmt.Errorf("dependency exclusion must have groupId:artifactId format, it was %s", exclude)
+		}
+	}
+	for _, override := range t.Overrides {
+		if gav := strings.Split(override, ":"); len(gav) != 3 || gav[0] == "" || gav[1] == "" || gav[2] == "" {
+			return false, fmt.Errorf("dependency override must have groupId:artifactId:version format, it was %s", override)
+		}
+	}
+
 	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization), nil
 }
 
@@ -92,3 +114,8 @@ func (t *dependenciesTrait) Apply(e *Environment) error {
 func (t *dependenciesTrait) IsPlatformTrait() bool {
 	return true
 }
+
+// InfluencesKit overrides base class method
+func (t *dependenciesTrait) InfluencesKit() bool {
+	return true
+}
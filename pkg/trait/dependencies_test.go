@@ -48,6 +48,33 @@ func TestDependenciesTraitApplicability(t *testing.T) {
 	assert.True(t, enabled)
 }
 
+func TestDependenciesTraitInvalidExcludesAndOverrides(t *testing.T) {
+	e := &Environment{
+		Catalog: NewEnvironmentTestCatalog(),
+		Integration: &v1.Integration{
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseInitialization,
+			},
+		},
+	}
+
+	trait := newDependenciesTrait().(*dependenciesTrait)
+	trait.Excludes = []string{"commons-logging:commons-logging"}
+	trait.Overrides = []string{"com.fasterxml.jackson.core:jackson-databind:2.12.1"}
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	trait.Excludes = []string{"commons-logging"}
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait.Excludes = nil
+	trait.Overrides = []string{"com.fasterxml.jackson.core:jackson-databind"}
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+}
+
 func TestIntegrationDefaultDeps(t *testing.T) {
 	catalog, err := camel.DefaultCatalog()
 	assert.Nil(t, err)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	appsv1 "k8s.io/api/apps/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
@@ -84,6 +85,9 @@ func (t *deployerTrait) Apply(e *Environment) error {
 		// Register a post action that patches the resources generated by the traits
 		e.PostActions = append(e.PostActions, func(env *Environment) error {
 			for _, resource := range env.Resources.Items() {
+				if err := t.clearRollingUpdate(env, resource); err != nil {
+					return err
+				}
 				// We assume that server-side apply is enabled by default.
 				// It is currently convoluted to check pro-actively whether server-side apply
 				// is enabled. This is possible to fetch the OpenAPI endpoint, which returns
@@ -113,6 +117,34 @@ func (t *deployerTrait) Apply(e *Environment) error {
 	return nil
 }
 
+// clearRollingUpdate removes the rolling update parameters from the existing Deployment, when it's switched to
+// the Recreate strategy, as they are rejected with that strategy, and neither the apply patch, nor the positive
+// merge patch, remove them when they have been defaulted server-side
+func (t *deployerTrait) clearRollingUpdate(env *Environment, resource ctrl.Object) error {
+	deployment, ok := resource.(*appsv1.Deployment)
+	if !ok || deployment.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType {
+		return nil
+	}
+
+	existing := &appsv1.Deployment{}
+	err := env.Client.Get(env.C, ctrl.ObjectKeyFromObject(deployment), existing)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if existing.Spec.Strategy.RollingUpdate == nil {
+		return nil
+	}
+
+	p := []byte(`{"spec":{"strategy":{"type":"Recreate","rollingUpdate":null}}}`)
+	err = env.Client.Patch(env.C, existing, ctrl.RawPatch(types.MergePatchType, p))
+	if err != nil {
+		return errors.Wrapf(err, "error during patch resource: %v", resource)
+	}
+	return nil
+}
+
 func (t *deployerTrait) serverSideApply(env *Environment, resource ctrl.Object) error {
 	target, err := patch.PositiveApplyPatch(resource)
 	if err != nil {
@@ -19,10 +19,13 @@ package trait
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 )
@@ -33,6 +36,28 @@ import (
 // +camel-k:trait=deployment
 type deploymentTrait struct {
 	BaseTrait `property:",squash"`
+	// The maximum time in seconds for the deployment to make progress before it
+	// is considered to be failed (default `600`).
+	ProgressDeadlineSeconds *int32 `property:"progress-deadline-seconds" json:"progressDeadlineSeconds,omitempty"`
+	// The deployment strategy to use to replace existing pods with new ones,
+	// either `RollingUpdate` or `Recreate` (default `RollingUpdate`).
+	// `Recreate` kills all the existing pods before creating new ones, which is required
+	// for integrations that cannot run two versions concurrently, e.g. with exclusive consumers.
+	Strategy string `property:"strategy" json:"strategy,omitempty"`
+	// The maximum number of pods that can be unavailable during the update,
+	// either an absolute number (e.g. `1`) or a percentage of the desired pods (e.g. `25%`).
+	// Only applies with the `RollingUpdate` strategy, and defaults to `25%`.
+	RollingUpdateMaxUnavailable string `property:"rolling-update-max-unavailable" json:"rollingUpdateMaxUnavailable,omitempty"`
+	// The maximum number of pods that can be scheduled above the desired number of pods,
+	// either an absolute number (e.g. `1`) or a percentage of the desired pods (e.g. `25%`).
+	// Only applies with the `RollingUpdate` strategy, and defaults to `25%`.
+	RollingUpdateMaxSurge string `property:"rolling-update-max-surge" json:"rollingUpdateMaxSurge,omitempty"`
+	// The duration in seconds the pods are given to terminate gracefully, after the pre-stop hook is executed
+	// and the termination signal is sent to the integration process (default `30`).
+	TerminationGracePeriodSeconds *int64 `property:"termination-grace-period-seconds" json:"terminationGracePeriodSeconds,omitempty"`
+	// A command executed in the integration container before it's terminated, e.g. to release
+	// exclusive locks held on files or databases, in the form of a list of arguments.
+	PreStop []string `property:"pre-stop" json:"preStop,omitempty"`
 }
 
 var _ ControllerStrategySelector = &deploymentTrait{}
@@ -55,6 +80,10 @@ func (t *deploymentTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if err := t.validate(); err != nil {
+		return false, err
+	}
+
 	if e.IntegrationInPhase(v1.IntegrationPhaseRunning) {
 		condition := e.Integration.Status.GetCondition(v1.IntegrationConditionDeploymentAvailable)
 		return condition != nil && condition.Status == corev1.ConditionTrue, nil
@@ -106,6 +135,25 @@ func (t *deploymentTrait) Apply(e *Environment) error {
 		e.Resources.AddAll(maps)
 		e.Resources.Add(deployment)
 
+		if len(t.PreStop) > 0 {
+			// The integration container is only added by the container trait
+			e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+				container := environment.getIntegrationContainer()
+				if container == nil {
+					return fmt.Errorf("unable to find integration container to set the pre-stop hook")
+				}
+				if container.Lifecycle == nil {
+					container.Lifecycle = &corev1.Lifecycle{}
+				}
+				container.Lifecycle.PreStop = &corev1.Handler{
+					Exec: &corev1.ExecAction{
+						Command: t.PreStop,
+					},
+				}
+				return nil
+			})
+		}
+
 		e.Integration.Status.SetCondition(
 			v1.IntegrationConditionDeploymentAvailable,
 			corev1.ConditionTrue,
@@ -135,6 +183,69 @@ func (t *deploymentTrait) IsPlatformTrait() bool {
 	return true
 }
 
+func (t *deploymentTrait) validate() error {
+	switch appsv1.DeploymentStrategyType(t.Strategy) {
+	case "", appsv1.RollingUpdateDeploymentStrategyType:
+	case appsv1.RecreateDeploymentStrategyType:
+		if t.RollingUpdateMaxUnavailable != "" || t.RollingUpdateMaxSurge != "" {
+			return fmt.Errorf("rolling update parameters are not supported with the %s deployment strategy", t.Strategy)
+		}
+	default:
+		return fmt.Errorf("unsupported deployment strategy %q, must be one of %q or %q",
+			t.Strategy, appsv1.RollingUpdateDeploymentStrategyType, appsv1.RecreateDeploymentStrategyType)
+	}
+
+	for name, value := range map[string]string{
+		"rolling-update-max-unavailable": t.RollingUpdateMaxUnavailable,
+		"rolling-update-max-surge":       t.RollingUpdateMaxSurge,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimSuffix(value, "%")); err != nil {
+			return fmt.Errorf("%s must be a number or a percentage, it was %s", name, value)
+		}
+	}
+
+	if t.ProgressDeadlineSeconds != nil && *t.ProgressDeadlineSeconds <= 0 {
+		return fmt.Errorf("progress-deadline-seconds must be a positive number, it was %d", *t.ProgressDeadlineSeconds)
+	}
+
+	if t.TerminationGracePeriodSeconds != nil && *t.TerminationGracePeriodSeconds < 0 {
+		return fmt.Errorf("termination-grace-period-seconds must not be negative, it was %d", *t.TerminationGracePeriodSeconds)
+	}
+
+	return nil
+}
+
+func (t *deploymentTrait) getDeploymentStrategy() appsv1.DeploymentStrategy {
+	if appsv1.DeploymentStrategyType(t.Strategy) == appsv1.RecreateDeploymentStrategyType {
+		// The rolling update parameters are rejected with the Recreate strategy,
+		// and are cleared from the existing Deployment by the deployer trait
+		return appsv1.DeploymentStrategy{
+			Type:          appsv1.RecreateDeploymentStrategyType,
+			RollingUpdate: nil,
+		}
+	}
+
+	strategy := appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+	}
+	if t.RollingUpdateMaxUnavailable != "" || t.RollingUpdateMaxSurge != "" {
+		strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+		if t.RollingUpdateMaxUnavailable != "" {
+			v := intstr.Parse(t.RollingUpdateMaxUnavailable)
+			strategy.RollingUpdate.MaxUnavailable = &v
+		}
+		if t.RollingUpdateMaxSurge != "" {
+			v := intstr.Parse(t.RollingUpdateMaxSurge)
+			strategy.RollingUpdate.MaxSurge = &v
+		}
+	}
+
+	return strategy
+}
+
 func (t *deploymentTrait) getDeploymentFor(e *Environment) *appsv1.Deployment {
 	// create a copy to avoid sharing the underlying annotation map
 	annotations := make(map[string]string)
@@ -158,7 +269,9 @@ func (t *deploymentTrait) getDeploymentFor(e *Environment) *appsv1.Deployment {
 			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: e.Integration.Spec.Replicas,
+			ProgressDeadlineSeconds: t.ProgressDeadlineSeconds,
+			Replicas:                e.Integration.Spec.Replicas,
+			Strategy:                t.getDeploymentStrategy(),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
 					v1.IntegrationLabel: e.Integration.Name,
@@ -172,7 +285,8 @@ func (t *deploymentTrait) getDeploymentFor(e *Environment) *appsv1.Deployment {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: e.Integration.Spec.ServiceAccountName,
+					ServiceAccountName:            e.Integration.Spec.ServiceAccountName,
+					TerminationGracePeriodSeconds: t.TerminationGracePeriodSeconds,
 				},
 			},
 		},
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/test"
 
@@ -30,6 +31,9 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestConfigureDisabledDeploymentTraitDoesNotSucceed(t *testing.T) {
@@ -143,6 +147,178 @@ func TestApplyDeploymentTraitWhileRunningIntegrationDoesSucceed(t *testing.T) {
 	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
 }
 
+func TestApplyDeploymentTraitWithRollingUpdateStrategy(t *testing.T) {
+	deploymentTrait, environment := createNominalDeploymentTest()
+	deploymentTrait.RollingUpdateMaxUnavailable = "1"
+	deploymentTrait.RollingUpdateMaxSurge = "50%"
+	progressDeadlineSeconds := int32(120)
+	deploymentTrait.ProgressDeadlineSeconds = &progressDeadlineSeconds
+
+	configured, err := deploymentTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = deploymentTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	deployment := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, deployment)
+	assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	assert.Equal(t, intstr.FromInt(1), *deployment.Spec.Strategy.RollingUpdate.MaxUnavailable)
+	assert.Equal(t, intstr.FromString("50%"), *deployment.Spec.Strategy.RollingUpdate.MaxSurge)
+	assert.Equal(t, int32(120), *deployment.Spec.ProgressDeadlineSeconds)
+}
+
+func TestApplyDeploymentTraitWithRecreateStrategy(t *testing.T) {
+	deploymentTrait, environment := createNominalDeploymentTest()
+	deploymentTrait.Strategy = string(appsv1.RecreateDeploymentStrategyType)
+
+	configured, err := deploymentTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = deploymentTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	deployment := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, deployment)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	assert.Nil(t, deployment.Spec.Strategy.RollingUpdate)
+}
+
+func TestApplyDeploymentTraitFromRollingUpdateToRecreateStrategy(t *testing.T) {
+	maxSurge := intstr.FromString("25%")
+	maxUnavailable := intstr.FromString("25%")
+	c, err := test.NewFakeClient(&appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "integration-name",
+			Namespace: "namespace",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	recorder := &patchRecorder{Client: c}
+
+	deploymentTrait, environment := createNominalDeploymentTest()
+	deploymentTrait.Strategy = string(appsv1.RecreateDeploymentStrategyType)
+	environment.C = context.TODO()
+	environment.Client = recorder
+	environment.Integration.Namespace = "namespace"
+
+	configured, err := deploymentTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	assert.Nil(t, deploymentTrait.Apply(environment))
+	assert.Nil(t, newDeployerTrait().Apply(environment))
+	for _, action := range environment.PostActions {
+		assert.Nil(t, action(environment))
+	}
+
+	// The rolling update parameters are cleared before the Deployment is applied
+	var patches []string
+	for _, p := range recorder.patches {
+		if p.kind == "Deployment" {
+			patches = append(patches, p.data)
+		}
+	}
+	assert.Len(t, patches, 2)
+	assert.Equal(t, `{"spec":{"strategy":{"type":"Recreate","rollingUpdate":null}}}`, patches[0])
+	assert.Contains(t, patches[1], `"strategy":{"type":"Recreate"}`)
+}
+
+type recordedPatch struct {
+	kind string
+	data string
+}
+
+// patchRecorder records the patches, as the fake client does not support merge patches on typed objects
+type patchRecorder struct {
+	client.Client
+	patches []recordedPatch
+}
+
+func (r *patchRecorder) Patch(ctx context.Context, obj ctrl.Object, patch ctrl.Patch, opts ...ctrl.PatchOption) error {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if _, ok := obj.(*appsv1.Deployment); ok {
+		kind = "Deployment"
+	}
+	r.patches = append(r.patches, recordedPatch{kind: kind, data: string(data)})
+	return nil
+}
+
+func TestApplyDeploymentTraitWithTerminationSettings(t *testing.T) {
+	deploymentTrait, environment := createNominalDeploymentTest()
+	deploymentTrait.Strategy = string(appsv1.RecreateDeploymentStrategyType)
+	gracePeriod := int64(120)
+	deploymentTrait.TerminationGracePeriodSeconds = &gracePeriod
+	deploymentTrait.PreStop = []string{"/bin/sh", "-c", "rm -f /data/lock"}
+
+	configured, err := deploymentTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = deploymentTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	deployment := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, deployment)
+	assert.Equal(t, &gracePeriod, deployment.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: defaultContainerName}}
+	assert.Len(t, environment.PostProcessors, 1)
+	err = environment.PostProcessors[0](environment)
+	assert.Nil(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.NotNil(t, container.Lifecycle)
+	assert.Equal(t, []string{"/bin/sh", "-c", "rm -f /data/lock"}, container.Lifecycle.PreStop.Exec.Command)
+}
+
+func TestConfigureDeploymentTraitWithInvalidTerminationGracePeriod(t *testing.T) {
+	deploymentTrait, environment := createNominalDeploymentTest()
+	gracePeriod := int64(-1)
+	deploymentTrait.TerminationGracePeriodSeconds = &gracePeriod
+
+	_, err := deploymentTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestConfigureDeploymentTraitWithInvalidStrategy(t *testing.T) {
+	deploymentTrait, environment := createNominalDeploymentTest()
+	deploymentTrait.Strategy = "BlueGreen"
+
+	_, err := deploymentTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	deploymentTrait.Strategy = string(appsv1.RecreateDeploymentStrategyType)
+	deploymentTrait.RollingUpdateMaxSurge = "1"
+
+	_, err = deploymentTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	deploymentTrait.Strategy = ""
+	deploymentTrait.RollingUpdateMaxSurge = "one"
+
+	_, err = deploymentTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
 func createNominalDeploymentTest() (*deploymentTrait, *Environment) {
 	trait := newDeploymentTrait().(*deploymentTrait)
 	trait.Enabled = BoolP(true)
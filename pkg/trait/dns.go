@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The DNS trait sets the DNS policy, the DNS configuration and the host aliases of the integration pods,
+// e.g. to resolve on-premise services that are not known to the cluster DNS.
+// See https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-dns-config for more details.
+//
+// Host aliases are added to the pod `/etc/hosts` file, and are expressed in the same format, i.e., `IP Hostname[ Hostname...]`,
+// for example `10.0.0.10 db.example.com db`.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=dns
+type dnsTrait struct {
+	BaseTrait `property:",squash"`
+	// The DNS policy of the pod, one of `ClusterFirst`, `ClusterFirstWithHostNet`, `Default` or `None`.
+	// When set to `None`, at least one nameserver must be provided.
+	Policy string `property:"policy" json:"policy,omitempty"`
+	// A list of IP addresses of the DNS servers.
+	Nameservers []string `property:"nameservers" json:"nameservers,omitempty"`
+	// A list of DNS search domains for host-name lookup.
+	Searches []string `property:"searches" json:"searches,omitempty"`
+	// A list of DNS resolver options, in the form `Name[:Value]`, e.g. `ndots:2`.
+	Options []string `property:"options" json:"options,omitempty"`
+	// A list of host aliases, in the form `IP Hostname[ Hostname...]`.
+	HostAliases []string `property:"host-aliases" json:"hostAliases,omitempty"`
+}
+
+func newDNSTrait() Trait {
+	return &dnsTrait{
+		BaseTrait: NewBaseTrait("dns", 1430),
+	}
+}
+
+func (t *dnsTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	switch corev1.DNSPolicy(t.Policy) {
+	case "", corev1.DNSClusterFirst, corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault:
+	case corev1.DNSNone:
+		if len(t.Nameservers) == 0 {
+			return false, fmt.Errorf("at least one nameserver must be provided when the DNS policy is %s", corev1.DNSNone)
+		}
+	default:
+		return false, fmt.Errorf("unsupported DNS policy: %s", t.Policy)
+	}
+
+	for _, ns := range t.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return false, fmt.Errorf("nameserver must be a valid IP address, it was %s", ns)
+		}
+	}
+
+	if _, err := t.hostAliases(); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *dnsTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	if t.Policy != "" {
+		podSpec.DNSPolicy = corev1.DNSPolicy(t.Policy)
+	}
+
+	if len(t.Nameservers) > 0 || len(t.Searches) > 0 || len(t.Options) > 0 {
+		if podSpec.DNSConfig == nil {
+			podSpec.DNSConfig = &corev1.PodDNSConfig{}
+		}
+		podSpec.DNSConfig.Nameservers = append(podSpec.DNSConfig.Nameservers, t.Nameservers...)
+		podSpec.DNSConfig.Searches = append(podSpec.DNSConfig.Searches, t.Searches...)
+		for _, o := range t.Options {
+			option := corev1.PodDNSConfigOption{}
+			kv := strings.SplitN(o, ":", 2)
+			option.Name = kv[0]
+			if len(kv) == 2 {
+				value := kv[1]
+				option.Value = &value
+			}
+			podSpec.DNSConfig.Options = append(podSpec.DNSConfig.Options, option)
+		}
+	}
+
+	hostAliases, err := t.hostAliases()
+	if err != nil {
+		return err
+	}
+	podSpec.HostAliases = append(podSpec.HostAliases, hostAliases...)
+
+	return nil
+}
+
+func (t *dnsTrait) hostAliases() ([]corev1.HostAlias, error) {
+	hostAliases := make([]corev1.HostAlias, 0, len(t.HostAliases))
+	for _, h := range t.HostAliases {
+		fields := strings.Fields(h)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			return nil, fmt.Errorf("host alias must have the IP Hostname[ Hostname...] format, it was %s", h)
+		}
+		hostAliases = append(hostAliases, corev1.HostAlias{
+			IP:        fields[0],
+			Hostnames: fields[1:],
+		})
+	}
+
+	return hostAliases, nil
+}
@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	serving "knative.dev/serving/pkg/apis/serving/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigureDNSTraitDisabledByDefault(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	dnsTrait := newDNSTrait().(*dnsTrait)
+
+	enabled, err := dnsTrait.Configure(environment)
+
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+}
+
+func TestConfigureDNSTraitInvalidConfiguration(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+
+	dnsTrait := createNominalDNSTrait()
+	dnsTrait.Policy = "Custom"
+	_, err := dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	dnsTrait = createNominalDNSTrait()
+	dnsTrait.Policy = string(corev1.DNSNone)
+	_, err = dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	dnsTrait = createNominalDNSTrait()
+	dnsTrait.Nameservers = []string{"ns.example.com"}
+	_, err = dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	dnsTrait = createNominalDNSTrait()
+	dnsTrait.HostAliases = []string{"10.0.0.10"}
+	_, err = dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestApplyDNSTrait(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	dnsTrait := createNominalDNSTrait()
+	dnsTrait.Policy = string(corev1.DNSNone)
+	dnsTrait.Nameservers = []string{"10.0.0.2"}
+	dnsTrait.Searches = []string{"example.com"}
+	dnsTrait.Options = []string{"ndots:2", "edns0"}
+	dnsTrait.HostAliases = []string{"10.0.0.10 db.example.com db"}
+
+	enabled, err := dnsTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = dnsTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	ndots := "2"
+	assert.Equal(t, corev1.DNSNone, podSpec.DNSPolicy)
+	assert.Equal(t, &corev1.PodDNSConfig{
+		Nameservers: []string{"10.0.0.2"},
+		Searches:    []string{"example.com"},
+		Options: []corev1.PodDNSConfigOption{
+			{Name: "ndots", Value: &ndots},
+			{Name: "edns0"},
+		},
+	}, podSpec.DNSConfig)
+	assert.Equal(t, []corev1.HostAlias{{IP: "10.0.0.10", Hostnames: []string{"db.example.com", "db"}}}, podSpec.HostAliases)
+}
+
+func TestDNSTraitWithKnativeProfile(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterOpenShift, "from('undertow:test').log('hello')")
+	env.Integration.Spec.Profile = v1.TraitProfileKnative
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"knative-service": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled": true,
+		}),
+		"dns": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled":  true,
+			"searches": []string{"example.com"},
+		}),
+	}
+	res := processTestEnv(t, env)
+
+	assert.NotNil(t, env.GetTrait("dns"))
+	knativeService := res.GetKnativeService(func(s *serving.Service) bool {
+		return s.Name == TestDeploymentName
+	})
+	assert.NotNil(t, knativeService)
+	assert.Equal(t, []string{"example.com"}, knativeService.Spec.Template.Spec.DNSConfig.Searches)
+}
+
+func TestApplyDNSTraitMissingDeployment(t *testing.T) {
+	environment := createNominalMissingDeploymentTraitTest()
+	dnsTrait := createNominalDNSTrait()
+
+	err := dnsTrait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func createNominalDNSTrait() *dnsTrait {
+	dnsTrait := newDNSTrait().(*dnsTrait)
+	dnsTrait.Enabled = BoolP(true)
+	return dnsTrait
+}
@@ -18,19 +18,39 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/property"
 )
 
 // The environment trait is used internally to inject standard environment variables in the integration container,
 // such as `NAMESPACE`, `POD_NAME` and others.
 //
+// It can also be used to declare custom environment variables, whose values are either literals,
+// taken from Secret or ConfigMap keys, or exposed from the pod fields using the downward API.
+//
 // +camel-k:trait=environment
 type environmentTrait struct {
 	BaseTrait `property:",squash"`
 	// Enables injection of `NAMESPACE` and `POD_NAME` environment variables (default `true`)
 	ContainerMeta *bool `property:"container-meta" json:"containerMeta,omitempty"`
+	// A list of environment variables to be added to the integration container, in the form `NAME=value`.
+	Vars []string `property:"vars" json:"vars,omitempty"`
+	// A list of environment variables whose values are taken from Secret keys,
+	// in the form `NAME=secret-name/key`.
+	SecretVars []string `property:"secret-vars" json:"secretVars,omitempty"`
+	// A list of environment variables whose values are taken from ConfigMap keys,
+	// in the form `NAME=configmap-name/key`.
+	ConfigMapVars []string `property:"configmap-vars" json:"configmapVars,omitempty"`
+	// A list of environment variables whose values are exposed from the pod fields, using the downward API,
+	// in the form `NAME=field-path`, e.g. `NODE_NAME=spec.nodeName` or `MEMORY_LIMIT=limits.memory`.
+	FieldVars []string `property:"field-vars" json:"fieldVars,omitempty"`
 }
 
 const (
@@ -59,6 +79,10 @@ func newEnvironmentTrait() Trait {
 
 func (t *environmentTrait) Configure(e *Environment) (bool, error) {
 	if IsNilOrTrue(t.Enabled) {
+		if _, err := t.customVars(); err != nil {
+			return false, err
+		}
+
 		return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
 	}
 
@@ -79,9 +103,106 @@ func (t *environmentTrait) Apply(e *Environment) error {
 		envvar.SetValFrom(&e.EnvVars, envVarPodName, "metadata.name")
 	}
 
+	vars, err := t.customVars()
+	if err != nil {
+		return err
+	}
+	for _, v := range vars {
+		envvar.SetVar(&e.EnvVars, v)
+	}
+
 	return nil
 }
 
+// customVars returns the environment variables declared with the trait properties
+func (t *environmentTrait) customVars() ([]corev1.EnvVar, error) {
+	vars := make([]corev1.EnvVar, 0)
+
+	for _, v := range t.Vars {
+		// Literal values can be empty
+		name, value := property.SplitPropertyFileEntry(v)
+		if name == "" || !strings.Contains(v, "=") {
+			return nil, fmt.Errorf("environment variable must have NAME=value format, it was %v", v)
+		}
+		vars = append(vars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	for _, v := range t.SecretVars {
+		name, ref, key, err := splitEnvVarKeyRef(v)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ref},
+					Key:                  key,
+				},
+			},
+		})
+	}
+
+	for _, v := range t.ConfigMapVars {
+		name, ref, key, err := splitEnvVarKeyRef(v)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ref},
+					Key:                  key,
+				},
+			},
+		})
+	}
+
+	for _, v := range t.FieldVars {
+		name, path, err := splitEnvVar(v)
+		if err != nil {
+			return nil, err
+		}
+		source := &corev1.EnvVarSource{}
+		if strings.HasPrefix(path, "limits.") || strings.HasPrefix(path, "requests.") {
+			// Container resources are exposed with a resource field selector
+			source.ResourceFieldRef = &corev1.ResourceFieldSelector{
+				Resource: path,
+			}
+		} else {
+			source.FieldRef = &corev1.ObjectFieldSelector{
+				FieldPath: path,
+			}
+		}
+		vars = append(vars, corev1.EnvVar{Name: name, ValueFrom: source})
+	}
+
+	return vars, nil
+}
+
+func splitEnvVar(v string) (string, string, error) {
+	name, value := property.SplitPropertyFileEntry(v)
+	if name == "" || value == "" {
+		return "", "", fmt.Errorf("environment variable must have NAME=value format, it was %v", v)
+	}
+
+	return name, value, nil
+}
+
+func splitEnvVarKeyRef(v string) (string, string, string, error) {
+	name, value, err := splitEnvVar(v)
+	if err != nil {
+		return "", "", "", err
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("environment variable must have NAME=name/key format, it was %v", v)
+	}
+
+	return name, parts[0], parts[1], nil
+}
+
 // IsPlatformTrait overrides base class method
 func (t *environmentTrait) IsPlatformTrait() bool {
 	return true
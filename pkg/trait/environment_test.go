@@ -29,6 +29,7 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/envvar"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/test"
 )
@@ -236,6 +237,101 @@ func TestDisabledContainerMetaDataEnvVars(t *testing.T) {
 	assert.True(t, ck)
 }
 
+func TestCustomEnvVars(t *testing.T) {
+	c, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	env := Environment{
+		CamelCatalog: c,
+		Catalog:      NewCatalog(context.TODO(), nil),
+		Integration: &v1.Integration{
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Traits: map[string]v1.TraitSpec{
+					"environment": test.TraitSpecFromMap(t, map[string]interface{}{
+						"vars":          []string{"MY_VAR=my-value", "EMPTY_VAR="},
+						"secretVars":    []string{"MY_PASSWORD=my-secret/password"},
+						"configmapVars": []string{"MY_CONFIG=my-cm/config"},
+						"fieldVars":     []string{"NODE_NAME=spec.nodeName", "MEMORY_LIMIT=limits.memory"},
+					}),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+			},
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterKubernetes,
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	env.Platform.ResyncStatusFullConfig()
+
+	err = NewEnvironmentTestCatalog().apply(&env)
+	assert.Nil(t, err)
+
+	var vars []corev1.EnvVar
+	env.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+		vars = deployment.Spec.Template.Spec.Containers[0].Env
+	})
+
+	myVar := envvar.Get(vars, "MY_VAR")
+	assert.NotNil(t, myVar)
+	assert.Equal(t, "my-value", myVar.Value)
+
+	emptyVar := envvar.Get(vars, "EMPTY_VAR")
+	assert.NotNil(t, emptyVar)
+	assert.Equal(t, "", emptyVar.Value)
+
+	password := envvar.Get(vars, "MY_PASSWORD")
+	assert.NotNil(t, password)
+	assert.Equal(t, "my-secret", password.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "password", password.ValueFrom.SecretKeyRef.Key)
+
+	config := envvar.Get(vars, "MY_CONFIG")
+	assert.NotNil(t, config)
+	assert.Equal(t, "my-cm", config.ValueFrom.ConfigMapKeyRef.Name)
+	assert.Equal(t, "config", config.ValueFrom.ConfigMapKeyRef.Key)
+
+	nodeName := envvar.Get(vars, "NODE_NAME")
+	assert.NotNil(t, nodeName)
+	assert.Equal(t, "spec.nodeName", nodeName.ValueFrom.FieldRef.FieldPath)
+
+	memoryLimit := envvar.Get(vars, "MEMORY_LIMIT")
+	assert.NotNil(t, memoryLimit)
+	assert.Equal(t, "limits.memory", memoryLimit.ValueFrom.ResourceFieldRef.Resource)
+}
+
+func TestInvalidCustomEnvVars(t *testing.T) {
+	trait := newEnvironmentTrait().(*environmentTrait)
+
+	trait.Vars = []string{"MY_VAR"}
+	_, err := trait.Configure(&Environment{})
+	assert.NotNil(t, err)
+
+	trait.Vars = nil
+	trait.SecretVars = []string{"MY_PASSWORD=my-secret"}
+	_, err = trait.Configure(&Environment{})
+	assert.NotNil(t, err)
+
+	trait.SecretVars = nil
+	trait.FieldVars = []string{"NODE_NAME"}
+	_, err = trait.Configure(&Environment{})
+	assert.NotNil(t, err)
+}
+
 func NewEnvironmentTestCatalog() *Catalog {
 	return NewCatalog(context.TODO(), nil)
 }
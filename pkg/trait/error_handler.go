@@ -18,6 +18,8 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"gopkg.in/yaml.v2"
@@ -25,11 +27,18 @@ import (
 
 // The error-handler is a platform trait used to inject Error Handler source into the integration runtime.
 //
+// The error handler is usually declared by the KameletBinding the integration is created from. It can also be
+// declared on the trait, for integrations that do not declare one, with the `type` and `dead-letter-uri` properties.
+//
 // +camel-k:trait=error-handler
 type errorHandlerTrait struct {
 	BaseTrait `property:",squash"`
 	// The error handler ref name provided or found in application properties
 	ErrorHandlerRef string `property:"ref" json:"ref,omitempty"`
+	// The type of the error handler, either `none`, `log` or `dead-letter-channel`.
+	Type string `property:"type" json:"type,omitempty"`
+	// The URI of the endpoint the failed messages are sent to, with the `dead-letter-channel` error handler type.
+	DeadLetterURI string `property:"dead-letter-uri" json:"deadLetterUri,omitempty"`
 }
 
 func newErrorHandlerTrait() Trait {
@@ -52,9 +61,18 @@ func (t *errorHandlerTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if t.Type != "" {
+		if _, err := t.errorHandler(); err != nil {
+			return false, err
+		}
+	}
+
 	if t.ErrorHandlerRef == "" {
 		t.ErrorHandlerRef = e.Integration.Spec.GetConfigurationProperty(v1alpha1.ErrorHandlerRefName)
 	}
+	if t.ErrorHandlerRef == "" && t.Type != "" {
+		t.ErrorHandlerRef = v1alpha1.ErrorHandlerRefDefaultName
+	}
 
 	return t.ErrorHandlerRef != "", nil
 }
@@ -63,6 +81,53 @@ func (t *errorHandlerTrait) Apply(e *Environment) error {
 	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
 		return t.addErrorHandlerAsSource(e)
 	}
+	if t.Type != "" {
+		return t.configureApplicationProperties(e)
+	}
+	return nil
+}
+
+func (t *errorHandlerTrait) errorHandler() (v1alpha1.ErrorHandler, error) {
+	switch v1alpha1.ErrorHandlerType(t.Type) {
+	case v1alpha1.ErrorHandlerTypeNone:
+		return v1alpha1.ErrorHandlerNone{}, nil
+	case v1alpha1.ErrorHandlerTypeLog:
+		return v1alpha1.ErrorHandlerLog{}, nil
+	case v1alpha1.ErrorHandlerTypeDeadLetterChannel:
+		if t.DeadLetterURI == "" {
+			return nil, fmt.Errorf("the dead letter URI is required with the %s error handler type", t.Type)
+		}
+		return v1alpha1.ErrorHandlerDeadLetterChannel{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported error handler type %q, must be one of none, log or dead-letter-channel", t.Type)
+	}
+}
+
+func (t *errorHandlerTrait) configureApplicationProperties(e *Environment) error {
+	errorHandler, err := t.errorHandler()
+	if err != nil {
+		return err
+	}
+	properties, err := errorHandler.Configuration()
+	if err != nil {
+		return err
+	}
+
+	// The error handler configured on the integration, e.g. by a KameletBinding, takes precedence
+	if e.Integration.Spec.GetConfigurationProperty(v1alpha1.ErrorHandlerAppPropertiesPrefix) != "" {
+		return nil
+	}
+
+	if e.ApplicationProperties == nil {
+		e.ApplicationProperties = make(map[string]string)
+	}
+	for key, value := range properties {
+		e.ApplicationProperties[key] = fmt.Sprintf("%v", value)
+	}
+	if errorHandler.Type() == v1alpha1.ErrorHandlerTypeDeadLetterChannel {
+		e.ApplicationProperties[v1alpha1.ErrorHandlerAppPropertiesPrefix+".deadLetterUri"] = t.DeadLetterURI
+	}
+
 	return nil
 }
 
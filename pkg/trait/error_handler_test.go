@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+func TestErrorHandlerConfigureFromIntegrationProperty(t *testing.T) {
+	e := createErrorHandlerTestEnvironment(v1.IntegrationPhaseInitialization)
+	e.Integration.Spec.AddConfiguration("property", v1alpha1.ErrorHandlerRefName+"=defaultErrorHandler")
+
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, "defaultErrorHandler", trait.ErrorHandlerRef)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.Integration.Status.GeneratedSources, 1)
+	assert.Equal(t, v1.SourceTypeErrorHandler, e.Integration.Status.GeneratedSources[0].Type)
+	assert.Contains(t, e.Integration.Status.GeneratedSources[0].Content, "ref: defaultErrorHandler")
+}
+
+func TestErrorHandlerNotConfigured(t *testing.T) {
+	e := createErrorHandlerTestEnvironment(v1.IntegrationPhaseInitialization)
+
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+}
+
+func TestErrorHandlerDeadLetterChannelType(t *testing.T) {
+	e := createErrorHandlerTestEnvironment(v1.IntegrationPhaseDeploying)
+
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = "dead-letter-channel"
+	trait.DeadLetterURI = "log:dlc"
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, v1alpha1.ErrorHandlerRefDefaultName, trait.ErrorHandlerRef)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Equal(t, "#class:org.apache.camel.builder.DeadLetterChannelBuilder", e.ApplicationProperties[v1alpha1.ErrorHandlerAppPropertiesPrefix])
+	assert.Equal(t, "log:dlc", e.ApplicationProperties[v1alpha1.ErrorHandlerAppPropertiesPrefix+".deadLetterUri"])
+	assert.Equal(t, v1alpha1.ErrorHandlerRefDefaultName, e.ApplicationProperties[v1alpha1.ErrorHandlerRefName])
+}
+
+func TestErrorHandlerTypeDoesNotOverrideIntegration(t *testing.T) {
+	e := createErrorHandlerTestEnvironment(v1.IntegrationPhaseDeploying)
+	e.Integration.Spec.AddConfiguration("property", v1alpha1.ErrorHandlerAppPropertiesPrefix+"=#class:org.apache.camel.builder.NoErrorHandlerBuilder")
+
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = "log"
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Empty(t, e.ApplicationProperties)
+}
+
+func TestErrorHandlerInvalidType(t *testing.T) {
+	e := createErrorHandlerTestEnvironment(v1.IntegrationPhaseDeploying)
+
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = "unknown"
+	_, err := trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait = newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = "dead-letter-channel"
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+}
+
+func createErrorHandlerTestEnvironment(phase v1.IntegrationPhase) *Environment {
+	return &Environment{
+		ApplicationProperties: make(map[string]string),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: phase,
+			},
+		},
+	}
+}
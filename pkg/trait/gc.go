@@ -19,13 +19,16 @@ package trait
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -35,6 +38,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/disk"
 	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/util/flowcontrol"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -47,6 +51,12 @@ var (
 	diskCachedDiscoveryClient   discovery.CachedDiscoveryInterface
 	memoryCachedDiscoveryClient discovery.CachedDiscoveryInterface
 	discoveryClientLock         sync.Mutex
+
+	// The deletable types are discovered at most once per minute, as the set
+	// of API resources rarely changes, and discovery queries are expensive
+	deletableTypes       map[schema.GroupVersionKind]struct{}
+	deletableTypesLock   sync.Mutex
+	discoveryRateLimiter = flowcontrol.NewTokenBucketRateLimiter(1.0/60, 1)
 )
 
 type discoveryCacheType string
@@ -64,6 +74,12 @@ type garbageCollectorTrait struct {
 	BaseTrait `property:",squash"`
 	// Discovery client cache to be used, either `disabled`, `disk` or `memory` (default `memory`)
 	DiscoveryCache *discoveryCacheType `property:"discovery-cache" json:"discoveryCache,omitempty"`
+	// The API groups whose resources are not garbage-collected, e.g. `serving.knative.dev`.
+	// The core API group can be referred to as `core`.
+	ExcludedGroups []string `property:"excluded-groups" json:"excludedGroups,omitempty"`
+	// Report the resources that would be garbage-collected in the `GarbageCollectionDryRun`
+	// integration condition, instead of deleting them.
+	DryRun *bool `property:"dry-run" json:"dryRun,omitempty"`
 }
 
 func newGarbageCollectorTrait() Trait {
@@ -98,6 +114,12 @@ func (t *garbageCollectorTrait) Apply(e *Environment) error {
 		// TODO: this should be refined so that it's run when all the replicas for the newer generation
 		// are ready. This is to be added when the integration scale status is refined with ready replicas
 		e.PostActions = append(e.PostActions, func(env *Environment) error {
+			if IsTrue(t.DryRun) {
+				// The collection is performed synchronously, so that it can be reported
+				// in the integration status.
+				t.reportGarbageCollectableResources(env)
+				return nil
+			}
 			// The collection and deletion are performed asynchronously to avoid blocking
 			// the reconcile loop.
 			go t.garbageCollectResources(env)
@@ -125,15 +147,11 @@ func (t *garbageCollectorTrait) Apply(e *Environment) error {
 }
 
 func (t *garbageCollectorTrait) garbageCollectResources(e *Environment) {
-	integration, _ := labels.NewRequirement(v1.IntegrationLabel, selection.Equals, []string{e.Integration.Name})
-	generation, err := labels.NewRequirement("camel.apache.org/generation", selection.LessThan, []string{strconv.FormatInt(e.Integration.GetGeneration(), 10)})
+	selector, err := t.previousGenerationsSelector(e)
 	if err != nil {
 		t.L.ForIntegration(e.Integration).Errorf(err, "cannot determine generation requirement")
 		return
 	}
-	selector := labels.NewSelector().
-		Add(*integration).
-		Add(*generation)
 
 	deletableGVKs, err := t.getDeletableTypes(e)
 	if err != nil {
@@ -144,12 +162,76 @@ func (t *garbageCollectorTrait) garbageCollectResources(e *Environment) {
 	t.deleteEachOf(deletableGVKs, e, selector)
 }
 
+func (t *garbageCollectorTrait) reportGarbageCollectableResources(e *Environment) {
+	selector, err := t.previousGenerationsSelector(e)
+	if err != nil {
+		t.L.ForIntegration(e.Integration).Errorf(err, "cannot determine generation requirement")
+		return
+	}
+
+	deletableGVKs, err := t.getDeletableTypes(e)
+	if err != nil {
+		t.L.ForIntegration(e.Integration).Errorf(err, "cannot discover GVK types")
+		return
+	}
+
+	e.Integration.Status.SetCondition(
+		v1.IntegrationConditionGarbageCollectionDryRun,
+		corev1.ConditionTrue,
+		v1.IntegrationConditionGarbageCollectionDryRunReason,
+		dryRunMessage(t.collectEachOf(deletableGVKs, e, selector)),
+	)
+}
+
+func dryRunMessage(resources []unstructured.Unstructured) string {
+	if len(resources) == 0 {
+		return "no resources would be garbage-collected"
+	}
+
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, resource.GetKind()+"/"+resource.GetName())
+	}
+	sort.Strings(names)
+
+	return fmt.Sprintf("%d resource(s) would be garbage-collected: %s", len(names), strings.Join(names, ", "))
+}
+
+func (t *garbageCollectorTrait) previousGenerationsSelector(e *Environment) (labels.Selector, error) {
+	integration, _ := labels.NewRequirement(v1.IntegrationLabel, selection.Equals, []string{e.Integration.Name})
+	generation, err := labels.NewRequirement("camel.apache.org/generation", selection.LessThan, []string{strconv.FormatInt(e.Integration.GetGeneration(), 10)})
+	if err != nil {
+		return nil, err
+	}
+
+	return labels.NewSelector().
+		Add(*integration).
+		Add(*generation), nil
+}
+
 func (t *garbageCollectorTrait) deleteEachOf(gvks map[schema.GroupVersionKind]struct{}, e *Environment, selector labels.Selector) {
+	for _, resource := range t.collectEachOf(gvks, e, selector) {
+		r := resource
+		err := t.Client.Delete(context.TODO(), &r, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil {
+			// The resource may have already been deleted
+			if !k8serrors.IsNotFound(err) {
+				t.L.ForIntegration(e.Integration).Errorf(err, "cannot delete child resource: %s/%s", resource.GetKind(), resource.GetName())
+			}
+		} else {
+			t.L.ForIntegration(e.Integration).Debugf("child resource deleted: %s/%s", resource.GetKind(), resource.GetName())
+		}
+	}
+}
+
+// collectEachOf returns the resources of the given types, matching the selector, that can be garbage-collected
+func (t *garbageCollectorTrait) collectEachOf(gvks map[schema.GroupVersionKind]struct{}, e *Environment, selector labels.Selector) []unstructured.Unstructured {
+	collectable := make([]unstructured.Unstructured, 0)
 	for gvk := range gvks {
 		resources := unstructured.UnstructuredList{
 			Object: map[string]interface{}{
 				"apiVersion": gvk.GroupVersion().String(),
-				"kind":       gvk.Kind,
+				"kind":       gvk.Kind + "List",
 			},
 		}
 		options := []client.ListOption{
@@ -164,21 +246,13 @@ func (t *garbageCollectorTrait) deleteEachOf(gvks map[schema.GroupVersionKind]st
 		}
 
 		for _, resource := range resources.Items {
-			r := resource
-			if !t.canBeDeleted(e, r) {
-				continue
-			}
-			err := t.Client.Delete(context.TODO(), &r, client.PropagationPolicy(metav1.DeletePropagationBackground))
-			if err != nil {
-				// The resource may have already been deleted
-				if !k8serrors.IsNotFound(err) {
-					t.L.ForIntegration(e.Integration).Errorf(err, "cannot delete child resource: %s/%s", resource.GetKind(), resource.GetName())
-				}
-			} else {
-				t.L.ForIntegration(e.Integration).Debugf("child resource deleted: %s/%s", resource.GetKind(), resource.GetName())
+			if t.canBeDeleted(e, resource) {
+				collectable = append(collectable, resource)
 			}
 		}
 	}
+
+	return collectable
 }
 
 func (t *garbageCollectorTrait) canBeDeleted(e *Environment, u unstructured.Unstructured) bool {
@@ -192,12 +266,24 @@ func (t *garbageCollectorTrait) canBeDeleted(e *Environment, u unstructured.Unst
 }
 
 func (t *garbageCollectorTrait) getDeletableTypes(e *Environment) (map[schema.GroupVersionKind]struct{}, error) {
+	deletableTypesLock.Lock()
+	defer deletableTypesLock.Unlock()
+
+	// Limit the rate of discovery queries
+	if !discoveryRateLimiter.TryAccept() && deletableTypes != nil {
+		return t.filterExcludedGroups(deletableTypes), nil
+	}
+
 	// We rely on the discovery API to retrieve all the resources GVK,
 	// that results in an unbounded set that can impact garbage collection latency when scaling up.
 	discoveryClient, err := t.discoveryClient(e)
 	if err != nil {
 		return nil, err
 	}
+	if cached, ok := discoveryClient.(discovery.CachedDiscoveryInterface); ok && deletableTypes != nil {
+		// Make sure the API resources installed since the last discovery are taken into account
+		cached.Invalidate()
+	}
 	resources, err := discoveryClient.ServerPreferredNamespacedResources()
 	// Swallow group discovery errors, e.g., Knative serving exposes
 	// an aggregated API for custom.metrics.k8s.io that requires special
@@ -208,8 +294,28 @@ func (t *garbageCollectorTrait) getDeletableTypes(e *Environment) (map[schema.Gr
 
 	// We only take types that support the "delete" verb,
 	// to prevents from performing queries that we know are going to return "MethodNotAllowed".
-	return groupVersionKinds(discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"delete"}}, resources)),
-		nil
+	deletableTypes = groupVersionKinds(discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"delete"}}, resources))
+
+	return t.filterExcludedGroups(deletableTypes), nil
+}
+
+func (t *garbageCollectorTrait) filterExcludedGroups(gvks map[schema.GroupVersionKind]struct{}) map[schema.GroupVersionKind]struct{} {
+	excluded := make(map[string]bool, len(t.ExcludedGroups))
+	for _, group := range t.ExcludedGroups {
+		if group == "core" {
+			group = ""
+		}
+		excluded[group] = true
+	}
+
+	filtered := make(map[schema.GroupVersionKind]struct{}, len(gvks))
+	for gvk := range gvks {
+		if !excluded[gvk.Group] {
+			filtered[gvk] = struct{}{}
+		}
+	}
+
+	return filtered
 }
 
 func groupVersionKinds(rls []*metav1.APIResourceList) map[schema.GroupVersionKind]struct{} {
@@ -22,9 +22,13 @@ import (
 	"testing"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
 	"github.com/stretchr/testify/assert"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestConfigureGarbageCollectorTraitDoesSucceed(t *testing.T) {
@@ -66,6 +70,85 @@ func TestApplyGarbageCollectorTraitDuringInitializationPhaseSkipPostActions(t *t
 	assert.Len(t, environment.PostActions, 0)
 }
 
+func TestGarbageCollectorTraitExcludedGroups(t *testing.T) {
+	gcTrait, _ := createNominalGarbageCollectorTest()
+	gcTrait.ExcludedGroups = []string{"core", "serving.knative.dev"}
+
+	gvks := map[schema.GroupVersionKind]struct{}{
+		{Group: "", Version: "v1", Kind: "ConfigMap"}:                       {},
+		{Group: "apps", Version: "v1", Kind: "Deployment"}:                  {},
+		{Group: "serving.knative.dev", Version: "v1", Kind: "Service"}:      {},
+		{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}: {},
+	}
+
+	filtered := gcTrait.filterExcludedGroups(gvks)
+
+	assert.Len(t, filtered, 2)
+	assert.Contains(t, filtered, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	assert.Contains(t, filtered, schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"})
+	assert.Len(t, gvks, 4)
+}
+
+func TestGarbageCollectorTraitCollectsPreviousGenerations(t *testing.T) {
+	gcTrait, environment := createNominalGarbageCollectorTest()
+	environment.Integration.Namespace = "ns"
+	environment.Integration.Generation = 2
+
+	owner := []metav1.OwnerReference{{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationKind,
+		Name:       "integration-name",
+	}}
+	configMap := func(name string, generation string, owners []metav1.OwnerReference) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Labels: map[string]string{
+					v1.IntegrationLabel:           "integration-name",
+					"camel.apache.org/generation": generation,
+				},
+				OwnerReferences: owners,
+			},
+		}
+	}
+
+	c, err := test.NewFakeClient(
+		configMap("previous", "1", owner),
+		configMap("current", "2", owner),
+		configMap("not-owned", "1", nil),
+	)
+	assert.Nil(t, err)
+	gcTrait.Client = c
+
+	selector, err := gcTrait.previousGenerationsSelector(environment)
+	assert.Nil(t, err)
+
+	gvks := map[schema.GroupVersionKind]struct{}{
+		{Group: "", Version: "v1", Kind: "ConfigMap"}: {},
+	}
+	collectable := gcTrait.collectEachOf(gvks, environment, selector)
+
+	assert.Len(t, collectable, 1)
+	assert.Equal(t, "previous", collectable[0].GetName())
+	assert.Equal(t, "1 resource(s) would be garbage-collected: ConfigMap/previous", dryRunMessage(collectable))
+}
+
+func TestGarbageCollectorTraitDryRunMessage(t *testing.T) {
+	assert.Equal(t, "no resources would be garbage-collected", dryRunMessage(nil))
+
+	resources := []unstructured.Unstructured{{}, {}}
+	resources[0].SetKind("Service")
+	resources[0].SetName("svc")
+	resources[1].SetKind("Deployment")
+	resources[1].SetName("deploy")
+	assert.Equal(t, "2 resource(s) would be garbage-collected: Deployment/deploy, Service/svc", dryRunMessage(resources))
+}
+
 func createNominalGarbageCollectorTest() (*garbageCollectorTrait, *Environment) {
 	trait := newGarbageCollectorTrait().(*garbageCollectorTrait)
 	trait.Enabled = BoolP(true)
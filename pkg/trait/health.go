@@ -0,0 +1,229 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+const (
+	defaultLivenessProbePath  = "/q/health/live"
+	defaultReadinessProbePath = "/q/health/ready"
+	// The startup probe relies on the liveness checks, that succeed once the integration has started
+	defaultStartupProbePath = "/q/health/live"
+)
+
+// The health trait is responsible for configuring the health probes on the integration container.
+//
+// Each probe can be enabled and tuned independently. The liveness and startup probes query the liveness
+// checks of the integration, while the readiness probe queries its readiness checks.
+//
+// It is disabled by default.
+//
+// +camel-k:trait=health
+type healthTrait struct {
+	BaseTrait `property:",squash"`
+
+	// Configures the liveness probe for the integration container (default `false`).
+	LivenessProbeEnabled *bool `property:"liveness-probe-enabled" json:"livenessProbeEnabled,omitempty"`
+	// Scheme to use when connecting to the liveness probe (default `HTTP`).
+	LivenessScheme string `property:"liveness-scheme" json:"livenessScheme,omitempty"`
+	// Number of seconds after the container has started before the liveness probe is initiated.
+	LivenessInitialDelay int32 `property:"liveness-initial-delay" json:"livenessInitialDelay,omitempty"`
+	// Number of seconds after which the liveness probe times out.
+	LivenessTimeout int32 `property:"liveness-timeout" json:"livenessTimeout,omitempty"`
+	// How often to perform the liveness probe.
+	LivenessPeriod int32 `property:"liveness-period" json:"livenessPeriod,omitempty"`
+	// Minimum consecutive successes for the liveness probe to be considered successful after having failed.
+	LivenessSuccessThreshold int32 `property:"liveness-success-threshold" json:"livenessSuccessThreshold,omitempty"`
+	// Minimum consecutive failures for the liveness probe to be considered failed after having succeeded.
+	LivenessFailureThreshold int32 `property:"liveness-failure-threshold" json:"livenessFailureThreshold,omitempty"`
+
+	// Configures the readiness probe for the integration container (default `true`).
+	ReadinessProbeEnabled *bool `property:"readiness-probe-enabled" json:"readinessProbeEnabled,omitempty"`
+	// Scheme to use when connecting to the readiness probe (default `HTTP`).
+	ReadinessScheme string `property:"readiness-scheme" json:"readinessScheme,omitempty"`
+	// Number of seconds after the container has started before the readiness probe is initiated.
+	ReadinessInitialDelay int32 `property:"readiness-initial-delay" json:"readinessInitialDelay,omitempty"`
+	// Number of seconds after which the readiness probe times out.
+	ReadinessTimeout int32 `property:"readiness-timeout" json:"readinessTimeout,omitempty"`
+	// How often to perform the readiness probe.
+	ReadinessPeriod int32 `property:"readiness-period" json:"readinessPeriod,omitempty"`
+	// Minimum consecutive successes for the readiness probe to be considered successful after having failed.
+	ReadinessSuccessThreshold int32 `property:"readiness-success-threshold" json:"readinessSuccessThreshold,omitempty"`
+	// Minimum consecutive failures for the readiness probe to be considered failed after having succeeded.
+	ReadinessFailureThreshold int32 `property:"readiness-failure-threshold" json:"readinessFailureThreshold,omitempty"`
+
+	// Configures the startup probe for the integration container (default `false`).
+	StartupProbeEnabled *bool `property:"startup-probe-enabled" json:"startupProbeEnabled,omitempty"`
+	// Scheme to use when connecting to the startup probe (default `HTTP`).
+	StartupScheme string `property:"startup-scheme" json:"startupScheme,omitempty"`
+	// Number of seconds after the container has started before the startup probe is initiated.
+	StartupInitialDelay int32 `property:"startup-initial-delay" json:"startupInitialDelay,omitempty"`
+	// Number of seconds after which the startup probe times out.
+	StartupTimeout int32 `property:"startup-timeout" json:"startupTimeout,omitempty"`
+	// How often to perform the startup probe.
+	StartupPeriod int32 `property:"startup-period" json:"startupPeriod,omitempty"`
+	// Minimum consecutive successes for the startup probe to be considered successful after having failed.
+	// It must be 1, that is the default, for startup probes.
+	StartupSuccessThreshold int32 `property:"startup-success-threshold" json:"startupSuccessThreshold,omitempty"`
+	// Minimum consecutive failures for the startup probe to be considered failed after having succeeded.
+	StartupFailureThreshold int32 `property:"startup-failure-threshold" json:"startupFailureThreshold,omitempty"`
+	// The maximum number of seconds slow-starting integrations are given to start, before the container gets restarted.
+	// It's used to compute the startup probe failure threshold, according to the startup probe period, when the
+	// failure threshold is not set, so that the liveness probe doesn't kill the container during warm-up.
+	StartupMaxDuration int32 `property:"startup-max-duration" json:"startupMaxDuration,omitempty"`
+}
+
+func newHealthTrait() Trait {
+	return &healthTrait{
+		BaseTrait:             NewBaseTrait("health", 1700),
+		LivenessScheme:        string(corev1.URISchemeHTTP),
+		ReadinessScheme:       string(corev1.URISchemeHTTP),
+		StartupScheme:         string(corev1.URISchemeHTTP),
+		LivenessProbeEnabled:  BoolP(false),
+		ReadinessProbeEnabled: BoolP(true),
+		StartupProbeEnabled:   BoolP(false),
+	}
+}
+
+func (t *healthTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.StartupSuccessThreshold > 1 {
+		return false, fmt.Errorf("the startup probe success threshold must be 1, not %d", t.StartupSuccessThreshold)
+	}
+	if t.StartupMaxDuration < 0 {
+		return false, fmt.Errorf("the startup maximum duration must not be negative: %d", t.StartupMaxDuration)
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization, v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *healthTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		if capability, ok := e.CamelCatalog.Runtime.Capabilities[v1.CapabilityHealth]; ok {
+			for _, dependency := range capability.Dependencies {
+				util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, dependency.GetDependencyID())
+			}
+
+			// sort the dependencies to get always the same list if they don't change
+			sort.Strings(e.Integration.Status.Dependencies)
+		}
+		return nil
+	}
+
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return nil
+	}
+
+	port, err := t.getProbePort(e)
+	if err != nil {
+		return err
+	}
+
+	// The health trait owns the probes, overriding the ones possibly set with the deprecated container trait properties
+	container.LivenessProbe = nil
+	if IsTrue(t.LivenessProbeEnabled) {
+		container.LivenessProbe = newHealthProbe(port, defaultLivenessProbePath, t.LivenessScheme,
+			t.LivenessInitialDelay, t.LivenessTimeout, t.LivenessPeriod, t.LivenessSuccessThreshold, t.LivenessFailureThreshold)
+	}
+	container.ReadinessProbe = nil
+	if IsTrue(t.ReadinessProbeEnabled) {
+		container.ReadinessProbe = newHealthProbe(port, defaultReadinessProbePath, t.ReadinessScheme,
+			t.ReadinessInitialDelay, t.ReadinessTimeout, t.ReadinessPeriod, t.ReadinessSuccessThreshold, t.ReadinessFailureThreshold)
+	}
+	container.StartupProbe = nil
+	if IsTrue(t.StartupProbeEnabled) {
+		container.StartupProbe = newHealthProbe(port, defaultStartupProbePath, t.StartupScheme,
+			t.StartupInitialDelay, t.StartupTimeout, t.StartupPeriod, t.StartupSuccessThreshold, t.startupFailureThreshold())
+	}
+
+	return nil
+}
+
+// startupFailureThreshold returns the startup probe failure threshold, possibly computed from the startup maximum duration
+func (t *healthTrait) startupFailureThreshold() int32 {
+	if t.StartupFailureThreshold > 0 || t.StartupMaxDuration == 0 {
+		return t.StartupFailureThreshold
+	}
+
+	period := t.StartupPeriod
+	if period <= 0 {
+		// The Kubernetes default probe period
+		period = 10
+	}
+	// The time spent before the first probe doesn't count in the threshold
+	duration := t.StartupMaxDuration - t.StartupInitialDelay
+	if duration <= 0 {
+		return 1
+	}
+
+	return (duration + period - 1) / period
+}
+
+// getProbePort returns the port of the probes, that must not be set on Knative services
+func (t *healthTrait) getProbePort(e *Environment) (int, error) {
+	strategy, err := e.DetermineControllerStrategy()
+	if err != nil {
+		return 0, err
+	}
+	if strategy == ControllerStrategyKnativeService {
+		return 0, nil
+	}
+
+	if containerPort := e.getIntegrationContainerPort(); containerPort != nil {
+		return int(containerPort.ContainerPort), nil
+	}
+	if ct, ok := e.Catalog.GetTrait(containerTraitID).(*containerTrait); ok && ct.Port > 0 {
+		return ct.Port, nil
+	}
+
+	return defaultContainerPort, nil
+}
+
+func newHealthProbe(port int, path string, scheme string, initialDelay, timeout, period, successThreshold, failureThreshold int32) *corev1.Probe {
+	action := corev1.HTTPGetAction{
+		Path:   path,
+		Scheme: corev1.URIScheme(scheme),
+	}
+	if port > 0 {
+		action.Port = intstr.FromInt(port)
+	}
+
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &action,
+		},
+		InitialDelaySeconds: initialDelay,
+		TimeoutSeconds:      timeout,
+		PeriodSeconds:       period,
+		SuccessThreshold:    successThreshold,
+		FailureThreshold:    failureThreshold,
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	serving "knative.dev/serving/pkg/apis/serving/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestHealthTraitDisabledByDefault(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseDeploying)
+
+	ok, err := newHealthTrait().Configure(&env)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestHealthTraitDependencies(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseInitialization)
+	health := newTestHealthTrait()
+
+	ok, err := health.Configure(&env)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = health.Apply(&env)
+	assert.Nil(t, err)
+	assert.Contains(t, env.Integration.Status.Dependencies, "mvn:org.apache.camel.quarkus:camel-quarkus-microprofile-health")
+}
+
+func TestHealthTraitDefaultProbesOnDeployment(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseDeploying)
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+							Ports: []corev1.ContainerPort{
+								{Name: defaultContainerPortName, ContainerPort: 8081},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	env.Resources.Add(deployment)
+
+	err := newTestHealthTrait().Apply(&env)
+	assert.Nil(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Nil(t, container.LivenessProbe)
+	assert.Nil(t, container.StartupProbe)
+	assert.NotNil(t, container.ReadinessProbe)
+	assert.Equal(t, defaultReadinessProbePath, container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(8081), container.ReadinessProbe.HTTPGet.Port.IntVal)
+	assert.Equal(t, corev1.URISchemeHTTP, container.ReadinessProbe.HTTPGet.Scheme)
+}
+
+func TestHealthTraitAllProbesOnDeployment(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseDeploying)
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+	env.Resources.Add(deployment)
+
+	health := newTestHealthTrait()
+	health.LivenessProbeEnabled = BoolP(true)
+	health.LivenessScheme = "HTTPS"
+	health.LivenessTimeout = 1234
+	health.ReadinessProbeEnabled = BoolP(false)
+	health.StartupProbeEnabled = BoolP(true)
+	health.StartupPeriod = 5
+	health.StartupFailureThreshold = 30
+
+	err := health.Apply(&env)
+	assert.Nil(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.NotNil(t, container.LivenessProbe)
+	assert.Equal(t, defaultLivenessProbePath, container.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(defaultContainerPort), container.LivenessProbe.HTTPGet.Port.IntVal)
+	assert.Equal(t, corev1.URISchemeHTTPS, container.LivenessProbe.HTTPGet.Scheme)
+	assert.Equal(t, int32(1234), container.LivenessProbe.TimeoutSeconds)
+	assert.Nil(t, container.ReadinessProbe)
+	assert.NotNil(t, container.StartupProbe)
+	assert.Equal(t, defaultStartupProbePath, container.StartupProbe.HTTPGet.Path)
+	assert.Equal(t, int32(5), container.StartupProbe.PeriodSeconds)
+	assert.Equal(t, int32(30), container.StartupProbe.FailureThreshold)
+}
+
+func TestHealthTraitInvalidStartupProbe(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseDeploying)
+
+	health := newTestHealthTrait()
+	health.StartupSuccessThreshold = 2
+	_, err := health.Configure(&env)
+	assert.NotNil(t, err)
+
+	health = newTestHealthTrait()
+	health.StartupMaxDuration = -1
+	_, err = health.Configure(&env)
+	assert.NotNil(t, err)
+}
+
+func TestHealthTraitStartupMaxDuration(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseDeploying)
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+	env.Resources.Add(deployment)
+
+	health := newTestHealthTrait()
+	health.StartupProbeEnabled = BoolP(true)
+	health.StartupInitialDelay = 5
+	health.StartupPeriod = 20
+	health.StartupMaxDuration = 300
+
+	err := health.Apply(&env)
+	assert.Nil(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.NotNil(t, container.StartupProbe)
+	assert.Equal(t, int32(15), container.StartupProbe.FailureThreshold)
+
+	// An explicit failure threshold takes precedence
+	health.StartupFailureThreshold = 3
+	err = health.Apply(&env)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), deployment.Spec.Template.Spec.Containers[0].StartupProbe.FailureThreshold)
+}
+
+func TestHealthTraitOverridesContainerProbes(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseDeploying)
+	deployment := &appsv1.Deployment{}
+	env.Resources.Add(deployment)
+
+	ctr := newContainerTrait().(*containerTrait)
+	ctr.ProbesEnabled = BoolP(true)
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+	assert.NotNil(t, deployment.Spec.Template.Spec.Containers[0].LivenessProbe)
+
+	err = newTestHealthTrait().Apply(&env)
+	assert.Nil(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Nil(t, container.LivenessProbe)
+	assert.Equal(t, defaultReadinessProbePath, container.ReadinessProbe.HTTPGet.Path)
+}
+
+func TestHealthTraitOnKnativeService(t *testing.T) {
+	env := newTestHealthEnv(t, v1.IntegrationPhaseDeploying)
+	ksvc := newKnativeServiceTrait().(*knativeServiceTrait)
+	ksvc.Enabled = BoolP(true)
+	env.ConfiguredTraits = []Trait{ksvc}
+	service := &serving.Service{}
+	service.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name: defaultContainerName,
+		},
+	}
+	env.Resources.Add(service)
+
+	health := newTestHealthTrait()
+	health.LivenessProbeEnabled = BoolP(true)
+
+	err := health.Apply(&env)
+	assert.Nil(t, err)
+
+	container := service.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, int32(0), container.LivenessProbe.HTTPGet.Port.IntVal)
+	assert.Equal(t, int32(0), container.ReadinessProbe.HTTPGet.Port.IntVal)
+}
+
+func newTestHealthTrait() *healthTrait {
+	tr := newHealthTrait().(*healthTrait)
+	tr.Enabled = BoolP(true)
+
+	return tr
+}
+
+func newTestHealthEnv(t *testing.T, phase v1.IntegrationPhase) Environment {
+	t.Helper()
+
+	catalog, err := camel.QuarkusCatalog()
+	assert.Nil(t, err)
+
+	return Environment{
+		Catalog:      NewCatalog(context.TODO(), nil),
+		CamelCatalog: catalog,
+		Integration: &v1.Integration{
+			Status: v1.IntegrationStatus{
+				Phase: phase,
+			},
+		},
+		Resources:             kubernetes.NewCollection(),
+		ApplicationProperties: make(map[string]string),
+	}
+}
@@ -20,8 +20,10 @@ package trait
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/property"
 
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -40,6 +42,19 @@ type ingressTrait struct {
 	BaseTrait `property:",squash"`
 	// **Required**. To configure the host exposed by the ingress.
 	Host string `property:"host" json:"host,omitempty"`
+	// To configure the path exposed by the ingress (default `/`).
+	Path string `property:"path" json:"path,omitempty"`
+	// To configure the path type exposed by the ingress, either `Exact`, `Prefix` or
+	// `ImplementationSpecific` (default `Prefix`).
+	PathType string `property:"path-type" json:"pathType,omitempty"`
+	// To configure the name of the IngressClass, that selects the ingress controller implementing the ingress.
+	IngressClassName string `property:"ingress-class-name" json:"ingressClassName,omitempty"`
+	// The annotations added to the ingress, in the form `key=value`,
+	// e.g. `cert-manager.io/cluster-issuer=letsencrypt` or `nginx.ingress.kubernetes.io/rewrite-target=/`.
+	Annotations []string `property:"annotations" json:"annotations,omitempty"`
+	// To configure the name of the secret holding the TLS certificate and key for the host.
+	// TLS is enabled when set.
+	TLSSecretName string `property:"tls-secret-name" json:"tlsSecretName,omitempty"`
 	// To automatically add an ingress whenever the integration uses a HTTP endpoint consumer.
 	Auto *bool `property:"auto" json:"auto,omitempty"`
 }
@@ -99,6 +114,16 @@ func (t *ingressTrait) Configure(e *Environment) (bool, error) {
 		return false, errors.New("cannot Apply ingress trait: no host defined")
 	}
 
+	switch t.getPathType() {
+	case v1beta1.PathTypeExact, v1beta1.PathTypePrefix, v1beta1.PathTypeImplementationSpecific:
+	default:
+		return false, fmt.Errorf("unsupported ingress path type: %s", t.PathType)
+	}
+
+	if _, err := t.getAnnotations(); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -108,35 +133,72 @@ func (t *ingressTrait) Apply(e *Environment) error {
 		return errors.New("cannot Apply ingress trait: no target service")
 	}
 
+	annotations, err := t.getAnnotations()
+	if err != nil {
+		return err
+	}
+
+	backend := v1beta1.IngressBackend{
+		ServiceName: service.Name,
+		ServicePort: intstr.FromString("http"),
+	}
+	path := t.Path
+	if path == "" {
+		path = "/"
+	}
+	pathType := t.getPathType()
+
 	ingress := v1beta1.Ingress{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Ingress",
 			APIVersion: v1beta1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      service.Name,
-			Namespace: service.Namespace,
+			Name:        service.Name,
+			Namespace:   service.Namespace,
+			Annotations: annotations,
 		},
 		Spec: v1beta1.IngressSpec{
-			Backend: &v1beta1.IngressBackend{
-				ServiceName: service.Name,
-				ServicePort: intstr.FromString("http"),
-			},
 			Rules: []v1beta1.IngressRule{
 				{
 					Host: t.Host,
+					IngressRuleValue: v1beta1.IngressRuleValue{
+						HTTP: &v1beta1.HTTPIngressRuleValue{
+							Paths: []v1beta1.HTTPIngressPath{
+								{
+									Path:     path,
+									PathType: &pathType,
+									Backend:  backend,
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 	}
 
+	if t.IngressClassName != "" {
+		ingress.Spec.IngressClassName = &t.IngressClassName
+	}
+
+	if t.TLSSecretName != "" {
+		ingress.Spec.TLS = []v1beta1.IngressTLS{
+			{
+				Hosts:      []string{t.Host},
+				SecretName: t.TLSSecretName,
+			},
+		}
+	}
+
 	e.Resources.Add(&ingress)
 
-	message := fmt.Sprintf("%s(%s) -> %s(%s)",
+	message := fmt.Sprintf("%s(%s%s) -> %s(%s)",
 		ingress.Name,
 		t.Host,
-		ingress.Spec.Backend.ServiceName,
-		ingress.Spec.Backend.ServicePort.String())
+		path,
+		backend.ServiceName,
+		backend.ServicePort.String())
 
 	e.Integration.Status.SetCondition(
 		v1.IntegrationConditionExposureAvailable,
@@ -147,3 +209,28 @@ func (t *ingressTrait) Apply(e *Environment) error {
 
 	return nil
 }
+
+func (t *ingressTrait) getPathType() v1beta1.PathType {
+	if t.PathType == "" {
+		return v1beta1.PathTypePrefix
+	}
+
+	return v1beta1.PathType(t.PathType)
+}
+
+func (t *ingressTrait) getAnnotations() (map[string]string, error) {
+	if len(t.Annotations) == 0 {
+		return nil, nil
+	}
+
+	annotations := make(map[string]string, len(t.Annotations))
+	for _, annotation := range t.Annotations {
+		key, value := property.SplitPropertyFileEntry(annotation)
+		if key == "" || !strings.Contains(annotation, "=") {
+			return nil, fmt.Errorf("ingress annotation must have key=value format, it was %v", annotation)
+		}
+		annotations[key] = value
+	}
+
+	return annotations, nil
+}
@@ -142,15 +142,80 @@ func TestApplyIngressTraitDoesSucceed(t *testing.T) {
 		if ingress, ok := resource.(*v1beta1.Ingress); ok {
 			assert.Equal(t, "service-name", ingress.Name)
 			assert.Equal(t, "namespace", ingress.Namespace)
-			assert.Equal(t, "service-name", ingress.Spec.Backend.ServiceName)
+			assert.Nil(t, ingress.Spec.Backend)
 			assert.Len(t, ingress.Spec.Rules, 1)
 			assert.Equal(t, "hostname", ingress.Spec.Rules[0].Host)
+			assert.Len(t, ingress.Spec.Rules[0].HTTP.Paths, 1)
+			assert.Equal(t, "/", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+			assert.Equal(t, v1beta1.PathTypePrefix, *ingress.Spec.Rules[0].HTTP.Paths[0].PathType)
+			assert.Equal(t, "service-name", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName)
+			assert.Nil(t, ingress.Spec.IngressClassName)
+			assert.Nil(t, ingress.Spec.TLS)
+			assert.Nil(t, ingress.Annotations)
 		}
 	})
 
 	conditions := environment.Integration.Status.Conditions
 	assert.Len(t, conditions, 1)
-	assert.Equal(t, "service-name(hostname) -> service-name(http)", conditions[0].Message)
+	assert.Equal(t, "service-name(hostname/) -> service-name(http)", conditions[0].Message)
+}
+
+func TestApplyIngressTraitWithCustomConfiguration(t *testing.T) {
+	ingressTrait, environment := createNominalIngressTest()
+	ingressTrait.Path = "/api"
+	ingressTrait.PathType = string(v1beta1.PathTypeExact)
+	ingressTrait.IngressClassName = "nginx"
+	ingressTrait.TLSSecretName = "hostname-tls"
+	ingressTrait.Annotations = []string{
+		"cert-manager.io/cluster-issuer=letsencrypt",
+		"nginx.ingress.kubernetes.io/rewrite-target=/",
+	}
+
+	configured, err := ingressTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = ingressTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	var ingress *v1beta1.Ingress
+	environment.Resources.Visit(func(resource runtime.Object) {
+		if i, ok := resource.(*v1beta1.Ingress); ok {
+			ingress = i
+		}
+	})
+	assert.NotNil(t, ingress)
+	assert.Nil(t, ingress.Spec.Backend)
+	assert.Equal(t, "/api", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, v1beta1.PathTypeExact, *ingress.Spec.Rules[0].HTTP.Paths[0].PathType)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Len(t, ingress.Spec.TLS, 1)
+	assert.Equal(t, []string{"hostname"}, ingress.Spec.TLS[0].Hosts)
+	assert.Equal(t, "hostname-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, map[string]string{
+		"cert-manager.io/cluster-issuer":             "letsencrypt",
+		"nginx.ingress.kubernetes.io/rewrite-target": "/",
+	}, ingress.Annotations)
+
+	conditions := environment.Integration.Status.Conditions
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, "service-name(hostname/api) -> service-name(http)", conditions[0].Message)
+}
+
+func TestConfigureIngressTraitWithInvalidValuesDoesNotSucceed(t *testing.T) {
+	ingressTrait, environment := createNominalIngressTest()
+	ingressTrait.PathType = "Regex"
+
+	configured, err := ingressTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+
+	ingressTrait, environment = createNominalIngressTest()
+	ingressTrait.Annotations = []string{"kubernetes.io/ingress.class"}
+
+	configured, err = ingressTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.NotNil(t, err)
 }
 
 func createNominalIngressTest() (*ingressTrait, *Environment) {
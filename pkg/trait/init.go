@@ -25,6 +25,7 @@ import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/dsl"
+	"github.com/apache/camel-k/pkg/util/source"
 )
 
 const flowsInternalSourceName = "camel-k-embedded-flow.yaml"
@@ -65,6 +66,11 @@ func (t *initTrait) Apply(e *Environment) error {
 			})
 		}
 
+		// Remote sources are fetched, and turned into generated sources
+		if err := t.fetchRemoteSources(e); err != nil {
+			return err
+		}
+
 		//
 		// Dependencies need to be recomputed in case of a trait declares a capability but as
 		// the dependencies trait runs earlier than some task such as the cron one, we need to
@@ -96,3 +102,33 @@ func (t *initTrait) Apply(e *Environment) error {
 
 	return nil
 }
+
+// fetchRemoteSources fetches the content of the sources with a location, with the credentials of their git secret
+func (t *initTrait) fetchRemoteSources(e *Environment) error {
+	credentials := make(map[string]*source.GitCredentials)
+	for _, s := range e.Integration.Spec.Sources {
+		if s.Location == "" {
+			continue
+		}
+
+		if s.GitSecret != "" && credentials[s.GitSecret] == nil {
+			c, err := source.LoadGitCredentials(e.C, e.Client, e.Integration.Namespace, s.GitSecret)
+			if err != nil {
+				return err
+			}
+			credentials[s.GitSecret] = c
+		}
+
+		content, err := source.LoadContent(e.C, s.Location, credentials[s.GitSecret])
+		if err != nil {
+			return errors.Wrapf(err, "cannot fetch remote source %s", s.Location)
+		}
+
+		s.Content = string(content)
+		s.Location = ""
+		s.GitSecret = ""
+		e.Integration.Status.AddOrReplaceGeneratedSources(s)
+	}
+
+	return nil
+}
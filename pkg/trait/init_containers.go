@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Init Containers trait declares init containers that run to completion before the Camel runtime starts,
+// e.g. to migrate a database schema, download files or fix the permissions of a volume.
+//
+// Each container is declared with its name and image, and is further configured by the other properties,
+// whose values are prefixed with the name of the container they apply to, e.g. `migrate:flyway migrate`.
+// The init containers run sequentially, in the order they are declared.
+//
+// Volumes are mounted by name. Volumes that are not already declared by the integration pod are created
+// as empty directories, that can be shared with the integration container by using its name,
+// e.g. `download:data@/data` and `integration:data@/deployments/data`.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=init-containers
+type initContainersTrait struct {
+	BaseTrait `property:",squash"`
+	// The init containers to run. Syntax: name:image
+	Containers []string `property:"containers" json:"containers,omitempty"`
+	// The command of the init containers, overriding the image entrypoint. Syntax: name:command [args...]
+	Commands []string `property:"commands" json:"commands,omitempty"`
+	// The volumes mounted into the init containers, or the integration container. Syntax: name:volume@/path
+	Mounts []string `property:"mounts" json:"mounts,omitempty"`
+}
+
+func newInitContainersTrait() Trait {
+	return &initContainersTrait{
+		BaseTrait: NewBaseTrait("init-containers", 1640),
+	}
+}
+
+func (t *initContainersTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if len(t.Containers) == 0 {
+		return false, nil
+	}
+
+	if _, err := t.containers(e); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *initContainersTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return fmt.Errorf("unable to find integration container: %s", e.Integration.Name)
+	}
+
+	initContainers, err := t.containers(e)
+	if err != nil {
+		return err
+	}
+	for _, c := range initContainers {
+		if findContainer(podSpec.InitContainers, c.Name) != nil {
+			return fmt.Errorf("init container %s already exists in integration %s pod", c.Name, e.Integration.Name)
+		}
+	}
+
+	mountUserContainersVolumes(podSpec, container, initContainers, t.Mounts)
+
+	podSpec.InitContainers = append(podSpec.InitContainers, initContainers...)
+
+	return nil
+}
+
+// containers validates the trait configuration and returns the declared init containers.
+func (t *initContainersTrait) containers(e *Environment) ([]corev1.Container, error) {
+	return parseUserContainers("init container", getIntegrationContainerName(e), t.Containers, t.Commands, t.Mounts)
+}
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestConfigureInitContainersTraitInvalid(t *testing.T) {
+	for _, configure := range []func(*initContainersTrait){
+		func(trait *initContainersTrait) { trait.Containers = []string{"download"} },
+		func(trait *initContainersTrait) { trait.Containers = []string{"integration:busybox"} },
+		func(trait *initContainersTrait) { trait.Commands = []string{"unknown:ls"} },
+		func(trait *initContainersTrait) { trait.Commands = []string{"download:"} },
+		func(trait *initContainersTrait) { trait.Mounts = []string{"download:data"} },
+	} {
+		trait, environment, _ := createInitContainersTest()
+		configure(trait)
+
+		_, err := trait.Configure(environment)
+
+		assert.NotNil(t, err)
+	}
+}
+
+func TestApplyInitContainersTrait(t *testing.T) {
+	trait, environment, deployment := createInitContainersTest()
+	trait.Containers = append(trait.Containers, "chmod:busybox")
+	trait.Commands = []string{"download:wget -O /data/file.csv http://example.com/file.csv", "chmod:chmod -R 777 /data"}
+	trait.Mounts = []string{"download:data@/data", "chmod:data@/data", "integration:data@/deployments/data"}
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Len(t, podSpec.Containers, 1)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "data", MountPath: "/deployments/data"}}, podSpec.Containers[0].VolumeMounts)
+
+	assert.Len(t, podSpec.InitContainers, 2)
+	assert.Equal(t, "download", podSpec.InitContainers[0].Name)
+	assert.Equal(t, "busybox:1.33", podSpec.InitContainers[0].Image)
+	assert.Equal(t, []string{"wget", "-O", "/data/file.csv", "http://example.com/file.csv"}, podSpec.InitContainers[0].Command)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}, podSpec.InitContainers[0].VolumeMounts)
+	assert.Equal(t, "chmod", podSpec.InitContainers[1].Name)
+	assert.Equal(t, []string{"chmod", "-R", "777", "/data"}, podSpec.InitContainers[1].Command)
+
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.NotNil(t, podSpec.Volumes[0].EmptyDir)
+}
+
+func createInitContainersTest() (*initContainersTrait, *Environment, *appsv1.Deployment) {
+	trait := newInitContainersTrait().(*initContainersTrait)
+	trait.Enabled = BoolP(true)
+	trait.Containers = []string{"download:busybox:1.33"}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	environment := &Environment{
+		Catalog: NewCatalog(context.TODO(), nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(deployment),
+	}
+
+	return trait, environment, deployment
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestInitTraitFetchesRemoteSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("from('timer:tick').to('log:info')"))
+	}))
+	defer server.Close()
+
+	environment := Environment{
+		C: context.TODO(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Spec: v1.IntegrationSpec{
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name: "routes.groovy",
+						},
+						Language: v1.LanguageGroovy,
+						Location: server.URL + "/routes.groovy",
+					},
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseInitialization,
+			},
+		},
+	}
+	// The remote source is only used once fetched
+	assert.Empty(t, environment.Integration.Sources())
+
+	trait := newInitTrait().(*initTrait)
+	err := trait.Apply(&environment)
+	assert.Nil(t, err)
+
+	sources := environment.Integration.Sources()
+	assert.Len(t, sources, 1)
+	assert.Equal(t, "routes.groovy", sources[0].Name)
+	assert.Equal(t, v1.LanguageGroovy, sources[0].Language)
+	assert.Equal(t, "from('timer:tick').to('log:info')", sources[0].Content)
+	assert.Empty(t, sources[0].Location)
+}
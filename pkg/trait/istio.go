@@ -18,17 +18,22 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
 )
 
 // The Istio trait allows to configure properties related to the Istio service mesh,
-// such as sidecar injection and outbound IP ranges.
+// such as sidecar injection and outbound IP ranges. It can also generate the Istio
+// `VirtualService` and `DestinationRule` resources for integrations exposing an HTTP service.
 //
 // +camel-k:trait=istio
 type istioTrait struct {
@@ -37,13 +42,31 @@ type istioTrait struct {
 	Allow string `property:"allow" json:"allow,omitempty"`
 	// Forces the value for labels `sidecar.istio.io/inject`. By default the label is set to `true` on deployment and not set on Knative Service.
 	Inject *bool `property:"inject" json:"inject,omitempty"`
+	// Configures a (comma-separated) list of CIDR subnets that should be excluded from the Istio proxy interception.
+	Deny string `property:"deny" json:"deny,omitempty"`
+	// Generates a `VirtualService` routing the traffic to the integration service (default `false`).
+	VirtualService *bool `property:"virtual-service" json:"virtualService,omitempty"`
+	// The hosts the `VirtualService` applies to. By default, the integration service host is used.
+	Hosts []string `property:"hosts" json:"hosts,omitempty"`
+	// The gateways the `VirtualService` is bound to, e.g. `istio-system/ingressgateway`.
+	// By default, the `VirtualService` applies to the sidecars in the mesh.
+	Gateways []string `property:"gateways" json:"gateways,omitempty"`
+	// Generates a `DestinationRule` for the integration service (default `false`).
+	DestinationRule *bool `property:"destination-rule" json:"destinationRule,omitempty"`
+	// The TLS mode of the `DestinationRule` traffic policy, either `DISABLE`, `SIMPLE`, `MUTUAL` or `ISTIO_MUTUAL` (default `ISTIO_MUTUAL`).
+	TLSMode string `property:"tls-mode" json:"tlsMode,omitempty"`
 }
 
 const (
-	istioSidecarInjectAnnotation    = "sidecar.istio.io/inject"
-	istioOutboundIPRangesAnnotation = "traffic.sidecar.istio.io/includeOutboundIPRanges"
+	istioSidecarInjectAnnotation           = "sidecar.istio.io/inject"
+	istioOutboundIPRangesAnnotation        = "traffic.sidecar.istio.io/includeOutboundIPRanges"
+	istioExcludeOutboundIPRangesAnnotation = "traffic.sidecar.istio.io/excludeOutboundIPRanges"
+	istioNetworkingAPIVersion              = "networking.istio.io/v1beta1"
+	istioDestinationRuleDefaultTLSMode     = "ISTIO_MUTUAL"
 )
 
+var istioTLSModes = []string{"DISABLE", "SIMPLE", "MUTUAL", "ISTIO_MUTUAL"}
+
 func newIstioTrait() Trait {
 	return &istioTrait{
 		BaseTrait: NewBaseTrait("istio", 2300),
@@ -53,6 +76,9 @@ func newIstioTrait() Trait {
 
 func (t *istioTrait) Configure(e *Environment) (bool, error) {
 	if IsTrue(t.Enabled) {
+		if t.TLSMode != "" && !util.StringSliceExists(istioTLSModes, t.TLSMode) {
+			return false, fmt.Errorf("unsupported TLS mode %q, must be one of %v", t.TLSMode, istioTLSModes)
+		}
 		return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
 	}
 
@@ -60,7 +86,7 @@ func (t *istioTrait) Configure(e *Environment) (bool, error) {
 }
 
 func (t *istioTrait) Apply(e *Environment) error {
-	if t.Allow != "" {
+	if t.Allow != "" || t.Deny != "" {
 		e.Resources.VisitDeployment(func(d *appsv1.Deployment) {
 			d.Spec.Template.Annotations = t.injectIstioAnnotation(d.Spec.Template.Annotations, true)
 		})
@@ -68,14 +94,113 @@ func (t *istioTrait) Apply(e *Environment) error {
 			cs.Template.Annotations = t.injectIstioAnnotation(cs.Template.Annotations, false)
 		})
 	}
+
+	// The routing resources only apply to the integrations exposing an HTTP service,
+	// Knative services being routed by Knative itself
+	service := e.Resources.GetUserServiceForIntegration(e.Integration)
+	if service == nil {
+		return nil
+	}
+
+	if IsTrue(t.VirtualService) {
+		e.Resources.Add(t.getVirtualServiceFor(e, service))
+	}
+	if IsTrue(t.DestinationRule) {
+		e.Resources.Add(t.getDestinationRuleFor(e, service))
+	}
+
 	return nil
 }
 
+func (t *istioTrait) getVirtualServiceFor(e *Environment, service *corev1.Service) *unstructured.Unstructured {
+	host := serviceHost(service)
+
+	hosts := t.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{host}
+	}
+
+	route := map[string]interface{}{
+		"route": []interface{}{
+			map[string]interface{}{
+				"destination": map[string]interface{}{
+					"host": host,
+				},
+			},
+		},
+	}
+
+	spec := map[string]interface{}{
+		"hosts": toInterfaceSlice(hosts),
+		"http":  []interface{}{route},
+	}
+	if len(t.Gateways) > 0 {
+		spec["gateways"] = toInterfaceSlice(t.Gateways)
+	}
+
+	return newIstioResource("VirtualService", e, service, spec)
+}
+
+func (t *istioTrait) getDestinationRuleFor(e *Environment, service *corev1.Service) *unstructured.Unstructured {
+	tlsMode := t.TLSMode
+	if tlsMode == "" {
+		tlsMode = istioDestinationRuleDefaultTLSMode
+	}
+
+	spec := map[string]interface{}{
+		"host": serviceHost(service),
+		"trafficPolicy": map[string]interface{}{
+			"tls": map[string]interface{}{
+				"mode": tlsMode,
+			},
+		},
+	}
+
+	return newIstioResource("DestinationRule", e, service, spec)
+}
+
+func newIstioResource(kind string, e *Environment, service *corev1.Service, spec map[string]interface{}) *unstructured.Unstructured {
+	resource := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	resource.SetAPIVersion(istioNetworkingAPIVersion)
+	resource.SetKind(kind)
+	resource.SetName(service.Name)
+	resource.SetNamespace(service.Namespace)
+	resource.SetLabels(map[string]string{
+		v1.IntegrationLabel: e.Integration.Name,
+	})
+
+	return &resource
+}
+
+func serviceHost(service *corev1.Service) string {
+	if service.Namespace == "" {
+		return service.Name
+	}
+	return service.Name + "." + service.Namespace + ".svc.cluster.local"
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	slice := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		slice = append(slice, v)
+	}
+	return slice
+}
+
 func (t *istioTrait) injectIstioAnnotation(annotations map[string]string, includeInject bool) map[string]string {
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
-	annotations[istioOutboundIPRangesAnnotation] = t.Allow
+	if t.Allow != "" {
+		annotations[istioOutboundIPRangesAnnotation] = t.Allow
+	}
+	if t.Deny != "" {
+		annotations[istioExcludeOutboundIPRangesAnnotation] = t.Deny
+	}
 	if includeInject {
 		annotations[istioSidecarInjectAnnotation] = True
 	}
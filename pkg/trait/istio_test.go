@@ -25,6 +25,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	serving "knative.dev/serving/pkg/apis/serving/v1"
 
@@ -168,3 +170,91 @@ func TestIstioDisabled(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotContains(t, env.ExecutedTraits, "istio")
 }
+
+func TestIstioDeny(t *testing.T) {
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{},
+		},
+	}
+
+	env := NewIstioTestEnv(t, &d, &serving.Service{}, true)
+	env.Integration.Spec.Traits["istio"] = test.TraitSpecFromMap(t, map[string]interface{}{
+		"enabled": true,
+		"deny":    "10.96.0.1/32",
+	})
+
+	err := env.Catalog.apply(&env)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "10.96.0.1/32", d.Spec.Template.Annotations[istioExcludeOutboundIPRangesAnnotation])
+	assert.Equal(t, "10.0.0.0/8,172.16.0.0/12,192.168.0.0/16", d.Spec.Template.Annotations[istioOutboundIPRangesAnnotation])
+}
+
+func TestIstioRoutingResources(t *testing.T) {
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{},
+		},
+	}
+	s := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-integration",
+			Namespace: "ns",
+			Labels: map[string]string{
+				v1.IntegrationLabel:             "my-integration",
+				"camel.apache.org/service.type": v1.ServiceTypeUser,
+			},
+		},
+	}
+
+	env := NewIstioTestEnv(t, &d, &serving.Service{}, true)
+	env.Integration.Name = "my-integration"
+	env.Resources.Add(&s)
+	env.Integration.Spec.Traits["istio"] = test.TraitSpecFromMap(t, map[string]interface{}{
+		"enabled":         true,
+		"virtualService":  true,
+		"gateways":        []string{"istio-system/ingressgateway"},
+		"hosts":           []string{"my-integration.example.com"},
+		"destinationRule": true,
+		"tlsMode":         "DISABLE",
+	})
+
+	err := env.Catalog.apply(&env)
+	assert.Nil(t, err)
+
+	resources := make(map[string]*unstructured.Unstructured)
+	env.Resources.Visit(func(o runtime.Object) {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			resources[u.GetKind()] = u
+		}
+	})
+	assert.Len(t, resources, 2)
+
+	vs := resources["VirtualService"]
+	assert.NotNil(t, vs)
+	assert.Equal(t, "networking.istio.io/v1beta1", vs.GetAPIVersion())
+	assert.Equal(t, "my-integration", vs.GetName())
+	hosts, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "hosts")
+	assert.Equal(t, []string{"my-integration.example.com"}, hosts)
+	gateways, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+	assert.Equal(t, []string{"istio-system/ingressgateway"}, gateways)
+
+	dr := resources["DestinationRule"]
+	assert.NotNil(t, dr)
+	host, _, _ := unstructured.NestedString(dr.Object, "spec", "host")
+	assert.Equal(t, "my-integration.ns.svc.cluster.local", host)
+	mode, _, _ := unstructured.NestedString(dr.Object, "spec", "trafficPolicy", "tls", "mode")
+	assert.Equal(t, "DISABLE", mode)
+}
+
+func TestIstioInvalidTLSMode(t *testing.T) {
+	env := NewIstioTestEnv(t, &appsv1.Deployment{}, &serving.Service{}, true)
+	env.Integration.Spec.Traits["istio"] = test.TraitSpecFromMap(t, map[string]interface{}{
+		"enabled": true,
+		"tlsMode": "PERMISSIVE",
+	})
+
+	err := env.Catalog.apply(&env)
+	assert.NotNil(t, err)
+}
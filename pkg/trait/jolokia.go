@@ -19,10 +19,13 @@ package trait
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util"
@@ -62,11 +65,25 @@ type jolokiaTrait struct {
 	User *string `property:"user" json:"user,omitempty"`
 	// Whether client certificates should be used for authentication (default `true` for OpenShift).
 	UseSslClientAuthentication *bool `property:"use-ssl-client-authentication" json:"useSSLClientAuthentication,omitempty"`
+	// The name of a secret of type `kubernetes.io/tls`, holding the `tls.crt` and `tls.key` entries,
+	// that is mounted into the integration container and used as the Jolokia endpoint serving certificate.
+	// The protocol defaults to `https` when set.
+	TLSSecret *string `property:"tls-secret" json:"tlsSecret,omitempty"`
+	// Request OpenShift to generate a serving certificate for the Jolokia endpoint, by creating
+	// a `<integration>-jolokia` service annotated with `service.beta.openshift.io/serving-cert-secret-name`.
+	// The generated secret is used as `tls-secret`, unless it's set explicitly. Only applicable for OpenShift (default `false`).
+	ServingCertificate *bool `property:"serving-certificate" json:"servingCertificate,omitempty"`
 	// A list of additional Jolokia options as defined
 	// in https://jolokia.org/reference/html/agents.html#agent-jvm-config[JVM agent configuration options]
 	Options []string `property:"options" json:"options,omitempty"`
 }
 
+const (
+	jolokiaTLSVolumeName    = "jolokia-tls"
+	jolokiaTLSMountPath     = "/etc/camel/jolokia/tls"
+	servingCertSecretAnnKey = "service.beta.openshift.io/serving-cert-secret-name"
+)
+
 func newJolokiaTrait() Trait {
 	return &jolokiaTrait{
 		BaseTrait: NewBaseTrait("jolokia", 1800),
@@ -119,8 +136,31 @@ func (t *jolokiaTrait) Apply(e *Environment) (err error) {
 	t.setDefaultJolokiaOption(options, &t.Host, "host", "*")
 	t.setDefaultJolokiaOption(options, &t.DiscoveryEnabled, "discoveryEnabled", false)
 
+	openshift := e.DetermineProfile() == v1.TraitProfileOpenShift
+
+	// Request the generation of the serving certificate, that's only supported by OpenShift
+	if openshift && IsTrue(t.ServingCertificate) {
+		secretName := e.Integration.Name + "-jolokia-tls"
+		e.Resources.Add(t.getServiceFor(e, secretName))
+		if t.TLSSecret == nil {
+			t.TLSSecret = &secretName
+		}
+	}
+
+	// Mount the serving certificate and configure HTTPS
+	if t.TLSSecret != nil && *t.TLSSecret != "" {
+		t.mountTLSSecret(e, container, *t.TLSSecret)
+		t.setDefaultJolokiaOption(options, &t.Protocol, "protocol", "https")
+		if _, ok := options["serverCert"]; !ok {
+			options["serverCert"] = path.Join(jolokiaTLSMountPath, corev1.TLSCertKey)
+		}
+		if _, ok := options["serverKey"]; !ok {
+			options["serverKey"] = path.Join(jolokiaTLSMountPath, corev1.TLSPrivateKeyKey)
+		}
+	}
+
 	// Configure HTTPS by default for OpenShift
-	if e.DetermineProfile() == v1.TraitProfileOpenShift {
+	if openshift {
 		t.setDefaultJolokiaOption(options, &t.Protocol, "protocol", "https")
 		t.setDefaultJolokiaOption(options, &t.CaCert, "caCert", "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt")
 		t.setDefaultJolokiaOption(options, &t.ExtendedClientCheck, "extendedClientCheck", true)
@@ -173,6 +213,59 @@ func (t *jolokiaTrait) Apply(e *Environment) (err error) {
 	return nil
 }
 
+func (t *jolokiaTrait) mountTLSSecret(e *Environment, container *corev1.Container, secretName string) {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: jolokiaTLSVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      jolokiaTLSVolumeName,
+		MountPath: jolokiaTLSMountPath,
+		ReadOnly:  true,
+	})
+}
+
+func (t *jolokiaTrait) getServiceFor(e *Environment, secretName string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name + "-jolokia",
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+			Annotations: map[string]string{
+				servingCertSecretAnnKey: secretName,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "jolokia",
+					Port:       int32(t.Port),
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromString("jolokia"),
+				},
+			},
+			Selector: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+	}
+}
+
 func (t *jolokiaTrait) setDefaultJolokiaOption(options map[string]string, option interface{}, key string, value interface{}) {
 	// Do not override existing option
 	if _, ok := options[key]; ok {
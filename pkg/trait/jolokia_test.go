@@ -134,6 +134,71 @@ func TestApplyJolokiaTraitWithOptionShouldOverrideDefault(t *testing.T) {
 	})
 }
 
+func TestApplyJolokiaTraitWithTLSSecretShouldMountCertificate(t *testing.T) {
+	trait, environment := createNominalJolokiaTest()
+	secret := "my-jolokia-tls"
+	trait.TLSSecret = &secret
+
+	err := trait.Apply(environment)
+
+	assert.Nil(t, err)
+
+	container := environment.Resources.GetContainerByName(defaultContainerName)
+
+	assert.Equal(t, container.Args, []string{
+		"-javaagent:dependencies/lib/main/org.jolokia.jolokia-jvm-1.6.2-agent.jar=discoveryEnabled=false,host=*,port=8778,protocol=https," +
+			"serverCert=/etc/camel/jolokia/tls/tls.crt,serverKey=/etc/camel/jolokia/tls/tls.key",
+	})
+
+	assert.Len(t, container.VolumeMounts, 1)
+	assert.Equal(t, jolokiaTLSVolumeName, container.VolumeMounts[0].Name)
+	assert.Equal(t, jolokiaTLSMountPath, container.VolumeMounts[0].MountPath)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "my-jolokia-tls", podSpec.Volumes[0].Secret.SecretName)
+}
+
+func TestApplyJolokiaTraitWithServingCertificateForOpenShiftProfile(t *testing.T) {
+	trait, environment := createNominalJolokiaTest()
+	environment.Integration.Name = "my-it"
+	environment.Resources.VisitDeployment(func(d *appsv1.Deployment) {
+		d.Name = "my-it"
+	})
+	environment.IntegrationKit.Spec.Profile = v1.TraitProfileOpenShift
+	trait.ServingCertificate = BoolP(true)
+
+	err := trait.Apply(environment)
+
+	assert.Nil(t, err)
+
+	service := environment.Resources.GetService(func(s *corev1.Service) bool {
+		return s.Name == "my-it-jolokia"
+	})
+	assert.NotNil(t, service)
+	assert.Equal(t, "my-it-jolokia-tls", service.Annotations[servingCertSecretAnnKey])
+	assert.Equal(t, int32(8778), service.Spec.Ports[0].Port)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "my-it-jolokia-tls", podSpec.Volumes[0].Secret.SecretName)
+
+	container := environment.Resources.GetContainerByName(defaultContainerName)
+	assert.Contains(t, container.Args[0], "serverCert=/etc/camel/jolokia/tls/tls.crt")
+	assert.Contains(t, container.Args[0], "useSslClientAuthentication=true")
+}
+
+func TestApplyJolokiaTraitWithServingCertificateForKubernetesProfileDoesNothing(t *testing.T) {
+	trait, environment := createNominalJolokiaTest()
+	trait.ServingCertificate = BoolP(true)
+
+	err := trait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Nil(t, environment.Resources.GetService(func(s *corev1.Service) bool { return true }))
+	assert.Len(t, environment.GetIntegrationPodSpec().Volumes, 0)
+}
+
 func TestApplyJolokiaTraitWithUnparseableOptionShouldReturnError(t *testing.T) {
 	trait, environment := createNominalJolokiaTest()
 	trait.Options = []string{"unparseable options"}
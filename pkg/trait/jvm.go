@@ -48,7 +48,8 @@ type jvmTrait struct {
 	Debug *bool `property:"debug" json:"debug,omitempty"`
 	// Suspends the target JVM immediately before the main class is loaded
 	DebugSuspend *bool `property:"debug-suspend" json:"debugSuspend,omitempty"`
-	// Prints the command used the start the JVM in the container logs (default `true`)
+	// Prints the command used the start the JVM in the container logs, and its class path and main class,
+	// without the JVM options, in the `JVMAvailable` integration condition (default `true`)
 	PrintCommand *bool `property:"print-command" json:"printCommand,omitempty"`
 	// Transport address at which to listen for the newly launched JVM (default `*:5005`)
 	DebugAddress string `property:"debug-address" json:"debugAddress,omitempty"`
@@ -56,6 +57,9 @@ type jvmTrait struct {
 	Options []string `property:"options" json:"options,omitempty"`
 	// Additional JVM classpath (use `Linux` classpath separator)
 	Classpath string `property:"classpath" json:"classpath,omitempty"`
+	// The percentage of the container memory limit used as the JVM maximum heap size, when no heap size option is set
+	// (default `50`, or `25` when the memory limit is lower than 300M)
+	HeapPercentage *int64 `property:"heap-percentage" json:"heapPercentage,omitempty"`
 }
 
 func newJvmTrait() Trait {
@@ -71,6 +75,10 @@ func (t *jvmTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if t.HeapPercentage != nil && (*t.HeapPercentage <= 0 || *t.HeapPercentage > 100) {
+		return false, fmt.Errorf("heap-percentage must be between 1 and 100, it was %d", *t.HeapPercentage)
+	}
+
 	return e.InPhase(v1.IntegrationKitPhaseReady, v1.IntegrationPhaseDeploying) ||
 		e.InPhase(v1.IntegrationKitPhaseReady, v1.IntegrationPhaseRunning), nil
 }
@@ -95,6 +103,11 @@ func (t *jvmTrait) Apply(e *Environment) error {
 		return fmt.Errorf("unable to find integration kit for integration %s", e.Integration.Name)
 	}
 
+	if kit.IsNative() {
+		// The integration runs as a native executable, so that the JVM configuration does not apply
+		return t.applyNative(e, kit)
+	}
+
 	classpath := strset.New()
 
 	classpath.Add("./resources")
@@ -169,6 +182,9 @@ func (t *jvmTrait) Apply(e *Environment) error {
 		if resource.NewScaledQuantity(300, 6).Cmp(memory) > 0 {
 			percentage = 25
 		}
+		if t.HeapPercentage != nil {
+			percentage = *t.HeapPercentage
+		}
 		memory.AsDec().Mul(memory.AsDec(), infp.NewDec(percentage, 2))
 		args = append(args, fmt.Sprintf("-Xmx%dM", memory.ScaledValue(resource.Mega)))
 	}
@@ -185,6 +201,14 @@ func (t *jvmTrait) Apply(e *Environment) error {
 	args = append(args, e.CamelCatalog.Runtime.ApplicationClass)
 
 	if IsNilOrTrue(t.PrintCommand) {
+		// The JVM options are left out from the condition, as they may hold sensitive values, e.g. system properties
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionJVMAvailable,
+			corev1.ConditionTrue,
+			v1.IntegrationConditionJVMAvailableReason,
+			fmt.Sprintf("java -cp %s %s", strings.Join(items, ":"), e.CamelCatalog.Runtime.ApplicationClass),
+		)
+
 		args = append([]string{"exec", "java"}, args...)
 		container.Command = []string{"/bin/sh", "-c"}
 		cmd := strings.Join(args, " ")
@@ -203,3 +227,22 @@ func (t *jvmTrait) Apply(e *Environment) error {
 func (t *jvmTrait) IsPlatformTrait() bool {
 	return true
 }
+
+func (t *jvmTrait) applyNative(e *Environment, kit *v1.IntegrationKit) error {
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return nil
+	}
+
+	for _, artifact := range kit.Status.Artifacts {
+		if strings.HasSuffix(artifact.Target, builder.QuarkusNativeRunnerSuffix) {
+			container.Command = []string{path.Join(builder.DeploymentDir, artifact.Target)}
+			container.Args = nil
+			container.WorkingDir = builder.DeploymentDir
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to find the native executable in integration kit %s/%s", kit.Namespace, kit.Name)
+}
@@ -29,6 +29,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	serving "knative.dev/serving/pkg/apis/serving/v1"
@@ -217,6 +218,39 @@ func TestApplyJvmTraitWithExternalKitType(t *testing.T) {
 	assert.Equal(t, "io.quarkus.bootstrap.runner.QuarkusEntryPoint", container.Args[2])
 }
 
+func TestApplyJvmTraitWithNativeKit(t *testing.T) {
+	trait, environment := createNominalJvmTest(v1.IntegrationKitTypePlatform)
+	environment.IntegrationKit.Labels[v1.IntegrationKitLayoutLabel] = v1.IntegrationKitLayoutNative
+	environment.IntegrationKit.Status.Artifacts = []v1.Artifact{
+		{ID: "camel-k-integration-runner", Target: "dependencies/camel-k-integration-runner"},
+	}
+
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	environment.Resources.Add(&d)
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	container := environment.getIntegrationContainer()
+
+	assert.Equal(t, []string{"/deployments/dependencies/camel-k-integration-runner"}, container.Command)
+	assert.Empty(t, container.Args)
+	assert.Equal(t, "/deployments", container.WorkingDir)
+}
+
 func TestApplyJvmTraitWithClasspath(t *testing.T) {
 	trait, environment := createNominalJvmTest(v1.IntegrationKitTypePlatform)
 	trait.Classpath = "/path/to/my-dep.jar:/path/to/another/dep.jar"
@@ -250,6 +284,75 @@ func TestApplyJvmTraitWithClasspath(t *testing.T) {
 	}, d.Spec.Template.Spec.Containers[0].Args)
 }
 
+func TestApplyJvmTraitWithHeapPercentage(t *testing.T) {
+	trait, environment := createNominalJvmTest(v1.IntegrationKitTypePlatform)
+	percentage := int64(75)
+	trait.HeapPercentage = &percentage
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("1G"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	environment.Resources.Add(&d)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, "-Xmx750M")
+
+	percentage = 0
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestApplyJvmTraitPrintsCommandInCondition(t *testing.T) {
+	trait, environment := createNominalJvmTest(v1.IntegrationKitTypePlatform)
+	trait.PrintCommand = BoolP(true)
+	trait.Options = []string{"-Xmx1G", "-Dpassword=secret"}
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+	environment.Resources.Add(&d)
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionJVMAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t,
+		fmt.Sprintf("java -cp ./resources:%s:%s io.quarkus.bootstrap.runner.QuarkusEntryPoint", configResourcesMountPath, resourcesDefaultMountPath),
+		condition.Message)
+	assert.NotContains(t, condition.Message, "secret")
+	assert.Equal(t, []string{"/bin/sh", "-c"}, d.Spec.Template.Spec.Containers[0].Command)
+}
+
 func createNominalJvmTest(kitType string) (*jvmTrait, *Environment) {
 	catalog, _ := camel.DefaultCatalog()
 
@@ -47,9 +47,9 @@ import (
 type kameletsTrait struct {
 	BaseTrait `property:",squash"`
 	// Automatically inject all referenced Kamelets and their default configuration (enabled by default)
-	Auto *bool `property:"auto"`
+	Auto *bool `property:"auto" json:"auto,omitempty"`
 	// Comma separated list of Kamelet names to load into the current integration
-	List string `property:"list"`
+	List string `property:"list" json:"list,omitempty"`
 }
 
 type configurationKey struct {
@@ -33,6 +33,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	serving "knative.dev/serving/pkg/apis/serving/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -42,6 +43,8 @@ import (
 	"github.com/apache/camel-k/pkg/util/envvar"
 	knativeutil "github.com/apache/camel-k/pkg/util/knative"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/property"
+	"github.com/apache/camel-k/pkg/util/uri"
 )
 
 // The Knative trait automatically discovers addresses of Knative resources and inject them into the
@@ -74,6 +77,13 @@ type knativeTrait struct {
 	// List of event types that the integration will produce.
 	// Can contain simple event types or full Camel URIs (to use a specific broker).
 	EventSinks []string `property:"event-sinks" json:"eventSinks,omitempty"`
+	// The name of the broker used by the event sources and sinks that do not explicitly
+	// reference one with the `name` URI parameter (default `default`).
+	Broker string `property:"broker" json:"broker,omitempty"`
+	// List of CloudEvents attributes to override on the events produced by the integration, in the form `name=value`,
+	// e.g. `source=my-integration` or `myextension=value`.
+	// When the integration is bound to its sink via a SinkBinding, only the extension attributes are overridden.
+	CloudEventsOverrides []string `property:"cloud-events-overrides" json:"cloudEventsOverrides,omitempty"`
 	// Enables filtering on events based on the header "ce-knativehistory". Since this header has been removed in newer versions of
 	// Knative, filtering is disabled by default.
 	FilterSourceChannels *bool `property:"filter-source-channels" json:"filterSourceChannels,omitempty"`
@@ -90,6 +100,9 @@ const (
 	knativeHistoryHeader = "ce-knativehistory"
 )
 
+// The CloudEvents context attributes, that cannot be overridden via the SinkBinding extensions
+var cloudEventsContextAttributes = []string{"id", "source", "specversion", "type", "datacontenttype", "dataschema", "subject", "time"}
+
 func newKnativeTrait() Trait {
 	t := &knativeTrait{
 		BaseTrait: NewBaseTrait("knative", 400),
@@ -217,6 +230,11 @@ func (t *knativeTrait) Apply(e *Environment) error {
 	}
 
 	if e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		overrides, err := t.getCloudEventsOverrides()
+		if err != nil {
+			return err
+		}
+
 		env := knativeapi.NewCamelEnvironment()
 		if t.Configuration != "" {
 			if err := env.Deserialize(t.Configuration); err != nil {
@@ -233,9 +251,10 @@ func (t *knativeTrait) Apply(e *Environment) error {
 		if err := t.configureEvents(e, &env); err != nil {
 			return err
 		}
-		if err := t.configureSinkBinding(e, &env); err != nil {
+		if err := t.configureSinkBinding(e, &env, overrides); err != nil {
 			return err
 		}
+		t.configureCloudEventsOverrides(&env, overrides)
 
 		conf, err := env.Serialize()
 		if err != nil {
@@ -442,7 +461,40 @@ func (t *knativeTrait) isSinkBindingAllowed(e *Environment) bool {
 	return true
 }
 
-func (t *knativeTrait) configureSinkBinding(e *Environment, env *knativeapi.CamelEnvironment) error {
+func (t *knativeTrait) getCloudEventsOverrides() (map[string]string, error) {
+	if len(t.CloudEventsOverrides) == 0 {
+		return nil, nil
+	}
+
+	overrides := make(map[string]string, len(t.CloudEventsOverrides))
+	for _, override := range t.CloudEventsOverrides {
+		k, v := property.SplitPropertyFileEntry(override)
+		if k == "" || v == "" {
+			return nil, fmt.Errorf("cloud events override must have name=value format, it was %v", override)
+		}
+		overrides[strings.TrimPrefix(strings.ToLower(k), "ce-")] = v
+	}
+
+	return overrides, nil
+}
+
+func (t *knativeTrait) configureCloudEventsOverrides(env *knativeapi.CamelEnvironment, overrides map[string]string) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	for i := range env.Services {
+		svc := &env.Services[i]
+		if svc.Metadata[knativeapi.CamelMetaEndpointKind] != string(knativeapi.CamelEndpointKindSink) {
+			continue
+		}
+		for k, v := range overrides {
+			svc.Metadata[knativeapi.CamelMetaCeOverridePrefix+"ce-"+k] = v
+		}
+	}
+}
+
+func (t *knativeTrait) configureSinkBinding(e *Environment, env *knativeapi.CamelEnvironment, overrides map[string]string) error {
 	if IsNilOrFalse(t.SinkBinding) {
 		return nil
 	}
@@ -506,7 +558,19 @@ func (t *knativeTrait) configureSinkBinding(e *Environment, env *knativeapi.Came
 					// Add the SinkBinding in first position, to make sure it is created
 					// before the reference source, so that the SinkBinding webhook has
 					// all the information to perform injection.
-					e.Resources.AddFirst(knativeutil.CreateSinkBinding(source, target))
+					sinkBinding := knativeutil.CreateSinkBinding(source, target)
+					extensions := make(map[string]string)
+					for k, v := range overrides {
+						if !util.StringSliceExists(cloudEventsContextAttributes, k) {
+							extensions[k] = v
+						}
+					}
+					if len(extensions) > 0 {
+						sinkBinding.Spec.CloudEventOverrides = &duckv1.CloudEventOverrides{
+							Extensions: extensions,
+						}
+					}
+					e.Resources.AddFirst(sinkBinding)
 
 					// Make sure the Eventing webhook will select the source resource,
 					// in order to inject the sink information.
@@ -566,6 +630,9 @@ func (t *knativeTrait) withServiceDo(
 		if err != nil {
 			return err
 		}
+		if serviceType == knativeapi.CamelServiceTypeEvent && t.Broker != "" && uri.GetQueryParameter(serviceURI, "name") == "" {
+			ref.Name = t.Broker
+		}
 		if skipDuplicates && env.ContainsService(ref.Name, endpointKind, serviceType, ref.APIVersion, ref.Kind) {
 			continue
 		}
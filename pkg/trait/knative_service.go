@@ -20,6 +20,9 @@ package trait
 import (
 	"fmt"
 	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +40,12 @@ const (
 	knativeServingTargetAnnotation   = "autoscaling.knative.dev/target"
 	knativeServingMinScaleAnnotation = "autoscaling.knative.dev/minScale"
 	knativeServingMaxScaleAnnotation = "autoscaling.knative.dev/maxScale"
+
+	knativeServingTargetUtilizationAnnotation = "autoscaling.knative.dev/target-utilization-percentage"
+	knativeServingScaleDownDelayAnnotation    = "autoscaling.knative.dev/scale-down-delay"
+
+	knativeServingVisibilityLabel        = "networking.knative.dev/visibility"
+	knativeServingVisibilityClusterLocal = "cluster-local"
 )
 
 // The Knative Service trait allows to configure options when running the integration as Knative service instead of
@@ -60,6 +69,20 @@ type knativeServiceTrait struct {
 	//
 	// Refer to the Knative documentation for more information.
 	Target *int `property:"autoscaling-target" json:"autoscalingTarget,omitempty"`
+	// The percentage of the autoscaling target that is actually aimed at, e.g. `70` to start scaling up
+	// before Pods reach their target concurrency level.
+	//
+	// Refer to the Knative documentation for more information.
+	TargetUtilization *int `property:"autoscaling-target-utilization" json:"autoscalingTargetUtilization,omitempty"`
+	// The hard limit of concurrent requests allowed to flow to each Pod. It's **zero** by default, meaning
+	// that there is no limit.
+	//
+	// Refer to the Knative documentation for more information.
+	ContainerConcurrency *int64 `property:"container-concurrency" json:"containerConcurrency,omitempty"`
+	// The amount of time that must pass at reduced concurrency before a scale down decision is applied, e.g. `15m`.
+	//
+	// Refer to the Knative documentation for more information.
+	ScaleDownDelay string `property:"scale-down-delay" json:"scaleDownDelay,omitempty"`
 	// The minimum number of Pods that should be running at any time for the integration. It's **zero** by default, meaning that
 	// the integration is scaled down to zero when not used for a configured amount of time.
 	//
@@ -70,6 +93,11 @@ type knativeServiceTrait struct {
 	//
 	// Refer to the Knative documentation for more information.
 	MaxScale *int `property:"max-scale" json:"maxScale,omitempty"`
+	// Setting `cluster-local`, Knative service becomes a private service.
+	// Specifically, this option applies the `networking.knative.dev/visibility` label to Knative service.
+	//
+	// Refer to the Knative documentation for more information.
+	Visibility string `property:"visibility" json:"visibility,omitempty"`
 	// Automatically deploy the integration as Knative service when all conditions hold:
 	//
 	// * Integration is using the Knative profile
@@ -102,6 +130,10 @@ func (t *knativeServiceTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if err := t.validate(); err != nil {
+		return false, err
+	}
+
 	if !e.InPhase(v1.IntegrationKitPhaseReady, v1.IntegrationPhaseDeploying) && !e.IntegrationInPhase(v1.IntegrationPhaseRunning) {
 		return false, nil
 	}
@@ -169,6 +201,25 @@ func (t *knativeServiceTrait) Configure(e *Environment) (bool, error) {
 	return true, nil
 }
 
+func (t *knativeServiceTrait) validate() error {
+	if t.TargetUtilization != nil && (*t.TargetUtilization < 1 || *t.TargetUtilization > 100) {
+		return fmt.Errorf("autoscaling-target-utilization must be between 1 and 100, it was %d", *t.TargetUtilization)
+	}
+	if t.ContainerConcurrency != nil && *t.ContainerConcurrency < 0 {
+		return fmt.Errorf("container-concurrency cannot be negative, it was %d", *t.ContainerConcurrency)
+	}
+	if t.ScaleDownDelay != "" {
+		if _, err := time.ParseDuration(t.ScaleDownDelay); err != nil {
+			return errors.Wrapf(err, "invalid scale-down-delay %s", t.ScaleDownDelay)
+		}
+	}
+	if t.Visibility != "" && t.Visibility != knativeServingVisibilityClusterLocal {
+		return fmt.Errorf("unsupported visibility: %s", t.Visibility)
+	}
+
+	return nil
+}
+
 func (t *knativeServiceTrait) Apply(e *Environment) error {
 	ksvc := t.getServiceFor(e)
 	maps := e.computeConfigMaps()
@@ -294,6 +345,20 @@ func (t *knativeServiceTrait) getServiceFor(e *Environment) *serving.Service {
 	if t.MaxScale != nil && *t.MaxScale > 0 {
 		annotations[knativeServingMaxScaleAnnotation] = strconv.Itoa(*t.MaxScale)
 	}
+	if t.TargetUtilization != nil {
+		annotations[knativeServingTargetUtilizationAnnotation] = strconv.Itoa(*t.TargetUtilization)
+	}
+	if t.ScaleDownDelay != "" {
+		annotations[knativeServingScaleDownDelayAnnotation] = t.ScaleDownDelay
+	}
+
+	serviceLabels := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		serviceLabels[k] = v
+	}
+	if t.Visibility != "" {
+		serviceLabels[knativeServingVisibilityLabel] = t.Visibility
+	}
 
 	svc := serving.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -303,7 +368,7 @@ func (t *knativeServiceTrait) getServiceFor(e *Environment) *serving.Service {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        e.Integration.Name,
 			Namespace:   e.Integration.Namespace,
-			Labels:      labels,
+			Labels:      serviceLabels,
 			Annotations: e.Integration.Annotations,
 		},
 		Spec: serving.ServiceSpec{
@@ -317,6 +382,7 @@ func (t *knativeServiceTrait) getServiceFor(e *Environment) *serving.Service {
 						PodSpec: corev1.PodSpec{
 							ServiceAccountName: e.Integration.Spec.ServiceAccountName,
 						},
+						ContainerConcurrency: t.ContainerConcurrency,
 					},
 				},
 			},
@@ -322,3 +322,46 @@ func TestKnativeServiceWithResr(t *testing.T) {
 		return service.Name == KnativeServiceTestName
 	}))
 }
+
+func TestKnativeServiceAutoscalingAndVisibility(t *testing.T) {
+	trait := newKnativeServiceTrait().(*knativeServiceTrait)
+	utilization := 70
+	concurrency := int64(10)
+	trait.TargetUtilization = &utilization
+	trait.ContainerConcurrency = &concurrency
+	trait.ScaleDownDelay = "15m"
+	trait.Visibility = "cluster-local"
+
+	assert.Nil(t, trait.validate())
+
+	environment := &Environment{
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      KnativeServiceTestName,
+				Namespace: KnativeServiceTestNamespace,
+			},
+		},
+	}
+
+	s := trait.getServiceFor(environment)
+
+	assert.Equal(t, "cluster-local", s.Labels["networking.knative.dev/visibility"])
+	assert.NotContains(t, s.Spec.Template.Labels, "networking.knative.dev/visibility")
+	assert.Equal(t, "70", s.Spec.Template.Annotations["autoscaling.knative.dev/target-utilization-percentage"])
+	assert.Equal(t, "15m", s.Spec.Template.Annotations["autoscaling.knative.dev/scale-down-delay"])
+	assert.Equal(t, int64(10), *s.Spec.Template.Spec.ContainerConcurrency)
+}
+
+func TestKnativeServiceInvalidAutoscaling(t *testing.T) {
+	utilization := 0
+	concurrency := int64(-1)
+
+	for _, trait := range []*knativeServiceTrait{
+		{TargetUtilization: &utilization},
+		{ContainerConcurrency: &concurrency},
+		{ScaleDownDelay: "forever"},
+		{Visibility: "public"},
+	} {
+		assert.NotNil(t, trait.validate())
+	}
+}
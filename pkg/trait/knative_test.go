@@ -147,6 +147,103 @@ func TestKnativeEnvConfigurationFromTrait(t *testing.T) {
 	assert.Equal(t, "http://broker-default.host/", eEventSink.URL)
 }
 
+func TestKnativeBrokerAndCloudEventsOverrides(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment := Environment{
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(context.TODO(), nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKnative,
+				Traits: map[string]v1.TraitSpec{
+					"knative": test.TraitSpecFromMap(t, map[string]interface{}{
+						"enabled":              true,
+						"auto":                 false,
+						"endpointSinks":        []string{"endpoint-sink-1"},
+						"eventSources":         []string{"knative:event/my.type"},
+						"eventSinks":           []string{"knative:event", "knative:event?name=default"},
+						"broker":               "custom",
+						"cloudEventsOverrides": []string{"source=my-source", "ce-myextension=value"},
+					}),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterOpenShift,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+				Profile: v1.TraitProfileKnative,
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      k8sutils.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	c, err := NewFakeClient("ns")
+	assert.Nil(t, err)
+
+	tc := NewCatalog(context.TODO(), c)
+
+	err = tc.configure(&environment)
+	assert.Nil(t, err)
+
+	tr := tc.GetTrait("knative").(*knativeTrait)
+	ok, err := tr.Configure(&environment)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = tr.Apply(&environment)
+	assert.Nil(t, err)
+
+	kc := envvar.Get(environment.EnvVars, "CAMEL_KNATIVE_CONFIGURATION")
+	assert.NotNil(t, kc)
+
+	ne := knativeapi.NewCamelEnvironment()
+	err = ne.Deserialize(kc.Value)
+	assert.Nil(t, err)
+
+	customSink := ne.FindService("custom", knativeapi.CamelEndpointKindSink, knativeapi.CamelServiceTypeEvent, "eventing.knative.dev/v1", "Broker")
+	assert.NotNil(t, customSink)
+	assert.Equal(t, "http://broker-custom.host/", customSink.URL)
+	assert.Equal(t, "my-source", customSink.Metadata["ce.override.ce-source"])
+	assert.Equal(t, "value", customSink.Metadata["ce.override.ce-myextension"])
+
+	defaultSink := ne.FindService("default", knativeapi.CamelEndpointKindSink, knativeapi.CamelServiceTypeEvent, "eventing.knative.dev/v1", "Broker")
+	assert.NotNil(t, defaultSink)
+	assert.Equal(t, "http://broker-default.host/", defaultSink.URL)
+
+	endpointSink := ne.FindService("endpoint-sink-1", knativeapi.CamelEndpointKindSink, knativeapi.CamelServiceTypeEndpoint, "serving.knative.dev/v1", "Service")
+	assert.NotNil(t, endpointSink)
+	assert.Equal(t, "my-source", endpointSink.Metadata["ce.override.ce-source"])
+
+	source := ne.FindService("my.type", knativeapi.CamelEndpointKindSource, knativeapi.CamelServiceTypeEvent, "eventing.knative.dev/v1", "Broker")
+	assert.NotNil(t, source)
+	assert.NotContains(t, source.Metadata, "ce.override.ce-source")
+
+	assert.True(t, environment.Resources.HasKnativeTrigger(func(trigger *eventing.Trigger) bool {
+		return trigger.Spec.Broker == "custom"
+	}))
+}
+
 func TestKnativeEnvConfigurationFromSource(t *testing.T) {
 	catalog, err := camel.DefaultCatalog()
 	assert.Nil(t, err)
@@ -424,6 +521,10 @@ func NewFakeClient(namespace string) (client.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	customBrokerURL, err := apis.ParseURL("http://broker-custom.host/")
+	if err != nil {
+		return nil, err
+	}
 
 	return test.NewFakeClient(
 		&messaging.Channel{
@@ -514,6 +615,22 @@ func NewFakeClient(namespace string) (client.Client, error) {
 				},
 			},
 		},
+		&eventing.Broker{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: eventing.SchemeGroupVersion.String(),
+				Kind:       "Broker",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "custom",
+			},
+			Spec: eventing.BrokerSpec{},
+			Status: eventing.BrokerStatus{
+				Address: duckv1.Addressable{
+					URL: customBrokerURL,
+				},
+			},
+		},
 		&eventing.Trigger{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: eventing.SchemeGroupVersion.String(),
@@ -18,9 +18,12 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/property"
 )
 
 const (
@@ -49,6 +52,9 @@ type loggingTrait struct {
 	Json *bool `property:"json" json:"json,omitempty"`
 	// Enable "pretty printing" of the JSON logs
 	JsonPrettyPrint *bool `property:"json-pretty-print" json:"jsonPrettyPrint,omitempty"`
+	// Adjust the logging level of specific categories, using the `category=level` format,
+	// e.g. `org.apache.camel=DEBUG`
+	Categories []string `property:"categories" json:"categories,omitempty"`
 }
 
 func newLoggingTraitTrait() Trait {
@@ -81,6 +87,18 @@ func (l loggingTrait) Apply(environment *Environment) error {
 
 	envvar.SetVal(&environment.EnvVars, envVarQuarkusLogLevel, l.Level)
 
+	// Category names contain dots, that cannot be mapped to environment variables reliably
+	for _, c := range l.Categories {
+		category, level := property.SplitPropertyFileEntry(c)
+		if category == "" || level == "" {
+			return fmt.Errorf("logging category must have category=level format, it was %v", c)
+		}
+		if environment.ApplicationProperties == nil {
+			environment.ApplicationProperties = make(map[string]string)
+		}
+		environment.ApplicationProperties[fmt.Sprintf("quarkus.log.category.\"%s\".level", category)] = level
+	}
+
 	if l.Format != "" {
 		envvar.SetVal(&environment.EnvVars, envVarQuarkusLogConsoleFormat, l.Format)
 	}
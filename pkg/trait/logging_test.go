@@ -196,3 +196,26 @@ func TestJsonLoggingTrait(t *testing.T) {
 	assert.True(t, logFormatIsNotDefault)
 	assert.NotEmpty(t, env.ExecutedTraits)
 }
+
+func TestCategoriesLoggingTrait(t *testing.T) {
+	env := createDefaultLoggingTestEnv(t)
+	env.Integration.Spec.Traits["logging"] = test.TraitSpecFromMap(t, map[string]interface{}{
+		"categories": []string{"org.apache.camel=DEBUG", "io.quarkus=WARN"},
+	})
+	err := NewLoggingTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DEBUG", env.ApplicationProperties[`quarkus.log.category."org.apache.camel".level`])
+	assert.Equal(t, "WARN", env.ApplicationProperties[`quarkus.log.category."io.quarkus".level`])
+}
+
+func TestInvalidCategoriesLoggingTrait(t *testing.T) {
+	env := createDefaultLoggingTestEnv(t)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	trait := newLoggingTraitTrait().(*loggingTrait)
+	trait.Categories = []string{"org.apache.camel"}
+
+	err := trait.Apply(env)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/resource"
+)
+
+// The Mount trait can be used to configure volumes mounted on the Integration Pods.
+//
+// Configurations are ConfigMaps or Secrets mounted as properties into the integration, while
+// resources are ConfigMaps or Secrets mounted as files, by default under `/etc/camel/resources`.
+// Volumes are Persistent Volume Claims mounted at the given path of the integration container.
+//
+// The syntax is the same as the one of the `kamel run` `--config`, `--resource` and `--volume` options.
+//
+// +camel-k:trait=mount
+type mountTrait struct {
+	BaseTrait `property:",squash"`
+	// A list of configuration pointing to configmap/secret.
+	// The configuration are expected to be UTF-8 resources as they are processed by runtime Camel Context and tried to be parsed as property files.
+	// They are also made available on the classpath in order to ease their usage directly from the Route.
+	// Syntax: [configmap|secret]:name[/key], where name represents the resource name and key optionally represents the resource key to be filtered
+	Configs []string `property:"configs" json:"configs,omitempty"`
+	// A list of resources pointing to configmap/secret.
+	// The resources are expected to be any resource type (text or binary content).
+	// The destination path can be either a default location or any path specified by the user.
+	// Syntax: [configmap|secret]:name[/key][@path], where name represents the resource name, key optionally represents the resource key to be filtered and path represents the destination path
+	Resources []string `property:"resources" json:"resources,omitempty"`
+	// A list of Persistent Volume Claims to be mounted. Syntax: [pvcname:/container/path]
+	Volumes []string `property:"volumes" json:"volumes,omitempty"`
+}
+
+var mountVolumeRegexp = regexp.MustCompile(`^([\w\.\-\_]+):(\/[\w\.\-\_\/]*)$`)
+
+func newMountTrait() Trait {
+	return &mountTrait{
+		BaseTrait: NewBaseTrait("mount", 1610),
+	}
+}
+
+func (t *mountTrait) Configure(e *Environment) (bool, error) {
+	if IsFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if len(t.Configs) == 0 && len(t.Resources) == 0 && len(t.Volumes) == 0 {
+		return false, nil
+	}
+
+	for _, c := range t.Configs {
+		r, err := parseMountedResource(c)
+		if err != nil {
+			return false, err
+		}
+		if r.DestinationPath() != "" {
+			return false, fmt.Errorf("configuration %s cannot declare a destination path", c)
+		}
+	}
+	for _, r := range t.Resources {
+		if _, err := parseMountedResource(r); err != nil {
+			return false, err
+		}
+	}
+	for _, v := range t.Volumes {
+		if _, _, err := parseMountedVolume(v); err != nil {
+			return false, err
+		}
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *mountTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return fmt.Errorf("unable to find integration container: %s", e.Integration.Name)
+	}
+
+	for _, c := range t.Configs {
+		r, err := parseMountedResource(c)
+		if err != nil {
+			return err
+		}
+		t.mountResource(podSpec, container, r, "config")
+	}
+	for _, res := range t.Resources {
+		r, err := parseMountedResource(res)
+		if err != nil {
+			return err
+		}
+		t.mountResource(podSpec, container, r, "data")
+	}
+	for _, v := range t.Volumes {
+		pvcName, mountPath, err := parseMountedVolume(v)
+		if err != nil {
+			return err
+		}
+
+		mountPersistentVolumeClaim(&podSpec.Volumes, &container.VolumeMounts, pvcName, mountPath)
+	}
+
+	return nil
+}
+
+func (t *mountTrait) mountResource(podSpec *corev1.PodSpec, container *corev1.Container, r *resource.Config, resourceType string) {
+	var mountPath string
+	if r.StorageType() == resource.StorageTypeSecret {
+		mountPath = getSecretMountPoint(r.Name(), r.DestinationPath(), resourceType)
+	} else {
+		mountPath = getConfigmapMountPoint(r.Name(), r.DestinationPath(), resourceType)
+	}
+	mountConfigmapOrSecret(&podSpec.Volumes, &container.VolumeMounts, r.Type(), r.Name(), r.Key(), mountPath)
+}
+
+func parseMountedResource(value string) (*resource.Config, error) {
+	config, err := resource.ParseConfig(value)
+	if err != nil {
+		return nil, err
+	}
+	if config.StorageType() != resource.StorageTypeConfigmap && config.StorageType() != resource.StorageTypeSecret {
+		return nil, fmt.Errorf("could not match %s as [configmap|secret]:name[/key][@path]", value)
+	}
+	if config.DestinationPath() != "" && !path.IsAbs(config.DestinationPath()) {
+		return nil, fmt.Errorf("the destination path %s must be absolute", config.DestinationPath())
+	}
+
+	return config, nil
+}
+
+func parseMountedVolume(value string) (string, string, error) {
+	groups := mountVolumeRegexp.FindStringSubmatch(value)
+	if groups == nil {
+		return "", "", fmt.Errorf("could not match %s as pvcname:/container/path", value)
+	}
+	if err := resource.ValidateDestinationPath(groups[2]); err != nil {
+		return "", "", err
+	}
+
+	return groups[1], groups[2], nil
+}
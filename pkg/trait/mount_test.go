@@ -0,0 +1,180 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConfigureMountTraitDisabledWithoutEntries(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	trait := newMountTrait().(*mountTrait)
+
+	configured, err := trait.Configure(environment)
+
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureMountTraitInvalidEntries(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+
+	for _, trait := range []*mountTrait{
+		{Configs: []string{"file:my-conf"}},
+		{Configs: []string{"configmap:my-cm@/tmp/my-cm"}},
+		{Resources: []string{"secret:my-sec@relative/path"}},
+		{Resources: []string{"configmap:my-cm@/etc/camel/conf"}},
+		{Volumes: []string{"my-pvc"}},
+		{Volumes: []string{"my-pvc:/deployments/dependencies"}},
+	} {
+		configured, err := trait.Configure(environment)
+
+		assert.NotNil(t, err)
+		assert.False(t, configured)
+	}
+}
+
+func TestApplyMountTrait(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name: defaultContainerName,
+		},
+	}
+	environment.Catalog = NewCatalog(context.TODO(), nil)
+	trait := newMountTrait().(*mountTrait)
+	trait.Configs = []string{"configmap:my-cm", "secret:my-sec/my-key"}
+	trait.Resources = []string{"configmap:my-res@/var/data"}
+	trait.Volumes = []string{"my-pvc:/var/pvc"}
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Len(t, podSpec.Volumes, 4)
+	assert.Equal(t, "my-cm", podSpec.Volumes[0].ConfigMap.Name)
+	assert.Nil(t, podSpec.Volumes[0].ConfigMap.Items)
+	assert.Equal(t, "my-sec", podSpec.Volumes[1].Secret.SecretName)
+	assert.Equal(t, "my-key", podSpec.Volumes[1].Secret.Items[0].Key)
+	assert.Equal(t, "my-res", podSpec.Volumes[2].ConfigMap.Name)
+	assert.Equal(t, "my-pvc", podSpec.Volumes[3].PersistentVolumeClaim.ClaimName)
+
+	mounts := podSpec.Containers[0].VolumeMounts
+	assert.Len(t, mounts, 4)
+	assert.Equal(t, "/etc/camel/conf.d/_configmaps/my-cm", mounts[0].MountPath)
+	assert.True(t, mounts[0].ReadOnly)
+	assert.Equal(t, "/etc/camel/conf.d/_secrets/my-sec", mounts[1].MountPath)
+	assert.Equal(t, "/var/data", mounts[2].MountPath)
+	assert.Equal(t, "my-pvc-data", mounts[3].Name)
+	assert.Equal(t, "/var/pvc", mounts[3].MountPath)
+	assert.False(t, mounts[3].ReadOnly)
+}
+
+func TestApplyMountTraitSkipsAlreadyMountedResources(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name: defaultContainerName,
+		},
+	}
+	deployment.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "my-cm",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "my-cm",
+					},
+				},
+			},
+		},
+	}
+	deployment.Spec.Template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      "my-cm",
+			MountPath: "/etc/camel/conf.d/_configmaps/my-cm",
+		},
+	}
+	environment.Catalog = NewCatalog(context.TODO(), nil)
+	trait := newMountTrait().(*mountTrait)
+	trait.Configs = []string{"configmap:my-cm"}
+	trait.Resources = []string{"configmap:my-cm@/var/data", "configmap:my-cm/my-key@/var/key", "secret:my-cm@/var/secret"}
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	// The ConfigMap volume is reused, while another key of the ConfigMap and
+	// a Secret with the same name are mounted with their own volumes
+	volumes := deployment.Spec.Template.Spec.Volumes
+	assert.Len(t, volumes, 3)
+	assert.Equal(t, "my-cm", volumes[0].Name)
+	assert.Regexp(t, "^my-cm-[0-9a-f]{8}$", volumes[1].Name)
+	assert.Equal(t, "my-key", volumes[1].ConfigMap.Items[0].Key)
+	assert.Regexp(t, "^my-cm-[0-9a-f]{8}$", volumes[2].Name)
+	assert.Equal(t, "my-cm", volumes[2].Secret.SecretName)
+	assert.NotEqual(t, volumes[1].Name, volumes[2].Name)
+
+	mounts := deployment.Spec.Template.Spec.Containers[0].VolumeMounts
+	assert.Len(t, mounts, 4)
+	assert.Equal(t, "my-cm", mounts[1].Name)
+	assert.Equal(t, "/var/data", mounts[1].MountPath)
+	assert.Equal(t, volumes[1].Name, mounts[2].Name)
+	assert.Equal(t, volumes[2].Name, mounts[3].Name)
+}
+
+func TestApplyMountTraitWithCollidingNames(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name: defaultContainerName,
+		},
+	}
+	environment.Catalog = NewCatalog(context.TODO(), nil)
+	trait := newMountTrait().(*mountTrait)
+	trait.Resources = []string{"configmap:ab@/var/ab", "configmap:a.b@/var/a.b"}
+	trait.Volumes = []string{"my-pvc:/var/pvc", "my-pvc:/var/other"}
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	volumes := deployment.Spec.Template.Spec.Volumes
+	assert.Len(t, volumes, 3)
+	assert.Equal(t, "ab", volumes[0].Name)
+	assert.Equal(t, "ab", volumes[0].ConfigMap.Name)
+	assert.Regexp(t, "^ab-[0-9a-f]{8}$", volumes[1].Name)
+	assert.Equal(t, "a.b", volumes[1].ConfigMap.Name)
+	assert.Equal(t, "my-pvc-data", volumes[2].Name)
+
+	// The claim is declared once, and mounted at both paths
+	mounts := deployment.Spec.Template.Spec.Containers[0].VolumeMounts
+	assert.Len(t, mounts, 4)
+	assert.Equal(t, volumes[1].Name, mounts[1].Name)
+	assert.Equal(t, "my-pvc-data", mounts[2].Name)
+	assert.Equal(t, "/var/pvc", mounts[2].MountPath)
+	assert.Equal(t, "my-pvc-data", mounts[3].Name)
+	assert.Equal(t, "/var/other", mounts[3].MountPath)
+}
@@ -0,0 +1,269 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// The Network Policy trait generates a NetworkPolicy for the integration pods, to restrict the network traffic
+// they accept and initiate, e.g. to comply with zero-trust cluster policies.
+//
+// By default, the ingress traffic is only allowed on the ports exposed by the integration container, e.g. the HTTP port
+// when the integration consumes HTTP endpoints, while the egress traffic is not restricted.
+//
+// When the egress traffic is restricted, it is only allowed towards the declared hosts, and the IP addresses
+// found in the endpoints of the integration. DNS resolution is always allowed.
+//
+// NOTE: The NetworkPolicy is only enforced when the cluster network plugin supports it.
+// It isn't supported with the Knative Service controller strategy.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=network-policy
+type networkPolicyTrait struct {
+	BaseTrait `property:",squash"`
+	// Whether the ingress traffic is restricted (default `true`).
+	Ingress *bool `property:"ingress" json:"ingress,omitempty"`
+	// The ports, as numbers or names, the ingress traffic is allowed on (default to the ports exposed by the integration container).
+	IngressPorts []string `property:"ingress-ports" json:"ingressPorts,omitempty"`
+	// Whether the egress traffic is restricted (default `false`).
+	Egress *bool `property:"egress" json:"egress,omitempty"`
+	// The hosts, as IP addresses or CIDR blocks, the egress traffic is allowed to.
+	EgressHosts []string `property:"egress-hosts" json:"egressHosts,omitempty"`
+	// The ports the egress traffic is allowed on (default to any port).
+	EgressPorts []string `property:"egress-ports" json:"egressPorts,omitempty"`
+	// Automatically allows the egress traffic to the IP addresses found in the endpoints of the integration (default `true`).
+	Auto *bool `property:"auto" json:"auto,omitempty"`
+}
+
+var ipv4Regexp = regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}\b`)
+
+func newNetworkPolicyTrait() Trait {
+	return &networkPolicyTrait{
+		BaseTrait: NewBaseTrait("network-policy", 2100),
+	}
+}
+
+func (t *networkPolicyTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		return false, nil
+	}
+
+	strategy, err := e.DetermineControllerStrategy()
+	if err != nil {
+		return false, fmt.Errorf("unable to determine the controller strategy")
+	}
+	if strategy == ControllerStrategyKnativeService {
+		return false, fmt.Errorf("network policy isn't supported with knative-service controller strategy")
+	}
+
+	for _, host := range t.EgressHosts {
+		if _, err := toCIDR(host); err != nil {
+			return false, err
+		}
+	}
+
+	return IsNilOrTrue(t.Ingress) || IsTrue(t.Egress), nil
+}
+
+func (t *networkPolicyTrait) Apply(e *Environment) error {
+	policy := &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					v1.IntegrationLabel: e.Integration.Name,
+				},
+			},
+		},
+	}
+
+	if IsNilOrTrue(t.Ingress) {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+		if ports := t.ingressPorts(e); len(ports) > 0 {
+			policy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: ports,
+				},
+			}
+		}
+	}
+
+	if IsTrue(t.Egress) {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		egress, err := t.egressRules(e)
+		if err != nil {
+			return err
+		}
+		policy.Spec.Egress = egress
+	}
+
+	e.Resources.Add(policy)
+
+	return nil
+}
+
+func (t *networkPolicyTrait) ingressPorts(e *Environment) []networkingv1.NetworkPolicyPort {
+	ports := make([]networkingv1.NetworkPolicyPort, 0)
+	if len(t.IngressPorts) > 0 {
+		for _, p := range t.IngressPorts {
+			ports = append(ports, newNetworkPolicyPort(corev1.ProtocolTCP, intstr.Parse(p)))
+		}
+		return ports
+	}
+
+	if container := e.getIntegrationContainer(); container != nil {
+		for _, p := range container.Ports {
+			protocol := p.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			ports = append(ports, newNetworkPolicyPort(protocol, intstr.FromInt(int(p.ContainerPort))))
+		}
+	}
+
+	return ports
+}
+
+func (t *networkPolicyTrait) egressRules(e *Environment) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		// Allow DNS resolution
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				newNetworkPolicyPort(corev1.ProtocolUDP, intstr.FromInt(53)),
+				newNetworkPolicyPort(corev1.ProtocolTCP, intstr.FromInt(53)),
+			},
+		},
+	}
+
+	hosts := append([]string{}, t.EgressHosts...)
+	if IsNilOrTrue(t.Auto) {
+		detected, err := t.detectEndpointHosts(e)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, detected...)
+	}
+	if len(hosts) == 0 {
+		return rules, nil
+	}
+
+	cidrs := make(map[string]bool)
+	for _, host := range hosts {
+		cidr, err := toCIDR(host)
+		if err != nil {
+			return nil, err
+		}
+		cidrs[cidr] = true
+	}
+	// Keep the peers sorted so that they are consistent over reconciliation cycles
+	sorted := make([]string, 0, len(cidrs))
+	for cidr := range cidrs {
+		sorted = append(sorted, cidr)
+	}
+	sort.Strings(sorted)
+
+	rule := networkingv1.NetworkPolicyEgressRule{}
+	for _, cidr := range sorted {
+		rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{
+				CIDR: cidr,
+			},
+		})
+	}
+	for _, p := range t.EgressPorts {
+		rule.Ports = append(rule.Ports, newNetworkPolicyPort(corev1.ProtocolTCP, intstr.Parse(p)))
+	}
+
+	return append(rules, rule), nil
+}
+
+// detectEndpointHosts returns the IP addresses found in the endpoint URIs of the integration
+func (t *networkPolicyTrait) detectEndpointHosts(e *Environment) ([]string, error) {
+	if e.CamelCatalog == nil {
+		return nil, nil
+	}
+
+	sources, err := kubernetes.ResolveIntegrationSources(e.C, e.Client, e.Integration, e.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]string, 0)
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+	for _, uri := range append(meta.FromURIs, meta.ToURIs...) {
+		for _, ip := range ipv4Regexp.FindAllString(uri, -1) {
+			if net.ParseIP(ip) != nil {
+				hosts = append(hosts, ip)
+			}
+		}
+	}
+
+	return hosts, nil
+}
+
+func newNetworkPolicyPort(protocol corev1.Protocol, port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &port,
+	}
+}
+
+// toCIDR returns the CIDR block matching the given IP address, or the given CIDR block
+func toCIDR(host string) (string, error) {
+	if _, network, err := net.ParseCIDR(host); err == nil {
+		return network.String(), nil
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return "", fmt.Errorf("egress host must be an IP address or a CIDR block, it was %s", host)
+	}
+	if ip.To4() != nil {
+		return ip.String() + "/32", nil
+	}
+
+	return ip.String() + "/128", nil
+}
@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestConfigureNetworkPolicyTraitDisabledByDefault(t *testing.T) {
+	_, environment := createNetworkPolicyTest(t)
+	networkPolicyTrait := newNetworkPolicyTrait()
+
+	configured, err := networkPolicyTrait.Configure(environment)
+
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureNetworkPolicyTraitInvalidEgressHost(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t)
+	networkPolicyTrait.EgressHosts = []string{"db.example.com"}
+
+	_, err := networkPolicyTrait.Configure(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyNetworkPolicyTraitIngress(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t)
+
+	configured, err := networkPolicyTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = networkPolicyTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	policy := getNetworkPolicy(environment)
+	assert.NotNil(t, policy)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+	assert.Equal(t, "integration-name", policy.Spec.PodSelector.MatchLabels[v1.IntegrationLabel])
+	assert.Len(t, policy.Spec.Ingress, 1)
+	assert.Len(t, policy.Spec.Ingress[0].Ports, 1)
+	assert.Equal(t, intstr.FromInt(8080), *policy.Spec.Ingress[0].Ports[0].Port)
+	assert.Equal(t, corev1.ProtocolTCP, *policy.Spec.Ingress[0].Ports[0].Protocol)
+	assert.Nil(t, policy.Spec.Egress)
+}
+
+func TestApplyNetworkPolicyTraitEgress(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t)
+	networkPolicyTrait.Ingress = BoolP(false)
+	networkPolicyTrait.Egress = BoolP(true)
+	networkPolicyTrait.EgressHosts = []string{"192.168.0.0/16", "10.0.0.5"}
+	networkPolicyTrait.EgressPorts = []string{"8080"}
+
+	configured, err := networkPolicyTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = networkPolicyTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	policy := getNetworkPolicy(environment)
+	assert.NotNil(t, policy)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+	assert.Nil(t, policy.Spec.Ingress)
+	assert.Len(t, policy.Spec.Egress, 2)
+	// DNS resolution
+	assert.Len(t, policy.Spec.Egress[0].Ports, 2)
+	assert.Equal(t, intstr.FromInt(53), *policy.Spec.Egress[0].Ports[0].Port)
+	// Declared and detected hosts
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+		{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.5/32"}},
+		{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.6/32"}},
+		{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/16"}},
+	}, policy.Spec.Egress[1].To)
+	assert.Equal(t, intstr.FromInt(8080), *policy.Spec.Egress[1].Ports[0].Port)
+}
+
+func createNetworkPolicyTest(t *testing.T) (*networkPolicyTrait, *Environment) {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	trait := newNetworkPolicyTrait().(*networkPolicyTrait)
+	trait.Enabled = BoolP(true)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          defaultContainerPortName,
+									ContainerPort: 8080,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	environment := &Environment{
+		C:            context.TODO(),
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(context.TODO(), nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Spec: v1.IntegrationSpec{
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.js",
+							Content: `from('timer:tick').to('http://10.0.0.6:8080/api')`,
+						},
+						Language: v1.LanguageJavaScript,
+					},
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(deployment),
+	}
+
+	return trait, environment
+}
+
+func getNetworkPolicy(e *Environment) *networkingv1.NetworkPolicy {
+	var policy *networkingv1.NetworkPolicy
+	e.Resources.Visit(func(o runtime.Object) {
+		if p, ok := o.(*networkingv1.NetworkPolicy); ok {
+			policy = p
+		}
+	})
+	return policy
+}
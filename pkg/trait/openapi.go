@@ -214,6 +214,13 @@ func (t *openAPITrait) createNewOpenAPIConfigMap(e *Environment, resource v1.Res
 	if settings != "" {
 		mc.SettingsContent = []byte(settings)
 	}
+	settingsSecurity, err := kubernetes.ResolveValueSource(e.C, e.Client, e.Platform.Namespace, &e.Platform.Status.Build.Maven.SettingsSecurity)
+	if err != nil {
+		return err
+	}
+	if settingsSecurity != "" {
+		mc.SettingsSecurityContent = []byte(settingsSecurity)
+	}
 
 	if e.Platform.Status.Build.Maven.CASecret != nil {
 		certData, err := kubernetes.GetSecretRefData(e.C, e.Client, e.Platform.Namespace, e.Platform.Status.Build.Maven.CASecret)
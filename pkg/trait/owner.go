@@ -18,9 +18,10 @@ limitations under the License.
 package trait
 
 import (
-	appsv1 "k8s.io/api/apps/v1"
+	"fmt"
+	"path"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	serving "knative.dev/serving/pkg/apis/serving/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 )
@@ -31,9 +32,11 @@ import (
 // +camel-k:trait=owner
 type ownerTrait struct {
 	BaseTrait `property:",squash"`
-	// The set of annotations to be transferred
+	// The set of annotations to be transferred. Each entry is either an annotation key, or a pattern
+	// matching annotation keys, e.g. `governance.mycompany.com/*`.
 	TargetAnnotations []string `property:"target-annotations" json:"targetAnnotations,omitempty"`
-	// The set of labels to be transferred
+	// The set of labels to be transferred. Each entry is either a label key, or a pattern
+	// matching label keys, e.g. `governance.mycompany.com/*`.
 	TargetLabels []string `property:"target-labels" json:"targetLabels,omitempty"`
 }
 
@@ -52,6 +55,12 @@ func (t *ownerTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	for _, pattern := range append(append([]string{}, t.TargetLabels...), t.TargetAnnotations...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return false, fmt.Errorf("invalid label or annotation pattern %q: %v", pattern, err)
+		}
+	}
+
 	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization, v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
 }
 
@@ -59,23 +68,8 @@ func (t *ownerTrait) Apply(e *Environment) error {
 	controller := true
 	blockOwnerDeletion := true
 
-	targetLabels := make(map[string]string)
-	if e.Integration.Labels != nil {
-		for _, k := range t.TargetLabels {
-			if v, ok := e.Integration.Labels[k]; ok {
-				targetLabels[k] = v
-			}
-		}
-	}
-
-	targetAnnotations := make(map[string]string)
-	if e.Integration.Annotations != nil {
-		for _, k := range t.TargetAnnotations {
-			if v, ok := e.Integration.Annotations[k]; ok {
-				targetAnnotations[k] = v
-			}
-		}
-	}
+	targetLabels := matchingEntries(e.Integration.Labels, t.TargetLabels)
+	targetAnnotations := matchingEntries(e.Integration.Annotations, t.TargetAnnotations)
 
 	e.Resources.VisitMetaObject(func(res metav1.Object) {
 		// Cross-namespace references are forbidden and also asynchronously refused
@@ -99,12 +93,9 @@ func (t *ownerTrait) Apply(e *Environment) error {
 		t.propagateLabelAndAnnotations(res, targetLabels, targetAnnotations)
 	})
 
-	e.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
-		t.propagateLabelAndAnnotations(&deployment.Spec.Template, targetLabels, targetAnnotations)
-	})
-
-	e.Resources.VisitKnativeService(func(service *serving.Service) {
-		t.propagateLabelAndAnnotations(&service.Spec.ConfigurationSpec.Template, targetLabels, targetAnnotations)
+	// Transfer to the pods, i.e., deployments, Knative services and cron jobs templates
+	e.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+		t.propagateLabelAndAnnotations(meta, targetLabels, targetAnnotations)
 	})
 
 	return nil
@@ -142,3 +133,18 @@ func (t *ownerTrait) propagateLabelAndAnnotations(res metav1.Object, targetLabel
 	}
 	res.SetLabels(labels)
 }
+
+// matchingEntries returns the entries whose key matches one of the given keys or patterns
+func matchingEntries(entries map[string]string, patterns []string) map[string]string {
+	matching := make(map[string]string)
+	for k, v := range entries {
+		for _, pattern := range patterns {
+			// Patterns have been validated beforehand
+			if ok, _ := path.Match(pattern, k); ok {
+				matching[k] = v
+				break
+			}
+		}
+	}
+	return matching
+}
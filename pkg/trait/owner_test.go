@@ -93,3 +93,51 @@ func ValidateLabelsAndAnnotations(t *testing.T, res metav1.Object) {
 	assert.Contains(t, res.GetAnnotations(), "com.mycompany/myannotation2")
 	assert.Equal(t, "myannotation2", res.GetAnnotations()["com.mycompany/myannotation2"])
 }
+
+func TestOwnerWithPatterns(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterOpenShift, "camel:core")
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"owner": test.TraitSpecFromMap(t, map[string]interface{}{
+			"targetLabels":      []string{"governance.mycompany.com/*"},
+			"targetAnnotations": []string{"governance.mycompany.com/*", "com.mycompany/myannotation1"},
+		}),
+	}
+
+	env.Integration.SetLabels(map[string]string{
+		"governance.mycompany.com/team":        "team",
+		"governance.mycompany.com/cost-center": "1234",
+		"com.mycompany/mylabel1":               "myvalue1",
+	})
+	env.Integration.SetAnnotations(map[string]string{
+		"governance.mycompany.com/owner": "owner",
+		"com.mycompany/myannotation1":    "myannotation1",
+		"com.mycompany/myannotation2":    "myannotation2",
+	})
+
+	processTestEnv(t, env)
+
+	validate := func(res metav1.Object) {
+		assert.Equal(t, "team", res.GetLabels()["governance.mycompany.com/team"])
+		assert.Equal(t, "1234", res.GetLabels()["governance.mycompany.com/cost-center"])
+		assert.NotContains(t, res.GetLabels(), "com.mycompany/mylabel1")
+
+		assert.Equal(t, "owner", res.GetAnnotations()["governance.mycompany.com/owner"])
+		assert.Equal(t, "myannotation1", res.GetAnnotations()["com.mycompany/myannotation1"])
+	}
+
+	env.Resources.VisitMetaObject(validate)
+	env.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+		validate(meta)
+	})
+}
+
+func TestOwnerWithInvalidPattern(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterOpenShift, "camel:core")
+
+	owner := newOwnerTrait().(*ownerTrait)
+	owner.TargetLabels = []string{"governance.mycompany.com/[team"}
+
+	configured, err := owner.Configure(env)
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+}
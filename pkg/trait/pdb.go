@@ -19,6 +19,8 @@ package trait
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -66,6 +68,13 @@ func (t *pdbTrait) Configure(e *Environment) (bool, error) {
 		return false, fmt.Errorf("both minAvailable and maxUnavailable can't be set simultaneously")
 	}
 
+	if err := validatePdbValue("min-available", t.MinAvailable); err != nil {
+		return false, err
+	}
+	if err := validatePdbValue("max-unavailable", t.MaxUnavailable); err != nil {
+		return false, err
+	}
+
 	return e.IntegrationInPhase(
 		v1.IntegrationPhaseDeploying,
 		v1.IntegrationPhaseRunning,
@@ -84,6 +93,13 @@ func (t *pdbTrait) Apply(e *Environment) error {
 }
 
 func (t *pdbTrait) podDisruptionBudgetFor(integration *v1.Integration) *v1beta1.PodDisruptionBudget {
+	// Copy the integration labels, so that they are not altered by the other traits
+	labels := make(map[string]string)
+	for k, v := range integration.Labels {
+		labels[k] = v
+	}
+	labels[v1.IntegrationLabel] = integration.Name
+
 	pdb := &v1beta1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "PodDisruptionBudget",
@@ -92,7 +108,7 @@ func (t *pdbTrait) podDisruptionBudgetFor(integration *v1.Integration) *v1beta1.
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      integration.Name,
 			Namespace: integration.Namespace,
-			Labels:    integration.Labels,
+			Labels:    labels,
 		},
 		Spec: v1beta1.PodDisruptionBudgetSpec{
 			Selector: &metav1.LabelSelector{
@@ -113,3 +129,25 @@ func (t *pdbTrait) podDisruptionBudgetFor(integration *v1.Integration) *v1beta1.
 
 	return pdb
 }
+
+// validatePdbValue checks the value is either a non-negative number or a percentage
+func validatePdbValue(name string, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	v := intstr.Parse(value)
+	if v.Type == intstr.Int {
+		if v.IntVal < 0 {
+			return fmt.Errorf("%s must be a non-negative number or a percentage, it was %s", name, value)
+		}
+		return nil
+	}
+
+	percentage, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if err != nil || !strings.HasSuffix(value, "%") || percentage < 0 || percentage > 100 {
+		return fmt.Errorf("%s must be a non-negative number or a percentage, it was %s", name, value)
+	}
+
+	return nil
+}
@@ -71,6 +71,36 @@ func TestPdbIsCreatedWithMinAvailable(t *testing.T) {
 	assert.Equal(t, int32(2), pdb.Spec.MinAvailable.IntVal)
 }
 
+func TestConfigurePdbTraitWithInvalidValues(t *testing.T) {
+	for _, value := range []string{"-1", "abc", "150%", "-5%", "%"} {
+		pdbTrait, environment, _ := createPdbTest()
+		pdbTrait.MaxUnavailable = value
+
+		configured, err := pdbTrait.Configure(environment)
+		assert.NotNil(t, err, value)
+		assert.False(t, configured, value)
+	}
+}
+
+func TestPdbIsCreatedWithPercentage(t *testing.T) {
+	pdbTrait, environment, _ := createPdbTest()
+	pdbTrait.MinAvailable = "50%"
+
+	pdb := pdbCreatedCheck(pdbTrait, environment, t)
+	assert.Equal(t, "50%", pdb.Spec.MinAvailable.StrVal)
+}
+
+func TestPdbDoesNotShareIntegrationLabels(t *testing.T) {
+	pdbTrait, environment, _ := createPdbTest()
+	environment.Integration.Labels = map[string]string{"team": "integration"}
+
+	pdb := pdbCreatedCheck(pdbTrait, environment, t)
+	assert.Equal(t, "integration", pdb.Labels["team"])
+
+	pdb.Labels["camel.apache.org/generation"] = "1"
+	assert.NotContains(t, environment.Integration.Labels, "camel.apache.org/generation")
+}
+
 func pdbCreatedCheck(pdbTrait *pdbTrait, environment *Environment, t *testing.T) *v1beta1.PodDisruptionBudget {
 	err := pdbTrait.Apply(environment)
 	assert.Nil(t, err)
@@ -79,7 +109,7 @@ func pdbCreatedCheck(pdbTrait *pdbTrait, environment *Environment, t *testing.T)
 	assert.NotNil(t, pdb)
 	assert.Equal(t, environment.Integration.Name, pdb.Name)
 	assert.Equal(t, environment.Integration.Namespace, pdb.Namespace)
-	assert.Equal(t, environment.Integration.Labels, pdb.Labels)
+	assert.Equal(t, environment.Integration.Name, pdb.Labels[v1.IntegrationLabel])
 	return pdb
 }
 
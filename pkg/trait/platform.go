@@ -29,11 +29,13 @@ import (
 // In case the platform is missing, the trait is allowed to create a default platform.
 // This feature is especially useful in contexts where there's no need to provide a custom configuration for the platform
 // (e.g. on OpenShift the default settings work, since there's an embedded container image registry).
+// It is also enabled by default when the operator is installed with the `--operator-create-default-platform` flag.
 //
 // +camel-k:trait=platform
 type platformTrait struct {
 	BaseTrait `property:",squash"`
-	// To create a default (empty) platform when the platform is missing.
+	// To create a default (empty) platform when the platform is missing (default `true` on OpenShift,
+	// or when the operator is configured to create default platforms).
 	CreateDefault *bool `property:"create-default" json:"createDefault,omitempty"`
 	// Indicates if the platform should be created globally in the case of global operator (default true).
 	Global *bool `property:"global" json:"global,omitempty"`
@@ -60,7 +62,9 @@ func (t *platformTrait) Configure(e *Environment) (bool, error) {
 		if e.Platform == nil {
 			if t.CreateDefault == nil {
 				// Calculate if the platform should be automatically created when missing.
-				if ocp, err := openshift.IsOpenShift(t.Client); err != nil {
+				if platform.IsOperatorCreatingDefaultPlatform() {
+					t.CreateDefault = BoolP(true)
+				} else if ocp, err := openshift.IsOpenShift(t.Client); err != nil {
 					return false, err
 				} else if ocp {
 					t.CreateDefault = &ocp
@@ -119,6 +123,8 @@ func (t *platformTrait) getOrCreatePlatform(e *Environment) (*v1.IntegrationPlat
 				defaultPlatform.Labels = make(map[string]string)
 			}
 			defaultPlatform.Labels["camel.apache.org/platform.generated"] = True
+			// The default platform is reconciled by the operator in charge of the integration
+			v1.SetOperatorIDAnnotation(&defaultPlatform, v1.GetOperatorIDAnnotation(e.Integration))
 			pl = &defaultPlatform
 			e.Resources.Add(pl)
 			return pl, nil
@@ -18,6 +18,7 @@ limitations under the License.
 package trait
 
 import (
+	"os"
 	"testing"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -111,6 +112,79 @@ func TestPlatformTraitCreatesDefaultPlatform(t *testing.T) {
 	assert.Contains(t, e.Resources.Items(), &defPlatform)
 }
 
+func TestPlatformTraitCreatesDefaultPlatformAssignedToOperator(t *testing.T) {
+	e := Environment{
+		Resources: kubernetes.NewCollection(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      "xx",
+				Annotations: map[string]string{
+					v1.OperatorIDAnnotation: "my-operator",
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseNone,
+			},
+		},
+	}
+
+	trait := newPlatformTrait().(*platformTrait)
+	trait.CreateDefault = BoolP(true)
+
+	var err error
+	trait.Client, err = test.NewFakeClient()
+	assert.Nil(t, err)
+
+	enabled, err := trait.Configure(&e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(&e)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(e.Resources.Items()))
+	defPlatform, ok := e.Resources.Items()[0].(*v1.IntegrationPlatform)
+	assert.True(t, ok)
+	assert.Equal(t, "my-operator", v1.GetOperatorIDAnnotation(defPlatform))
+}
+
+func TestPlatformTraitCreatesDefaultPlatformWhenOperatorConfigured(t *testing.T) {
+	assert.Nil(t, os.Setenv(platform.OperatorCreateDefaultPlatformEnvVariable, "true"))
+	defer os.Unsetenv(platform.OperatorCreateDefaultPlatformEnvVariable)
+
+	e := Environment{
+		Resources: kubernetes.NewCollection(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      "xx",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseNone,
+			},
+		},
+	}
+
+	trait := newPlatformTrait().(*platformTrait)
+	trait.Global = BoolP(false)
+
+	var err error
+	trait.Client, err = test.NewFakeClient()
+	assert.Nil(t, err)
+
+	enabled, err := trait.Configure(&e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.True(t, *trait.CreateDefault)
+
+	err = trait.Apply(&e)
+	assert.Nil(t, err)
+
+	assert.Equal(t, v1.IntegrationPhaseWaitingForPlatform, e.Integration.Status.Phase)
+	assert.Equal(t, 1, len(e.Resources.Items()))
+}
+
 func TestPlatformTraitExisting(t *testing.T) {
 
 	table := []struct {
@@ -36,7 +36,8 @@ import (
 // into the Integration deployment Pods template, using strategic merge patch.
 //
 // This can be used to customize the container where Camel routes execute,
-// by using the `integration` container name.
+// by using the `integration` container name, to add sidecar and init containers,
+// or to set pod level fields such as `hostAliases`.
 //
 // +camel-k:trait=pod
 type podTrait struct {
@@ -109,6 +110,18 @@ func (t *podTrait) applyChangesTo(podSpec *corev1.PodSpec, changes v1.PodSpec) (
 		return
 	}
 
+	// The containers field is not omitted when empty, and its null value would remove all the containers
+	if len(changes.Containers) == 0 {
+		fields := make(map[string]interface{})
+		if err = json.Unmarshal(patch, &fields); err != nil {
+			return
+		}
+		delete(fields, "containers")
+		if patch, err = json.Marshal(fields); err != nil {
+			return
+		}
+	}
+
 	sourceJson, err := json.Marshal(podSpec)
 	if err != nil {
 		return
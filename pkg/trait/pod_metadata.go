@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Pod Metadata trait sets labels and annotations on the integration pods only, e.g. `sidecar.istio.io/inject`,
+// Prometheus scrape hints or cost allocation labels, without affecting the labels and annotations of the
+// Integration, nor of the other resources created for it.
+//
+// The labels and annotations are applied to the pod template of the integration deployment, Knative service or cron job,
+// and take precedence over the ones transferred from the Integration by the owner trait.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=pod-metadata
+type podMetadataTrait struct {
+	BaseTrait `property:",squash"`
+	// The labels to set on the integration pods. Syntax: key=value
+	Labels []string `property:"labels" json:"labels,omitempty"`
+	// The annotations to set on the integration pods. Syntax: key=value
+	Annotations []string `property:"annotations" json:"annotations,omitempty"`
+}
+
+func newPodMetadataTrait() Trait {
+	return &podMetadataTrait{
+		BaseTrait: NewBaseTrait("pod-metadata", 2550),
+	}
+}
+
+func (t *podMetadataTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if _, err := t.labels(); err != nil {
+		return false, err
+	}
+	if _, err := t.annotations(); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *podMetadataTrait) Apply(e *Environment) error {
+	labels, err := t.labels()
+	if err != nil {
+		return err
+	}
+	annotations, err := t.annotations()
+	if err != nil {
+		return err
+	}
+
+	e.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+		if len(labels) > 0 && meta.Labels == nil {
+			meta.Labels = make(map[string]string)
+		}
+		for k, v := range labels {
+			meta.Labels[k] = v
+		}
+		if len(annotations) > 0 && meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		for k, v := range annotations {
+			meta.Annotations[k] = v
+		}
+	})
+
+	return nil
+}
+
+func (t *podMetadataTrait) labels() (map[string]string, error) {
+	labels, err := parsePodMetadata("label", t.Labels)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range labels {
+		if k == v1.IntegrationLabel {
+			return nil, fmt.Errorf("the %s label is reserved and cannot be set on the integration pods", k)
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label value %q for key %s: %s", v, k, strings.Join(errs, ", "))
+		}
+	}
+	return labels, nil
+}
+
+func (t *podMetadataTrait) annotations() (map[string]string, error) {
+	return parsePodMetadata("annotation", t.Annotations)
+}
+
+func parsePodMetadata(kind string, entries []string) (map[string]string, error) {
+	metadata := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid %s %q, must be key=value", kind, entry)
+		}
+		if errs := validation.IsQualifiedName(parts[0]); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid %s key %q: %s", kind, parts[0], strings.Join(errs, ", "))
+		}
+		metadata[parts[0]] = parts[1]
+	}
+	return metadata, nil
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestConfigurePodMetadataTraitInvalid(t *testing.T) {
+	for _, configure := range []func(*podMetadataTrait){
+		func(trait *podMetadataTrait) { trait.Labels = []string{"team"} },
+		func(trait *podMetadataTrait) { trait.Labels = []string{"team=in valid"} },
+		func(trait *podMetadataTrait) { trait.Labels = []string{v1.IntegrationLabel + "=other"} },
+		func(trait *podMetadataTrait) { trait.Annotations = []string{"in valid=true"} },
+	} {
+		trait, environment, _ := createPodMetadataTest()
+		configure(trait)
+
+		_, err := trait.Configure(environment)
+
+		assert.NotNil(t, err)
+	}
+}
+
+func TestApplyPodMetadataTrait(t *testing.T) {
+	trait, environment, deployment := createPodMetadataTest()
+	trait.Labels = []string{"cost-center=team-a"}
+	trait.Annotations = []string{"sidecar.istio.io/inject=false", "prometheus.io/scrape=true"}
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]string{
+		v1.IntegrationLabel: "integration-name",
+		"cost-center":       "team-a",
+	}, deployment.Spec.Template.Labels)
+	assert.Equal(t, map[string]string{
+		"sidecar.istio.io/inject": "false",
+		"prometheus.io/scrape":    "true",
+	}, deployment.Spec.Template.Annotations)
+
+	assert.Empty(t, deployment.Labels)
+	assert.Empty(t, deployment.Annotations)
+	assert.Empty(t, environment.Integration.Labels)
+	assert.Empty(t, environment.Integration.Annotations)
+}
+
+func createPodMetadataTest() (*podMetadataTrait, *Environment, *appsv1.Deployment) {
+	trait := newPodMetadataTrait().(*podMetadataTrait)
+	trait.Enabled = BoolP(true)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1.IntegrationLabel: "integration-name",
+					},
+				},
+			},
+		},
+	}
+
+	environment := &Environment{
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(deployment),
+	}
+
+	return trait, environment, deployment
+}
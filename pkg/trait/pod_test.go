@@ -70,6 +70,28 @@ func TestChangeEnvVariables(t *testing.T) {
 	assert.Equal(t, containsEnvVariables(templateSpec, "integration", "CAMEL_K_DIGEST"), "new_value")
 }
 
+func TestHostAliases(t *testing.T) {
+	trait, environment, deployment := createPodTest("")
+	environment.Integration.Spec.PodTemplate.Spec.HostAliases = []corev1.HostAlias{
+		{
+			IP:        "10.0.0.1",
+			Hostnames: []string{"broker.on-prem.local", "registry.on-prem.local"},
+		},
+	}
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []corev1.HostAlias{
+		{
+			IP:        "10.0.0.1",
+			Hostnames: []string{"broker.on-prem.local", "registry.on-prem.local"},
+		},
+	}, deployment.Spec.Template.Spec.HostAliases)
+	// The containers are not removed when the template does not declare any
+	assert.Equal(t, 2, len(deployment.Spec.Template.Spec.Containers))
+}
+
 func createPodTest(podSpecTemplate string) (*podTrait, *Environment, *appsv1.Deployment) {
 	trait := newPodTrait().(*podTrait)
 	trait.Enabled = BoolP(true)
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Priority Class trait sets the priority class of the integration pods, so that the scheduler can
+// preempt lower priority pods to schedule them, and that they are evicted last under node pressure.
+// See https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/ for more details.
+//
+// The priority class can also be set over the build pods, with the `build` property, when the builds are
+// executed with the pod strategy.
+//
+// NOTE: The `PriorityClass` must exist in the cluster, and it determines the preemption policy of the pods.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=priority-class
+type priorityClassTrait struct {
+	BaseTrait `property:",squash"`
+	// The name of the `PriorityClass` to set on the integration pods.
+	Name string `property:"name" json:"name,omitempty"`
+	// Whether the priority class is also set over the build pods (default `false`)
+	Build *bool `property:"build" json:"build,omitempty"`
+}
+
+func newPriorityClassTrait() Trait {
+	return &priorityClassTrait{
+		BaseTrait: NewBaseTrait("priority-class", 1410),
+	}
+}
+
+func (t *priorityClassTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.Name == "" {
+		return false, fmt.Errorf("no priority class name was provided")
+	}
+
+	if IsTrue(t.Build) && e.IntegrationKitInPhase(v1.IntegrationKitPhaseBuildSubmitted) {
+		return true, nil
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+// InfluencesKit overrides base class method
+func (t *priorityClassTrait) InfluencesKit() bool {
+	return true
+}
+
+func (t *priorityClassTrait) Apply(e *Environment) error {
+	if e.IntegrationKitInPhase(v1.IntegrationKitPhaseBuildSubmitted) {
+		e.BuildPriorityClassName = t.Name
+		return nil
+	}
+
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+	podSpec.PriorityClassName = t.Name
+
+	return nil
+}
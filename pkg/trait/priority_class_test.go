@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	serving "knative.dev/serving/pkg/apis/serving/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigurePriorityClassTraitMissingName(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	priorityClassTrait := createNominalPriorityClassTrait()
+	priorityClassTrait.Name = ""
+
+	success, err := priorityClassTrait.Configure(environment)
+
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestApplyPriorityClassTrait(t *testing.T) {
+	priorityClassTrait := createNominalPriorityClassTrait()
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	success, err := priorityClassTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, success)
+	err = priorityClassTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "high-priority", deployment.Spec.Template.Spec.PriorityClassName)
+
+	environment, knativeService := createNominalKnativeServiceTraitTest()
+	err = priorityClassTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "high-priority", knativeService.Spec.Template.Spec.PriorityClassName)
+
+	environment, cronJob := createNominalCronJobTraitTest()
+	err = priorityClassTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "high-priority", cronJob.Spec.JobTemplate.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestPriorityClassTraitWithKnativeProfile(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterOpenShift, "from('undertow:test').log('hello')")
+	env.Integration.Spec.Profile = v1.TraitProfileKnative
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"knative-service": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled": true,
+		}),
+		"priority-class": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled": true,
+			"name":    "high-priority",
+		}),
+	}
+	res := processTestEnv(t, env)
+
+	assert.NotNil(t, env.GetTrait("priority-class"))
+	knativeService := res.GetKnativeService(func(s *serving.Service) bool {
+		return s.Name == TestDeploymentName
+	})
+	assert.NotNil(t, knativeService)
+	assert.Equal(t, "high-priority", knativeService.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestApplyPriorityClassTraitMissingDeployment(t *testing.T) {
+	priorityClassTrait := createNominalPriorityClassTrait()
+
+	environment := createNominalMissingDeploymentTraitTest()
+	err := priorityClassTrait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyPriorityClassTraitForBuild(t *testing.T) {
+	environment := createNominalTolerationBuildTest()
+	priorityClassTrait := createNominalPriorityClassTrait()
+
+	success, err := priorityClassTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, success)
+
+	priorityClassTrait.Build = BoolP(true)
+
+	success, err = priorityClassTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, success)
+
+	err = priorityClassTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "high-priority", environment.BuildPriorityClassName)
+}
+
+func createNominalPriorityClassTrait() *priorityClassTrait {
+	priorityClassTrait := newPriorityClassTrait().(*priorityClassTrait)
+	priorityClassTrait.Enabled = BoolP(true)
+	priorityClassTrait.Name = "high-priority"
+
+	return priorityClassTrait
+}
@@ -27,12 +27,13 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/envvar"
 )
 
 // The Prometheus trait configures a Prometheus-compatible endpoint. It also creates a `PodMonitor` resource,
 // so that the endpoint can be scraped automatically, when using the Prometheus operator.
 //
-// The metrics are exposed using MicroProfile Metrics.
+// The metrics are exposed using Micrometer, with the Prometheus registry, on the `/q/metrics` endpoint.
 //
 // WARNING: The creation of the `PodMonitor` resource requires the https://github.com/coreos/prometheus-operator[Prometheus Operator]
 // custom resource definition to be installed.
@@ -47,8 +48,21 @@ type prometheusTrait struct {
 	PodMonitor *bool `property:"pod-monitor" json:"podMonitor,omitempty"`
 	// The `PodMonitor` resource labels, applicable when `pod-monitor` is `true`.
 	PodMonitorLabels []string `property:"pod-monitor-labels" json:"podMonitorLabels,omitempty"`
+	// The interval at which the metrics are scraped, e.g. `30s`, applicable when `pod-monitor` is `true`
+	// (default to the Prometheus global scrape interval).
+	PodMonitorInterval string `property:"pod-monitor-interval" json:"podMonitorInterval,omitempty"`
+	// The timeout after which the scrape is ended, e.g. `10s`, applicable when `pod-monitor` is `true`
+	// (default to the Prometheus global scrape timeout).
+	PodMonitorScrapeTimeout string `property:"pod-monitor-scrape-timeout" json:"podMonitorScrapeTimeout,omitempty"`
 }
 
+const (
+	prometheusMetricsPath = "/q/metrics"
+
+	envVarQuarkusMicrometerPrometheusEnabled = "QUARKUS_MICROMETER_EXPORT_PROMETHEUS_ENABLED"
+	envVarQuarkusMicrometerPrometheusPath    = "QUARKUS_MICROMETER_EXPORT_PROMETHEUS_PATH"
+)
+
 func newPrometheusTrait() Trait {
 	return &prometheusTrait{
 		BaseTrait:  NewBaseTrait("prometheus", 1900),
@@ -66,8 +80,9 @@ func (t *prometheusTrait) Configure(e *Environment) (bool, error) {
 
 func (t *prometheusTrait) Apply(e *Environment) (err error) {
 	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
-		// Add the Camel Quarkus MP Metrics extension
-		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "mvn:org.apache.camel.quarkus:camel-quarkus-microprofile-metrics")
+		// Add the Camel Quarkus Micrometer extension, and the Micrometer Prometheus registry
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "mvn:org.apache.camel.quarkus:camel-quarkus-micrometer")
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "mvn:io.quarkus:quarkus-micrometer-registry-prometheus")
 		return nil
 	}
 
@@ -82,6 +97,11 @@ func (t *prometheusTrait) Apply(e *Environment) (err error) {
 		return nil
 	}
 
+	// The application properties are already rendered by the controller traits,
+	// so the Micrometer Prometheus registry is configured on the container
+	envvar.SetVal(&container.Env, envVarQuarkusMicrometerPrometheusEnabled, True)
+	envvar.SetVal(&container.Env, envVarQuarkusMicrometerPrometheusPath, prometheusMetricsPath)
+
 	condition := v1.IntegrationCondition{
 		Type:   v1.IntegrationConditionPrometheusAvailable,
 		Status: corev1.ConditionTrue,
@@ -173,8 +193,10 @@ func (t *prometheusTrait) getPodMonitorFor(e *Environment, portName string) (*mo
 			},
 			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
 				{
-					Port: portName,
-					Path: "/q/metrics",
+					Port:          portName,
+					Path:          prometheusMetricsPath,
+					Interval:      t.PodMonitorInterval,
+					ScrapeTimeout: t.PodMonitorScrapeTimeout,
 				},
 			},
 		},
@@ -31,7 +31,9 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/envvar"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
 )
 
 func TestConfigurePrometheusTraitInRightPhaseDoesSucceed(t *testing.T) {
@@ -113,6 +115,53 @@ func TestPrometheusTraitGetPodMonitor(t *testing.T) {
 	assert.Equal(t, defaultContainerPortName, podMonitor.Spec.PodMetricsEndpoints[0].Port)
 }
 
+func TestApplyPrometheusTraitInInitializationPhaseAddsDependencies(t *testing.T) {
+	trait, environment := createNominalPrometheusTest()
+	environment.Integration.Status.Phase = v1.IntegrationPhaseInitialization
+
+	err := trait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Contains(t, environment.Integration.Status.Dependencies, "mvn:org.apache.camel.quarkus:camel-quarkus-micrometer")
+	assert.Contains(t, environment.Integration.Status.Dependencies, "mvn:io.quarkus:quarkus-micrometer-registry-prometheus")
+}
+
+func TestApplyPrometheusTraitConfiguresMicrometerRegistry(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterKubernetes, "from('timer:tick').to('log:info')")
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"prometheus": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled":    true,
+			"podMonitor": false,
+		}),
+	}
+	res := processTestEnv(t, env)
+
+	assert.NotNil(t, env.GetTrait("prometheus"))
+	deployment := res.GetDeployment(func(d *appsv1.Deployment) bool {
+		return d.Name == TestDeploymentName
+	})
+	assert.NotNil(t, deployment)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "true", envvar.Get(container.Env, "QUARKUS_MICROMETER_EXPORT_PROMETHEUS_ENABLED").Value)
+	assert.Equal(t, "/q/metrics", envvar.Get(container.Env, "QUARKUS_MICROMETER_EXPORT_PROMETHEUS_PATH").Value)
+}
+
+func TestPrometheusTraitGetPodMonitorWithIntervalAndLabels(t *testing.T) {
+	trait, environment := createNominalPrometheusTest()
+	trait.PodMonitorLabels = []string{"team=integration"}
+	trait.PodMonitorInterval = "30s"
+	trait.PodMonitorScrapeTimeout = "10s"
+
+	podMonitor, err := trait.getPodMonitorFor(environment, defaultContainerPortName)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "integration", podMonitor.Labels["team"])
+	assert.Len(t, podMonitor.Spec.PodMetricsEndpoints, 1)
+	assert.Equal(t, "/q/metrics", podMonitor.Spec.PodMetricsEndpoints[0].Path)
+	assert.Equal(t, "30s", podMonitor.Spec.PodMetricsEndpoints[0].Interval)
+	assert.Equal(t, "10s", podMonitor.Spec.PodMetricsEndpoints[0].ScrapeTimeout)
+}
+
 func createNominalPrometheusTest() (*prometheusTrait, *Environment) {
 	trait := newPrometheusTrait().(*prometheusTrait)
 	enabled := true
@@ -35,13 +35,19 @@ import (
 // to allow Kubernetes to retrieve the container image from an external registry.
 //
 // The pull secret can be specified manually or, in case you've configured authentication for an external container registry
-// on the `IntegrationPlatform`, the same secret is used to pull images.
+// on the `IntegrationPlatform`, the same secret is used to pull images. When the integration runs in a different
+// namespace than the platform, the platform registry secret, that holds the credentials used to push images, is only
+// copied into the integration namespace when explicitly enabled with the `copy-platform-secret` property. A dedicated
+// pull-only secret, set with the `secret-name` property, should be preferred.
 //
 // It's enabled by default whenever you configure authentication for an external container registry,
 // so it assumes that external registries are private.
 //
 // If your registry does not need authentication for pulling images, you can disable this trait.
 //
+// Additional pull secrets can also be configured, e.g. for base images living in a different private registry
+// than the one configured on the platform.
+//
 // +camel-k:trait=pull-secret
 type pullSecretTrait struct {
 	BaseTrait `property:",squash"`
@@ -51,6 +57,14 @@ type pullSecretTrait struct {
 	ImagePullerDelegation *bool `property:"image-puller-delegation" json:"imagePullerDelegation,omitempty"`
 	// Automatically configures the platform registry secret on the pod if it is of type `kubernetes.io/dockerconfigjson`.
 	Auto *bool `property:"auto" json:"auto,omitempty"`
+	// A list of additional pull secret names to set on the Pod, independently of the `IntegrationPlatform` registry configuration.
+	SecretNames []string `property:"secret-names" json:"secretNames,omitempty"`
+	// Copy the platform registry secret into the integration namespace, when the integration runs in a different namespace
+	// than the platform (default `false`). The copied secret holds the credentials used to push images to the registry.
+	CopyPlatformSecret *bool `property:"copy-platform-secret" json:"copyPlatformSecret,omitempty"`
+
+	// The platform registry secret, when it must be copied into the integration namespace
+	platformSecret *corev1.Secret
 }
 
 func newPullSecretTrait() Trait {
@@ -78,7 +92,17 @@ func (t *pullSecretTrait) Configure(e *Environment) (bool, error) {
 					return false, err
 				}
 				if obj.Type == corev1.SecretTypeDockerConfigJson {
-					t.SecretName = secret
+					switch {
+					case e.Platform.Namespace == e.Integration.Namespace:
+						t.SecretName = secret
+					case IsTrue(t.CopyPlatformSecret):
+						// Pods can only reference secrets from their own namespace
+						t.SecretName = secret
+						t.platformSecret = &obj
+					default:
+						t.L.Infof("The platform registry secret %s is not copied into namespace %s, "+
+							"a pull secret must be configured with the pull-secret.secret-name property", secret, e.Integration.Namespace)
+					}
 				}
 			}
 		}
@@ -98,15 +122,24 @@ func (t *pullSecretTrait) Configure(e *Environment) (bool, error) {
 		}
 	}
 
-	return t.SecretName != "" || IsTrue(t.ImagePullerDelegation), nil
+	return t.SecretName != "" || len(t.SecretNames) > 0 || IsTrue(t.ImagePullerDelegation), nil
 }
 
 func (t *pullSecretTrait) Apply(e *Environment) error {
+	if t.platformSecret != nil {
+		secret := t.newPullSecretCopy(e)
+		e.Resources.Add(secret)
+		t.SecretName = secret.Name
+	}
+	secretNames := t.SecretNames
 	if t.SecretName != "" {
+		secretNames = append([]string{t.SecretName}, secretNames...)
+	}
+	if len(secretNames) > 0 {
 		e.Resources.VisitPodSpec(func(p *corev1.PodSpec) {
-			p.ImagePullSecrets = append(p.ImagePullSecrets, corev1.LocalObjectReference{
-				Name: t.SecretName,
-			})
+			for _, name := range secretNames {
+				addImagePullSecret(p, name)
+			}
 		})
 	}
 	if IsTrue(t.ImagePullerDelegation) {
@@ -118,6 +151,35 @@ func (t *pullSecretTrait) Apply(e *Environment) error {
 	return nil
 }
 
+func addImagePullSecret(p *corev1.PodSpec, name string) {
+	for _, s := range p.ImagePullSecrets {
+		if s.Name == name {
+			return
+		}
+	}
+	p.ImagePullSecrets = append(p.ImagePullSecrets, corev1.LocalObjectReference{
+		Name: name,
+	})
+}
+
+func (t *pullSecretTrait) newPullSecretCopy(e *Environment) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: e.Integration.Namespace,
+			Name:      fmt.Sprintf("%s-%s", e.Integration.Name, t.platformSecret.Name),
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Type: t.platformSecret.Type,
+		Data: t.platformSecret.Data,
+	}
+}
+
 func (t *pullSecretTrait) delegateImagePuller(e *Environment) error {
 	// Applying the rolebinding directly because it's a resource in the operator namespace
 	// (different from the integration namespace when delegation is enabled).
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stretchr/testify/assert"
@@ -47,6 +48,22 @@ func TestPullSecret(t *testing.T) {
 	assert.Contains(t, deployment.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: "xxxy"})
 }
 
+func TestPullSecretAdditionalSecrets(t *testing.T) {
+	e, deployment := getEnvironmentAndDeployment(t)
+	e.Platform = &v1.IntegrationPlatform{}
+
+	trait := newPullSecretTrait().(*pullSecretTrait)
+	trait.SecretName = "xxxy"
+	trait.SecretNames = []string{"base-images", "xxxy"}
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(e)
+	assert.Nil(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "xxxy"}, {Name: "base-images"}}, deployment.Spec.Template.Spec.ImagePullSecrets)
+}
+
 func TestPullSecretDoesNothingWhenNotSetOnPlatform(t *testing.T) {
 	e, _ := getEnvironmentAndDeployment(t)
 	e.Platform = &v1.IntegrationPlatform{}
@@ -123,3 +140,59 @@ func getEnvironmentAndDeployment(t *testing.T) (*Environment, *appsv1.Deployment
 
 	return e, &deployment
 }
+
+func TestPullSecretCopiedFromPlatformNamespace(t *testing.T) {
+	e, deployment := getEnvironmentAndDeployment(t)
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+			Name:      "registry-secret",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`),
+		},
+	}
+	var err error
+	e.Client, err = test.NewFakeClient(e.Integration, deployment, &secret)
+	assert.NoError(t, err)
+	e.Platform = &v1.IntegrationPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+		},
+	}
+	e.Platform.Status.Build.Registry.Secret = "registry-secret"
+
+	// The platform secret holds the push credentials, and is not copied by default
+	trait := newPullSecretTrait().(*pullSecretTrait)
+	trait.Client = e.Client
+	trait.Ctx = e.C
+	trait.ImagePullerDelegation = BoolP(false)
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	trait = newPullSecretTrait().(*pullSecretTrait)
+	trait.Client = e.Client
+	trait.Ctx = e.C
+	trait.ImagePullerDelegation = BoolP(false)
+	trait.CopyPlatformSecret = BoolP(true)
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(e)
+	assert.Nil(t, err)
+
+	var copied *corev1.Secret
+	e.Resources.Visit(func(o runtime.Object) {
+		if s, ok := o.(*corev1.Secret); ok {
+			copied = s
+		}
+	})
+	assert.NotNil(t, copied)
+	assert.Equal(t, "test", copied.Namespace)
+	assert.Equal(t, "myit-registry-secret", copied.Name)
+	assert.Equal(t, secret.Data, copied.Data)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "myit-registry-secret"}}, deployment.Spec.Template.Spec.ImagePullSecrets)
+}
@@ -18,18 +18,61 @@ limitations under the License.
 package trait
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+const (
+	quarkusPackageTypeProperty = "quarkus.package.type"
+	defaultNativeBaseImage     = "quay.io/quarkus/quarkus-micro-image:1.0"
+	defaultNativeBuilderImage  = "quay.io/quarkus/ubi-quarkus-mandrel:21.3-java11"
+	defaultNativeRequestMemory = "4Gi"
 )
 
 // The Quarkus trait activates the Quarkus runtime.
 //
 // It's enabled by default.
 //
+// NOTE: Compiling to a native executable, i.e. when using `package-type=native`, is only supported
+// for kamelets, as well as YAML integrations. It also requires at least 4GiB of memory.
+// The native executable is compiled by a dedicated build step, that runs in a container from the
+// native builder image, and is only supported by the pod build strategy.
+//
 // +camel-k:trait=quarkus
 type quarkusTrait struct {
 	BaseTrait `property:",squash"`
-	// The Quarkus runtime type (reserved for future use)
-	Native bool `property:"native" json:"native,omitempty"`
+	// The Quarkus package types, either `fast-jar` or `native` (default `fast-jar`).
+	// In case both `fast-jar` and `native` are specified, two IntegrationKit resources are created,
+	// with the `native` kit having precedence over the `fast-jar` one once ready.
+	// The order influences the resolution of the current kit for the integration.
+	// The kit corresponding to the first package type is assigned to the
+	// integration in case no existing kit that matches the integration exists.
+	PackageTypes []string `property:"package-type" json:"packageTypes,omitempty"`
+	// The base image used to run the native executable (default `quay.io/quarkus/quarkus-micro-image:1.0`)
+	NativeBaseImage string `property:"native-base-image" json:"nativeBaseImage,omitempty"`
+	// The image providing the GraalVM / Mandrel `native-image` tooling, used to compile the native executable
+	// with the pod build strategy (default `quay.io/quarkus/ubi-quarkus-mandrel:21.3-java11`)
+	NativeBuilderImage string `property:"native-builder-image" json:"nativeBuilderImage,omitempty"`
+	// The timeout of the native kit builds, e.g. `30m`, that defaults to the platform build timeout,
+	// extended to a minimum of 10 minutes
+	NativeBuildTimeout string `property:"native-build-timeout" json:"nativeBuildTimeout,omitempty"`
+	// The minimum amount of CPU required by the native compilation (only applies with the pod build strategy).
+	NativeBuildRequestCPU string `property:"native-build-request-cpu" json:"nativeBuildRequestCPU,omitempty"`
+	// The minimum amount of memory required by the native compilation (only applies with the pod build strategy,
+	// default `4Gi`).
+	NativeBuildRequestMemory string `property:"native-build-request-memory" json:"nativeBuildRequestMemory,omitempty"`
+	// The maximum amount of CPU required by the native compilation (only applies with the pod build strategy).
+	NativeBuildLimitCPU string `property:"native-build-limit-cpu" json:"nativeBuildLimitCPU,omitempty"`
+	// The maximum amount of memory required by the native compilation (only applies with the pod build strategy).
+	NativeBuildLimitMemory string `property:"native-build-limit-memory" json:"nativeBuildLimitMemory,omitempty"`
 }
 
 func newQuarkusTrait() Trait {
@@ -39,13 +82,103 @@ func newQuarkusTrait() Trait {
 }
 
 func (t *quarkusTrait) Configure(e *Environment) (bool, error) {
-	return IsNilOrTrue(t.Enabled), nil
+	if IsFalse(t.Enabled) {
+		return false, nil
+	}
+
+	for _, packageType := range t.PackageTypes {
+		if packageType != v1.IntegrationKitLayoutFastJar && packageType != v1.IntegrationKitLayoutNative {
+			return false, fmt.Errorf("unsupported quarkus package type %q, must be one of %q or %q",
+				packageType, v1.IntegrationKitLayoutFastJar, v1.IntegrationKitLayoutNative)
+		}
+	}
+	if util.StringSliceExists(t.PackageTypes, v1.IntegrationKitLayoutNative) && e.Platform != nil {
+		// The native compilation must not run inside the operator
+		if strategy := e.Platform.Status.Build.BuildStrategy; strategy == v1.IntegrationPlatformBuildStrategyRoutine {
+			return false, fmt.Errorf("the %s package type is not supported by the %s build strategy, use the %s build strategy",
+				v1.IntegrationKitLayoutNative, strategy, v1.IntegrationPlatformBuildStrategyPod)
+		}
+	}
+	if t.NativeBuildTimeout != "" {
+		if d, err := time.ParseDuration(t.NativeBuildTimeout); err != nil || d <= 0 {
+			return false, fmt.Errorf("native-build-timeout must be a positive duration, it was %v", t.NativeBuildTimeout)
+		}
+	}
+	if _, err := t.nativeBuildResources(); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (t *quarkusTrait) Apply(e *Environment) error {
+	if !e.IntegrationKitInPhase(v1.IntegrationKitPhaseBuildSubmitted) || !e.IntegrationKit.IsNative() {
+		return nil
+	}
+
+	baseImage := t.NativeBaseImage
+	if baseImage == "" {
+		baseImage = defaultNativeBaseImage
+	}
+
+	builderImage := t.NativeBuilderImage
+	if builderImage == "" {
+		builderImage = defaultNativeBuilderImage
+	}
+
+	resources, err := t.nativeBuildResources()
+	if err != nil {
+		return err
+	}
+
+	if t.NativeBuildTimeout != "" {
+		timeout, err := time.ParseDuration(t.NativeBuildTimeout)
+		if err != nil {
+			return err
+		}
+		e.BuildTimeout = &metav1.Duration{Duration: timeout}
+	}
+
+	// The builder trait has already contributed the build tasks, so that they are
+	// amended to generate the native sources, that are compiled into a native executable
+	// by a dedicated task, and runs on a minimal base image
+	tasks := make([]v1.Task, 0, len(e.BuildTasks)+1)
+	for _, task := range e.BuildTasks {
+		if task.Spectrum != nil {
+			task.Spectrum.BaseImage = baseImage
+		}
+		if task.Jib != nil {
+			task.Jib.BaseImage = baseImage
+		}
+		tasks = append(tasks, task)
+		if task.Builder != nil {
+			if task.Builder.Maven.Properties == nil {
+				task.Builder.Maven.Properties = make(map[string]string)
+			}
+			task.Builder.Maven.Properties[quarkusPackageTypeProperty] = builder.QuarkusNativeSourcesPackageType
+			task.Builder.BaseImage = baseImage
+			tasks = append(tasks, v1.Task{Native: &v1.NativeTask{
+				BaseTask: v1.BaseTask{
+					Name: "native",
+				},
+				Image:     builderImage,
+				Resources: resources,
+			}})
+		}
+	}
+	e.BuildTasks = tasks
+
 	return nil
 }
 
+func (t *quarkusTrait) nativeBuildResources() (*corev1.ResourceRequirements, error) {
+	requestMemory := t.NativeBuildRequestMemory
+	if requestMemory == "" {
+		requestMemory = defaultNativeRequestMemory
+	}
+	return parseBuildResources("native-build-", t.NativeBuildRequestCPU, requestMemory, t.NativeBuildLimitCPU, t.NativeBuildLimitMemory)
+}
+
 // IsPlatformTrait overrides base class method
 func (t *quarkusTrait) IsPlatformTrait() bool {
 	return true
@@ -59,3 +192,57 @@ func (t *quarkusTrait) InfluencesKit() bool {
 func (t *quarkusTrait) addBuildSteps(steps *[]builder.Step) {
 	*steps = append(*steps, builder.QuarkusSteps...)
 }
+
+// GetQuarkusPackageTypes returns the Quarkus package types configured in the given traits,
+// defaulting to fast-jar when none is configured
+func GetQuarkusPackageTypes(traits map[string]v1.TraitSpec) ([]string, error) {
+	t := newQuarkusTrait().(*quarkusTrait)
+	if spec, ok := traits[string(t.ID())]; ok {
+		if err := decodeTraitSpec(&spec, t); err != nil {
+			return nil, err
+		}
+	}
+	if len(t.PackageTypes) == 0 {
+		return []string{v1.IntegrationKitLayoutFastJar}, nil
+	}
+
+	return t.PackageTypes, nil
+}
+
+// WithQuarkusPackageType returns a copy of the given traits, with the Quarkus package types
+// narrowed down to the given one, so that it can be set on the kit built for that package type
+func WithQuarkusPackageType(traits map[string]v1.TraitSpec, packageType string) (map[string]v1.TraitSpec, error) {
+	spec, ok := traits["quarkus"]
+	if !ok {
+		return traits, nil
+	}
+
+	configuration := make(map[string]interface{})
+	data, err := json.Marshal(spec.Configuration)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &configuration); err != nil {
+		return nil, err
+	}
+	if _, ok := configuration["packageTypes"]; !ok {
+		return traits, nil
+	}
+	configuration["packageTypes"] = []string{packageType}
+	data, err = json.Marshal(configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]v1.TraitSpec, len(traits))
+	for name, conf := range traits {
+		out[name] = conf
+	}
+	out["quarkus"] = v1.TraitSpec{
+		Configuration: v1.TraitConfiguration{
+			RawMessage: data,
+		},
+	}
+
+	return out, nil
+}
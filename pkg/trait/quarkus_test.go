@@ -19,12 +19,18 @@ package trait
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
 )
 
 func TestConfigureQuarkusTraitShouldSucceed(t *testing.T) {
@@ -65,6 +71,158 @@ func TestQuarkusTraitAddBuildStepsShouldSucceed(t *testing.T) {
 	assert.Len(t, steps, len(builder.DefaultSteps)+len(builder.QuarkusSteps))
 }
 
+func TestConfigureQuarkusTraitInvalidPackageType(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	quarkusTrait.PackageTypes = []string{"fast-jar", "uber-jar"}
+
+	configured, err := quarkusTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+}
+
+func TestConfigureQuarkusTraitNativeWithRoutineStrategy(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	quarkusTrait.PackageTypes = []string{"fast-jar", "native"}
+	environment.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyRoutine
+
+	configured, err := quarkusTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+
+	environment.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
+
+	configured, err = quarkusTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestApplyQuarkusTraitNativeKit(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	quarkusTrait.PackageTypes = []string{v1.IntegrationKitLayoutNative}
+	environment.IntegrationKit = &v1.IntegrationKit{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				v1.IntegrationKitLayoutLabel: v1.IntegrationKitLayoutNative,
+			},
+		},
+		Status: v1.IntegrationKitStatus{
+			Phase: v1.IntegrationKitPhaseBuildSubmitted,
+		},
+	}
+	environment.BuildTasks = []v1.Task{
+		{Builder: &v1.BuilderTask{}},
+		{Spectrum: &v1.SpectrumTask{}},
+	}
+
+	err := quarkusTrait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Len(t, environment.BuildTasks, 3)
+	assert.Equal(t, "native-sources", environment.BuildTasks[0].Builder.Maven.Properties["quarkus.package.type"])
+	assert.Equal(t, defaultNativeBaseImage, environment.BuildTasks[0].Builder.BaseImage)
+	assert.NotNil(t, environment.BuildTasks[1].Native)
+	assert.Equal(t, defaultNativeBuilderImage, environment.BuildTasks[1].Native.Image)
+	assert.Equal(t, resource.MustParse("4Gi"), environment.BuildTasks[1].Native.Resources.Requests[corev1.ResourceMemory])
+	assert.Equal(t, defaultNativeBaseImage, environment.BuildTasks[2].Spectrum.BaseImage)
+	assert.Nil(t, environment.BuildTimeout)
+}
+
+func TestApplyQuarkusTraitNativeBuildSettings(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	quarkusTrait.PackageTypes = []string{v1.IntegrationKitLayoutNative}
+	quarkusTrait.NativeBuilderImage = "quay.io/quarkus/ubi-quarkus-native-image:21.3-java11"
+	quarkusTrait.NativeBuildTimeout = "30m"
+	quarkusTrait.NativeBuildLimitMemory = "8Gi"
+	environment.IntegrationKit = &v1.IntegrationKit{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				v1.IntegrationKitLayoutLabel: v1.IntegrationKitLayoutNative,
+			},
+		},
+		Status: v1.IntegrationKitStatus{
+			Phase: v1.IntegrationKitPhaseBuildSubmitted,
+		},
+	}
+	environment.BuildTasks = []v1.Task{
+		{Builder: &v1.BuilderTask{}},
+		{Kaniko: &v1.KanikoTask{}},
+	}
+
+	configured, err := quarkusTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = quarkusTrait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Len(t, environment.BuildTasks, 3)
+	assert.Equal(t, "quay.io/quarkus/ubi-quarkus-native-image:21.3-java11", environment.BuildTasks[1].Native.Image)
+	assert.Equal(t, resource.MustParse("8Gi"), environment.BuildTasks[1].Native.Resources.Limits[corev1.ResourceMemory])
+	assert.NotNil(t, environment.BuildTasks[2].Kaniko)
+	assert.Equal(t, 30*time.Minute, environment.BuildTimeout.Duration)
+}
+
+func TestConfigureQuarkusTraitInvalidNativeBuildSettings(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	quarkusTrait.NativeBuildTimeout = "forever"
+
+	configured, err := quarkusTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+
+	quarkusTrait.NativeBuildTimeout = ""
+	quarkusTrait.NativeBuildLimitCPU = "lots"
+
+	configured, err = quarkusTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+}
+
+func TestApplyQuarkusTraitFastJarKit(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	environment.IntegrationKit = &v1.IntegrationKit{
+		Status: v1.IntegrationKitStatus{
+			Phase: v1.IntegrationKitPhaseBuildSubmitted,
+		},
+	}
+	environment.BuildTasks = []v1.Task{
+		{Builder: &v1.BuilderTask{}},
+	}
+
+	err := quarkusTrait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Empty(t, environment.BuildTasks[0].Builder.Maven.Properties)
+	assert.Empty(t, environment.BuildTasks[0].Builder.BaseImage)
+}
+
+func TestQuarkusPackageTypes(t *testing.T) {
+	packageTypes, err := GetQuarkusPackageTypes(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"fast-jar"}, packageTypes)
+
+	traits := map[string]v1.TraitSpec{
+		"quarkus": test.TraitSpecFromMap(t, map[string]interface{}{
+			"packageTypes": []string{"native", "fast-jar"},
+		}),
+	}
+	packageTypes, err = GetQuarkusPackageTypes(traits)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"native", "fast-jar"}, packageTypes)
+
+	narrowed, err := WithQuarkusPackageType(traits, "native")
+	assert.Nil(t, err)
+	packageTypes, err = GetQuarkusPackageTypes(narrowed)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"native"}, packageTypes)
+
+	// The input traits are left untouched
+	packageTypes, err = GetQuarkusPackageTypes(traits)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"native", "fast-jar"}, packageTypes)
+}
+
 func createNominalQuarkusTest() (*quarkusTrait, *Environment) {
 	trait := newQuarkusTrait().(*quarkusTrait)
 	trait.Enabled = BoolP(true)
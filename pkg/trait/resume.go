@@ -0,0 +1,183 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// The Resume trait configures the resume strategy of the consumers that support it, so that the
+// offsets of the processed records are stored and long-running, batch-style, integrations resume
+// where they left off after a restart, instead of reprocessing the data from the beginning.
+//
+// Only the `kafka` strategy is currently supported, that stores the offsets in a compacted Kafka topic.
+// When a Strimzi cluster is set, the backing topic is created as a `KafkaTopic` resource owned by the
+// integration, and the bootstrap server defaults to the cluster plain listener.
+//
+// The resume strategy is provided by the `camel-k-resume-kafka` runtime module, that must be available in the
+// Camel catalog of the runtime the integration runs on. Otherwise the trait is not applied, and the
+// `ResumeAvailable` integration condition reports the reason.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=resume
+type resumeTrait struct {
+	BaseTrait `property:",squash"`
+	// The resume strategy to use (default `kafka`).
+	Strategy string `property:"strategy" json:"strategy,omitempty"`
+	// The path where the offsets are stored, i.e. the name of the Kafka topic (default `<integration>-offsets`).
+	Path string `property:"path" json:"path,omitempty"`
+	// The address of the server backing the offset store, i.e. the Kafka bootstrap servers.
+	Server string `property:"server" json:"server,omitempty"`
+	// The policy used to fill the offsets cache on startup, either `minimizing` (default) or `maximizing`.
+	CacheFillPolicy string `property:"cache-fill-policy" json:"cacheFillPolicy,omitempty"`
+	// The name of the Strimzi Kafka cluster the backing topic is created into.
+	Cluster string `property:"cluster" json:"cluster,omitempty"`
+}
+
+const (
+	resumeStrategyKafka = "kafka"
+	resumeKafkaArtifact = "camel-k-resume-kafka"
+
+	resumeCacheFillPolicyMinimizing = "minimizing"
+	resumeCacheFillPolicyMaximizing = "maximizing"
+)
+
+func newResumeTrait() Trait {
+	return &resumeTrait{
+		BaseTrait: NewBaseTrait("resume", 560),
+	}
+}
+
+func (t *resumeTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.Strategy == "" {
+		t.Strategy = resumeStrategyKafka
+	}
+	if t.Strategy != resumeStrategyKafka {
+		return false, fmt.Errorf("unsupported resume strategy %q, must be %s", t.Strategy, resumeStrategyKafka)
+	}
+
+	if t.CacheFillPolicy == "" {
+		t.CacheFillPolicy = resumeCacheFillPolicyMinimizing
+	}
+	if t.CacheFillPolicy != resumeCacheFillPolicyMinimizing && t.CacheFillPolicy != resumeCacheFillPolicyMaximizing {
+		return false, fmt.Errorf("unsupported resume cache fill policy %q, must be one of %s or %s",
+			t.CacheFillPolicy, resumeCacheFillPolicyMinimizing, resumeCacheFillPolicyMaximizing)
+	}
+
+	if t.Server == "" && t.Cluster != "" {
+		t.Server = fmt.Sprintf("%s-kafka-bootstrap:9092", t.Cluster)
+	}
+	if t.Server == "" {
+		return false, fmt.Errorf("the resume trait requires either the server or the cluster to be set")
+	}
+
+	if !e.IntegrationInPhase(v1.IntegrationPhaseInitialization, v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		return false, nil
+	}
+
+	if e.CamelCatalog == nil || !e.CamelCatalog.HasArtifact(resumeKafkaArtifact) {
+		runtimeVersion := ""
+		if e.CamelCatalog != nil {
+			runtimeVersion = e.CamelCatalog.Runtime.Version
+		}
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionResumeAvailable,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionResumeNotAvailableReason,
+			fmt.Sprintf("the %s resume strategy is not supported by the runtime version %s: the %s module is not available",
+				t.Strategy, runtimeVersion, resumeKafkaArtifact),
+		)
+		return false, nil
+	}
+
+	e.Integration.Status.SetCondition(
+		v1.IntegrationConditionResumeAvailable,
+		corev1.ConditionTrue,
+		v1.IntegrationConditionResumeAvailableReason,
+		fmt.Sprintf("%s resume strategy configured", t.Strategy),
+	)
+
+	return true, nil
+}
+
+func (t *resumeTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "camel-k:resume-kafka")
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "camel:kafka")
+
+		// sort the dependencies to get always the same list if they don't change
+		sort.Strings(e.Integration.Status.Dependencies)
+		return nil
+	}
+
+	path := t.Path
+	if path == "" {
+		path = e.Integration.Name + "-offsets"
+	}
+
+	if e.ApplicationProperties == nil {
+		e.ApplicationProperties = make(map[string]string)
+	}
+	e.ApplicationProperties["customizer.resume.enabled"] = "true"
+	e.ApplicationProperties["customizer.resume.resumeStrategy"] = t.Strategy
+	e.ApplicationProperties["customizer.resume.resumePath"] = path
+	e.ApplicationProperties["customizer.resume.resumeServer"] = t.Server
+	e.ApplicationProperties["customizer.resume.cacheFillPolicy"] = t.CacheFillPolicy
+
+	if t.Cluster != "" {
+		e.Resources.Add(t.kafkaTopicFor(e, path))
+	}
+
+	return nil
+}
+
+func (t *resumeTrait) kafkaTopicFor(e *Environment, name string) *unstructured.Unstructured {
+	topic := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"partitions": int64(1),
+				"config": map[string]interface{}{
+					// Only the last offset of each key matters
+					"cleanup.policy": "compact",
+				},
+			},
+		},
+	}
+	topic.SetAPIVersion("kafka.strimzi.io/v1beta2")
+	topic.SetKind("KafkaTopic")
+	topic.SetNamespace(e.Integration.Namespace)
+	topic.SetName(name)
+	topic.SetLabels(map[string]string{
+		v1.IntegrationLabel:  e.Integration.Name,
+		"strimzi.io/cluster": t.Cluster,
+	})
+
+	return topic
+}
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestConfigureResumeTraitDisabled(t *testing.T) {
+	trait, environment := createResumeTest(v1.IntegrationPhaseDeploying)
+	trait.Enabled = nil
+
+	configured, err := trait.Configure(environment)
+
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureResumeTraitInvalid(t *testing.T) {
+	trait, environment := createResumeTest(v1.IntegrationPhaseDeploying)
+	trait.Strategy = "file"
+	_, err := trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait, environment = createResumeTest(v1.IntegrationPhaseDeploying)
+	trait.CacheFillPolicy = "all"
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait, environment = createResumeTest(v1.IntegrationPhaseDeploying)
+	trait.Server = ""
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestConfigureResumeTraitUnsupportedRuntime(t *testing.T) {
+	trait, environment := createResumeTest(v1.IntegrationPhaseInitialization)
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+	environment.CamelCatalog = catalog
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionResumeAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionResumeNotAvailableReason, condition.Reason)
+	assert.Contains(t, condition.Message, "camel-k-resume-kafka")
+}
+
+func TestApplyResumeTraitDependencies(t *testing.T) {
+	trait, environment := createResumeTest(v1.IntegrationPhaseInitialization)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"camel-k:resume-kafka", "camel:kafka"}, environment.Integration.Status.Dependencies)
+	assert.Empty(t, environment.ApplicationProperties)
+}
+
+func TestApplyResumeTraitProperties(t *testing.T) {
+	trait, environment := createResumeTest(v1.IntegrationPhaseDeploying)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"customizer.resume.enabled":         "true",
+		"customizer.resume.resumeStrategy":  "kafka",
+		"customizer.resume.resumePath":      "integration-name-offsets",
+		"customizer.resume.resumeServer":    "my-kafka:9092",
+		"customizer.resume.cacheFillPolicy": "minimizing",
+	}, environment.ApplicationProperties)
+	assert.Equal(t, 0, environment.Resources.Size())
+}
+
+func TestApplyResumeTraitKafkaTopic(t *testing.T) {
+	trait, environment := createResumeTest(v1.IntegrationPhaseDeploying)
+	trait.Server = ""
+	trait.Cluster = "my-cluster"
+	trait.Path = "offsets"
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9092", environment.ApplicationProperties["customizer.resume.resumeServer"])
+	assert.Equal(t, "offsets", environment.ApplicationProperties["customizer.resume.resumePath"])
+
+	assert.Equal(t, 1, environment.Resources.Size())
+	topic, ok := environment.Resources.Items()[0].(*unstructured.Unstructured)
+	assert.True(t, ok)
+	assert.Equal(t, "KafkaTopic", topic.GetKind())
+	assert.Equal(t, "offsets", topic.GetName())
+	assert.Equal(t, "ns", topic.GetNamespace())
+	assert.Equal(t, "my-cluster", topic.GetLabels()["strimzi.io/cluster"])
+	policy, _, _ := unstructured.NestedString(topic.Object, "spec", "config", "cleanup.policy")
+	assert.Equal(t, "compact", policy)
+}
+
+func createResumeTest(phase v1.IntegrationPhase) (*resumeTrait, *Environment) {
+	trait := newResumeTrait().(*resumeTrait)
+	trait.Enabled = BoolP(true)
+	trait.Server = "my-kafka:9092"
+
+	environment := &Environment{
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "integration-name",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: phase,
+			},
+		},
+		CamelCatalog: camel.NewRuntimeCatalog(v1.CamelCatalogSpec{
+			Runtime: v1.RuntimeSpec{
+				Version:  "1.x",
+				Provider: v1.RuntimeProviderQuarkus,
+			},
+			Artifacts: map[string]v1.CamelArtifact{
+				"camel-k-resume-kafka": {},
+			},
+		}),
+		Resources: kubernetes.NewCollection(),
+	}
+
+	return trait, environment
+}
@@ -20,6 +20,7 @@ package trait
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
 // The Route trait can be used to configure the creation of OpenShift routes for the integration.
@@ -49,10 +51,25 @@ type routeTrait struct {
 	//
 	// Refer to the OpenShift documentation for additional information.
 	TLSKey string `property:"tls-key" json:"tlsKey,omitempty"`
+	// To configure the TLS certificate contents, as a reference to a secret.
+	//
+	// The syntax is `secret-name[/key-name]`, the key defaults to `tls.crt`.
+	// It cannot be set together with `tls-certificate`.
+	TLSCertificateSecret string `property:"tls-certificate-secret" json:"tlsCertificateSecret,omitempty"`
+	// To configure the TLS certificate key contents, as a reference to a secret.
+	//
+	// The syntax is `secret-name[/key-name]`, the key defaults to `tls.key`.
+	// It cannot be set together with `tls-key`.
+	TLSKeySecret string `property:"tls-key-secret" json:"tlsKeySecret,omitempty"`
 	// The TLS cert authority certificate contents.
 	//
 	// Refer to the OpenShift documentation for additional information.
 	TLSCACertificate string `property:"tls-ca-certificate" json:"tlsCACertificate,omitempty"`
+	// To configure the TLS cert authority certificate contents, as a reference to a secret.
+	//
+	// The syntax is `secret-name[/key-name]`, the key defaults to `ca.crt`.
+	// It cannot be set together with `tls-ca-certificate`.
+	TLSCACertificateSecret string `property:"tls-ca-certificate-secret" json:"tlsCACertificateSecret,omitempty"`
 	// The destination CA certificate provides the contents of the ca certificate of the final destination.  When using reencrypt
 	// termination this file should be provided in order to have routers use it for health checks on the secure connection.
 	// If this field is not specified, the router may provide its own destination CA and perform hostname validation using
@@ -61,6 +78,11 @@ type routeTrait struct {
 	//
 	// Refer to the OpenShift documentation for additional information.
 	TLSDestinationCACertificate string `property:"tls-destination-ca-certificate" json:"tlsDestinationCACertificate,omitempty"`
+	// To configure the destination CA certificate contents, as a reference to a secret.
+	//
+	// The syntax is `secret-name[/key-name]`, the key defaults to `ca.crt`.
+	// It cannot be set together with `tls-destination-ca-certificate`.
+	TLSDestinationCACertificateSecret string `property:"tls-destination-ca-certificate-secret" json:"tlsDestinationCACertificateSecret,omitempty"`
 	// To configure how to deal with insecure traffic, e.g. `Allow`, `Disable` or `Redirect` traffic.
 	//
 	// Refer to the OpenShift documentation for additional information.
@@ -98,6 +120,10 @@ func (t *routeTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if err := t.validateTLSConfig(); err != nil {
+		return false, err
+	}
+
 	t.service = e.Resources.GetUserServiceForIntegration(e.Integration)
 	if t.service == nil {
 		if e.Integration != nil {
@@ -116,6 +142,11 @@ func (t *routeTrait) Configure(e *Environment) (bool, error) {
 }
 
 func (t *routeTrait) Apply(e *Environment) error {
+	tlsConfig, err := t.getTLSConfig(e)
+	if err != nil {
+		return err
+	}
+
 	servicePortName := defaultContainerPortName
 	dt := e.Catalog.GetTrait(containerTraitID)
 	if dt != nil {
@@ -143,7 +174,7 @@ func (t *routeTrait) Apply(e *Environment) error {
 				Name: t.service.Name,
 			},
 			Host: t.Host,
-			TLS:  t.getTLSConfig(),
+			TLS:  tlsConfig,
 		},
 	}
 
@@ -174,19 +205,82 @@ func (t *routeTrait) Apply(e *Environment) error {
 	return nil
 }
 
-func (t *routeTrait) getTLSConfig() *routev1.TLSConfig {
+func (t *routeTrait) validateTLSConfig() error {
+	switch routev1.TLSTerminationType(t.TLSTermination) {
+	case "", routev1.TLSTerminationEdge, routev1.TLSTerminationPassthrough, routev1.TLSTerminationReencrypt:
+	default:
+		return fmt.Errorf("unsupported TLS termination type: %s", t.TLSTermination)
+	}
+
+	switch routev1.InsecureEdgeTerminationPolicyType(t.TLSInsecureEdgeTerminationPolicy) {
+	case "", routev1.InsecureEdgeTerminationPolicyNone, routev1.InsecureEdgeTerminationPolicyAllow,
+		routev1.InsecureEdgeTerminationPolicyRedirect:
+	default:
+		return fmt.Errorf("unsupported TLS insecure edge termination policy: %s", t.TLSInsecureEdgeTerminationPolicy)
+	}
+
+	for property, values := range map[string][]string{
+		"tls-certificate":                {t.TLSCertificate, t.TLSCertificateSecret},
+		"tls-key":                        {t.TLSKey, t.TLSKeySecret},
+		"tls-ca-certificate":             {t.TLSCACertificate, t.TLSCACertificateSecret},
+		"tls-destination-ca-certificate": {t.TLSDestinationCACertificate, t.TLSDestinationCACertificateSecret},
+	} {
+		if values[0] != "" && values[1] != "" {
+			return fmt.Errorf("%s and %s-secret cannot be set at the same time", property, property)
+		}
+	}
+
+	return nil
+}
+
+func (t *routeTrait) getTLSConfig(e *Environment) (*routev1.TLSConfig, error) {
+	certificate, err := t.resolveTLSContent(e, t.TLSCertificate, t.TLSCertificateSecret, corev1.TLSCertKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.resolveTLSContent(e, t.TLSKey, t.TLSKeySecret, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, err
+	}
+	caCertificate, err := t.resolveTLSContent(e, t.TLSCACertificate, t.TLSCACertificateSecret, "ca.crt")
+	if err != nil {
+		return nil, err
+	}
+	destinationCACertificate, err := t.resolveTLSContent(e, t.TLSDestinationCACertificate, t.TLSDestinationCACertificateSecret, "ca.crt")
+	if err != nil {
+		return nil, err
+	}
+
 	config := routev1.TLSConfig{
 		Termination:                   routev1.TLSTerminationType(t.TLSTermination),
-		Certificate:                   t.TLSCertificate,
-		Key:                           t.TLSKey,
-		CACertificate:                 t.TLSCACertificate,
-		DestinationCACertificate:      t.TLSDestinationCACertificate,
+		Certificate:                   certificate,
+		Key:                           key,
+		CACertificate:                 caCertificate,
+		DestinationCACertificate:      destinationCACertificate,
 		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyType(t.TLSInsecureEdgeTerminationPolicy),
 	}
 
 	if reflect.DeepEqual(config, routev1.TLSConfig{}) {
-		return nil
+		return nil, nil
 	}
 
-	return &config
+	return &config, nil
+}
+
+func (t *routeTrait) resolveTLSContent(e *Environment, content string, secretRef string, defaultKey string) (string, error) {
+	if secretRef == "" {
+		return content, nil
+	}
+
+	name, key := secretRef, defaultKey
+	if i := strings.Index(secretRef, "/"); i >= 0 {
+		name, key = secretRef[:i], secretRef[i+1:]
+	}
+
+	return kubernetes.GetSecretRefValue(e.C, e.Client, e.Integration.Namespace, &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: name,
+		},
+		Key: key,
+	})
 }
@@ -180,6 +180,70 @@ func TestRoute_TLS(t *testing.T) {
 	assert.Equal(t, routev1.TLSTerminationEdge, route.Spec.TLS.Termination)
 }
 
+func TestRoute_TLSFromSecret(t *testing.T) {
+	name := xid.New().String()
+	environment := createTestRouteEnvironment(t, name)
+	traitsCatalog := environment.Catalog
+
+	client, _ := test.NewFakeClient(&corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-ns",
+			Name:      "my-tls",
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+			"my-ca.crt":             []byte("ca"),
+		},
+	})
+	environment.Client = client
+	environment.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"route": test.TraitSpecFromMap(t, map[string]interface{}{
+			"tlsTermination":                    string(routev1.TLSTerminationReencrypt),
+			"tlsCertificateSecret":              "my-tls",
+			"tlsKeySecret":                      "my-tls",
+			"tlsDestinationCACertificateSecret": "my-tls/my-ca.crt",
+			"tlsInsecureEdgeTerminationPolicy":  string(routev1.InsecureEdgeTerminationPolicyRedirect),
+		}),
+	}
+
+	err := traitsCatalog.apply(environment)
+	assert.Nil(t, err)
+
+	route := environment.Resources.GetRoute(func(r *routev1.Route) bool {
+		return r.ObjectMeta.Name == name
+	})
+
+	assert.NotNil(t, route)
+	assert.NotNil(t, route.Spec.TLS)
+	assert.Equal(t, routev1.TLSTerminationReencrypt, route.Spec.TLS.Termination)
+	assert.Equal(t, "cert", route.Spec.TLS.Certificate)
+	assert.Equal(t, "key", route.Spec.TLS.Key)
+	assert.Equal(t, "", route.Spec.TLS.CACertificate)
+	assert.Equal(t, "ca", route.Spec.TLS.DestinationCACertificate)
+	assert.Equal(t, routev1.InsecureEdgeTerminationPolicyRedirect, route.Spec.TLS.InsecureEdgeTerminationPolicy)
+}
+
+func TestRoute_InvalidTLS(t *testing.T) {
+	name := xid.New().String()
+	environment := createTestRouteEnvironment(t, name)
+
+	for _, trait := range []*routeTrait{
+		{TLSTermination: "none"},
+		{TLSInsecureEdgeTerminationPolicy: "Deny"},
+		{TLSCertificate: "cert", TLSCertificateSecret: "my-tls"},
+	} {
+		configured, err := trait.Configure(environment)
+
+		assert.NotNil(t, err)
+		assert.False(t, configured)
+	}
+}
+
 func TestRoute_WithCustomServicePort(t *testing.T) {
 	name := xid.New().String()
 	environment := createTestRouteEnvironment(t, name)
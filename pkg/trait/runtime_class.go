@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Runtime Class trait sets the runtime class of the integration pods, so that they run under
+// a sandboxed container runtime, e.g. gVisor or Kata Containers.
+// See https://kubernetes.io/docs/concepts/containers/runtime-class/ for more details.
+//
+// NOTE: The `RuntimeClass` must exist in the cluster, and the `kubernetes.podspec-runtimeclassname`
+// feature must be enabled in Knative Serving, when the integration is deployed as a Knative service.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=runtime-class
+type runtimeClassTrait struct {
+	BaseTrait `property:",squash"`
+	// The name of the `RuntimeClass` to set on the integration pods.
+	Name string `property:"name" json:"name,omitempty"`
+}
+
+func newRuntimeClassTrait() Trait {
+	return &runtimeClassTrait{
+		BaseTrait: NewBaseTrait("runtime-class", 1420),
+	}
+}
+
+func (t *runtimeClassTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.Name == "" {
+		return false, fmt.Errorf("no runtime class name was provided")
+	}
+	if errs := validation.IsDNS1123Subdomain(t.Name); len(errs) > 0 {
+		return false, fmt.Errorf("invalid runtime class name %q: %s", t.Name, strings.Join(errs, ", "))
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *runtimeClassTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+	name := t.Name
+	podSpec.RuntimeClassName = &name
+
+	return nil
+}
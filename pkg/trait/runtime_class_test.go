@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	serving "knative.dev/serving/pkg/apis/serving/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigureRuntimeClassTraitInvalidName(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	runtimeClassTrait := createNominalRuntimeClassTrait()
+
+	runtimeClassTrait.Name = ""
+	success, err := runtimeClassTrait.Configure(environment)
+	assert.False(t, success)
+	assert.NotNil(t, err)
+
+	runtimeClassTrait.Name = "G_Visor"
+	success, err = runtimeClassTrait.Configure(environment)
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestApplyRuntimeClassTrait(t *testing.T) {
+	runtimeClassTrait := createNominalRuntimeClassTrait()
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	success, err := runtimeClassTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, success)
+	err = runtimeClassTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "gvisor", *deployment.Spec.Template.Spec.RuntimeClassName)
+
+	environment, knativeService := createNominalKnativeServiceTraitTest()
+	err = runtimeClassTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "gvisor", *knativeService.Spec.Template.Spec.RuntimeClassName)
+
+	environment, cronJob := createNominalCronJobTraitTest()
+	err = runtimeClassTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "gvisor", *cronJob.Spec.JobTemplate.Spec.Template.Spec.RuntimeClassName)
+}
+
+func TestRuntimeClassTraitWithKnativeProfile(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterOpenShift, "from('undertow:test').log('hello')")
+	env.Integration.Spec.Profile = v1.TraitProfileKnative
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"knative-service": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled": true,
+		}),
+		"runtime-class": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled": true,
+			"name":    "gvisor",
+		}),
+	}
+	res := processTestEnv(t, env)
+
+	assert.NotNil(t, env.GetTrait("runtime-class"))
+	knativeService := res.GetKnativeService(func(s *serving.Service) bool {
+		return s.Name == TestDeploymentName
+	})
+	assert.NotNil(t, knativeService)
+	assert.Equal(t, "gvisor", *knativeService.Spec.Template.Spec.RuntimeClassName)
+}
+
+func TestApplyRuntimeClassTraitMissingDeployment(t *testing.T) {
+	runtimeClassTrait := createNominalRuntimeClassTrait()
+
+	environment := createNominalMissingDeploymentTraitTest()
+	err := runtimeClassTrait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func createNominalRuntimeClassTrait() *runtimeClassTrait {
+	runtimeClassTrait := newRuntimeClassTrait().(*runtimeClassTrait)
+	runtimeClassTrait.Enabled = BoolP(true)
+	runtimeClassTrait.Name = "gvisor"
+
+	return runtimeClassTrait
+}
@@ -0,0 +1,155 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Security Context trait sets the security context of the integration pod and container.
+//
+// The defaults comply with the `restricted` Pod Security Standard, so that the integration can be deployed into
+// namespaces enforcing it: the pod must run as a non-root user, the `RuntimeDefault` seccomp profile is used,
+// privilege escalation is not allowed, and all the capabilities are dropped.
+//
+// NOTE: On Kubernetes, the `run-as-user` property should be set when the container image runs as the root user.
+// OpenShift assigns the user automatically, according to the Security Context Constraints of the namespace.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=security-context
+type securityContextTrait struct {
+	BaseTrait `property:",squash"`
+	// The UID to run the entrypoint of the integration container.
+	RunAsUser *int64 `property:"run-as-user" json:"runAsUser,omitempty"`
+	// Whether the integration container must run as a non-root user (default `true`).
+	RunAsNonRoot *bool `property:"run-as-non-root" json:"runAsNonRoot,omitempty"`
+	// The supplemental group that owns the volumes mounted into the integration pod.
+	FSGroup *int64 `property:"fs-group" json:"fsGroup,omitempty"`
+	// The seccomp profile type, either `RuntimeDefault`, `Localhost` or `Unconfined` (default `RuntimeDefault`).
+	SeccompProfileType string `property:"seccomp-profile-type" json:"seccompProfileType,omitempty"`
+	// The path of the seccomp profile, relative to the kubelet configured seccomp profile location,
+	// applicable when `seccomp-profile-type` is `Localhost`.
+	SeccompProfileLocalhost string `property:"seccomp-profile-localhost" json:"seccompProfileLocalhost,omitempty"`
+	// Whether the integration container process can gain more privileges than its parent process (default `false`).
+	AllowPrivilegeEscalation *bool `property:"allow-privilege-escalation" json:"allowPrivilegeEscalation,omitempty"`
+	// The capabilities to drop from the integration container (default `ALL`).
+	CapabilitiesDrop []string `property:"capabilities-drop" json:"capabilitiesDrop,omitempty"`
+	// The capabilities to add to the integration container.
+	CapabilitiesAdd []string `property:"capabilities-add" json:"capabilitiesAdd,omitempty"`
+}
+
+const (
+	defaultSeccompProfileType = corev1.SeccompProfileTypeRuntimeDefault
+	defaultCapabilityDrop     = "ALL"
+)
+
+func newSecurityContextTrait() Trait {
+	return &securityContextTrait{
+		BaseTrait: NewBaseTrait("security-context", 1650),
+	}
+}
+
+func (t *securityContextTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.RunAsNonRoot == nil {
+		t.RunAsNonRoot = BoolP(true)
+	}
+	if t.AllowPrivilegeEscalation == nil {
+		t.AllowPrivilegeEscalation = BoolP(false)
+	}
+	if t.SeccompProfileType == "" {
+		t.SeccompProfileType = string(defaultSeccompProfileType)
+	}
+	if len(t.CapabilitiesDrop) == 0 {
+		t.CapabilitiesDrop = []string{defaultCapabilityDrop}
+	}
+
+	switch corev1.SeccompProfileType(t.SeccompProfileType) {
+	case corev1.SeccompProfileTypeRuntimeDefault, corev1.SeccompProfileTypeUnconfined:
+	case corev1.SeccompProfileTypeLocalhost:
+		if t.SeccompProfileLocalhost == "" {
+			return false, fmt.Errorf("seccomp-profile-localhost must be set when seccomp-profile-type is %s",
+				corev1.SeccompProfileTypeLocalhost)
+		}
+	default:
+		return false, fmt.Errorf("unsupported seccomp profile type: %s", t.SeccompProfileType)
+	}
+
+	if t.RunAsUser != nil && *t.RunAsUser == 0 && IsTrue(t.RunAsNonRoot) {
+		return false, fmt.Errorf("run-as-user cannot be set to 0 when run-as-non-root is true")
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *securityContextTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSpec.SecurityContext.RunAsUser = t.RunAsUser
+	podSpec.SecurityContext.RunAsNonRoot = t.RunAsNonRoot
+	podSpec.SecurityContext.FSGroup = t.FSGroup
+	podSpec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+		Type: corev1.SeccompProfileType(t.SeccompProfileType),
+	}
+	if podSpec.SecurityContext.SeccompProfile.Type == corev1.SeccompProfileTypeLocalhost {
+		podSpec.SecurityContext.SeccompProfile.LocalhostProfile = &t.SeccompProfileLocalhost
+	}
+
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return nil
+	}
+
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	container.SecurityContext.AllowPrivilegeEscalation = t.AllowPrivilegeEscalation
+	container.SecurityContext.Capabilities = &corev1.Capabilities{
+		Drop: toCapabilities(t.CapabilitiesDrop),
+		Add:  toCapabilities(t.CapabilitiesAdd),
+	}
+
+	return nil
+}
+
+func toCapabilities(values []string) []corev1.Capability {
+	if len(values) == 0 {
+		return nil
+	}
+
+	capabilities := make([]corev1.Capability, 0, len(values))
+	for _, v := range values {
+		capabilities = append(capabilities, corev1.Capability(v))
+	}
+
+	return capabilities
+}
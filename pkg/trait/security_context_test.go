@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConfigureSecurityContextTraitDisabledByDefault(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	trait := newSecurityContextTrait().(*securityContextTrait)
+
+	configured, err := trait.Configure(environment)
+
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureSecurityContextTraitInvalidSeccompProfile(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	trait := createNominalSecurityContextTrait()
+	trait.SeccompProfileType = "Custom"
+
+	configured, err := trait.Configure(environment)
+
+	assert.NotNil(t, err)
+	assert.False(t, configured)
+
+	trait = createNominalSecurityContextTrait()
+	trait.SeccompProfileType = string(corev1.SeccompProfileTypeLocalhost)
+
+	configured, err = trait.Configure(environment)
+
+	assert.NotNil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureSecurityContextTraitRootUserWithNonRoot(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	trait := createNominalSecurityContextTrait()
+	root := int64(0)
+	trait.RunAsUser = &root
+
+	configured, err := trait.Configure(environment)
+
+	assert.NotNil(t, err)
+	assert.False(t, configured)
+}
+
+func TestApplySecurityContextTraitDefaults(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name: defaultContainerName,
+		},
+	}
+	environment.Catalog = NewCatalog(context.TODO(), nil)
+	trait := createNominalSecurityContextTrait()
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSecurityContext := deployment.Spec.Template.Spec.SecurityContext
+	assert.NotNil(t, podSecurityContext)
+	assert.True(t, *podSecurityContext.RunAsNonRoot)
+	assert.Nil(t, podSecurityContext.RunAsUser)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSecurityContext.SeccompProfile.Type)
+
+	securityContext := deployment.Spec.Template.Spec.Containers[0].SecurityContext
+	assert.NotNil(t, securityContext)
+	assert.False(t, *securityContext.AllowPrivilegeEscalation)
+	assert.Equal(t, []corev1.Capability{"ALL"}, securityContext.Capabilities.Drop)
+	assert.Nil(t, securityContext.Capabilities.Add)
+}
+
+func TestApplySecurityContextTraitCustomValues(t *testing.T) {
+	environment, knativeService := createNominalKnativeServiceTraitTest()
+	environment.Catalog = NewCatalog(context.TODO(), nil)
+	trait := createNominalSecurityContextTrait()
+	user := int64(1000)
+	group := int64(2000)
+	trait.RunAsUser = &user
+	trait.FSGroup = &group
+	trait.SeccompProfileType = string(corev1.SeccompProfileTypeLocalhost)
+	trait.SeccompProfileLocalhost = "profiles/integration.json"
+	trait.CapabilitiesAdd = []string{"NET_BIND_SERVICE"}
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSecurityContext := knativeService.Spec.Template.Spec.SecurityContext
+	assert.Equal(t, int64(1000), *podSecurityContext.RunAsUser)
+	assert.Equal(t, int64(2000), *podSecurityContext.FSGroup)
+	assert.Equal(t, corev1.SeccompProfileTypeLocalhost, podSecurityContext.SeccompProfile.Type)
+	assert.Equal(t, "profiles/integration.json", *podSecurityContext.SeccompProfile.LocalhostProfile)
+}
+
+func TestApplySecurityContextTraitMissingDeployment(t *testing.T) {
+	environment := createNominalMissingDeploymentTraitTest()
+	trait := createNominalSecurityContextTrait()
+
+	err := trait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func createNominalSecurityContextTrait() *securityContextTrait {
+	trait := newSecurityContextTrait().(*securityContextTrait)
+	trait.Enabled = BoolP(true)
+
+	return trait
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -36,8 +38,18 @@ type serviceTrait struct {
 	BaseTrait `property:",squash"`
 	// To automatically detect from the code if a Service needs to be created.
 	Auto *bool `property:"auto" json:"auto,omitempty"`
-	// Enable Service to be exposed as NodePort
+	// Enable Service to be exposed as NodePort (default `true`).
+	// Deprecated: replaced by the `type` property.
 	NodePort *bool `property:"node-port" json:"nodePort,omitempty"`
+	// The type of service to be used, either 'ClusterIP', 'NodePort' or 'LoadBalancer'
+	// (default `NodePort`, or `ClusterIP` when `node-port` is `false`).
+	Type string `property:"type" json:"type,omitempty"`
+	// The port on each node on which the service is exposed, when the service type is 'NodePort' or 'LoadBalancer'.
+	// It is allocated by the cluster when not set.
+	NodePortNumber *int32 `property:"node-port-number" json:"nodePortNumber,omitempty"`
+	// How the service routes external traffic, either 'Cluster' or 'Local',
+	// when the service type is 'NodePort' or 'LoadBalancer'.
+	ExternalTrafficPolicy string `property:"external-traffic-policy" json:"externalTrafficPolicy,omitempty"`
 }
 
 const serviceTraitID = "service"
@@ -70,6 +82,10 @@ func (t *serviceTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if err := t.validate(); err != nil {
+		return false, err
+	}
+
 	if IsNilOrTrue(t.Auto) {
 		sources, err := kubernetes.ResolveIntegrationSources(t.Ctx, t.Client, e.Integration, e.Resources)
 		if err != nil {
@@ -99,14 +115,49 @@ func (t *serviceTrait) Configure(e *Environment) (bool, error) {
 	return true, nil
 }
 
+func (t *serviceTrait) validate() error {
+	switch t.getServiceType() {
+	case corev1.ServiceTypeClusterIP:
+		if t.NodePortNumber != nil {
+			return fmt.Errorf("node-port-number cannot be set when the service type is %s", corev1.ServiceTypeClusterIP)
+		}
+		if t.ExternalTrafficPolicy != "" {
+			return fmt.Errorf("external-traffic-policy cannot be set when the service type is %s", corev1.ServiceTypeClusterIP)
+		}
+	case corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+	default:
+		return fmt.Errorf("unsupported service type: %s", t.Type)
+	}
+
+	switch corev1.ServiceExternalTrafficPolicyType(t.ExternalTrafficPolicy) {
+	case "", corev1.ServiceExternalTrafficPolicyTypeCluster, corev1.ServiceExternalTrafficPolicyTypeLocal:
+	default:
+		return fmt.Errorf("unsupported external traffic policy: %s", t.ExternalTrafficPolicy)
+	}
+
+	return nil
+}
+
+func (t *serviceTrait) getServiceType() corev1.ServiceType {
+	if t.Type != "" {
+		return corev1.ServiceType(t.Type)
+	}
+	if IsNilOrTrue(t.NodePort) {
+		return corev1.ServiceTypeNodePort
+	}
+
+	return corev1.ServiceTypeClusterIP
+}
+
 func (t *serviceTrait) Apply(e *Environment) error {
 	svc := e.Resources.GetServiceForIntegration(e.Integration)
 	// add a new service if not already created
 	if svc == nil {
 		svc = getServiceFor(e)
 
-		if IsNilOrTrue(t.NodePort) {
-			svc.Spec.Type = corev1.ServiceTypeNodePort
+		if serviceType := t.getServiceType(); serviceType != corev1.ServiceTypeClusterIP {
+			svc.Spec.Type = serviceType
+			svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyType(t.ExternalTrafficPolicy)
 		}
 	}
 	e.Resources.Add(svc)
@@ -19,7 +19,11 @@ package trait
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/property"
 	"github.com/apache/camel-k/pkg/util/reference"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -40,8 +44,21 @@ type serviceBindingTrait struct {
 	BaseTrait `property:",squash"`
 	// List of Provisioned Services and ServiceBindings in the form [[apigroup/]version:]kind:[namespace/]name
 	ServiceBindings []string `property:"service-bindings" json:"serviceBindings,omitempty"`
+	// List of Camel properties to be set from the binding secrets, in the form `property=[binding/]key`,
+	// e.g. `camel.component.kafka.brokers=bootstrapServers`. The binding is the name of the ServiceBinding,
+	// or the integration name for the Provisioned Services, and can be omitted when there is only one.
+	Properties []string `property:"properties" json:"properties,omitempty"`
 }
 
+// serviceBindingProperty maps a Camel property to a key of a service binding secret
+type serviceBindingProperty struct {
+	property string
+	binding  string
+	key      string
+}
+
+var invalidEnvVarChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
 func newServiceBindingTrait() Trait {
 	return &serviceBindingTrait{
 		BaseTrait: NewBaseTrait("service-binding", 250),
@@ -57,6 +74,10 @@ func (t *serviceBindingTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if _, err := t.parseProperties(); err != nil {
+		return false, err
+	}
+
 	return e.IntegrationInPhase(
 		v1.IntegrationPhaseInitialization,
 		v1.IntegrationPhaseWaitingForBindings,
@@ -144,10 +165,70 @@ func (t *serviceBindingTrait) Apply(e *Environment) error {
 		}
 		e.ApplicationProperties["quarkus.kubernetes-service-binding.enabled"] = "true"
 		e.ApplicationProperties["SERVICE_BINDING_ROOT"] = serviceBindingsMountPath
+
+		return t.mapProperties(e, serviceBindings)
 	}
 	return nil
 }
 
+// mapProperties sets the Camel properties from the binding secrets, using environment variables
+// referencing the secrets keys
+func (t *serviceBindingTrait) mapProperties(e *Environment, serviceBindings []string) error {
+	properties, err := t.parseProperties()
+	if err != nil {
+		return err
+	}
+	for _, p := range properties {
+		binding := p.binding
+		if binding == "" {
+			if len(serviceBindings) != 1 {
+				return fmt.Errorf("the binding of property %q must be specified, as there are %d bindings", p.property, len(serviceBindings))
+			}
+			binding = serviceBindings[0]
+		}
+		secret, ok := e.ServiceBindings[binding]
+		if !ok {
+			return fmt.Errorf("unknown binding %q for property %q", binding, p.property)
+		}
+
+		name := serviceBindingEnvVarName(binding, p.key)
+		envvar.SetVar(&e.EnvVars, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+					Key:                  p.key,
+				},
+			},
+		})
+		e.ApplicationProperties[p.property] = "${" + name + "}"
+	}
+	return nil
+}
+
+func (t *serviceBindingTrait) parseProperties() ([]serviceBindingProperty, error) {
+	properties := make([]serviceBindingProperty, 0, len(t.Properties))
+	for _, p := range t.Properties {
+		name, ref := property.SplitPropertyFileEntry(p)
+		if name == "" || ref == "" {
+			return nil, fmt.Errorf("service binding property must have property=[binding/]key format, it was %v", p)
+		}
+		mapping := serviceBindingProperty{property: name, key: ref}
+		if i := strings.LastIndex(ref, "/"); i >= 0 {
+			mapping.binding, mapping.key = ref[:i], ref[i+1:]
+			if mapping.binding == "" || mapping.key == "" {
+				return nil, fmt.Errorf("service binding property must have property=[binding/]key format, it was %v", p)
+			}
+		}
+		properties = append(properties, mapping)
+	}
+	return properties, nil
+}
+
+func serviceBindingEnvVarName(binding string, key string) string {
+	return invalidEnvVarChars.ReplaceAllString(strings.ToUpper("SERVICE_BINDING_"+binding+"_"+key), "_")
+}
+
 func setCollectionReady(e *Environment, serviceBinding string, status corev1.ConditionStatus) {
 	e.Integration.Status.SetCondition(
 		v1.IntegrationConditionServiceBindingsCollectionReady,
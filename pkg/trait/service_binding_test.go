@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+func TestServiceBindingProperties(t *testing.T) {
+	trait := newServiceBindingTrait().(*serviceBindingTrait)
+	trait.Properties = []string{
+		"camel.component.kafka.brokers=my-kafka/bootstrapServers",
+		"quarkus.datasource.username=my-db/username",
+	}
+
+	e := &Environment{
+		ApplicationProperties: make(map[string]string),
+		EnvVars:               make([]corev1.EnvVar, 0),
+		ServiceBindings: map[string]string{
+			"my-kafka": "my-kafka-secret",
+			"my-db":    "my-db-secret",
+		},
+	}
+
+	err := trait.mapProperties(e, []string{"my-kafka", "my-db"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "${SERVICE_BINDING_MY_KAFKA_BOOTSTRAPSERVERS}", e.ApplicationProperties["camel.component.kafka.brokers"])
+	assert.Equal(t, "${SERVICE_BINDING_MY_DB_USERNAME}", e.ApplicationProperties["quarkus.datasource.username"])
+
+	v := envvar.Get(e.EnvVars, "SERVICE_BINDING_MY_KAFKA_BOOTSTRAPSERVERS")
+	assert.NotNil(t, v)
+	assert.Equal(t, "my-kafka-secret", v.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "bootstrapServers", v.ValueFrom.SecretKeyRef.Key)
+}
+
+func TestServiceBindingPropertiesDefaultBinding(t *testing.T) {
+	trait := newServiceBindingTrait().(*serviceBindingTrait)
+	trait.Properties = []string{"camel.component.kafka.brokers=bootstrapServers"}
+
+	e := &Environment{
+		ApplicationProperties: make(map[string]string),
+		ServiceBindings: map[string]string{
+			"my-integration": "my-integration-secret",
+		},
+	}
+
+	err := trait.mapProperties(e, []string{"my-integration"})
+	assert.Nil(t, err)
+	assert.Equal(t, "${SERVICE_BINDING_MY_INTEGRATION_BOOTSTRAPSERVERS}", e.ApplicationProperties["camel.component.kafka.brokers"])
+
+	// The binding is required when there are several of them
+	e.ServiceBindings["other"] = "other-secret"
+	err = trait.mapProperties(e, []string{"my-integration", "other"})
+	assert.NotNil(t, err)
+
+	// The binding must exist
+	trait.Properties = []string{"camel.component.kafka.brokers=unknown/bootstrapServers"}
+	err = trait.mapProperties(e, []string{"my-integration", "other"})
+	assert.NotNil(t, err)
+}
+
+func TestServiceBindingInvalidProperties(t *testing.T) {
+	trait := newServiceBindingTrait().(*serviceBindingTrait)
+
+	for _, p := range []string{"camel.component.kafka.brokers", "=key", "camel.component.kafka.brokers=binding/", "camel.component.kafka.brokers=/key"} {
+		trait.Properties = []string{p}
+		_, err := trait.parseProperties()
+		assert.NotNil(t, err, p)
+	}
+}
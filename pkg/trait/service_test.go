@@ -25,11 +25,11 @@ import (
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/gzip"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"github.com/apache/camel-k/pkg/util/test"
 )
 
 const (
@@ -119,6 +119,7 @@ func TestServiceWithDefaults(t *testing.T) {
 	assert.Equal(t, int32(80), s.Spec.Ports[0].Port)
 	assert.Equal(t, "http", s.Spec.Ports[0].Name)
 	assert.Equal(t, "http", s.Spec.Ports[0].TargetPort.String())
+	assert.Equal(t, corev1.ServiceTypeNodePort, s.Spec.Type)
 
 	assert.Len(t, d.Spec.Template.Spec.Containers, 1)
 	assert.Len(t, d.Spec.Template.Spec.Containers[0].Ports, 1)
@@ -378,3 +379,83 @@ func TestServiceWithNodePort(t *testing.T) {
 
 	assert.Equal(t, corev1.ServiceTypeNodePort, s.Spec.Type)
 }
+
+func TestServiceWithLoadBalancer(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	traitCatalog := NewCatalog(context.TODO(), nil)
+
+	environment := Environment{
+		CamelCatalog: catalog,
+		Catalog:      traitCatalog,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ServiceTestName,
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Traits: map[string]v1.TraitSpec{
+					"service": test.TraitSpecFromMap(t, map[string]interface{}{
+						"enabled":               true,
+						"auto":                  false,
+						"type":                  "LoadBalancer",
+						"nodePortNumber":        30080,
+						"externalTrafficPolicy": "Local",
+					}),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterOpenShift,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	err = traitCatalog.apply(&environment)
+	assert.Nil(t, err)
+
+	s := environment.Resources.GetService(func(service *corev1.Service) bool {
+		return service.Name == ServiceTestName
+	})
+
+	assert.NotNil(t, s)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, s.Spec.Type)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyTypeLocal, s.Spec.ExternalTrafficPolicy)
+	assert.Len(t, s.Spec.Ports, 1)
+	assert.Equal(t, int32(30080), s.Spec.Ports[0].NodePort)
+}
+
+func TestServiceTraitInvalidValues(t *testing.T) {
+	nodePort := int32(30080)
+
+	for _, trait := range []*serviceTrait{
+		{Type: "ExternalName"},
+		{NodePort: BoolP(false), NodePortNumber: &nodePort},
+		{Type: "NodePort", ExternalTrafficPolicy: "Remote"},
+		{Type: "ClusterIP", ExternalTrafficPolicy: "Local"},
+	} {
+		assert.NotNil(t, trait.validate())
+	}
+
+	assert.Nil(t, (&serviceTrait{NodePort: BoolP(true), NodePortNumber: &nodePort}).validate())
+	assert.Nil(t, (&serviceTrait{NodePortNumber: &nodePort}).validate())
+}
@@ -0,0 +1,290 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+// The Sidecar trait injects user-defined sidecar containers into the integration pod,
+// e.g. a proxy, a log shipper or a cloud SQL connector.
+//
+// Each container is declared with its name and image, and is further configured by the other
+// properties, whose values are prefixed with the name of the container they apply to, e.g.
+// `proxy:HTTP_PORT=8081` for an environment variable of the `proxy` container.
+//
+// Volumes are mounted by name. Volumes that are not already declared by the integration pod
+// are created as empty directories, that can be shared with the integration container by
+// using its name, e.g. `integration:shared@/var/shared`.
+//
+// Containers are started in the order they are declared in the pod, so the sidecars
+// are added after the integration container by default, or before it, when they
+// must be started first.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=sidecar
+type sidecarTrait struct {
+	BaseTrait `property:",squash"`
+	// The sidecar containers to inject. Syntax: name:image
+	Containers []string `property:"containers" json:"containers,omitempty"`
+	// The command of the sidecar containers, overriding the image entrypoint. Syntax: name:command [args...]
+	Commands []string `property:"commands" json:"commands,omitempty"`
+	// The environment variables of the sidecar containers. Syntax: name:KEY=value
+	Env []string `property:"env" json:"env,omitempty"`
+	// The ports exposed by the sidecar containers. Syntax: name:port[/protocol]
+	Ports []string `property:"ports" json:"ports,omitempty"`
+	// The volumes mounted into the sidecar containers, or the integration container. Syntax: name:volume@/path
+	Mounts []string `property:"mounts" json:"mounts,omitempty"`
+	// The position of the sidecar containers relative to the integration container, either `after` (default) or `before`.
+	Position string `property:"position" json:"position,omitempty"`
+}
+
+const (
+	sidecarPositionAfter  = "after"
+	sidecarPositionBefore = "before"
+)
+
+var (
+	sidecarContainerRegexp = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?):(\S+)$`)
+	sidecarEnvRegexp       = regexp.MustCompile(`^([^=]+)=(.*)$`)
+	sidecarPortRegexp      = regexp.MustCompile(`^([0-9]+)(/(TCP|UDP|SCTP))?$`)
+	sidecarMountRegexp     = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?)@(/[\w\.\-\_\/]*)$`)
+)
+
+func newSidecarTrait() Trait {
+	return &sidecarTrait{
+		BaseTrait: NewBaseTrait("sidecar", 1630),
+	}
+}
+
+func (t *sidecarTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if len(t.Containers) == 0 {
+		return false, nil
+	}
+
+	if t.Position == "" {
+		t.Position = sidecarPositionAfter
+	}
+	if t.Position != sidecarPositionAfter && t.Position != sidecarPositionBefore {
+		return false, fmt.Errorf("unsupported sidecar position %q, must be one of %s or %s",
+			t.Position, sidecarPositionAfter, sidecarPositionBefore)
+	}
+
+	if _, err := t.containers(e); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *sidecarTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return fmt.Errorf("unable to find integration container: %s", e.Integration.Name)
+	}
+
+	sidecars, err := t.containers(e)
+	if err != nil {
+		return err
+	}
+	for _, sidecar := range sidecars {
+		if findContainer(podSpec.Containers, sidecar.Name) != nil {
+			return fmt.Errorf("container %s already exists in integration %s pod", sidecar.Name, e.Integration.Name)
+		}
+	}
+
+	mountUserContainersVolumes(podSpec, container, sidecars, t.Mounts)
+
+	// The traits executed afterwards look up the integration container by name,
+	// so that it can be moved after the sidecars
+	if t.Position == sidecarPositionBefore {
+		podSpec.Containers = append(sidecars, podSpec.Containers...)
+	} else {
+		podSpec.Containers = append(podSpec.Containers, sidecars...)
+	}
+
+	return nil
+}
+
+// containers validates the trait configuration and returns the declared sidecar containers.
+func (t *sidecarTrait) containers(e *Environment) ([]corev1.Container, error) {
+	containers, err := parseUserContainers("sidecar container", getIntegrationContainerName(e), t.Containers, t.Commands, t.Mounts)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := func(property string, value string) (*corev1.Container, string, error) {
+		name, v := splitSidecarValue(value)
+		container := findContainer(containers, name)
+		if container == nil {
+			return nil, "", fmt.Errorf("sidecar %s %q references an undeclared container", property, value)
+		}
+		return container, v, nil
+	}
+
+	for _, env := range t.Env {
+		container, value, err := lookup("env", env)
+		if err != nil {
+			return nil, err
+		}
+		parts := sidecarEnvRegexp.FindStringSubmatch(value)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid sidecar env %q, must be name:KEY=value", env)
+		}
+		envvar.SetVal(&container.Env, parts[1], parts[2])
+	}
+	for _, p := range t.Ports {
+		container, value, err := lookup("port", p)
+		if err != nil {
+			return nil, err
+		}
+		parts := sidecarPortRegexp.FindStringSubmatch(value)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid sidecar port %q, must be name:port[/protocol]", p)
+		}
+		port, err := strconv.ParseInt(parts[1], 10, 32)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid sidecar port %q", p)
+		}
+		protocol := corev1.ProtocolTCP
+		if parts[3] != "" {
+			protocol = corev1.Protocol(parts[3])
+		}
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			ContainerPort: int32(port),
+			Protocol:      protocol,
+		})
+	}
+	return containers, nil
+}
+
+// getIntegrationContainerName returns the name of the integration container, as configured by the container trait
+func getIntegrationContainerName(e *Environment) string {
+	if ct := e.Catalog.GetTrait(containerTraitID); ct != nil {
+		return ct.(*containerTrait).Name
+	}
+	return defaultContainerName
+}
+
+// parseUserContainers validates the user provided containers, declared with the name:image syntax, along with
+// their commands and volume mounts, and returns the declared containers. It is shared by the traits adding
+// user provided containers to the integration pod, the kind of containers being used in the error messages.
+func parseUserContainers(kind string, integrationContainerName string, declarations []string, commands []string, mounts []string) ([]corev1.Container, error) {
+	containers := make([]corev1.Container, 0, len(declarations))
+	for _, c := range declarations {
+		parts := sidecarContainerRegexp.FindStringSubmatch(c)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid %s %q, must be name:image", kind, c)
+		}
+		if parts[1] == integrationContainerName || findContainer(containers, parts[1]) != nil {
+			return nil, fmt.Errorf("duplicate %s name %s", kind, parts[1])
+		}
+		containers = append(containers, corev1.Container{
+			Name:  parts[1],
+			Image: parts[3],
+		})
+	}
+
+	for _, c := range commands {
+		name, command := splitSidecarValue(c)
+		container := findContainer(containers, name)
+		if container == nil {
+			return nil, fmt.Errorf("%s command %q references an undeclared container", kind, c)
+		}
+		container.Command = strings.Fields(command)
+		if len(container.Command) == 0 {
+			return nil, fmt.Errorf("empty %s command %q", kind, c)
+		}
+	}
+	for _, m := range mounts {
+		name, value := splitSidecarValue(m)
+		if name != integrationContainerName && findContainer(containers, name) == nil {
+			return nil, fmt.Errorf("%s mount %q references an undeclared container", kind, m)
+		}
+		if !sidecarMountRegexp.MatchString(value) {
+			return nil, fmt.Errorf("invalid %s mount %q, must be name:volume@/path", kind, m)
+		}
+	}
+
+	return containers, nil
+}
+
+// mountUserContainersVolumes mounts the volumes, declared with the name:volume@/path syntax, into the user provided
+// containers or the integration container. The volumes that are not declared by the pod are created as empty directories.
+func mountUserContainersVolumes(podSpec *corev1.PodSpec, integrationContainer *corev1.Container, containers []corev1.Container, mounts []string) {
+	for _, m := range mounts {
+		name, value := splitSidecarValue(m)
+		parts := sidecarMountRegexp.FindStringSubmatch(value)
+		volume, path := parts[1], parts[3]
+
+		if !hasVolume(podSpec.Volumes, volume) {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: volume,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			})
+		}
+
+		mount := corev1.VolumeMount{
+			Name:      volume,
+			MountPath: path,
+		}
+		if name == integrationContainer.Name {
+			integrationContainer.VolumeMounts = append(integrationContainer.VolumeMounts, mount)
+		} else {
+			c := findContainer(containers, name)
+			c.VolumeMounts = append(c.VolumeMounts, mount)
+		}
+	}
+}
+
+func splitSidecarValue(value string) (string, string) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
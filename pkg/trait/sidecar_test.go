@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestConfigureSidecarTraitInvalid(t *testing.T) {
+	for _, configure := range []func(*sidecarTrait){
+		func(trait *sidecarTrait) { trait.Containers = []string{"proxy"} },
+		func(trait *sidecarTrait) { trait.Containers = []string{"integration:envoy"} },
+		func(trait *sidecarTrait) { trait.Env = []string{"unknown:KEY=value"} },
+		func(trait *sidecarTrait) { trait.Env = []string{"proxy:KEY"} },
+		func(trait *sidecarTrait) { trait.Ports = []string{"proxy:70000"} },
+		func(trait *sidecarTrait) { trait.Mounts = []string{"proxy:shared"} },
+		func(trait *sidecarTrait) { trait.Position = "middle" },
+	} {
+		trait, environment, _ := createSidecarTest()
+		configure(trait)
+
+		_, err := trait.Configure(environment)
+
+		assert.NotNil(t, err)
+	}
+}
+
+func TestApplySidecarTrait(t *testing.T) {
+	trait, environment, deployment := createSidecarTest()
+	trait.Commands = []string{"proxy:envoy -c /etc/envoy/envoy.yaml"}
+	trait.Env = []string{"proxy:LOG_LEVEL=debug"}
+	trait.Ports = []string{"proxy:9901", "proxy:8125/UDP"}
+	trait.Mounts = []string{"proxy:shared@/var/shared", "integration:shared@/var/shared"}
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, defaultContainerName, podSpec.Containers[0].Name)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "shared", MountPath: "/var/shared"}}, podSpec.Containers[0].VolumeMounts)
+
+	sidecar := podSpec.Containers[1]
+	assert.Equal(t, "proxy", sidecar.Name)
+	assert.Equal(t, "envoyproxy/envoy:v1.18.3", sidecar.Image)
+	assert.Equal(t, []string{"envoy", "-c", "/etc/envoy/envoy.yaml"}, sidecar.Command)
+	assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}, sidecar.Env)
+	assert.Equal(t, []corev1.ContainerPort{
+		{ContainerPort: 9901, Protocol: corev1.ProtocolTCP},
+		{ContainerPort: 8125, Protocol: corev1.ProtocolUDP},
+	}, sidecar.Ports)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "shared", MountPath: "/var/shared"}}, sidecar.VolumeMounts)
+
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.NotNil(t, podSpec.Volumes[0].EmptyDir)
+}
+
+func TestApplySidecarTraitBefore(t *testing.T) {
+	trait, environment, deployment := createSidecarTest()
+	trait.Position = sidecarPositionBefore
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "proxy", containers[0].Name)
+	assert.Equal(t, defaultContainerName, containers[1].Name)
+	assert.NotNil(t, environment.getIntegrationContainer())
+}
+
+func createSidecarTest() (*sidecarTrait, *Environment, *appsv1.Deployment) {
+	trait := newSidecarTrait().(*sidecarTrait)
+	trait.Enabled = BoolP(true)
+	trait.Containers = []string{"proxy:envoyproxy/envoy:v1.18.3"}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	environment := &Environment{
+		Catalog: NewCatalog(context.TODO(), nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(deployment),
+	}
+
+	return trait, environment, deployment
+}
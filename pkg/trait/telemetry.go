@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// The Telemetry trait can be used to automatically publish tracing information to an
+// OpenTelemetry compatible collector, using the Camel Quarkus OpenTelemetry extension.
+//
+// The trait is able to automatically discover the OTLP endpoint of an OpenTelemetry collector
+// available in the namespace (supports collectors managed by the **OpenTelemetry Operator**).
+//
+// The Telemetry trait is disabled by default.
+//
+// +camel-k:trait=telemetry
+type telemetryTrait struct {
+	BaseTrait `property:",squash"`
+	// Enables automatic configuration of the trait, including automatic discovery of the telemetry endpoint.
+	Auto *bool `property:"auto" json:"auto,omitempty"`
+	// The name of the service that publishes telemetry data (defaults to the integration name)
+	ServiceName string `property:"service-name" json:"serviceName,omitempty"`
+	// The target OTLP endpoint of the OpenTelemetry collector (automatically discovered by default)
+	Endpoint string `property:"endpoint" json:"endpoint,omitempty"`
+	// The sampler of the telemetry used for tracing, one of `on`, `off` or `ratio` (default "ratio")
+	Sampler *string `property:"sampler" json:"sampler,omitempty"`
+	// The sampler ratio, applicable when the sampler is `ratio` (default "1")
+	SamplerRatio *string `property:"sampler-ratio" json:"samplerRatio,omitempty"`
+}
+
+const (
+	telemetryDependency = "mvn:org.apache.camel.quarkus:camel-quarkus-opentelemetry"
+
+	telemetryCollectorComponentLabel = "app.kubernetes.io/component"
+	telemetryCollectorComponent      = "opentelemetry-collector"
+	telemetryCollectorPortName       = "otlp-grpc"
+)
+
+var (
+	defaultTelemetrySampler      = "ratio"
+	defaultTelemetrySamplerRatio = "1"
+)
+
+func newTelemetryTrait() Trait {
+	return &telemetryTrait{
+		BaseTrait: NewBaseTrait("telemetry", TraitOrderBeforeControllerCreation),
+	}
+}
+
+func (t *telemetryTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1.IntegrationPhaseInitialization, v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		return false, nil
+	}
+
+	if IsNilOrTrue(t.Auto) && !e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		if t.Endpoint == "" {
+			endpoint, err := t.findCollectorEndpoint(e)
+			if err != nil {
+				return false, err
+			}
+			if endpoint != "" {
+				t.L.Infof("Using telemetry endpoint: %s", endpoint)
+				t.Endpoint = endpoint
+			}
+		}
+
+		if t.ServiceName == "" {
+			t.ServiceName = e.Integration.Name
+		}
+
+		if t.Sampler == nil {
+			t.Sampler = &defaultTelemetrySampler
+		}
+
+		if t.SamplerRatio == nil {
+			t.SamplerRatio = &defaultTelemetrySamplerRatio
+		}
+	}
+
+	return true, nil
+}
+
+func (t *telemetryTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		// Add the Camel Quarkus OpenTelemetry extension
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, telemetryDependency)
+		return nil
+	}
+
+	e.ApplicationProperties["quarkus.opentelemetry.enabled"] = "true"
+
+	if t.Endpoint != "" {
+		e.ApplicationProperties["quarkus.opentelemetry.tracer.exporter.otlp.endpoint"] = t.Endpoint
+	}
+
+	if t.ServiceName != "" {
+		e.ApplicationProperties["quarkus.opentelemetry.tracer.resource-attributes"] = "service.name=" + t.ServiceName
+	}
+
+	if t.Sampler != nil {
+		e.ApplicationProperties["quarkus.opentelemetry.tracer.sampler"] = *t.Sampler
+	}
+
+	if t.SamplerRatio != nil {
+		e.ApplicationProperties["quarkus.opentelemetry.tracer.sampler.ratio"] = *t.SamplerRatio
+	}
+
+	return nil
+}
+
+// findCollectorEndpoint looks up the OTLP endpoint of the OpenTelemetry collectors
+// available in the integration namespace. The Services are listed with the controller
+// client, so that they are read from the operator informer cache rather than from the
+// API server on every reconciliation.
+func (t *telemetryTrait) findCollectorEndpoint(e *Environment) (string, error) {
+	services := corev1.ServiceList{}
+	err := t.Client.List(e.C, &services,
+		ctrl.InNamespace(e.Integration.Namespace),
+		ctrl.MatchingLabels{
+			telemetryCollectorComponentLabel: telemetryCollectorComponent,
+		})
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []string
+	for _, svc := range services.Items {
+		// The operator also creates headless and monitoring services for each collector
+		if strings.HasSuffix(svc.Name, "-headless") || strings.HasSuffix(svc.Name, "-monitoring") {
+			continue
+		}
+
+		for _, port := range svc.Spec.Ports {
+			if port.Name == telemetryCollectorPortName && port.Port > 0 {
+				candidates = append(candidates, fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", svc.Name, svc.Namespace, port.Port))
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	sort.Strings(candidates)
+	for _, endpoint := range candidates {
+		t.L.Infof("Detected OpenTelemetry collector endpoint at: %s", endpoint)
+	}
+
+	return candidates[0], nil
+}
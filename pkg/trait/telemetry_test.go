@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestTelemetryTraitAddsDependencyOnInitialization(t *testing.T) {
+	trait, environment := createNominalTelemetryTest(t)
+	environment.Integration.Status.Phase = v1.IntegrationPhaseInitialization
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Contains(t, environment.Integration.Status.Dependencies, "mvn:org.apache.camel.quarkus:camel-quarkus-opentelemetry")
+	assert.Empty(t, environment.ApplicationProperties)
+}
+
+func TestTelemetryTraitWithExplicitEndpoint(t *testing.T) {
+	trait, environment := createNominalTelemetryTest(t)
+	trait.Endpoint = "http://collector:4317"
+	trait.SamplerRatio = &[]string{"0.5"}[0]
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "true", environment.ApplicationProperties["quarkus.opentelemetry.enabled"])
+	assert.Equal(t, "http://collector:4317", environment.ApplicationProperties["quarkus.opentelemetry.tracer.exporter.otlp.endpoint"])
+	assert.Equal(t, "service.name=test", environment.ApplicationProperties["quarkus.opentelemetry.tracer.resource-attributes"])
+	assert.Equal(t, "ratio", environment.ApplicationProperties["quarkus.opentelemetry.tracer.sampler"])
+	assert.Equal(t, "0.5", environment.ApplicationProperties["quarkus.opentelemetry.tracer.sampler.ratio"])
+}
+
+func TestTelemetryTraitDiscoversCollector(t *testing.T) {
+	trait, environment := createNominalTelemetryTest(t,
+		collectorService("otel-collector", "otlp-grpc", 4317),
+		collectorService("otel-collector-headless", "otlp-grpc", 4317),
+		collectorService("otel-collector-monitoring", "monitoring", 8888),
+	)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://otel-collector.ns.svc.cluster.local:4317", environment.ApplicationProperties["quarkus.opentelemetry.tracer.exporter.otlp.endpoint"])
+}
+
+func TestTelemetryTraitWithoutAuto(t *testing.T) {
+	trait, environment := createNominalTelemetryTest(t, collectorService("otel-collector", "otlp-grpc", 4317))
+	trait.Auto = BoolP(false)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"quarkus.opentelemetry.enabled": "true"}, environment.ApplicationProperties)
+}
+
+func TestTelemetryTraitDisabledByDefault(t *testing.T) {
+	trait, environment := createNominalTelemetryTest(t)
+	trait.Enabled = nil
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func createNominalTelemetryTest(t *testing.T, objects ...runtime.Object) (*telemetryTrait, *Environment) {
+	t.Helper()
+
+	client, err := test.NewFakeClient(objects...)
+	assert.Nil(t, err)
+
+	trait := newTelemetryTrait().(*telemetryTrait)
+	trait.Enabled = BoolP(true)
+	trait.Client = client
+
+	environment := &Environment{
+		C:                     context.TODO(),
+		Catalog:               NewCatalog(context.TODO(), nil),
+		ApplicationProperties: make(map[string]string),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+
+	return trait, environment
+}
+
+func collectorService(name string, portName string, port int32) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			Labels: map[string]string{
+				"app.kubernetes.io/component": "opentelemetry-collector",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name: portName,
+					Port: port,
+				},
+			},
+		},
+	}
+}
@@ -37,6 +37,9 @@ import (
 // - `node.kubernetes.io/network-unavailable:NoExecute:3000`
 // - `disktype=ssd:PreferNoSchedule`
 //
+// The tolerations can also be set over the build pods, with the `build` property, so that the integration kits
+// can be built onto the tainted nodes, when the builds are executed with the pod strategy.
+//
 // It's disabled by default.
 //
 // +camel-k:trait=toleration
@@ -44,6 +47,8 @@ type tolerationTrait struct {
 	BaseTrait `property:",squash"`
 	// The list of taints to tolerate, in the form `Key[=Value]:Effect[:Seconds]`
 	Taints []string `property:"taints" json:"taints,omitempty"`
+	// Whether the tolerations are also set over the build pods (default `false`)
+	Build *bool `property:"build" json:"build,omitempty"`
 }
 
 func newTolerationTrait() Trait {
@@ -61,14 +66,29 @@ func (t *tolerationTrait) Configure(e *Environment) (bool, error) {
 		return false, fmt.Errorf("no taint was provided")
 	}
 
+	if IsTrue(t.Build) && e.IntegrationKitInPhase(v1.IntegrationKitPhaseBuildSubmitted) {
+		return true, nil
+	}
+
 	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
 }
 
+// InfluencesKit overrides base class method
+func (t *tolerationTrait) InfluencesKit() bool {
+	return true
+}
+
 func (t *tolerationTrait) Apply(e *Environment) (err error) {
 	tolerations, err := kubernetes.NewTolerations(t.Taints)
 	if err != nil {
 		return err
 	}
+
+	if e.IntegrationKitInPhase(v1.IntegrationKitPhaseBuildSubmitted) {
+		e.BuildTolerations = append(e.BuildTolerations, tolerations...)
+		return nil
+	}
+
 	podSpec := e.GetIntegrationPodSpec()
 
 	if podSpec == nil {
@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 )
 
 func TestConfigureTolerationTraitMissingTaint(t *testing.T) {
@@ -124,6 +126,51 @@ func TestTolerationValidTaints(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestConfigureTolerationTraitForBuild(t *testing.T) {
+	environment := createNominalTolerationBuildTest()
+	tolerationTrait := createNominalTolerationTrait()
+	tolerationTrait.Taints = append(tolerationTrait.Taints, "dedicated=builds:NoSchedule")
+
+	success, err := tolerationTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, success)
+
+	tolerationTrait.Build = BoolP(true)
+
+	success, err = tolerationTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, success)
+}
+
+func TestApplyTolerationTraitForBuild(t *testing.T) {
+	environment := createNominalTolerationBuildTest()
+	tolerationTrait := createNominalTolerationTrait()
+	tolerationTrait.Build = BoolP(true)
+	tolerationTrait.Taints = append(tolerationTrait.Taints, "dedicated=builds:NoSchedule")
+
+	err := tolerationTrait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []corev1.Toleration{
+		{
+			Key:      "dedicated",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "builds",
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}, environment.BuildTolerations)
+}
+
+func createNominalTolerationBuildTest() *Environment {
+	return &Environment{
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseBuildSubmitted,
+			},
+		},
+	}
+}
+
 func createNominalTolerationTrait() *tolerationTrait {
 	tolerationTrait := newTolerationTrait().(*tolerationTrait)
 	tolerationTrait.Enabled = BoolP(true)
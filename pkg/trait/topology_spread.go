@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Topology Spread trait sets topology spread constraints over the integration pods, so that the replicas
+// of the integration are evenly spread across failure domains, such as zones or nodes.
+// See https://kubernetes.io/docs/concepts/workloads/pods/pod-topology-spread-constraints/ for more details.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=topology-spread
+type topologySpreadTrait struct {
+	BaseTrait `property:",squash"`
+	// The maximum permitted difference between the number of integration pods in any two topology domains (default `1`).
+	MaxSkew *int32 `property:"max-skew" json:"maxSkew,omitempty"`
+	// The node label used to determine the topology domains (default `topology.kubernetes.io/zone`).
+	TopologyKey string `property:"topology-key" json:"topologyKey,omitempty"`
+	// How the scheduler deals with a pod that does not satisfy the spread constraint,
+	// either `DoNotSchedule` or `ScheduleAnyway` (default `ScheduleAnyway`).
+	WhenUnsatisfiable string `property:"when-unsatisfiable" json:"whenUnsatisfiable,omitempty"`
+}
+
+const defaultTopologySpreadKey = "topology.kubernetes.io/zone"
+
+func newTopologySpreadTrait() Trait {
+	return &topologySpreadTrait{
+		BaseTrait: NewBaseTrait("topology-spread", 1440),
+	}
+}
+
+func (t *topologySpreadTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.MaxSkew == nil {
+		maxSkew := int32(1)
+		t.MaxSkew = &maxSkew
+	}
+	if t.TopologyKey == "" {
+		t.TopologyKey = defaultTopologySpreadKey
+	}
+	if t.WhenUnsatisfiable == "" {
+		t.WhenUnsatisfiable = string(corev1.ScheduleAnyway)
+	}
+
+	if *t.MaxSkew <= 0 {
+		return false, fmt.Errorf("max-skew must be a positive number, it was %d", *t.MaxSkew)
+	}
+	switch corev1.UnsatisfiableConstraintAction(t.WhenUnsatisfiable) {
+	case corev1.DoNotSchedule, corev1.ScheduleAnyway:
+	default:
+		return false, fmt.Errorf("unsupported when-unsatisfiable action %q, must be one of %q or %q",
+			t.WhenUnsatisfiable, corev1.DoNotSchedule, corev1.ScheduleAnyway)
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning), nil
+}
+
+func (t *topologySpreadTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+		MaxSkew:           *t.MaxSkew,
+		TopologyKey:       t.TopologyKey,
+		WhenUnsatisfiable: corev1.UnsatisfiableConstraintAction(t.WhenUnsatisfiable),
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	serving "knative.dev/serving/pkg/apis/serving/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestApplyTopologySpreadTraitDefaults(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	topologySpreadTrait := createNominalTopologySpreadTrait()
+
+	success, err := topologySpreadTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, success)
+
+	err = topologySpreadTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					v1.IntegrationLabel: "integration-name",
+				},
+			},
+		},
+	}, deployment.Spec.Template.Spec.TopologySpreadConstraints)
+}
+
+func TestApplyTopologySpreadTrait(t *testing.T) {
+	environment, cronJob := createNominalCronJobTraitTest()
+	topologySpreadTrait := createNominalTopologySpreadTrait()
+	maxSkew := int32(2)
+	topologySpreadTrait.MaxSkew = &maxSkew
+	topologySpreadTrait.TopologyKey = "kubernetes.io/hostname"
+	topologySpreadTrait.WhenUnsatisfiable = string(corev1.DoNotSchedule)
+
+	success, err := topologySpreadTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, success)
+
+	err = topologySpreadTrait.Apply(environment)
+	assert.Nil(t, err)
+	constraints := cronJob.Spec.JobTemplate.Spec.Template.Spec.TopologySpreadConstraints
+	assert.Len(t, constraints, 1)
+	assert.Equal(t, int32(2), constraints[0].MaxSkew)
+	assert.Equal(t, "kubernetes.io/hostname", constraints[0].TopologyKey)
+	assert.Equal(t, corev1.DoNotSchedule, constraints[0].WhenUnsatisfiable)
+}
+
+func TestConfigureTopologySpreadTraitInvalid(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+
+	topologySpreadTrait := createNominalTopologySpreadTrait()
+	maxSkew := int32(0)
+	topologySpreadTrait.MaxSkew = &maxSkew
+	_, err := topologySpreadTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	topologySpreadTrait = createNominalTopologySpreadTrait()
+	topologySpreadTrait.WhenUnsatisfiable = "Sometimes"
+	_, err = topologySpreadTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestTopologySpreadTraitWithKnativeProfile(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterOpenShift, "from('undertow:test').log('hello')")
+	env.Integration.Spec.Profile = v1.TraitProfileKnative
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"knative-service": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled": true,
+		}),
+		"topology-spread": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled":     true,
+			"topologyKey": "kubernetes.io/hostname",
+		}),
+	}
+	res := processTestEnv(t, env)
+
+	assert.NotNil(t, env.GetTrait("topology-spread"))
+	knativeService := res.GetKnativeService(func(s *serving.Service) bool {
+		return s.Name == TestDeploymentName
+	})
+	assert.NotNil(t, knativeService)
+	constraints := knativeService.Spec.Template.Spec.TopologySpreadConstraints
+	assert.Len(t, constraints, 1)
+	assert.Equal(t, "kubernetes.io/hostname", constraints[0].TopologyKey)
+}
+
+func TestApplyTopologySpreadTraitMissingDeployment(t *testing.T) {
+	environment := createNominalMissingDeploymentTraitTest()
+	topologySpreadTrait := createNominalTopologySpreadTrait()
+
+	err := topologySpreadTrait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func createNominalTopologySpreadTrait() *topologySpreadTrait {
+	topologySpreadTrait := newTopologySpreadTrait().(*topologySpreadTrait)
+	topologySpreadTrait.Enabled = BoolP(true)
+
+	return topologySpreadTrait
+}
@@ -28,6 +28,8 @@ func init() {
 	AddToTraits(newKameletsTrait)
 	AddToTraits(newErrorHandlerTrait)
 	AddToTraits(newDependenciesTrait)
+	AddToTraits(newVaultTrait)
+	AddToTraits(newResumeTrait)
 	AddToTraits(newBuilderTrait)
 	AddToTraits(newQuarkusTrait)
 	AddToTraits(newEnvironmentTrait)
@@ -36,7 +38,11 @@ func init() {
 	AddToTraits(newDeploymentTrait)
 	AddToTraits(newGarbageCollectorTrait)
 	AddToTraits(newAffinityTrait)
+	AddToTraits(newTopologySpreadTrait)
 	AddToTraits(newTolerationTrait)
+	AddToTraits(newPriorityClassTrait)
+	AddToTraits(newRuntimeClassTrait)
+	AddToTraits(newDNSTrait)
 	AddToTraits(newKnativeServiceTrait)
 	AddToTraits(newServiceTrait)
 	AddToTraits(newContainerTrait)
@@ -50,6 +56,15 @@ func init() {
 	AddToTraits(newServiceBindingTrait)
 	AddToTraits(newOwnerTrait)
 	AddToTraits(newPdbTrait)
+	AddToTraits(newNetworkPolicyTrait)
 	AddToTraits(newPodTrait)
+	AddToTraits(newPodMetadataTrait)
 	AddToTraits(newLoggingTraitTrait)
+	AddToTraits(newTelemetryTrait)
+	AddToTraits(newHealthTrait)
+	AddToTraits(newSecurityContextTrait)
+	AddToTraits(newMountTrait)
+	AddToTraits(newSidecarTrait)
+	AddToTraits(newInitContainersTrait)
+	AddToTraits(newWorkloadIdentityTrait)
 }
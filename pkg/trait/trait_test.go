@@ -496,7 +496,7 @@ func TestConfigureVolumesAndMountsBinaryAndTextResources(t *testing.T) {
 
 func TestOnlySomeTraitsInfluenceBuild(t *testing.T) {
 	c := NewTraitTestCatalog()
-	buildTraits := []string{"builder", "quarkus"}
+	buildTraits := []string{"builder", "dependencies", "priority-class", "quarkus", "toleration"}
 
 	for _, trait := range c.allTraits() {
 		if trait.InfluencesKit() {
@@ -19,6 +19,8 @@ package trait
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path"
 	"sort"
@@ -30,7 +32,9 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	serving "knative.dev/serving/pkg/apis/serving/v1"
 
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
@@ -187,25 +191,30 @@ type ControllerStrategySelector interface {
 
 // A Environment provides the context where the trait is executed
 type Environment struct {
-	CamelCatalog          *camel.RuntimeCatalog
-	RuntimeVersion        string
-	Catalog               *Catalog
-	C                     context.Context
-	Client                client.Client
-	Platform              *v1.IntegrationPlatform
-	IntegrationKit        *v1.IntegrationKit
-	Integration           *v1.Integration
-	Resources             *kubernetes.Collection
-	PostActions           []func(*Environment) error
-	PostStepProcessors    []func(*Environment) error
-	PostProcessors        []func(*Environment) error
-	BuildTasks            []v1.Task
-	ConfiguredTraits      []Trait
-	ExecutedTraits        []Trait
-	EnvVars               []corev1.EnvVar
-	ApplicationProperties map[string]string
-	Interceptors          []string
-	ServiceBindings       map[string]string
+	CamelCatalog           *camel.RuntimeCatalog
+	RuntimeVersion         string
+	Catalog                *Catalog
+	C                      context.Context
+	Client                 client.Client
+	Platform               *v1.IntegrationPlatform
+	IntegrationKit         *v1.IntegrationKit
+	Integration            *v1.Integration
+	Resources              *kubernetes.Collection
+	PostActions            []func(*Environment) error
+	PostStepProcessors     []func(*Environment) error
+	PostProcessors         []func(*Environment) error
+	BuildTasks             []v1.Task
+	BuildTolerations       []corev1.Toleration
+	BuildNodeSelector      map[string]string
+	BuildResources         *corev1.ResourceRequirements
+	BuildPriorityClassName string
+	BuildTimeout           *metav1.Duration
+	ConfiguredTraits       []Trait
+	ExecutedTraits         []Trait
+	EnvVars                []corev1.EnvVar
+	ApplicationProperties  map[string]string
+	Interceptors           []string
+	ServiceBindings        map[string]string
 }
 
 // ControllerStrategy is used to determine the kind of controller that needs to be created for the integration
@@ -693,36 +702,8 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 	// Volumes :: Additional ConfigMaps
 	//
 	for _, configmaps := range e.collectConfigurations("configmap") {
-		refName := kubernetes.SanitizeLabel(configmaps["value"])
-
-		configmapVolume := corev1.Volume{
-			Name: refName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: configmaps["value"],
-					},
-				},
-			},
-		}
-
-		// Filter the items selected, if specified
-		if configmaps["resourceKey"] != "" {
-			configmapVolume.VolumeSource.ConfigMap.Items = []corev1.KeyToPath{
-				{
-					Key:  configmaps["resourceKey"],
-					Path: configmaps["resourceKey"],
-				},
-			}
-		}
-
-		*vols = append(*vols, configmapVolume)
-
-		*mnts = append(*mnts, corev1.VolumeMount{
-			Name:      refName,
-			MountPath: getConfigmapMountPoint(configmaps["value"], configmaps["resourceMountPoint"], configmaps["resourceType"]),
-			ReadOnly:  true,
-		})
+		mountPath := getConfigmapMountPoint(configmaps["value"], configmaps["resourceMountPoint"], configmaps["resourceType"])
+		mountConfigmapOrSecret(vols, mnts, "configmap", configmaps["value"], configmaps["resourceKey"], mountPath)
 	}
 
 	//
@@ -748,34 +729,8 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 	}
 
 	for _, secret := range e.collectConfigurations("secret") {
-		refName := kubernetes.SanitizeLabel(secret["value"])
-
-		secretVolume := corev1.Volume{
-			Name: refName,
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: secret["value"],
-				},
-			},
-		}
-
-		// Filter the items selected, if specified
-		if secret["resourceKey"] != "" {
-			secretVolume.VolumeSource.Secret.Items = []corev1.KeyToPath{
-				{
-					Key:  secret["resourceKey"],
-					Path: secret["resourceKey"],
-				},
-			}
-		}
-
-		*vols = append(*vols, secretVolume)
-
-		*mnts = append(*mnts, corev1.VolumeMount{
-			Name:      refName,
-			MountPath: getSecretMountPoint(secret["value"], secret["resourceMountPoint"], secret["resourceType"]),
-			ReadOnly:  true,
-		})
+		mountPath := getSecretMountPoint(secret["value"], secret["resourceMountPoint"], secret["resourceType"])
+		mountConfigmapOrSecret(vols, mnts, "secret", secret["value"], secret["resourceKey"], mountPath)
 	}
 
 	//
@@ -788,10 +743,78 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 			continue
 		}
 
-		pvcName := configParts[0]
-		mountPath := configParts[1]
-		volumeName := pvcName + "-data"
+		mountPersistentVolumeClaim(vols, mnts, configParts[0], configParts[1])
+	}
+}
+
+// getMountVolumeName returns the name of the volume mounting a ConfigMap or a Secret. The resource name is used,
+// as for the volumes created by the previous versions, unless another volume with that name already mounts another
+// source, e.g. another key of the same resource, or another resource with the same sanitized name. A hash of the
+// storage type, the resource name and the key is then appended, so that the volume names do not collide.
+func getMountVolumeName(vols []corev1.Volume, source corev1.VolumeSource, storageType string, name string, key string) string {
+	volumeName := kubernetes.SanitizeLabel(name)
+	if existing := getVolume(vols, volumeName); existing == nil || equality.Semantic.DeepEqual(existing.VolumeSource, source) {
+		return volumeName
+	}
 
+	hash := sha256.Sum256([]byte(storageType + "/" + name + "/" + key))
+	suffix := hex.EncodeToString(hash[:])[:8]
+	// Volume names must be valid DNS labels
+	if len(volumeName) > validation.DNS1123LabelMaxLength-len(suffix)-1 {
+		volumeName = strings.TrimRight(volumeName[:validation.DNS1123LabelMaxLength-len(suffix)-1], "-.")
+	}
+	return volumeName + "-" + suffix
+}
+
+// mountConfigmapOrSecret adds the volume and the volume mount of the given ConfigMap or Secret, optionally filtered
+// by key. The volume is declared once, and mounted once at each mount path.
+func mountConfigmapOrSecret(vols *[]corev1.Volume, mnts *[]corev1.VolumeMount, storageType string, name string, key string, mountPath string) {
+	var items []corev1.KeyToPath
+	// Filter the items selected, if specified
+	if key != "" {
+		items = []corev1.KeyToPath{
+			{
+				Key:  key,
+				Path: key,
+			},
+		}
+	}
+
+	source := corev1.VolumeSource{}
+	if storageType == "secret" {
+		source.Secret = &corev1.SecretVolumeSource{
+			SecretName: name,
+			Items:      items,
+		}
+	} else {
+		source.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: name,
+			},
+			Items: items,
+		}
+	}
+
+	volumeName := getMountVolumeName(*vols, source, storageType, name, key)
+	if getVolume(*vols, volumeName) == nil {
+		*vols = append(*vols, corev1.Volume{
+			Name:         volumeName,
+			VolumeSource: source,
+		})
+	}
+
+	appendVolumeMount(mnts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+}
+
+// mountPersistentVolumeClaim adds the volume of the given Persistent Volume Claim, unless the claim is already
+// declared, and its volume mount at the given path
+func mountPersistentVolumeClaim(vols *[]corev1.Volume, mnts *[]corev1.VolumeMount, pvcName string, mountPath string) {
+	volumeName := pvcName + "-data"
+	if getVolume(*vols, volumeName) == nil {
 		*vols = append(*vols, corev1.Volume{
 			Name: volumeName,
 			VolumeSource: corev1.VolumeSource{
@@ -800,12 +823,42 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 				},
 			},
 		})
+	}
 
-		*mnts = append(*mnts, corev1.VolumeMount{
-			Name:      volumeName,
-			MountPath: mountPath,
-		})
+	appendVolumeMount(mnts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+	})
+}
+
+// appendVolumeMount adds the volume mount, unless the volume is already mounted at the same path
+func appendVolumeMount(mnts *[]corev1.VolumeMount, mount corev1.VolumeMount) {
+	for _, m := range *mnts {
+		if m.Name == mount.Name && m.MountPath == mount.MountPath {
+			return
+		}
+	}
+	*mnts = append(*mnts, mount)
+}
+
+func getVolume(vols []corev1.Volume, name string) *corev1.Volume {
+	for i := range vols {
+		if vols[i].Name == name {
+			return &vols[i]
+		}
 	}
+
+	return nil
+}
+
+func hasVolume(vols []corev1.Volume, name string) bool {
+	for _, v := range vols {
+		if v.Name == name {
+			return true
+		}
+	}
+
+	return false
 }
 
 func getResourcePath(resourceName string, maybePath string, resourceType v1.ResourceType) string {
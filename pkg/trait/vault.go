@@ -0,0 +1,186 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+// The Vault trait configures the secrets manager Camel resolves property placeholders from at runtime,
+// e.g. `{{aws:database/password}}`, so that secrets are not stored in the integration configuration.
+//
+// The HashiCorp Vault (`hashicorp`), AWS Secrets Manager (`aws`) and GCP Secret Manager (`gcp`) providers are supported.
+// The matching Camel component is added to the integration dependencies.
+//
+// The provider must be supported by the runtime the integration runs on, i.e. the Camel catalog must provide
+// the provider component, and the Camel version must support the provider properties function (Camel 3.16 for
+// `aws` and `gcp`, Camel 3.19 for `hashicorp`). Otherwise the trait is not applied, and the `VaultAvailable`
+// integration condition reports the reason.
+//
+// The connection configuration is read from a Secret, in the integration namespace, whose keys are mapped
+// to the matching `camel.vault.<provider>.<key>` properties, e.g. the `token` key to the `camel.vault.hashicorp.token`
+// property. The values are injected as environment variables, and are never copied into the integration ConfigMaps.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=vault
+type vaultTrait struct {
+	BaseTrait `property:",squash"`
+	// The secrets manager provider, one of `hashicorp`, `aws` or `gcp`.
+	Provider string `property:"provider" json:"provider,omitempty"`
+	// The name of the Secret holding the connection configuration of the secrets manager.
+	Secret string `property:"secret" json:"secret,omitempty"`
+}
+
+type vaultProvider struct {
+	// The Camel component providing the properties function
+	artifact string
+	// The Camel versions supporting the properties function and the `camel.vault.<provider>.*` properties
+	camelVersion string
+}
+
+var vaultProviders = map[string]vaultProvider{
+	"hashicorp": {artifact: "hashicorp-vault", camelVersion: ">=3.19.0"},
+	"aws":       {artifact: "aws-secrets-manager", camelVersion: ">=3.16.0"},
+	"gcp":       {artifact: "google-secret-manager", camelVersion: ">=3.16.0"},
+}
+
+func newVaultTrait() Trait {
+	return &vaultTrait{
+		BaseTrait: NewBaseTrait("vault", 550),
+	}
+}
+
+func (t *vaultTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	provider, ok := vaultProviders[t.Provider]
+	if !ok {
+		return false, fmt.Errorf("unsupported vault provider %q, must be one of hashicorp, aws or gcp", t.Provider)
+	}
+
+	if !e.IntegrationInPhase(v1.IntegrationPhaseInitialization, v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		return false, nil
+	}
+
+	if message := t.checkRuntimeSupport(e, provider); message != "" {
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionVaultAvailable,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionVaultNotAvailableReason,
+			message,
+		)
+		return false, nil
+	}
+
+	e.Integration.Status.SetCondition(
+		v1.IntegrationConditionVaultAvailable,
+		corev1.ConditionTrue,
+		v1.IntegrationConditionVaultAvailableReason,
+		fmt.Sprintf("%s vault provider configured", t.Provider),
+	)
+
+	return true, nil
+}
+
+// checkRuntimeSupport returns the reason why the runtime does not support the vault provider, if any
+func (t *vaultTrait) checkRuntimeSupport(e *Environment, provider vaultProvider) string {
+	if e.CamelCatalog == nil {
+		return "unable to check the vault provider support: no Camel catalog available"
+	}
+
+	artifact := "camel-" + provider.artifact
+	if e.CamelCatalog.Runtime.Provider == v1.RuntimeProviderQuarkus {
+		artifact = "camel-quarkus-" + provider.artifact
+	}
+	if !e.CamelCatalog.HasArtifact(artifact) {
+		return fmt.Sprintf("the %s vault provider is not supported by the runtime version %s: the %s component is not available",
+			t.Provider, e.CamelCatalog.Runtime.Version, artifact)
+	}
+
+	if !camel.MatchesCamelVersion(e.CamelCatalog.CamelCatalogSpec, provider.camelVersion) {
+		return fmt.Sprintf("the %s vault provider is not supported by the runtime version %s: Camel %s is required, found %s",
+			t.Provider, e.CamelCatalog.Runtime.Version, provider.camelVersion, e.CamelCatalog.Runtime.Metadata["camel.version"])
+	}
+
+	return ""
+}
+
+func (t *vaultTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "camel:"+vaultProviders[t.Provider].artifact)
+
+		// sort the dependencies to get always the same list if they don't change
+		sort.Strings(e.Integration.Status.Dependencies)
+		return nil
+	}
+
+	if t.Secret == "" {
+		return nil
+	}
+
+	secret := corev1.Secret{}
+	key := client.ObjectKey{Namespace: e.Integration.Namespace, Name: t.Secret}
+	if err := e.Client.Get(e.C, key, &secret); err != nil {
+		return errors.Wrapf(err, "unable to get vault secret %s", t.Secret)
+	}
+
+	keys := make([]string, 0, len(secret.Data)+len(secret.StringData))
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	for k := range secret.StringData {
+		if _, ok := secret.Data[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	// Keep the keys sorted so that the environment is consistent over reconciliation cycles
+	sort.Strings(keys)
+
+	if e.ApplicationProperties == nil {
+		e.ApplicationProperties = make(map[string]string)
+	}
+	for _, k := range keys {
+		name := invalidEnvVarChars.ReplaceAllString(strings.ToUpper("CAMEL_VAULT_"+t.Provider+"_"+k), "_")
+		envvar.SetVar(&e.EnvVars, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: t.Secret},
+					Key:                  k,
+				},
+			},
+		})
+		e.ApplicationProperties["camel.vault."+t.Provider+"."+k] = "${" + name + "}"
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigureVaultTraitInvalidProvider(t *testing.T) {
+	trait, environment := createVaultTest(t, v1.IntegrationPhaseInitialization)
+	trait.Provider = "azure"
+
+	_, err := trait.Configure(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestConfigureVaultTraitUnsupportedRuntime(t *testing.T) {
+	trait, environment := createVaultTest(t, v1.IntegrationPhaseInitialization)
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+	environment.CamelCatalog = catalog
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionVaultAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionVaultNotAvailableReason, condition.Reason)
+	assert.Contains(t, condition.Message, "camel-quarkus-hashicorp-vault")
+}
+
+func TestConfigureVaultTraitUnsupportedCamelVersion(t *testing.T) {
+	trait, environment := createVaultTest(t, v1.IntegrationPhaseInitialization)
+	environment.CamelCatalog.Runtime.Metadata["camel.version"] = "3.16.0"
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionVaultAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, ">=3.19.0")
+}
+
+func TestApplyVaultTraitDependencies(t *testing.T) {
+	trait, environment := createVaultTest(t, v1.IntegrationPhaseInitialization)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Contains(t, environment.Integration.Status.Dependencies, "camel:hashicorp-vault")
+}
+
+func TestApplyVaultTraitConnectionSecret(t *testing.T) {
+	trait, environment := createVaultTest(t, v1.IntegrationPhaseDeploying)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]string{
+		"camel.vault.hashicorp.host":  "${CAMEL_VAULT_HASHICORP_HOST}",
+		"camel.vault.hashicorp.token": "${CAMEL_VAULT_HASHICORP_TOKEN}",
+	}, environment.ApplicationProperties)
+
+	token := envvar.Get(environment.EnvVars, "CAMEL_VAULT_HASHICORP_TOKEN")
+	assert.NotNil(t, token)
+	assert.Equal(t, "vault-connection", token.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "token", token.ValueFrom.SecretKeyRef.Key)
+}
+
+func TestApplyVaultTraitMissingSecret(t *testing.T) {
+	trait, environment := createVaultTest(t, v1.IntegrationPhaseDeploying)
+	trait.Secret = "unknown"
+
+	err := trait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func createVaultTest(t *testing.T, phase v1.IntegrationPhase) (*vaultTrait, *Environment) {
+	t.Helper()
+
+	client, err := test.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "vault-connection",
+		},
+		Data: map[string][]byte{
+			"host":  []byte("vault.example.com"),
+			"token": []byte("s.token"),
+		},
+	})
+	assert.Nil(t, err)
+
+	trait := newVaultTrait().(*vaultTrait)
+	trait.Enabled = BoolP(true)
+	trait.Provider = "hashicorp"
+	trait.Secret = "vault-connection"
+
+	environment := &Environment{
+		C:      context.TODO(),
+		Client: client,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "integration-name",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: phase,
+			},
+		},
+		CamelCatalog: camel.NewRuntimeCatalog(v1.CamelCatalogSpec{
+			Runtime: v1.RuntimeSpec{
+				Version:  "1.x",
+				Provider: v1.RuntimeProviderQuarkus,
+				Metadata: map[string]string{
+					"camel.version": "3.19.0",
+				},
+			},
+			Artifacts: map[string]v1.CamelArtifact{
+				"camel-quarkus-hashicorp-vault": {},
+			},
+		}),
+		EnvVars: make([]corev1.EnvVar, 0),
+	}
+
+	return trait, environment
+}
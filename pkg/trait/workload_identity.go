@@ -0,0 +1,196 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+// The Workload Identity trait configures the integration to authenticate against cloud provider services,
+// using the identity federated with its Kubernetes ServiceAccount, rather than static credentials.
+//
+// A ServiceAccount, named after the integration, is created and annotated for the configured providers, i.e.,
+// AWS IAM Roles for Service Accounts, GCP Workload Identity, or Azure Workload Identity, and the integration pods
+// run with it.
+//
+// When the cluster does not inject the identity token into the pods, the `token-audience` property can be set
+// to mount a projected ServiceAccount token, and configure the environment of the AWS and Azure SDKs accordingly.
+//
+// NOTE: The trait cannot be used when the integration runs with an explicit ServiceAccount.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=workload-identity
+type workloadIdentityTrait struct {
+	BaseTrait `property:",squash"`
+	// The ARN of the AWS IAM role the integration assumes.
+	AWSRoleARN string `property:"aws-role-arn" json:"awsRoleARN,omitempty"`
+	// The email of the GCP service account the integration impersonates.
+	GCPServiceAccount string `property:"gcp-service-account" json:"gcpServiceAccount,omitempty"`
+	// The client ID of the Azure managed identity, or application, the integration uses.
+	AzureClientID string `property:"azure-client-id" json:"azureClientID,omitempty"`
+	// The Azure tenant ID of the identity the integration uses.
+	AzureTenantID string `property:"azure-tenant-id" json:"azureTenantID,omitempty"`
+	// The audience of the projected ServiceAccount token, e.g. `sts.amazonaws.com`.
+	// The token is only mounted into the integration container when it's set.
+	TokenAudience string `property:"token-audience" json:"tokenAudience,omitempty"`
+	// The requested duration of validity of the projected ServiceAccount token (default `3600`).
+	TokenExpirationSeconds *int64 `property:"token-expiration-seconds" json:"tokenExpirationSeconds,omitempty"`
+}
+
+const (
+	workloadIdentityTokenVolumeName = "workload-identity-token"
+	workloadIdentityTokenMountPath  = "/var/run/secrets/workload-identity"
+	workloadIdentityTokenPath       = "token"
+)
+
+func newWorkloadIdentityTrait() Trait {
+	return &workloadIdentityTrait{
+		BaseTrait: NewBaseTrait("workload-identity", 1620),
+	}
+}
+
+func (t *workloadIdentityTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.AWSRoleARN == "" && t.GCPServiceAccount == "" && t.AzureClientID == "" {
+		return false, fmt.Errorf("at least one of aws-role-arn, gcp-service-account or azure-client-id must be set")
+	}
+	if t.TokenExpirationSeconds != nil && *t.TokenExpirationSeconds < 600 {
+		return false, fmt.Errorf("token-expiration-seconds must be at least 600, it was %d", *t.TokenExpirationSeconds)
+	}
+
+	if !e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
+		return false, nil
+	}
+
+	if e.Integration.Spec.ServiceAccountName != "" {
+		return false, fmt.Errorf("workload identity cannot be used with the explicit service account %s", e.Integration.Spec.ServiceAccountName)
+	}
+
+	return true, nil
+}
+
+func (t *workloadIdentityTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	e.Resources.Add(t.newServiceAccount(e))
+	podSpec.ServiceAccountName = e.Integration.Name
+
+	if t.AzureClientID != "" {
+		e.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+			if meta.Labels == nil {
+				meta.Labels = make(map[string]string)
+			}
+			meta.Labels["azure.workload.identity/use"] = True
+		})
+	}
+
+	if t.TokenAudience == "" {
+		return nil
+	}
+
+	expiration := int64(3600)
+	if t.TokenExpirationSeconds != nil {
+		expiration = *t.TokenExpirationSeconds
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: workloadIdentityTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          t.TokenAudience,
+							ExpirationSeconds: &expiration,
+							Path:              workloadIdentityTokenPath,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return nil
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      workloadIdentityTokenVolumeName,
+		MountPath: workloadIdentityTokenMountPath,
+		ReadOnly:  true,
+	})
+
+	tokenFile := path.Join(workloadIdentityTokenMountPath, workloadIdentityTokenPath)
+	if t.AWSRoleARN != "" {
+		envvar.SetVal(&container.Env, "AWS_ROLE_ARN", t.AWSRoleARN)
+		envvar.SetVal(&container.Env, "AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile)
+	}
+	if t.AzureClientID != "" {
+		envvar.SetVal(&container.Env, "AZURE_CLIENT_ID", t.AzureClientID)
+		envvar.SetVal(&container.Env, "AZURE_FEDERATED_TOKEN_FILE", tokenFile)
+		if t.AzureTenantID != "" {
+			envvar.SetVal(&container.Env, "AZURE_TENANT_ID", t.AzureTenantID)
+		}
+	}
+
+	return nil
+}
+
+func (t *workloadIdentityTrait) newServiceAccount(e *Environment) *corev1.ServiceAccount {
+	annotations := make(map[string]string)
+	if t.AWSRoleARN != "" {
+		annotations["eks.amazonaws.com/role-arn"] = t.AWSRoleARN
+	}
+	if t.GCPServiceAccount != "" {
+		annotations["iam.gke.io/gcp-service-account"] = t.GCPServiceAccount
+	}
+	if t.AzureClientID != "" {
+		annotations["azure.workload.identity/client-id"] = t.AzureClientID
+		if t.AzureTenantID != "" {
+			annotations["azure.workload.identity/tenant-id"] = t.AzureTenantID
+		}
+	}
+
+	return &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+			Annotations: annotations,
+		},
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestConfigureWorkloadIdentityTraitWithoutProvider(t *testing.T) {
+	trait, environment, _ := createWorkloadIdentityTest()
+	trait.AWSRoleARN = ""
+
+	_, err := trait.Configure(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestConfigureWorkloadIdentityTraitWithExplicitServiceAccount(t *testing.T) {
+	trait, environment, _ := createWorkloadIdentityTest()
+	environment.Integration.Spec.ServiceAccountName = "my-sa"
+
+	_, err := trait.Configure(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyWorkloadIdentityTrait(t *testing.T) {
+	trait, environment, deployment := createWorkloadIdentityTest()
+	trait.GCPServiceAccount = "integration@project.iam.gserviceaccount.com"
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "integration-name", deployment.Spec.Template.Spec.ServiceAccountName)
+	assert.Empty(t, deployment.Spec.Template.Spec.Volumes)
+
+	var sa *corev1.ServiceAccount
+	environment.Resources.Visit(func(o runtime.Object) {
+		if s, ok := o.(*corev1.ServiceAccount); ok {
+			sa = s
+		}
+	})
+	assert.NotNil(t, sa)
+	assert.Equal(t, "integration-name", sa.Name)
+	assert.Equal(t, map[string]string{
+		"eks.amazonaws.com/role-arn":     "arn:aws:iam::123456789012:role/integration",
+		"iam.gke.io/gcp-service-account": "integration@project.iam.gserviceaccount.com",
+	}, sa.Annotations)
+}
+
+func TestApplyWorkloadIdentityTraitWithProjectedToken(t *testing.T) {
+	trait, environment, deployment := createWorkloadIdentityTest()
+	trait.AzureClientID = "client-id"
+	trait.AzureTenantID = "tenant-id"
+	trait.TokenAudience = "sts.amazonaws.com"
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, True, deployment.Spec.Template.Labels["azure.workload.identity/use"])
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "sts.amazonaws.com", podSpec.Volumes[0].Projected.Sources[0].ServiceAccountToken.Audience)
+	assert.Equal(t, int64(3600), *podSpec.Volumes[0].Projected.Sources[0].ServiceAccountToken.ExpirationSeconds)
+
+	container := podSpec.Containers[0]
+	assert.Equal(t, workloadIdentityTokenMountPath, container.VolumeMounts[0].MountPath)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/integration", envvar.Get(container.Env, "AWS_ROLE_ARN").Value)
+	assert.Equal(t, "/var/run/secrets/workload-identity/token", envvar.Get(container.Env, "AWS_WEB_IDENTITY_TOKEN_FILE").Value)
+	assert.Equal(t, "client-id", envvar.Get(container.Env, "AZURE_CLIENT_ID").Value)
+	assert.Equal(t, "tenant-id", envvar.Get(container.Env, "AZURE_TENANT_ID").Value)
+}
+
+func createWorkloadIdentityTest() (*workloadIdentityTrait, *Environment, *appsv1.Deployment) {
+	trait := newWorkloadIdentityTrait().(*workloadIdentityTrait)
+	trait.Enabled = BoolP(true)
+	trait.AWSRoleARN = "arn:aws:iam::123456789012:role/integration"
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	environment := &Environment{
+		Catalog: NewCatalog(context.TODO(), nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(deployment),
+	}
+
+	return trait, environment, deployment
+}
@@ -45,3 +45,19 @@ func LoadCatalog(ctx context.Context, client client.Client, namespace string, ru
 
 	return catalog, nil
 }
+
+// LoadCatalogForCamelVersion returns the catalog with the highest runtime version that provides
+// an Apache Camel version matching the given version or semver constraint
+func LoadCatalogForCamelVersion(ctx context.Context, client client.Client, namespace string, provider v1.RuntimeProvider, camelVersion string) (*RuntimeCatalog, error) {
+	options := []k8sclient.ListOption{
+		k8sclient.InNamespace(namespace),
+	}
+
+	list := v1.NewCamelCatalogList()
+	err := client.List(ctx, &list, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return findBestMatchForCamelVersion(list.Items, provider, camelVersion), nil
+}
@@ -49,6 +49,46 @@ func findBestMatch(catalogs []v1.CamelCatalog, runtime v1.RuntimeSpec) (*Runtime
 	return nil, nil
 }
 
+// findBestMatchForCamelVersion returns the catalog with the highest runtime version, for the given provider,
+// that provides an Apache Camel version matching the given version or semver constraint
+func findBestMatchForCamelVersion(catalogs []v1.CamelCatalog, provider v1.RuntimeProvider, camelVersion string) *RuntimeCatalog {
+	cc := newCatalogVersionCollection(catalogs)
+	for _, c := range cc {
+		if c.Catalog.Spec.Runtime.Provider != provider {
+			continue
+		}
+		if MatchesCamelVersion(c.Catalog.Spec, camelVersion) {
+			return NewRuntimeCatalog(c.Catalog.Spec)
+		}
+	}
+
+	return nil
+}
+
+// MatchesCamelVersion returns whether the Apache Camel version provided by the catalog
+// matches the given version or semver constraint
+func MatchesCamelVersion(catalog v1.CamelCatalogSpec, camelVersion string) bool {
+	version := catalog.Runtime.Metadata["camel.version"]
+	if version == "" {
+		return false
+	}
+	if version == camelVersion {
+		return true
+	}
+
+	constraint := newSemVerConstraint(camelVersion)
+	if constraint == nil {
+		return false
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		log.Debugf("Invalid semver version (camel) %s", version)
+		return false
+	}
+
+	return constraint.Check(v)
+}
+
 func newSemVerConstraint(versionConstraint string) *semver.Constraints {
 	constraint, err := semver.NewConstraint(versionConstraint)
 	if err != nil || constraint == nil {
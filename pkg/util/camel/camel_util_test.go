@@ -64,3 +64,22 @@ func TestFindRangeMatch(t *testing.T) {
 	assert.Equal(t, "1.0.2", c.Runtime.Version)
 	assert.Equal(t, v1.RuntimeProviderQuarkus, c.Runtime.Provider)
 }
+
+func TestFindBestMatchForCamelVersion(t *testing.T) {
+	catalogs := []v1.CamelCatalog{
+		{Spec: v1.CamelCatalogSpec{Runtime: v1.RuntimeSpec{Version: "1.7.0", Provider: v1.RuntimeProviderQuarkus, Metadata: map[string]string{"camel.version": "3.9.0"}}}},
+		{Spec: v1.CamelCatalogSpec{Runtime: v1.RuntimeSpec{Version: "1.8.0", Provider: v1.RuntimeProviderQuarkus, Metadata: map[string]string{"camel.version": "3.11.0"}}}},
+		{Spec: v1.CamelCatalogSpec{Runtime: v1.RuntimeSpec{Version: "1.8.1", Provider: v1.RuntimeProviderQuarkus, Metadata: map[string]string{"camel.version": "3.11.1"}}}},
+	}
+
+	c := findBestMatchForCamelVersion(catalogs, v1.RuntimeProviderQuarkus, "3.9.0")
+	assert.NotNil(t, c)
+	assert.Equal(t, "1.7.0", c.Runtime.Version)
+
+	c = findBestMatchForCamelVersion(catalogs, v1.RuntimeProviderQuarkus, "~3.11.0")
+	assert.NotNil(t, c)
+	assert.Equal(t, "1.8.1", c.Runtime.Version)
+
+	c = findBestMatchForCamelVersion(catalogs, v1.RuntimeProviderQuarkus, "3.10.0")
+	assert.Nil(t, c)
+}
@@ -74,6 +74,10 @@ func GenerateCatalog(
 	if err != nil {
 		return nil, err
 	}
+	settingsSecurity, err := kubernetes.ResolveValueSource(ctx, client, namespace, &mvn.SettingsSecurity)
+	if err != nil {
+		return nil, err
+	}
 
 	var caCert []byte
 	if mvn.CASecret != nil {
@@ -83,12 +87,13 @@ func GenerateCatalog(
 		}
 	}
 
-	return GenerateCatalogCommon(ctx, settings, caCert, mvn, runtime, providerDependencies)
+	return GenerateCatalogCommon(ctx, settings, settingsSecurity, caCert, mvn, runtime, providerDependencies)
 }
 
 func GenerateCatalogCommon(
 	ctx context.Context,
 	settings string,
+	settingsSecurity string,
 	caCert []byte,
 	mvn v1.MavenSpec,
 	runtime v1.RuntimeSpec,
@@ -118,6 +123,9 @@ func GenerateCatalogCommon(
 	if settings != "" {
 		mc.SettingsContent = []byte(settings)
 	}
+	if settingsSecurity != "" {
+		mc.SettingsSecurityContent = []byte(settingsSecurity)
+	}
 
 	if caCert != nil {
 		trustStoreName := "trust.jks"
@@ -34,6 +34,12 @@ const (
 	// KanikoVersion --
 	KanikoVersion = "0.17.1"
 
+	// JibVersion --
+	JibVersion = "3.1.4"
+
+	// BuildKitVersion --
+	BuildKitVersion = "0.9.0"
+
 	// baseImage --
 	baseImage = "adoptopenjdk/openjdk11:slim"
 
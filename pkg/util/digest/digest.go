@@ -22,6 +22,7 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -63,6 +64,12 @@ func ComputeForIntegration(integration *v1.Integration) (string, error) {
 				return "", err
 			}
 		}
+		// Remote sources are fetched again when their location changes
+		if s.Location != "" {
+			if _, err := hash.Write([]byte(s.Location + s.GitSecret)); err != nil {
+				return "", err
+			}
+		}
 	}
 
 	// Integration resources
@@ -277,3 +284,21 @@ func ComputeSHA1(elem ...string) (string, error) {
 
 	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
 }
+
+// ComputeSHA256 returns the hex encoded SHA-256 checksum of the file, as published in Maven repositories
+func ComputeSHA256(elem ...string) (string, error) {
+	file := path.Join(elem...)
+
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
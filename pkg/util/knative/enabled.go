@@ -34,7 +34,12 @@ import (
 //
 // This method can be used at operator level to check if knative resources can be accessed.
 func IsEnabledInNamespace(ctx context.Context, c client.Client, namespace string) bool {
-	dyn, err := dynamic.NewForConfig(c.GetConfig())
+	config := c.GetConfig()
+	if config == nil {
+		log.Infof("could not check knative installation in namespace %s, no client configuration available", namespace)
+		return false
+	}
+	dyn, err := dynamic.NewForConfig(config)
 	if err != nil {
 		log.Infof("could not create dynamic client to check knative installation in namespace %s, got error: %v", namespace, err)
 		return false
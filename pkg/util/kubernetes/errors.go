@@ -17,10 +17,34 @@ limitations under the License.
 
 package kubernetes
 
-import "strings"
+import (
+	"errors"
+	"net"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
 
 // IsUnknownAPIError checks if the given error is due to some missing APIs in the cluster.
 // Apparently there's no such method in Kubernetes Go API.
 func IsUnknownAPIError(err error) bool {
 	return err != nil && strings.HasPrefix(err.Error(), "no matches for kind")
 }
+
+// IsTransientError checks if the given error is likely due to a temporary disruption of the
+// communication with the API server, so that the failed operation can be retried.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if k8serrors.IsServerTimeout(err) || k8serrors.IsTimeout(err) || k8serrors.IsServiceUnavailable(err) ||
+		k8serrors.IsTooManyRequests(err) || k8serrors.IsInternalError(err) {
+		return true
+	}
+	if utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
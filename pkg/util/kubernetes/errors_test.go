@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransientError(t *testing.T) {
+	assert.False(t, IsTransientError(nil))
+	assert.False(t, IsTransientError(errors.New("invalid configuration")))
+	assert.False(t, IsTransientError(k8serrors.NewNotFound(schema.GroupResource{Resource: "integrations"}, "my-it")))
+
+	assert.True(t, IsTransientError(k8serrors.NewServiceUnavailable("unavailable")))
+	assert.True(t, IsTransientError(k8serrors.NewTimeoutError("timeout", 1)))
+	assert.True(t, IsTransientError(io.ErrUnexpectedEOF))
+	assert.True(t, IsTransientError(pkgerrors.Wrap(&net.OpError{Op: "dial", Err: errors.New("connection refused")}, "cannot update integration")))
+}
@@ -41,16 +41,18 @@ type SelectorScraper struct {
 	labelSelector        string
 	podScrapers          sync.Map
 	counter              uint64
+	options              Options
 	L                    klog.Logger
 }
 
 // NewSelectorScraper creates a new SelectorScraper
-func NewSelectorScraper(client kubernetes.Interface, namespace string, defaultContainerName string, labelSelector string) *SelectorScraper {
+func NewSelectorScraper(client kubernetes.Interface, namespace string, defaultContainerName string, labelSelector string, options Options) *SelectorScraper {
 	return &SelectorScraper{
 		client:               client,
 		namespace:            namespace,
 		defaultContainerName: defaultContainerName,
 		labelSelector:        labelSelector,
+		options:              options,
 		L:                    klog.WithName("scraper").WithName("label").WithValues("selector", labelSelector),
 	}
 }
@@ -128,7 +130,7 @@ func (s *SelectorScraper) synchronize(ctx context.Context, out *bufio.Writer) er
 }
 
 func (s *SelectorScraper) addPodScraper(ctx context.Context, podName string, out *bufio.Writer) {
-	podScraper := NewPodScraper(s.client, s.namespace, podName, s.defaultContainerName)
+	podScraper := NewPodScraper(s.client, s.namespace, podName, s.defaultContainerName, s.options)
 	podCtx, podCancel := context.WithCancel(ctx)
 	id := atomic.AddUint64(&s.counter, 1)
 	prefix := "[" + strconv.FormatUint(id, 10) + "] "
@@ -137,9 +139,11 @@ func (s *SelectorScraper) addPodScraper(ctx context.Context, podName string, out
 	go func() {
 		defer podCancel()
 
-		if _, err := out.WriteString(prefix + "Monitoring pod " + podName + "\n"); err != nil {
-			s.L.Error(err, "Cannot write to output")
-			return
+		if !s.options.JSON {
+			if _, err := out.WriteString(prefix + "Monitoring pod " + podName + "\n"); err != nil {
+				s.L.Error(err, "Cannot write to output")
+				return
+			}
 		}
 		for {
 			str, err := podReader.ReadString('\n')
@@ -149,7 +153,14 @@ func (s *SelectorScraper) addPodScraper(ctx context.Context, podName string, out
 				s.L.Error(err, "Cannot read from pod stream")
 				return
 			}
-			if _, err := out.WriteString(prefix + str); err != nil {
+			line := prefix + str
+			if s.options.JSON {
+				if line, err = formatJSON(podName, str); err != nil {
+					s.L.Error(err, "Cannot format log line")
+					return
+				}
+			}
+			if _, err := out.WriteString(line); err != nil {
 				s.L.Error(err, "Cannot write to output")
 				return
 			}
@@ -19,6 +19,7 @@ package log
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"io"
 	"time"
@@ -26,6 +27,7 @@ import (
 	klog "github.com/apache/camel-k/pkg/util/log"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,16 +47,24 @@ type PodScraper struct {
 	podName              string
 	defaultContainerName string
 	client               kubernetes.Interface
-	L                    klog.Logger
+	// the timestamp, as sent by the server, of the last log line received, and the number of lines received
+	// with that timestamp, used to resume the stream without replaying the log after a connection drop
+	lastTimestamp time.Time
+	lastCount     int
+	// the number of lines received with the last timestamp since the stream has been resumed
+	replayed int
+	options  Options
+	L        klog.Logger
 }
 
 // NewPodScraper creates a new pod scraper
-func NewPodScraper(c kubernetes.Interface, namespace string, podName string, defaultContainerName string) *PodScraper {
+func NewPodScraper(c kubernetes.Interface, namespace string, podName string, defaultContainerName string, options Options) *PodScraper {
 	return &PodScraper{
 		namespace:            namespace,
 		podName:              podName,
 		defaultContainerName: defaultContainerName,
 		client:               c,
+		options:              options,
 		L:                    klog.WithName("scraper").WithName("pod").WithValues("name", podName),
 	}
 }
@@ -79,8 +89,19 @@ func (s *PodScraper) doScrape(ctx context.Context, out *bufio.Writer, clientClos
 		return
 	}
 	logOptions := corev1.PodLogOptions{
-		Follow:    true,
-		Container: containerName,
+		Follow:     true,
+		Container:  containerName,
+		Timestamps: true,
+	}
+	if s.lastCount > 0 {
+		// resume the stream where it has been interrupted, the lines already received being skipped
+		// as the server only honors the since time with a one second precision
+		since := metav1.NewTime(s.lastTimestamp)
+		logOptions.SinceTime = &since
+		s.replayed = 0
+	} else {
+		logOptions.SinceSeconds = s.options.SinceSeconds
+		logOptions.TailLines = s.options.TailLines
 	}
 	byteReader, err := s.client.CoreV1().Pods(s.namespace).GetLogs(s.podName, &logOptions).Stream(ctx)
 	if err != nil {
@@ -92,12 +113,26 @@ func (s *PodScraper) doScrape(ctx context.Context, out *bufio.Writer, clientClos
 	for {
 		data, err := reader.ReadBytes('\n')
 		if err == io.EOF {
-			return
+			// the stream is closed when the container terminates, or when the connection drops,
+			// in which case it's resumed, unless the pod is no longer monitored
+			err = nil
+			if s.isPodTerminated(ctx) {
+				s.L.Debug("Pod has terminated")
+				if err := clientCloser(); err != nil {
+					s.L.Error(err, "Unable to close the client")
+				}
+				return
+			}
+			break
 		}
 		if err != nil {
 			break
 		}
-		_, err = out.Write(data)
+		line, ok := s.skipReceived(data)
+		if !ok {
+			continue
+		}
+		_, err = out.Write(line)
 		if err != nil {
 			break
 		}
@@ -107,6 +142,46 @@ func (s *PodScraper) doScrape(ctx context.Context, out *bufio.Writer, clientClos
 	s.handleAndRestart(ctx, err, 5*time.Second, out, clientCloser)
 }
 
+// skipReceived strips the timestamp the server prefixes the log line with, and returns whether the line
+// has not already been received, before the stream has been resumed
+func (s *PodScraper) skipReceived(data []byte) ([]byte, bool) {
+	i := bytes.IndexByte(data, ' ')
+	if i < 0 {
+		return data, true
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, string(data[:i]))
+	if err != nil {
+		return data, true
+	}
+
+	switch {
+	case timestamp.Before(s.lastTimestamp):
+		return nil, false
+	case timestamp.Equal(s.lastTimestamp):
+		if s.replayed < s.lastCount {
+			s.replayed++
+			return nil, false
+		}
+		s.lastCount++
+		s.replayed++
+	default:
+		s.lastTimestamp = timestamp
+		s.lastCount = 1
+		s.replayed = 1
+	}
+
+	return data[i+1:], true
+}
+
+// isPodTerminated returns whether the pod has terminated, or has been deleted
+func (s *PodScraper) isPodTerminated(ctx context.Context) bool {
+	pod, err := s.client.CoreV1().Pods(s.namespace).Get(ctx, s.podName, metav1.GetOptions{})
+	if err != nil {
+		return k8serrors.IsNotFound(err)
+	}
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
 func (s *PodScraper) handleAndRestart(ctx context.Context, err error, wait time.Duration, out *bufio.Writer, clientCloser func() error) {
 	if err != nil {
 		s.L.Error(err, "error caught during log scraping")
@@ -134,7 +209,7 @@ func (s *PodScraper) handleAndRestart(ctx context.Context, err error, wait time.
 	s.doScrape(ctx, out, clientCloser)
 }
 
-// waitForPodRunning waits for a given pod to reach the running state.
+// waitForPodRunning waits for a given pod to reach the running state, or to have terminated.
 // It may return the internal container to watch if present
 func (s *PodScraper) waitForPodRunning(ctx context.Context, namespace string, podName string, defaultContainerName string) (string, error) {
 	pod := corev1.Pod{
@@ -182,7 +257,8 @@ func (s *PodScraper) waitForPodRunning(ctx context.Context, namespace string, po
 					recvPod = gotPod
 				}
 
-				if recvPod != nil && recvPod.Status.Phase == corev1.PodRunning {
+				if recvPod != nil && (recvPod.Status.Phase == corev1.PodRunning ||
+					recvPod.Status.Phase == corev1.PodSucceeded || recvPod.Status.Phase == corev1.PodFailed) {
 					return s.chooseContainer(recvPod, defaultContainerName), nil
 				}
 			} else if e.Type == watch.Deleted || e.Type == watch.Error {
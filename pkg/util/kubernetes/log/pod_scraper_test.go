@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSkipReceived(t *testing.T) {
+	s := NewPodScraper(nil, "ns", "my-pod", "", Options{})
+
+	receive := func(lines ...string) []string {
+		received := make([]string, 0)
+		for _, l := range lines {
+			if line, ok := s.skipReceived([]byte(l)); ok {
+				received = append(received, string(line))
+			}
+		}
+		return received
+	}
+
+	assert.Equal(t, []string{"first\n", "second\n", "third\n"}, receive(
+		"2021-05-01T10:00:00.100000000Z first\n",
+		"2021-05-01T10:00:00.200000000Z second\n",
+		"2021-05-01T10:00:00.200000000Z third\n",
+	))
+
+	// The stream is resumed from the start of the second, as the since time has a one second precision
+	s.replayed = 0
+	assert.Equal(t, []string{"fourth\n", "fifth\n"}, receive(
+		"2021-05-01T10:00:00.100000000Z first\n",
+		"2021-05-01T10:00:00.200000000Z second\n",
+		"2021-05-01T10:00:00.200000000Z third\n",
+		"2021-05-01T10:00:00.200000000Z fourth\n",
+		"2021-05-01T10:00:01.000000000Z fifth\n",
+	))
+
+	// Lines without timestamp are kept as is
+	assert.Equal(t, []string{"no timestamp\n"}, receive("no timestamp\n"))
+}
+
+func TestIsPodTerminated(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-pod",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	c := fake.NewSimpleClientset(pod)
+	s := NewPodScraper(c, "ns", "my-pod", "", Options{})
+	assert.False(t, s.isPodTerminated(context.TODO()))
+
+	pod.Status.Phase = corev1.PodSucceeded
+	_, err := c.CoreV1().Pods("ns").UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+	assert.True(t, s.isPodTerminated(context.TODO()))
+
+	s = NewPodScraper(c, "ns", "deleted-pod", "", Options{})
+	assert.True(t, s.isPodTerminated(context.TODO()))
+}
@@ -19,23 +19,44 @@ package log
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 
 	"k8s.io/client-go/kubernetes"
 )
 
+// Options configures the part of the logs that is scraped and how it is printed
+type Options struct {
+	// SinceSeconds, when set, skips the log lines older than the given number of seconds
+	SinceSeconds *int64
+	// TailLines, when set, only prints the given number of lines from the end of the logs
+	TailLines *int64
+	// JSON prints each log line as a JSON object, along with the name of the pod
+	JSON bool
+}
+
 // Print prints integrations logs to the stdout
 func Print(ctx context.Context, client kubernetes.Interface, integration *v1.Integration, out io.Writer) error {
-	return PrintUsingSelector(ctx, client, integration.Namespace, integration.Name, v1.IntegrationLabel+"="+integration.Name, out)
+	return PrintWithOptions(ctx, client, integration, Options{}, out)
+}
+
+// PrintWithOptions prints integrations logs to the stdout, filtered and formatted according to the given options
+func PrintWithOptions(ctx context.Context, client kubernetes.Interface, integration *v1.Integration, options Options, out io.Writer) error {
+	return printUsingSelector(ctx, client, integration.Namespace, integration.Name, v1.IntegrationLabel+"="+integration.Name, options, out)
 }
 
 // PrintUsingSelector prints pod logs using a selector
 func PrintUsingSelector(ctx context.Context, client kubernetes.Interface, namespace, defaultContainerName, selector string, out io.Writer) error {
-	scraper := NewSelectorScraper(client, namespace, defaultContainerName, selector)
+	return printUsingSelector(ctx, client, namespace, defaultContainerName, selector, Options{}, out)
+}
+
+func printUsingSelector(ctx context.Context, client kubernetes.Interface, namespace, defaultContainerName, selector string, options Options, out io.Writer) error {
+	scraper := NewSelectorScraper(client, namespace, defaultContainerName, selector, options)
 	reader := scraper.Start(ctx)
 
 	if _, err := io.Copy(out, ioutil.NopCloser(reader)); err != nil {
@@ -44,3 +65,23 @@ func PrintUsingSelector(ctx context.Context, client kubernetes.Interface, namesp
 
 	return nil
 }
+
+// formatJSON returns the log line as a JSON object including the pod name. Structured log lines,
+// as emitted by the runtime when JSON logging is enabled, are preserved, while the other lines
+// are wrapped into the message field.
+func formatJSON(podName string, line string) (string, error) {
+	entry := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry == nil {
+		entry = map[string]interface{}{
+			"message": strings.TrimRight(line, "\r\n"),
+		}
+	}
+	entry["pod"] = podName
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatJSON(t *testing.T) {
+	line, err := formatJSON("my-pod", `{"level":"INFO","message":"Started"}`+"\n")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"level":"INFO","message":"Started","pod":"my-pod"}`+"\n", line)
+
+	line, err = formatJSON("my-pod", "2021-05-01 10:00:00 INFO Started\n")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"message":"2021-05-01 10:00:00 INFO Started","pod":"my-pod"}`+"\n", line)
+
+	line, err = formatJSON("my-pod", "null\n")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"message":"null","pod":"my-pod"}`+"\n", line)
+}
@@ -0,0 +1,220 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// localRepositoryUsedMarker is the file touched in the local repository directories of the artifacts used by the builds
+const localRepositoryUsedMarker = ".camel-k-used"
+
+// cacheEntry groups the files of a local repository directory, e.g., an artifact version
+type cacheEntry struct {
+	dir     string
+	files   []string
+	size    int64
+	modTime time.Time
+}
+
+// PruneLocalRepository removes the files from the local repository that have been used, or downloaded,
+// earlier than maxAge, then the least recently used ones until the repository size is lower than maxSize.
+// The last use of a directory is recorded by MarkLocalRepositoryUsed, it defaults to the time its files
+// have been downloaded. The files are pruned by directory, so that the artifacts remain consistent.
+// A zero maxAge, or maxSize, disables the corresponding pruning. It returns the pruned directories.
+func PruneLocalRepository(repository string, maxAge time.Duration, maxSize int64, now time.Time) ([]string, error) {
+	if _, err := os.Stat(repository); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	entries := make(map[string]*cacheEntry)
+	var total int64
+
+	err := filepath.Walk(repository, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		dir := filepath.Dir(filePath)
+		entry, ok := entries[dir]
+		if !ok {
+			entry = &cacheEntry{dir: dir}
+			entries[dir] = entry
+		}
+		entry.files = append(entry.files, filePath)
+		entry.size += info.Size()
+		// The marker being touched on use, its modification time is the last use of the directory
+		if info.ModTime().After(entry.modTime) {
+			entry.modTime = info.ModTime()
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot scan local Maven repository %s", repository)
+	}
+
+	sorted := make([]*cacheEntry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].modTime.Equal(sorted[j].modTime) {
+			return sorted[i].dir < sorted[j].dir
+		}
+		return sorted[i].modTime.Before(sorted[j].modTime)
+	})
+
+	pruned := make([]string, 0)
+	for _, entry := range sorted {
+		expired := maxAge > 0 && now.Sub(entry.modTime) > maxAge
+		oversized := maxSize > 0 && total > maxSize
+		if !expired && !oversized {
+			// The remaining entries are more recent
+			break
+		}
+		for _, file := range entry.files {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return pruned, errors.Wrapf(err, "cannot prune %s", file)
+			}
+		}
+		total -= entry.size
+		removeEmptyDirs(repository, entry.dir)
+
+		rel, err := filepath.Rel(repository, entry.dir)
+		if err != nil {
+			rel = entry.dir
+		}
+		pruned = append(pruned, rel)
+	}
+
+	return pruned, nil
+}
+
+// MarkLocalRepositoryUsed touches the marker of the local repository directories holding the given artifacts,
+// so that they are not pruned by age while they are still used. The artifacts are identified by their file names
+// in the application dependencies, i.e. `<groupId>.<artifactId>-<version>[-<classifier>].jar`.
+func MarkLocalRepositoryUsed(repository string, artifacts []string, now time.Time) error {
+	if _, err := os.Stat(repository); os.IsNotExist(err) {
+		return nil
+	}
+
+	used := make(map[string]bool, len(artifacts))
+	for _, artifact := range artifacts {
+		used[artifact] = true
+	}
+
+	err := filepath.Walk(repository, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || filepath.Ext(filePath) != ".jar" {
+			return nil
+		}
+		rel, err := filepath.Rel(repository, filePath)
+		if err != nil {
+			return err
+		}
+		// <groupId path>/<artifactId>/<version>/<file>
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+		if len(segments) < 4 {
+			return nil
+		}
+		groupID := strings.Join(segments[:len(segments)-3], ".")
+		if !used[groupID+"."+info.Name()] {
+			return nil
+		}
+
+		marker := filepath.Join(filepath.Dir(filePath), localRepositoryUsedMarker)
+		if err := os.Chtimes(marker, now, now); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			file, err := os.Create(marker)
+			if err != nil {
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			return os.Chtimes(marker, now, now)
+		}
+		return nil
+	})
+
+	return errors.Wrapf(err, "cannot mark the used artifacts of local Maven repository %s", repository)
+}
+
+// FindMissingLocalArtifact returns the first of the project dependencies, imported BOMs and build plugins that is
+// missing from the local repository, if any, so that offline builds can report it before running Maven.
+// The artifacts whose version is managed, e.g. by a BOM, are only checked for any version.
+func FindMissingLocalArtifact(repository string, project Project) (string, bool) {
+	dependencies := make([]Dependency, 0, len(project.Dependencies))
+	if project.DependencyManagement != nil {
+		for _, d := range project.DependencyManagement.Dependencies {
+			if d.Scope == "import" {
+				dependencies = append(dependencies, d)
+			}
+		}
+	}
+	dependencies = append(dependencies, project.Dependencies...)
+	if project.Build != nil {
+		for _, p := range project.Build.Plugins {
+			dependencies = append(dependencies, Dependency{GroupID: p.GroupID, ArtifactID: p.ArtifactID, Version: p.Version})
+		}
+	}
+
+	for _, d := range dependencies {
+		version := d.Version
+		if strings.HasPrefix(version, "${") && strings.HasSuffix(version, "}") {
+			version = project.Properties[strings.TrimSuffix(strings.TrimPrefix(version, "${"), "}")]
+		}
+
+		dir := filepath.Join(repository, filepath.FromSlash(strings.ReplaceAll(d.GroupID, ".", "/")), d.ArtifactID)
+		gav := d.GroupID + ":" + d.ArtifactID
+		if version != "" {
+			dir = filepath.Join(dir, version)
+			gav += ":" + version
+		}
+		if entries, err := ioutil.ReadDir(dir); err != nil || len(entries) == 0 {
+			return gav, true
+		}
+	}
+
+	return "", false
+}
+
+// removeEmptyDirs removes dir and its parents, up to the root directory excluded, as long as they are empty
+func removeEmptyDirs(root string, dir string) {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && len(dir) > len(root); dir = filepath.Dir(dir) {
+		// Removing a non empty directory fails
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCachedArtifact(t *testing.T, repository string, dir string, size int, modTime time.Time) {
+	t.Helper()
+
+	artifactDir := filepath.Join(repository, dir)
+	require.NoError(t, os.MkdirAll(artifactDir, 0755))
+	for _, name := range []string{"artifact.jar", "artifact.pom"} {
+		file := filepath.Join(artifactDir, name)
+		require.NoError(t, ioutil.WriteFile(file, make([]byte, size), 0644))
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+}
+
+func TestPruneLocalRepositoryByAge(t *testing.T) {
+	repository, err := ioutil.TempDir("", "camel-k-m2-")
+	require.NoError(t, err)
+	defer os.RemoveAll(repository)
+
+	now := time.Now()
+	createCachedArtifact(t, repository, "org/apache/camel/camel-core/3.0.0", 10, now.Add(-48*time.Hour))
+	createCachedArtifact(t, repository, "org/apache/camel/camel-core/3.1.0", 10, now.Add(-time.Hour))
+
+	pruned, err := PruneLocalRepository(repository, 24*time.Hour, 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/apache/camel/camel-core/3.0.0"}, pruned)
+
+	assert.NoDirExists(t, filepath.Join(repository, "org/apache/camel/camel-core/3.0.0"))
+	assert.FileExists(t, filepath.Join(repository, "org/apache/camel/camel-core/3.1.0/artifact.jar"))
+}
+
+func TestPruneLocalRepositoryBySize(t *testing.T) {
+	repository, err := ioutil.TempDir("", "camel-k-m2-")
+	require.NoError(t, err)
+	defer os.RemoveAll(repository)
+
+	now := time.Now()
+	createCachedArtifact(t, repository, "org/foo/foo/1.0", 100, now.Add(-3*time.Hour))
+	createCachedArtifact(t, repository, "org/bar/bar/1.0", 100, now.Add(-2*time.Hour))
+	createCachedArtifact(t, repository, "org/baz/baz/1.0", 100, now.Add(-time.Hour))
+
+	// Each artifact directory weighs 200 bytes
+	pruned, err := PruneLocalRepository(repository, 0, 450, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/foo/foo/1.0"}, pruned)
+
+	// Empty parent directories are removed
+	assert.NoDirExists(t, filepath.Join(repository, "org/foo"))
+	assert.DirExists(t, filepath.Join(repository, "org/bar/bar/1.0"))
+	assert.DirExists(t, filepath.Join(repository, "org/baz/baz/1.0"))
+
+	pruned, err = PruneLocalRepository(repository, 0, 0, now)
+	require.NoError(t, err)
+	assert.Empty(t, pruned)
+}
+
+func TestPruneMissingLocalRepository(t *testing.T) {
+	pruned, err := PruneLocalRepository("/tmp/camel-k-missing-m2", time.Hour, 0, time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, pruned)
+}
+
+func TestMarkLocalRepositoryUsed(t *testing.T) {
+	repository, err := ioutil.TempDir("", "camel-k-m2-")
+	require.NoError(t, err)
+	defer os.RemoveAll(repository)
+
+	now := time.Now()
+	downloaded := now.Add(-48 * time.Hour)
+	for _, file := range []string{
+		"org/apache/camel/camel-core/3.0.0/camel-core-3.0.0.jar",
+		"org/apache/camel/camel-main/3.0.0/camel-main-3.0.0.jar",
+	} {
+		file = filepath.Join(repository, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, ioutil.WriteFile(file, make([]byte, 10), 0644))
+		require.NoError(t, os.Chtimes(file, downloaded, downloaded))
+	}
+
+	// The artifacts are identified by their file names in the application dependencies
+	err = MarkLocalRepositoryUsed(repository, []string{"org.apache.camel.camel-core-3.0.0.jar"}, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(repository, "org/apache/camel/camel-core/3.0.0", localRepositoryUsedMarker))
+
+	// The recently used artifacts are kept
+	pruned, err := PruneLocalRepository(repository, 24*time.Hour, 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/apache/camel/camel-main/3.0.0"}, pruned)
+	assert.FileExists(t, filepath.Join(repository, "org/apache/camel/camel-core/3.0.0/camel-core-3.0.0.jar"))
+}
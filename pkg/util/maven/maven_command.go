@@ -71,6 +71,16 @@ func (c *Command) Do(ctx context.Context) error {
 		args = append(args, "--settings", settingsPath)
 	}
 
+	settingsSecurityPath := path.Join(c.context.Path, "settings-security.xml")
+	settingsSecurityExists, err := util.FileExists(settingsSecurityPath)
+	if err != nil {
+		return err
+	}
+
+	if settingsSecurityExists {
+		args = append(args, "-Dsettings.security="+settingsSecurityPath)
+	}
+
 	args = append(args, c.context.AdditionalArguments...)
 
 	cmd := exec.CommandContext(ctx, mvnCmd, args...)
@@ -132,12 +142,18 @@ func (c *Command) Do(ctx context.Context) error {
 
 	scanner := bufio.NewScanner(stdOut)
 
+	// The first error reported by Maven, that's usually the most descriptive of the build failure
+	var failure string
+
 	Log.Debug("About to start parsing the Maven output")
 	for scanner.Scan() {
 		line := scanner.Text()
 		mavenLog, parseError := parseLog(line)
 		if parseError == nil {
 			normalizeLog(mavenLog)
+			if failure == "" && (mavenLog.Level == ERROR || mavenLog.Level == FATAL) {
+				failure = mavenLog.Msg
+			}
 		} else {
 			// Why we are ignoring the parsing errors here: there are a few scenarios where this would likely occur.
 			// For example, if something outside of Maven outputs something (i.e.: the JDK, a misbehaved plugin,
@@ -147,7 +163,14 @@ func (c *Command) Do(ctx context.Context) error {
 	}
 	Log.Debug("Finished parsing Maven output")
 
-	return cmd.Wait()
+	if err := cmd.Wait(); err != nil {
+		if failure != "" {
+			return errors.Wrap(err, failure)
+		}
+		return err
+	}
+
+	return nil
 }
 
 func NewContext(buildDir string) Context {
@@ -168,6 +191,9 @@ type Context struct {
 	// Timeout             time.Duration
 	LocalRepository string
 	// Stdout              io.Writer
+	// The content of the settings-security.xml file, that holds the master password
+	// used to decrypt the server passwords of the settings
+	SettingsSecurityContent []byte
 }
 
 func (c *Context) AddEntry(id string, entry interface{}) {
@@ -205,6 +231,12 @@ func generateProjectStructure(context Context, project Project) error {
 		}
 	}
 
+	if context.SettingsSecurityContent != nil {
+		if err := util.WriteFileWithContent(context.Path, "settings-security.xml", context.SettingsSecurityContent); err != nil {
+			return err
+		}
+	}
+
 	for k, v := range context.AdditionalEntries {
 		var bytes []byte
 		var err error
@@ -237,8 +269,7 @@ func generateProjectStructure(context Context, project Project) error {
 //
 // The artifact id is in the form of:
 //
-//     <groupId>:<artifactId>[:<packagingType>[:<classifier>]]:(<version>|'?')
-//
+//	<groupId>:<artifactId>[:<packagingType>[:<classifier>]]:(<version>|'?')
 func ParseGAV(gav string) (Dependency, error) {
 	// <groupId>:<artifactId>[:<packagingType>[:<classifier>]]:(<version>|'?')
 	dep := Dependency{}
@@ -267,3 +298,25 @@ func ParseGAV(gav string) (Dependency, error) {
 
 	return dep, nil
 }
+
+var offlineMissingArtifactRegexp = regexp.MustCompile(`(?i)in offline mode and the artifact (\S+) has not been downloaded`)
+
+// IsOffline returns whether the Maven CLI options enable the offline mode.
+func IsOffline(cliOptions []string) bool {
+	for _, option := range cliOptions {
+		if option == "--offline" || option == "-o" {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingOfflineArtifact returns the artifact that an offline Maven execution
+// failed to resolve from the local repository, if any.
+func MissingOfflineArtifact(message string) (string, bool) {
+	res := offlineMissingArtifactRegexp.FindStringSubmatch(message)
+	if res == nil {
+		return "", false
+	}
+	return res[1], true
+}
@@ -112,6 +112,33 @@ func SettingsConfigMap(namespace string, name string, settings Settings) (*corev
 	return cm, nil
 }
 
+// SettingsSecret returns a Secret containing the settings, to be used when the settings contain credentials
+func SettingsSecret(namespace string, name string, settings Settings) (*corev1.Secret, error) {
+	data, err := util.EncodeXML(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-maven-settings",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": "camel-k",
+			},
+		},
+		Data: map[string][]byte{
+			"settings.xml": data,
+		},
+	}
+
+	return secret, nil
+}
+
 func defaultMavenRepositories() (repos []v1.Repository) {
 	for _, repoDesc := range strings.Split(DefaultMavenRepositories, ",") {
 		repos = append(repos, NewRepository(repoDesc))
@@ -55,6 +55,8 @@ const expectedSettings = `<?xml version="1.0" encoding="UTF-8"?>
     </profile>
   </profiles>
   <mirrors></mirrors>
+  <proxies></proxies>
+  <servers></servers>
 </settings>`
 
 const expectedDefaultSettings = `<?xml version="1.0" encoding="UTF-8"?>
@@ -98,6 +100,8 @@ const expectedDefaultSettings = `<?xml version="1.0" encoding="UTF-8"?>
     </profile>
   </profiles>
   <mirrors></mirrors>
+  <proxies></proxies>
+  <servers></servers>
 </settings>`
 
 const expectedDefaultSettingsWithExtraRepo = `<?xml version="1.0" encoding="UTF-8"?>
@@ -171,6 +175,8 @@ const expectedDefaultSettingsWithExtraRepo = `<?xml version="1.0" encoding="UTF-
       <mirrorOf>*</mirrorOf>
     </mirror>
   </mirrors>
+  <proxies></proxies>
+  <servers></servers>
 </settings>`
 
 func TestSettingsGeneration(t *testing.T) {
@@ -251,3 +257,53 @@ func TestCreateSettingsConfigMap(t *testing.T) {
 
 	assert.Equal(t, string(content), configMap.Data["settings.xml"])
 }
+
+func TestCreateSettingsSecretWithCredentials(t *testing.T) {
+	settings := NewDefaultSettings([]v1.Repository{}, []Mirror{
+		NewMirror("https://nexus.example.com/repository/maven-public@id=nexus@mirrorOf=*"),
+	})
+	settings.Proxies = []Proxy{
+		{
+			ID:            "corporate",
+			Active:        true,
+			Protocol:      "http",
+			Host:          "proxy.example.com",
+			Port:          3128,
+			Username:      "proxy-user",
+			Password:      "proxy-password",
+			NonProxyHosts: "*.example.com",
+		},
+	}
+	settings.Servers = []Server{
+		{
+			ID:       "nexus",
+			Username: "nexus-user",
+			Password: "nexus-password",
+		},
+	}
+
+	secret, err := SettingsSecret("foo", "bar", settings)
+	assert.Nil(t, err)
+	assert.Equal(t, "bar-maven-settings", secret.Name)
+
+	content := string(secret.Data["settings.xml"])
+	assert.Contains(t, content, `  <proxies>
+    <proxy>
+      <id>corporate</id>
+      <active>true</active>
+      <protocol>http</protocol>
+      <host>proxy.example.com</host>
+      <port>3128</port>
+      <username>proxy-user</username>
+      <password>proxy-password</password>
+      <nonProxyHosts>*.example.com</nonProxyHosts>
+    </proxy>
+  </proxies>`)
+	assert.Contains(t, content, `  <servers>
+    <server>
+      <id>nexus</id>
+      <username>nexus-user</username>
+      <password>nexus-password</password>
+    </server>
+  </servers>`)
+}
@@ -23,11 +23,23 @@ import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 )
 
-type Mirror struct {
+type Mirror = v1.Mirror
+
+type Proxy struct {
+	ID            string `xml:"id,omitempty"`
+	Active        bool   `xml:"active"`
+	Protocol      string `xml:"protocol,omitempty"`
+	Host          string `xml:"host"`
+	Port          int    `xml:"port,omitempty"`
+	Username      string `xml:"username,omitempty"`
+	Password      string `xml:"password,omitempty"`
+	NonProxyHosts string `xml:"nonProxyHosts,omitempty"`
+}
+
+type Server struct {
 	ID       string `xml:"id"`
-	Name     string `xml:"name,omitempty"`
-	URL      string `xml:"url"`
-	MirrorOf string `xml:"mirrorOf"`
+	Username string `xml:"username,omitempty"`
+	Password string `xml:"password,omitempty"`
 }
 
 type Build struct {
@@ -61,6 +73,8 @@ type Settings struct {
 	LocalRepository   string    `xml:"localRepository"`
 	Profiles          []Profile `xml:"profiles>profile,omitempty"`
 	Mirrors           []Mirror  `xml:"mirrors>mirror,omitempty"`
+	Proxies           []Proxy   `xml:"proxies>proxy,omitempty"`
+	Servers           []Server  `xml:"servers>server,omitempty"`
 }
 
 // Project models a Maven project
@@ -55,19 +55,23 @@ var DefaultSourceNamespace = "openshift-marketplace"
 // DefaultStartingCSV contains the specific version to install
 var DefaultStartingCSV = ""
 
+// DefaultInstallPlanApproval is the approval strategy of the install plans generated for the subscription
+var DefaultInstallPlanApproval = string(operatorsv1alpha1.ApprovalAutomatic)
+
 // DefaultGlobalNamespace indicates a namespace containing an OperatorGroup that enables the operator to watch all namespaces.
 // It will be used in global installation mode.
 var DefaultGlobalNamespace = "openshift-operators"
 
 // Options contains information about an operator in OLM
 type Options struct {
-	OperatorName    string
-	Package         string
-	Channel         string
-	Source          string
-	SourceNamespace string
-	StartingCSV     string
-	GlobalNamespace string
+	OperatorName        string
+	Package             string
+	Channel             string
+	Source              string
+	SourceNamespace     string
+	StartingCSV         string
+	GlobalNamespace     string
+	InstallPlanApproval string
 }
 
 // IsOperatorInstalled tells if a OLM CSV or a Subscription is already installed in the namespace
@@ -164,7 +168,7 @@ func Install(ctx context.Context, client client.Client, namespace string, global
 			Package:                options.Package,
 			Channel:                options.Channel,
 			StartingCSV:            options.StartingCSV,
-			InstallPlanApproval:    operatorsv1alpha1.ApprovalAutomatic,
+			InstallPlanApproval:    operatorsv1alpha1.Approval(options.InstallPlanApproval),
 		},
 	}
 	// Additional configuration
@@ -339,5 +343,8 @@ func fillDefaults(o Options) Options {
 	if o.GlobalNamespace == "" {
 		o.GlobalNamespace = DefaultGlobalNamespace
 	}
+	if o.InstallPlanApproval == "" {
+		o.InstallPlanApproval = DefaultInstallPlanApproval
+	}
 	return o
 }
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// ResolveDigest returns the digest of the manifest the image reference points to, e.g. once the image is pushed,
+// so that the image can be addressed by digest instead of by tag. The credentials are looked up from the
+// Docker compatible config.json content, if any. The registry requests are canceled once the context is done.
+func ResolveDigest(ctx context.Context, image string, dockerConfig []byte, insecure bool) (string, error) {
+	var options []name.Option
+	if insecure {
+		options = append(options, name.Insecure)
+	}
+	ref, err := name.ParseReference(image, options...)
+	if err != nil {
+		return "", err
+	}
+
+	auth, err := authenticatorFor(ref.Context().RegistryStr(), dockerConfig)
+	if err != nil {
+		return "", err
+	}
+
+	descriptor, err := remote.Get(ref, remote.WithAuth(auth), remote.WithTransport(&contextTransport{ctx: ctx, transport: http.DefaultTransport}))
+	if err != nil {
+		return "", err
+	}
+
+	return descriptor.Digest.String(), nil
+}
+
+// contextTransport binds the requests to the context, as the remote package does not accept one
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req.WithContext(t.ctx))
+}
+
+// authenticatorFor returns the authenticator for the given registry, from the credentials of the Docker config.json content
+func authenticatorFor(registry string, dockerConfig []byte) (authn.Authenticator, error) {
+	if len(dockerConfig) == 0 {
+		return authn.Anonymous, nil
+	}
+
+	config := dockerConfigList{}
+	if err := json.Unmarshal(dockerConfig, &config); err != nil {
+		return nil, fmt.Errorf("invalid registry authentication file: %v", err)
+	}
+
+	for server, c := range config.Auths {
+		if !matchesRegistry(server, registry) || c.Auth == "" {
+			continue
+		}
+		credentials, err := base64.StdEncoding.DecodeString(c.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credentials for registry %s: %v", server, err)
+		}
+		parts := strings.SplitN(string(credentials), ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid credentials for registry %s", server)
+		}
+		return authn.FromConfig(authn.AuthConfig{
+			Username: parts[0],
+			Password: parts[1],
+		}), nil
+	}
+
+	return authn.Anonymous, nil
+}
+
+// matchesRegistry returns whether the server of a config.json entry, e.g. https://index.docker.io/v1/, is the given registry
+func matchesRegistry(server string, registry string) bool {
+	host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	if _, ok := knownServersByRegistry[host]; ok {
+		// e.g. docker.io
+		host = name.DefaultRegistry
+	}
+	return host == registry
+}
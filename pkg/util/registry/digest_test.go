@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	containerregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveDigest(t *testing.T) {
+	server := httptest.NewServer(containerregistry.New())
+	defer server.Close()
+
+	image := strings.TrimPrefix(server.URL, "http://") + "/camel-k/camel-k-kit-123:1"
+	ref, err := name.ParseReference(image, name.Insecure)
+	assert.Nil(t, err)
+	img, err := random.Image(1024, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, remote.Write(ref, img))
+
+	expected, err := img.Digest()
+	assert.Nil(t, err)
+
+	digest, err := ResolveDigest(context.TODO(), image, nil, true)
+	assert.Nil(t, err)
+	assert.Equal(t, expected.String(), digest)
+
+	// Overwrite the tag
+	img, err = random.Image(1024, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, remote.Write(ref, img))
+
+	digest, err = ResolveDigest(context.TODO(), image, nil, true)
+	assert.Nil(t, err)
+	assert.NotEqual(t, expected.String(), digest)
+
+	// The registry is not requested once the context is done
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = ResolveDigest(ctx, image, nil, true)
+	assert.NotNil(t, err)
+}
+
+func TestAuthenticatorFor(t *testing.T) {
+	config := []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"bmljOnBhc3M="},"quay.io":{"auth":"Y2FtZWw6az0x"}}}`)
+
+	auth, err := authenticatorFor(name.DefaultRegistry, config)
+	assert.Nil(t, err)
+	credentials, err := auth.Authorization()
+	assert.Nil(t, err)
+	assert.Equal(t, "nic", credentials.Username)
+	assert.Equal(t, "pass", credentials.Password)
+
+	auth, err = authenticatorFor("quay.io", config)
+	assert.Nil(t, err)
+	credentials, err = auth.Authorization()
+	assert.Nil(t, err)
+	assert.Equal(t, "camel", credentials.Username)
+	assert.Equal(t, "k=1", credentials.Password)
+
+	auth, err = authenticatorFor("registry.example.com", config)
+	assert.Nil(t, err)
+	assert.Equal(t, authn.Anonymous, auth)
+}
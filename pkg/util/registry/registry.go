@@ -72,6 +72,19 @@ func (a Auth) GenerateDockerConfig() ([]byte, error) {
 	return json.Marshal(content)
 }
 
+// ValidateDockerConfig checks that the given content is a Docker compatible config.json file containing credentials
+// for at least one registry. Configurations relying on credential helpers only cannot be used by the builder.
+func ValidateDockerConfig(content []byte) error {
+	config := dockerConfigList{}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return fmt.Errorf("invalid registry authentication file: %v", err)
+	}
+	if len(config.Auths) == 0 {
+		return errors.New("invalid registry authentication file: no credentials found in the auths section")
+	}
+	return nil
+}
+
 func (a Auth) generateDockerConfigObject() dockerConfigList {
 	return dockerConfigList{
 		map[string]dockerConfig{
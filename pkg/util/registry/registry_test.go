@@ -66,3 +66,9 @@ func TestAuth_Validate(t *testing.T) {
 		Server:   "quay.io",
 	}.validate())
 }
+
+func TestValidateDockerConfig(t *testing.T) {
+	assert.Nil(t, ValidateDockerConfig([]byte(`{"auths":{"quay.io":{"auth":"bmljOnBhc3M="}}}`)))
+	assert.NotNil(t, ValidateDockerConfig([]byte(`{"credsStore":"desktop"}`)))
+	assert.NotNil(t, ValidateDockerConfig([]byte(`not json`)))
+}
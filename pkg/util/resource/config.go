@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var invalidPaths = []string{"/etc/camel", "/deployments/dependencies"}
+
+// Config represents a config option, pointing to a ConfigMap, a Secret or a local file,
+// with an optional key and destination path
+type Config struct {
+	storageType     StorageType
+	resourceName    string
+	resourceKey     string
+	destinationPath string
+}
+
+// DestinationPath is the location where the resource will be stored on destination
+func (config *Config) DestinationPath() string {
+	return config.destinationPath
+}
+
+// StorageType is the type of storage used by the resource
+func (config *Config) StorageType() StorageType {
+	return config.storageType
+}
+
+// Type is the type, converted as string
+func (config *Config) Type() string {
+	return string(config.storageType)
+}
+
+// Name is the name of the resource
+func (config *Config) Name() string {
+	return config.resourceName
+}
+
+// Key is the key specified for the resource
+func (config *Config) Key() string {
+	return config.resourceKey
+}
+
+// Validate checks if the DestinationPath is correctly configured
+func (config *Config) Validate() error {
+	if config.destinationPath == "" {
+		return nil
+	}
+	return ValidateDestinationPath(config.destinationPath)
+}
+
+// ValidateDestinationPath checks the given path is not reserved to the integration runtime
+func ValidateDestinationPath(destinationPath string) error {
+	// Check for invalid path
+	for _, invalidPath := range invalidPaths {
+		if destinationPath == invalidPath || strings.HasPrefix(destinationPath, invalidPath+"/") {
+			return fmt.Errorf("you cannot mount a file under %s path", invalidPath)
+		}
+	}
+	return nil
+}
+
+// StorageType represents the kind of storage of a config option
+type StorageType string
+
+const (
+	// StorageTypeConfigmap --
+	StorageTypeConfigmap StorageType = "configmap"
+	// StorageTypeSecret --
+	StorageTypeSecret StorageType = "secret"
+	// StorageTypeFile --
+	StorageTypeFile StorageType = "file"
+)
+
+var validConfigSecretRegexp = regexp.MustCompile(`^(configmap|secret)\:([\w\.\-\_\:\/@]+)$`)
+var validFileRegexp = regexp.MustCompile(`^file\:([\w\.\-\_\:\/@" ]+)$`)
+var validResourceRegexp = regexp.MustCompile(`^([\w\.\-\_\:]+)(\/([\w\.\-\_\:]+))?(\@([\w\.\-\_\:\/]+))?$`)
+
+func newConfig(storageType StorageType, value string) *Config {
+	rn, mk, mp := parseResourceValue(storageType, value)
+	return &Config{
+		storageType:     storageType,
+		resourceName:    rn,
+		resourceKey:     mk,
+		destinationPath: mp,
+	}
+}
+
+func parseResourceValue(storageType StorageType, value string) (resource string, maybeKey string, maybeDestinationPath string) {
+	if storageType == StorageTypeFile {
+		resource, maybeDestinationPath = ParseFileValue(value)
+		return resource, "", maybeDestinationPath
+	}
+	return parseCMOrSecretValue(value)
+}
+
+// ParseFileValue splits the local path and the optional destination path of a file option value
+func ParseFileValue(value string) (localPath string, maybeDestinationPath string) {
+	split := strings.SplitN(value, "@", 2)
+	if len(split) == 2 {
+		return split[0], split[1]
+	}
+	return value, ""
+}
+
+func parseCMOrSecretValue(value string) (resource string, maybeKey string, maybeDestinationPath string) {
+	if !validResourceRegexp.MatchString(value) {
+		return value, "", ""
+	}
+	// Must have 3 values
+	groups := validResourceRegexp.FindStringSubmatch(value)
+	return groups[1], groups[3], groups[5]
+}
+
+// ParseConfig will parse and return a Config, in the form of
+// [configmap|secret|file]:name[/key][@path]
+func ParseConfig(item string) (*Config, error) {
+	var storageType StorageType
+	var value string
+	if validConfigSecretRegexp.MatchString(item) {
+		// parse as secret/configmap
+		groups := validConfigSecretRegexp.FindStringSubmatch(item)
+		switch groups[1] {
+		case "configmap":
+			storageType = StorageTypeConfigmap
+		case "secret":
+			storageType = StorageTypeSecret
+		}
+		value = groups[2]
+	} else if validFileRegexp.MatchString(item) {
+		//parse as file
+		groups := validFileRegexp.FindStringSubmatch(item)
+		storageType = StorageTypeFile
+		value = groups[1]
+	} else {
+		return nil, fmt.Errorf("could not match config, secret or file configuration as %s", item)
+	}
+
+	config := newConfig(storageType, value)
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
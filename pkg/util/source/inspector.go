@@ -60,6 +60,7 @@ var (
 	xtokenizeRegexp         = regexp.MustCompile(`.*\.xtokenize\s*\(.*\).*`)
 	singleQuotedKameletEip  = regexp.MustCompile(`kamelet\s*\(\s*'(?://)?([a-z0-9-.]+(/[a-z0-9-.]+)?)(?:$|[^a-z0-9-.].*)'`)
 	doubleQuotedKameletEip  = regexp.MustCompile(`kamelet\s*\(\s*"(?://)?([a-z0-9-.]+(/[a-z0-9-.]+)?)(?:$|[^a-z0-9-.].*)"`)
+	schemeRegexp            = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*$`)
 
 	sourceCapabilities = map[*regexp.Regexp][]string{
 		circuitBreakerRegexp: {v1.CapabilityCircuitBreaker},
@@ -247,6 +248,8 @@ func (i *baseInspector) discoverDependencies(source v1.SourceSpec, meta *Metadat
 					i.addDependency(dep, meta)
 				}
 			}
+		} else {
+			i.addUnknownScheme(uri, meta)
 		}
 	}
 
@@ -259,6 +262,8 @@ func (i *baseInspector) discoverDependencies(source v1.SourceSpec, meta *Metadat
 					i.addDependency(dep, meta)
 				}
 			}
+		} else {
+			i.addUnknownScheme(uri, meta)
 		}
 	}
 
@@ -303,6 +308,18 @@ func (i *baseInspector) addDependency(dependency string, meta *Metadata) {
 	meta.Dependencies.Add(dependency)
 }
 
+// addUnknownScheme records the scheme of an endpoint that is not known by the catalog,
+// ignoring URIs whose scheme is resolved at runtime, e.g. from a property placeholder
+func (i *baseInspector) addUnknownScheme(uri string, meta *Metadata) {
+	if !strings.Contains(uri, ":") {
+		return
+	}
+	scheme := i.getURIPrefix(uri)
+	if schemeRegexp.MatchString(scheme) {
+		meta.UnknownSchemes.Add(scheme)
+	}
+}
+
 func (i *baseInspector) decodeComponent(uri string) (*v1.CamelArtifact, *v1.CamelScheme) {
 	uriSplit := strings.SplitN(uri, ":", 2)
 	if len(uriSplit) < 2 {
@@ -79,3 +79,35 @@ func TestJavaSourceKamelet(t *testing.T) {
 		})
 	}
 }
+
+const JavaSourceUnknownSchemes = `
+from("timer:tick")
+    .to("foo:bar")
+    .to("{{endpoint.uri}}")
+    .toD("baz:${header.name}")
+    .to("log:info")
+`
+
+func TestJavaSourceUnknownSchemes(t *testing.T) {
+	code := v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Content: JavaSourceUnknownSchemes,
+		},
+	}
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := NewMetadata()
+	inspector := JavaSourceInspector{
+		baseInspector: baseInspector{
+			catalog: catalog,
+		},
+	}
+
+	err = inspector.Extract(code, &meta)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"foo", "baz"}, meta.UnknownSchemes.List())
+	assert.True(t, meta.Dependencies.Has("camel:timer"))
+	assert.True(t, meta.Dependencies.Has("camel:log"))
+}
@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// GitSecretTokenKey is the key of the git secret holding a personal access token
+	GitSecretTokenKey = "token"
+	// GitSecretHostsKey is the key of the git secret holding the comma separated list
+	// of the hosts the credentials are sent to
+	GitSecretHostsKey = "hosts"
+
+	gitSecretUsernameKey = corev1.BasicAuthUsernameKey
+	gitSecretPasswordKey = corev1.BasicAuthPasswordKey
+)
+
+// DefaultGitHosts are the hosts the credentials are sent to, when the git secret does not list them
+var DefaultGitHosts = []string{
+	"github.com",
+	"api.github.com",
+	"raw.githubusercontent.com",
+	"gist.githubusercontent.com",
+	"gitlab.com",
+}
+
+var githubLocation = regexp.MustCompile(`^github:([^/]+)/([^/]+)/(.+)$`)
+
+// GitCredentials are used to fetch the sources hosted in private Git repositories.
+// They are only sent over HTTPS, to the configured hosts.
+type GitCredentials struct {
+	Token    string
+	Username string
+	Password string
+	Hosts    []string
+}
+
+// LoadGitCredentials reads the credentials from the given secret, that either holds a token
+// in the "token" key, or a username and password like the kubernetes.io/basic-auth secrets.
+// SSH keys are not supported, as the sources are fetched over HTTPS.
+func LoadGitCredentials(ctx context.Context, c ctrl.Reader, namespace string, name string) (*GitCredentials, error) {
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "cannot load git secret %s", name)
+	}
+
+	credentials := GitCredentials{
+		Hosts: DefaultGitHosts,
+	}
+	if hosts, ok := secret.Data[GitSecretHostsKey]; ok {
+		credentials.Hosts = nil
+		for _, host := range strings.Split(string(hosts), ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				credentials.Hosts = append(credentials.Hosts, strings.ToLower(host))
+			}
+		}
+	}
+
+	if token, ok := secret.Data[GitSecretTokenKey]; ok {
+		credentials.Token = strings.TrimSpace(string(token))
+		return &credentials, nil
+	}
+	if username, ok := secret.Data[gitSecretUsernameKey]; ok {
+		credentials.Username = string(username)
+		credentials.Password = string(secret.Data[gitSecretPasswordKey])
+		return &credentials, nil
+	}
+	if secret.Type == corev1.SecretTypeSSHAuth {
+		return nil, fmt.Errorf("git secret %s holds an SSH key, that is not supported as sources are fetched over HTTPS: "+
+			"use a secret with a %q key or a basic-auth secret instead", name, GitSecretTokenKey)
+	}
+
+	return nil, fmt.Errorf("git secret %s has neither a %q nor a %q key", name, GitSecretTokenKey, gitSecretUsernameKey)
+}
+
+// Allows returns whether the credentials can be sent to the given URL
+func (c *GitCredentials) Allows(u *url.URL) bool {
+	if c == nil || u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range c.Hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize sets the credentials on the given request, if they can be sent to the request URL
+func (c *GitCredentials) Authorize(req *http.Request) {
+	if !c.Allows(req.URL) {
+		return
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	} else if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+}
+
+// LoadContent fetches the content of the source at the given location, either a `github:` or an HTTP(S) URL
+func LoadContent(ctx context.Context, location string, credentials *GitCredentials) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "github":
+		return LoadContentGitHub(ctx, u, credentials)
+	case "http", "https":
+		return LoadContentHTTP(ctx, u, credentials)
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s for remote source %s", u.Scheme, location)
+	}
+}
+
+// LoadContentHTTP fetches the content at the given URL. The credentials are not sent when
+// the request is redirected to a URL they are not allowed for.
+func LoadContentHTTP(ctx context.Context, u *url.URL, credentials *GitCredentials) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return []byte{}, err
+	}
+	credentials.Authorize(req)
+
+	client := http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if !credentials.Allows(req.URL) {
+				req.Header.Del("Authorization")
+			}
+			return nil
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return []byte{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != 200 {
+		return []byte{}, fmt.Errorf("the provided URL %s is not reachable, error code is %d", u.String(), resp.StatusCode)
+	}
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return content, nil
+}
+
+// LoadContentGitHub fetches the content of the given `github:user/repo/path[?branch=name]` source
+func LoadContentGitHub(ctx context.Context, u *url.URL, credentials *GitCredentials) ([]byte, error) {
+	src := u.Scheme + ":" + u.Opaque
+
+	items := githubLocation.FindStringSubmatch(src)
+	if len(items) != 4 {
+		return []byte{}, fmt.Errorf("malformed github url: %s", src)
+	}
+
+	branch := u.Query().Get("branch")
+	if branch == "" {
+		branch = "master"
+	}
+
+	srcURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", items[1], items[2], branch, items[3])
+	rawURL, err := url.Parse(srcURL)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return LoadContentHTTP(ctx, rawURL, credentials)
+}
@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func gitSecret(name string, secretType corev1.SecretType, data map[string]string) *corev1.Secret {
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		Type: secretType,
+		Data: make(map[string][]byte),
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return &secret
+}
+
+func TestLoadGitCredentials(t *testing.T) {
+	c, err := test.NewFakeClient(
+		gitSecret("token", corev1.SecretTypeOpaque, map[string]string{"token": "my-token\n"}),
+		gitSecret("basic", corev1.SecretTypeBasicAuth, map[string]string{"username": "user", "password": "pass", "hosts": "git.example.com, Other.example.com"}),
+		gitSecret("ssh", corev1.SecretTypeSSHAuth, map[string]string{"ssh-privatekey": "key"}),
+	)
+	assert.Nil(t, err)
+
+	credentials, err := LoadGitCredentials(context.TODO(), c, "default", "token")
+	assert.Nil(t, err)
+	assert.Equal(t, &GitCredentials{Token: "my-token", Hosts: DefaultGitHosts}, credentials)
+
+	credentials, err = LoadGitCredentials(context.TODO(), c, "default", "basic")
+	assert.Nil(t, err)
+	assert.Equal(t, &GitCredentials{Username: "user", Password: "pass", Hosts: []string{"git.example.com", "other.example.com"}}, credentials)
+
+	_, err = LoadGitCredentials(context.TODO(), c, "default", "ssh")
+	assert.NotNil(t, err)
+
+	_, err = LoadGitCredentials(context.TODO(), c, "default", "missing")
+	assert.NotNil(t, err)
+}
+
+func TestGitCredentialsAllows(t *testing.T) {
+	credentials := &GitCredentials{Token: "my-token", Hosts: DefaultGitHosts}
+
+	for location, allowed := range map[string]bool{
+		"https://raw.githubusercontent.com/user/repo/master/Routes.java": true,
+		"https://GitHub.com:443/user/repo":                               true,
+		"http://raw.githubusercontent.com/user/repo/master/Routes.java":  false,
+		"https://example.com/Routes.java":                                false,
+		"https://raw.githubusercontent.com.example.com/Routes.java":      false,
+	} {
+		u, err := url.Parse(location)
+		assert.Nil(t, err)
+		assert.Equal(t, allowed, credentials.Allows(u), location)
+	}
+
+	var none *GitCredentials
+	assert.False(t, none.Allows(&url.URL{Scheme: "https", Host: "github.com"}))
+}
+
+func TestLoadContentHTTPWithCredentials(t *testing.T) {
+	var other *httptest.Server
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, other.URL+"/routes.groovy", http.StatusFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("from('timer:tick').to('log:info')"))
+	}))
+	defer server.Close()
+	other = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The credentials must not be sent over HTTP
+		if r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("from('timer:tick').to('log:info')"))
+	}))
+	defer other.Close()
+
+	// Trust the test server certificate
+	transport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	defer func() {
+		http.DefaultTransport = transport
+	}()
+
+	u, err := url.Parse(server.URL + "/routes.groovy")
+	assert.Nil(t, err)
+
+	_, err = LoadContentHTTP(context.TODO(), u, nil)
+	assert.NotNil(t, err)
+
+	// The credentials are not sent to the hosts that are not configured
+	_, err = LoadContentHTTP(context.TODO(), u, &GitCredentials{Token: "my-token", Hosts: DefaultGitHosts})
+	assert.NotNil(t, err)
+
+	credentials := &GitCredentials{Token: "my-token", Hosts: []string{u.Hostname()}}
+	content, err := LoadContent(context.TODO(), u.String(), credentials)
+	assert.Nil(t, err)
+	assert.Equal(t, "from('timer:tick').to('log:info')", string(content))
+
+	// The credentials are dropped when redirected over HTTP
+	u.Path = "/redirect"
+	content, err = LoadContentHTTP(context.TODO(), u, credentials)
+	assert.Nil(t, err)
+	assert.Equal(t, "from('timer:tick').to('log:info')", string(content))
+}
@@ -39,6 +39,9 @@ type Metadata struct {
 	RequiredCapabilities *strset.Set
 	// All kamelets
 	Kamelets []string
+	// All endpoint schemes that are not known by the catalog, for which no
+	// dependency could be inferred
+	UnknownSchemes *strset.Set
 }
 
 // NewMetadata --
@@ -48,5 +51,6 @@ func NewMetadata() Metadata {
 		ToURIs:               make([]string, 0),
 		Dependencies:         strset.New(),
 		RequiredCapabilities: strset.New(),
+		UnknownSchemes:       strset.New(),
 	}
 }
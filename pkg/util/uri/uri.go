@@ -45,6 +45,19 @@ func GetComponent(uri string) string {
 	return parts[0]
 }
 
+// GetPath returns the path of the URI, without the component and the query parameters
+func GetPath(uri string) string {
+	component := GetComponent(uri)
+	if component == "" {
+		return ""
+	}
+	path := uri[len(component)+1:]
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	return strings.TrimPrefix(path, "//")
+}
+
 // GetQueryParameter returns the given parameter from the uri, if present
 func GetQueryParameter(uri string, param string) string {
 	paramRegexp := regexp.MustCompile(fmt.Sprintf(queryExtractorRegexp, regexp.QuoteMeta(param)))
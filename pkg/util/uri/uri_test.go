@@ -179,3 +179,11 @@ func TestCamelURIFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPath(t *testing.T) {
+	assert.Equal(t, "orders", GetPath("kafka:orders"))
+	assert.Equal(t, "orders", GetPath("kafka:orders?brokers=my-cluster:9092"))
+	assert.Equal(t, "queue:orders", GetPath("amqp:queue:orders"))
+	assert.Equal(t, "sqs-queue", GetPath("aws2-sqs://sqs-queue?region=eu-west-1"))
+	assert.Empty(t, GetPath("orders"))
+}
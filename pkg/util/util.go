@@ -211,7 +211,12 @@ func CopyFile(src, dst string) (int64, error) {
 	}
 	defer destination.Close()
 	nBytes, err := io.Copy(destination, source)
-	return nBytes, err
+	if err != nil {
+		return nBytes, err
+	}
+
+	// Preserve the file mode, e.g. for native executables
+	return nBytes, destination.Chmod(sourceFileStat.Mode())
 }
 
 // WriteFileWithContent --